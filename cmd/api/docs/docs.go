@@ -465,6 +465,86 @@ var doc = `{
                 }
             }
         },
+        "/v1/bigmap/{network}/{ptr}/keys/find": {
+            "post": {
+                "description": "Key is passed as JSON schema form data of big map key type. It's packed and hashed on server side.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bigmap"
+                ],
+                "summary": "Get big map value and its history by key",
+                "operationId": "get-bigmap-key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Big map pointer",
+                        "name": "ptr",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.getBigMapByKeyRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 10,
+                        "type": "integer",
+                        "description": "Requested count",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BigMapKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/bigmap/{network}/{ptr}/keys/{key_hash}": {
             "get": {
                 "description": "Get big map diffs by pointer and key hash",
@@ -539,6 +619,92 @@ var doc = `{
                 }
             }
         },
+        "/v1/bigmap/{network}/{ptr}/range": {
+            "get": {
+                "description": "Keys are sorted in ascending order. Available for big maps with keys of nat, int, mutez, timestamp, address, key_hash, string or bytes type. Timestamp bounds can be passed in RFC3339 format or as unix time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bigmap"
+                ],
+                "summary": "Get active big map keys in range",
+                "operationId": "get-bigmap-keys-range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Big map pointer",
+                        "name": "ptr",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lower bound of keys (inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upper bound of keys (inclusive)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 10,
+                        "type": "integer",
+                        "description": "Requested count",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.BigMapResponseItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/contract/{network}/{address}": {
             "get": {
                 "description": "Get full contract info",
@@ -779,6 +945,69 @@ var doc = `{
                 }
             }
         },
+        "/v1/contract/{network}/{address}/entrypoints/forge": {
+            "post": {
+                "description": "Build transaction which calls entrypoint with passed arguments and forge it to bytes. The same transaction is simulated by ` + "`" + `run_operation` + "`" + `. Gas and storage limits are set by simulation result and fee is estimated by minimal fees of bakers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "Forge entrypoint call",
+                "operationId": "forge-operation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 36,
+                        "minLength": 36,
+                        "type": "string",
+                        "description": "KT address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.runOperationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ForgedOperation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/contract/{network}/{address}/entrypoints/schema": {
             "get": {
                 "description": "Get contract` + "`" + `s entrypoint schema",
@@ -852,7 +1081,7 @@ var doc = `{
         },
         "/v1/contract/{network}/{address}/entrypoints/trace": {
             "post": {
-                "description": "Execute entrypoint with passed arguments",
+                "description": "Execute entrypoint with passed arguments. If ` + "`" + `profile` + "`" + ` is set, gas consumption and stack depth of every executed instruction and code block are returned in ` + "`" + `profile` + "`" + ` field of the first operation. Positions refer to Michelson code in the profile.",
                 "consumes": [
                     "application/json"
                 ],
@@ -916,9 +1145,9 @@ var doc = `{
                 }
             }
         },
-        "/v1/contract/{network}/{address}/mempool": {
+        "/v1/contract/{network}/{address}/interfaces": {
             "get": {
-                "description": "Get contract mempool operations",
+                "description": "Get interfaces implemented by contract with score. Score is share of interface entrypoints, views and storage fields found in contract.",
                 "consumes": [
                     "application/json"
                 ],
@@ -928,8 +1157,8 @@ var doc = `{
                 "tags": [
                     "contract"
                 ],
-                "summary": "Get contract mempool operations",
-                "operationId": "get-contract-mempool",
+                "summary": "Get interfaces implemented by contract",
+                "operationId": "get-contract-interface-matches",
                 "parameters": [
                     {
                         "type": "string",
@@ -954,7 +1183,7 @@ var doc = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/handlers.Operation"
+                                "$ref": "#/definitions/handlers.InterfaceMatch"
                             }
                         }
                     },
@@ -964,6 +1193,12 @@ var doc = `{
                             "$ref": "#/definitions/handlers.Error"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -973,9 +1208,9 @@ var doc = `{
                 }
             }
         },
-        "/v1/contract/{network}/{address}/migrations": {
+        "/v1/contract/{network}/{address}/mempool": {
             "get": {
-                "description": "Get contract migrations",
+                "description": "Get contract mempool operations",
                 "consumes": [
                     "application/json"
                 ],
@@ -985,8 +1220,8 @@ var doc = `{
                 "tags": [
                     "contract"
                 ],
-                "summary": "Get contract migrations",
-                "operationId": "get-contract-migrations",
+                "summary": "Get contract mempool operations",
+                "operationId": "get-contract-mempool",
                 "parameters": [
                     {
                         "type": "string",
@@ -1011,7 +1246,7 @@ var doc = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/handlers.Migration"
+                                "$ref": "#/definitions/handlers.Operation"
                             }
                         }
                     },
@@ -1030,9 +1265,9 @@ var doc = `{
                 }
             }
         },
-        "/v1/contract/{network}/{address}/operations": {
+        "/v1/contract/{network}/{address}/migrations": {
             "get": {
-                "description": "Get contract operations",
+                "description": "Get contract migrations",
                 "consumes": [
                     "application/json"
                 ],
@@ -1042,8 +1277,8 @@ var doc = `{
                 "tags": [
                     "contract"
                 ],
-                "summary": "Get contract operations",
-                "operationId": "get-contract-operations",
+                "summary": "Get contract migrations",
+                "operationId": "get-contract-migrations",
                 "parameters": [
                     {
                         "type": "string",
@@ -1060,14 +1295,71 @@ var doc = `{
                         "name": "address",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.Migration"
+                            }
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Last operation ID",
-                        "name": "last_id",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
                     },
-                    {
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/contract/{network}/{address}/operations": {
+            "get": {
+                "description": "Get contract operations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "Get contract operations",
+                "operationId": "get-contract-operations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 36,
+                        "minLength": 36,
+                        "type": "string",
+                        "description": "KT address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last operation ID",
+                        "name": "last_id",
+                        "in": "query"
+                    },
+                    {
                         "type": "integer",
                         "description": "Timestamp",
                         "name": "from",
@@ -1268,7 +1560,7 @@ var doc = `{
         },
         "/v1/contract/{network}/{address}/storage": {
             "get": {
-                "description": "Get contract storage",
+                "description": "Get contract storage. Storage at ` + "`" + `level` + "`" + ` is rebuilt from indexed data, so it's available even if node has pruned that state.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1397,7 +1689,7 @@ var doc = `{
         },
         "/v1/contract/{network}/{address}/storage/rich": {
             "get": {
-                "description": "Get contract rich storage",
+                "description": "Get contract storage with big map contents. Storage and big maps at ` + "`" + `level` + "`" + ` are rebuilt from indexed data, so they are available even if node has pruned that state. Storage of contracts rewritten by protocol migration after their last operation is requested from node.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1525,6 +1817,63 @@ var doc = `{
                 }
             }
         },
+        "/v1/contract/{network}/{address}/tickets": {
+            "get": {
+                "description": "Get tickets held by contract in its storage and big maps. Tickets with the same ticketer and content are summed up.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "Get tickets held by contract",
+                "operationId": "get-contract-tickets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 36,
+                        "minLength": 36,
+                        "type": "string",
+                        "description": "KT address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.Ticket"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/contract/{network}/{address}/tokens": {
             "get": {
                 "description": "List contract tokens",
@@ -1640,8 +1989,7 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "minimum": 0,
-                        "type": "integer",
+                        "type": "string",
                         "description": "Token ID",
                         "name": "token_id",
                         "in": "query",
@@ -1675,7 +2023,7 @@ var doc = `{
         },
         "/v1/contract/{network}/{address}/views/execute": {
             "post": {
-                "description": "Execute view of contracts metadata",
+                "description": "Execute view of contracts metadata. ` + "`" + `michelsonStorageView` + "`" + ` returns Micheline tree of the result. ` + "`" + `restApiQuery` + "`" + ` returns JSON response of the called API as is, ` + "`" + `data` + "`" + ` is passed as its parameters.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1741,7 +2089,7 @@ var doc = `{
         },
         "/v1/contract/{network}/{address}/views/schema": {
             "get": {
-                "description": "Get view schemas of contract metadata",
+                "description": "Get view schemas of contract metadata. Views implemented by ` + "`" + `michelsonStorageView` + "`" + ` and ` + "`" + `restApiQuery` + "`" + ` are returned. Arguments of ` + "`" + `restApiQuery` + "`" + ` views are taken from the referenced OpenAPI specification.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1999,6 +2347,39 @@ var doc = `{
                 }
             }
         },
+        "/v1/interfaces": {
+            "get": {
+                "description": "Get all built-in and registered contract interfaces. Contracts implementing interface are tagged by its name.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "Get contract interfaces",
+                "operationId": "get-contract-interfaces",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ContractInterface"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/operation/{id}/error_location": {
             "get": {
                 "consumes": [
@@ -2143,9 +2524,9 @@ var doc = `{
                 }
             }
         },
-        "/v1/search": {
+        "/v1/sapling/{network}/{ptr}": {
             "get": {
-                "description": "Search any data in contracts, operations and big map diff with filters",
+                "description": "Get sapling pool state: commitment tree size, count of spent nullifiers and updates. Pool copied from another one includes its state at the moment of copy.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2153,76 +2534,31 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "search"
+                    "sapling"
                 ],
-                "summary": "Search in better-call",
-                "operationId": "search",
+                "summary": "Get sapling pool state",
+                "operationId": "get-sapling-state",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Query string",
-                        "name": "q",
-                        "in": "query",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Comma-separated field names among which will search",
-                        "name": "f",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comma-separated networks list for searching",
-                        "name": "n",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Offset for pagination",
-                        "name": "o",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Return search result since given timestamp",
-                        "name": "s",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Return search result before given timestamp",
-                        "name": "e",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            0,
-                            1
-                        ],
                         "type": "integer",
-                        "description": "Grouping by contracts similarity. 0 - false, any others - true",
-                        "name": "g",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comma-separated list of indices for searching. Values: contract, operation, bigmapdiff",
-                        "name": "i",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comma-separated list of languages for searching. Values: smartpy, liquidity, ligo, lorentz, michelson",
-                        "name": "l",
-                        "in": "query"
+                        "description": "Sapling state pointer",
+                        "name": "ptr",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Result"
+                            "$ref": "#/definitions/handlers.SaplingState"
                         }
                     },
                     "400": {
@@ -2240,9 +2576,9 @@ var doc = `{
                 }
             }
         },
-        "/v1/slug/{slug}": {
+        "/v1/sapling/{network}/{ptr}/history": {
             "get": {
-                "description": "Get contract by slug",
+                "description": "Get sapling pool updates: allocations, copies and shielded transactions with their commitments, ciphertexts and nullifiers",
                 "consumes": [
                     "application/json"
                 ],
@@ -2250,24 +2586,47 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contract"
+                    "sapling"
                 ],
-                "summary": "Get contract by slug",
-                "operationId": "get-contract-by-slug",
+                "summary": "Get sapling pool updates",
+                "operationId": "get-sapling-history",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Slug",
-                        "name": "slug",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sapling state pointer",
+                        "name": "ptr",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "maximum": 10,
+                        "type": "integer",
+                        "description": "Updates count",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.Alias"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.SaplingUpdate"
+                            }
                         }
                     },
                     "400": {
@@ -2285,9 +2644,9 @@ var doc = `{
                 }
             }
         },
-        "/v1/stats": {
+        "/v1/sapling/{network}/{ptr}/series": {
             "get": {
-                "description": "get indexer states for all networks",
+                "description": "Get series of added commitments, spent nullifiers or updates count of sapling pool",
                 "consumes": [
                     "application/json"
                 ],
@@ -2295,30 +2654,315 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "statistics"
+                    "sapling"
                 ],
-                "summary": "Show indexer stats",
-                "operationId": "get-stats",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.Block"
-                            }
-                        }
+                "summary": "Get sapling pool activity series",
+                "operationId": "get-sapling-series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.Error"
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/stats/{network}": {
+                    {
+                        "type": "integer",
+                        "description": "Sapling state pointer",
+                        "name": "ptr",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "commitments",
+                            "nullifiers",
+                            "updates"
+                        ],
+                        "type": "string",
+                        "description": "One of names",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "year",
+                            "month",
+                            "week",
+                            "day"
+                        ],
+                        "type": "string",
+                        "description": "One of periods",
+                        "name": "period",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/search": {
+            "get": {
+                "description": "Search any data in contracts, operations and big map diff with filters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search in better-call",
+                "operationId": "search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Query string",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated field names among which will search",
+                        "name": "f",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated networks list for searching",
+                        "name": "n",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset for pagination",
+                        "name": "o",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return search result since given timestamp",
+                        "name": "s",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return search result before given timestamp",
+                        "name": "e",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1
+                        ],
+                        "type": "integer",
+                        "description": "Grouping by contracts similarity. 0 - false, any others - true",
+                        "name": "g",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of indices for searching. Values: contract, operation, bigmapdiff",
+                        "name": "i",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of languages for searching. Values: smartpy, liquidity, ligo, lorentz, michelson",
+                        "name": "l",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of contract tags or interfaces for searching, e.g. fa2,multisig",
+                        "name": "t",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Result"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/simulate/{network}": {
+            "post": {
+                "description": "Simulate ordered list of transactions and originations from one source as one operation group. Contents are simulated in passed order. Originations are built like ` + "`" + `fork` + "`" + ` does. Response contains applied operations with internal ones, token transfers and the first failed operation with formatted errors.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "operations"
+                ],
+                "summary": "Simulate operation group",
+                "operationId": "simulate-batch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.simulateBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimulationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/slug/{slug}": {
+            "get": {
+                "description": "Get contract by slug",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contract"
+                ],
+                "summary": "Get contract by slug",
+                "operationId": "get-contract-by-slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Alias"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats": {
+            "get": {
+                "description": "get indexer states for all networks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "statistics"
+                ],
+                "summary": "Show indexer stats",
+                "operationId": "get-stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.Block"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/{network}": {
             "get": {
                 "description": "Get detailed statistics for network",
                 "consumes": [
@@ -2493,10 +3137,219 @@ var doc = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "array",
-                                "items": {
-                                    "type": "integer"
-                                }
+                                "type": "array",
+                                "items": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stream/sse": {
+            "get": {
+                "description": "Pushes new operations, big map diffs, token transfers and originated contracts matching the filter. SSE event name equals the event type.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "stream"
+                ],
+                "summary": "Stream indexed events via Server-Sent Events",
+                "operationId": "stream-sse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 36,
+                        "minLength": 36,
+                        "type": "string",
+                        "description": "Address of operation participant, big map owner, transfer participant or contract",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entrypoint of operation",
+                        "name": "entrypoint",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Big map pointer",
+                        "name": "ptr",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 36,
+                        "minLength": 36,
+                        "type": "string",
+                        "description": "Token contract of transfer",
+                        "name": "contract",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of event types: operation, big_map_diff, transfer, contract",
+                        "name": "types",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StreamEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stream/ws": {
+            "get": {
+                "description": "Pushes new operations, big map diffs, token transfers and originated contracts matching the filter. Each message is JSON-encoded event.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream"
+                ],
+                "summary": "Stream indexed events via WebSocket",
+                "operationId": "stream-ws",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 36,
+                        "minLength": 36,
+                        "type": "string",
+                        "description": "Address of operation participant, big map owner, transfer participant or contract",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entrypoint of operation",
+                        "name": "entrypoint",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Big map pointer",
+                        "name": "ptr",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 36,
+                        "minLength": 36,
+                        "type": "string",
+                        "description": "Token contract of transfer",
+                        "name": "contract",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of event types: operation, big_map_diff, transfer, contract",
+                        "name": "types",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StreamEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tickets/{network}/{address}": {
+            "get": {
+                "description": "Get holders of tickets created by contract. Tickets with the same holder and content are summed up.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Get tickets created by contract",
+                "operationId": "get-ticketer-tickets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Network",
+                        "name": "network",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 36,
+                        "minLength": 36,
+                        "type": "string",
+                        "description": "KT address of ticketer",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.Ticket"
                             }
                         }
                     },
@@ -2619,8 +3472,7 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "minimum": 0,
-                        "type": "integer",
+                        "type": "string",
                         "description": "Token ID",
                         "name": "token_id",
                         "in": "query",
@@ -2724,7 +3576,7 @@ var doc = `{
                         "in": "query"
                     },
                     {
-                        "type": "integer",
+                        "type": "string",
                         "description": "Token ID",
                         "name": "token_id",
                         "in": "query"
@@ -2754,7 +3606,7 @@ var doc = `{
         },
         "/v1/tokens/{network}/version/{faversion}": {
             "get": {
-                "description": "Get all contracts that implement FA1/FA1.2 standard by version",
+                "description": "Get all contracts that implement FA1/FA1.2 standard by version or any contract interface from ` + "`" + `/v1/interfaces` + "`" + `",
                 "consumes": [
                     "application/json"
                 ],
@@ -2775,13 +3627,8 @@ var doc = `{
                         "required": true
                     },
                     {
-                        "enum": [
-                            "fa1",
-                            "fa12",
-                            "fa2"
-                        ],
                         "type": "string",
-                        "description": "FA token version",
+                        "description": "FA token version (fa1, fa12, fa2) or name of contract interface",
                         "name": "faversion",
                         "in": "path",
                         "required": true
@@ -2823,6 +3670,53 @@ var doc = `{
                 }
             }
         },
+        "/v1/unforge": {
+            "post": {
+                "description": "Decode forged operation group (signed or not) and show what it does",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "operations"
+                ],
+                "summary": "Unforge operation group",
+                "operationId": "unforge-operation",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.unforgeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UnforgedOperationGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Error"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/{network}/{address}/transfers": {
             "get": {
                 "description": "Show contract` + "`" + `s tokens transfers.",
@@ -2868,7 +3762,7 @@ var doc = `{
                         "in": "query"
                     },
                     {
-                        "type": "integer",
+                        "type": "string",
                         "description": "Token ID",
                         "name": "token_id",
                         "in": "query"
@@ -2961,6 +3855,49 @@ var doc = `{
                 }
             }
         },
+        "ast.LambdaOperation": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "x-nullable": true
+                },
+                "delegate": {
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "entrypoint": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "parameters": {
+                    "x-nullable": true,
+                    "$ref": "#/definitions/ast.MiguelNode"
+                }
+            }
+        },
+        "ast.LambdaOperations": {
+            "type": "object",
+            "properties": {
+                "complete": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "boolean"
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ast.LambdaOperation"
+                    }
+                }
+            }
+        },
         "ast.MiguelNode": {
             "type": "object",
             "properties": {
@@ -2976,6 +3913,10 @@ var doc = `{
                 "from": {
                     "type": "object"
                 },
+                "lambda": {
+                    "x-nullable": true,
+                    "$ref": "#/definitions/ast.LambdaOperations"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -3218,6 +4159,31 @@ var doc = `{
                 }
             }
         },
+        "handlers.BigMapKeyResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "$ref": "#/definitions/handlers.BigMapDiffItem"
+                },
+                "key": {
+                    "type": "object",
+                    "x-nullable": true
+                },
+                "key_hash": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BigMapDiffItem"
+                    },
+                    "x-nullable": true
+                }
+            }
+        },
         "handlers.BigMapResponseItem": {
             "type": "object",
             "properties": {
@@ -3435,6 +4401,41 @@ var doc = `{
                 }
             }
         },
+        "handlers.ContractInterface": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "entrypoints": {
+                    "type": "object"
+                },
+                "is_root": {
+                    "type": "boolean"
+                },
+                "methods": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "min_score": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "optional_entrypoints": {
+                    "type": "object"
+                },
+                "storage": {
+                    "type": "object"
+                },
+                "views": {
+                    "type": "object"
+                }
+            }
+        },
         "handlers.CountResponse": {
             "type": "object",
             "properties": {
@@ -3487,6 +4488,29 @@ var doc = `{
                 }
             }
         },
+        "handlers.ForgedOperation": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "type": "string"
+                },
+                "bytes": {
+                    "type": "string"
+                },
+                "counter": {
+                    "type": "integer"
+                },
+                "fee": {
+                    "type": "integer"
+                },
+                "gas_limit": {
+                    "type": "integer"
+                },
+                "storage_limit": {
+                    "type": "integer"
+                }
+            }
+        },
         "handlers.GetBigMapResponse": {
             "type": "object",
             "properties": {
@@ -3573,6 +4597,17 @@ var doc = `{
                 }
             }
         },
+        "handlers.InterfaceMatch": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
         "handlers.Migration": {
             "type": "object",
             "properties": {
@@ -3711,6 +4746,10 @@ var doc = `{
                     "type": "object",
                     "x-nullable": true
                 },
+                "profile": {
+                    "x-nullable": true,
+                    "$ref": "#/definitions/profiler.Profile"
+                },
                 "protocol": {
                     "type": "string"
                 },
@@ -3839,8 +4878,111 @@ var doc = `{
                         "$ref": "#/definitions/handlers.Contract"
                     }
                 },
-                "count": {
-                    "type": "integer"
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.SaplingCiphertext": {
+            "type": "object",
+            "properties": {
+                "cv": {
+                    "type": "string"
+                },
+                "epk": {
+                    "type": "string"
+                },
+                "nonce_enc": {
+                    "type": "string"
+                },
+                "nonce_out": {
+                    "type": "string"
+                },
+                "payload_enc": {
+                    "type": "string"
+                },
+                "payload_out": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SaplingState": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "first_level": {
+                    "type": "integer"
+                },
+                "last_level": {
+                    "type": "integer"
+                },
+                "memo_size": {
+                    "type": "integer",
+                    "x-nullable": true
+                },
+                "network": {
+                    "type": "string"
+                },
+                "nullifiers_count": {
+                    "type": "integer"
+                },
+                "ptr": {
+                    "type": "integer"
+                },
+                "source_ptr": {
+                    "type": "integer",
+                    "x-nullable": true
+                },
+                "tree_size": {
+                    "type": "integer"
+                },
+                "updates_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.SaplingUpdate": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "ciphertexts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SaplingCiphertext"
+                    }
+                },
+                "commitments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "memo_size": {
+                    "type": "integer",
+                    "x-nullable": true
+                },
+                "nullifiers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source_ptr": {
+                    "type": "integer",
+                    "x-nullable": true
+                },
+                "timestamp": {
+                    "type": "string"
                 }
             }
         },
@@ -3992,6 +5134,38 @@ var doc = `{
                 }
             }
         },
+        "handlers.SimulationResponse": {
+            "type": "object",
+            "properties": {
+                "failure": {
+                    "x-nullable": true,
+                    "$ref": "#/definitions/handlers.Operation"
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.Operation"
+                    }
+                },
+                "transfers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.Transfer"
+                    }
+                }
+            }
+        },
+        "handlers.StreamEvent": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "object"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
         "handlers.Subscription": {
             "type": "object",
             "properties": {
@@ -4035,6 +5209,14 @@ var doc = `{
                 },
                 "watch_similar": {
                     "type": "boolean"
+                },
+                "webhook_secret": {
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "webhook_url": {
+                    "type": "string",
+                    "x-nullable": true
                 }
             }
         },
@@ -4095,6 +5277,26 @@ var doc = `{
                 }
             }
         },
+        "handlers.Ticket": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "object"
+                },
+                "content_type": {
+                    "type": "object"
+                },
+                "holder": {
+                    "type": "string"
+                },
+                "ticketer": {
+                    "type": "string"
+                }
+            }
+        },
         "handlers.Token": {
             "type": "object",
             "properties": {
@@ -4156,7 +5358,7 @@ var doc = `{
                     "x-nullable": true
                 },
                 "token_id": {
-                    "type": "integer"
+                    "type": "string"
                 },
                 "token_info": {
                     "type": "object",
@@ -4233,7 +5435,7 @@ var doc = `{
                     "x-nullable": true
                 },
                 "token_id": {
-                    "type": "integer"
+                    "type": "string"
                 },
                 "token_info": {
                     "type": "object",
@@ -4370,7 +5572,7 @@ var doc = `{
                     "x-nullable": true
                 },
                 "token_id": {
-                    "type": "integer"
+                    "type": "string"
                 },
                 "token_info": {
                     "type": "object",
@@ -4462,7 +5664,7 @@ var doc = `{
                     "$ref": "#/definitions/handlers.TokenMetadata"
                 },
                 "token_id": {
-                    "type": "integer"
+                    "type": "string"
                 }
             }
         },
@@ -4484,6 +5686,28 @@ var doc = `{
                 }
             }
         },
+        "handlers.UnforgedOperationGroup": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "type": "string"
+                },
+                "contents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.Operation"
+                    }
+                },
+                "hash": {
+                    "type": "string",
+                    "x-nullable": true
+                },
+                "signature": {
+                    "type": "string",
+                    "x-nullable": true
+                }
+            }
+        },
         "handlers.ViewSchema": {
             "type": "object",
             "properties": {
@@ -4497,6 +5721,9 @@ var doc = `{
                 "implementation": {
                     "type": "integer"
                 },
+                "kind": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -4511,6 +5738,33 @@ var doc = `{
                 }
             }
         },
+        "handlers.batchOperationRequest": {
+            "type": "object",
+            "required": [
+                "kind"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "fork": {
+                    "$ref": "#/definitions/handlers.forkRequest"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "handlers.executeViewRequest": {
             "type": "object",
             "required": [
@@ -4543,6 +5797,39 @@ var doc = `{
                 }
             }
         },
+        "handlers.forkRequest": {
+            "type": "object",
+            "required": [
+                "storage"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "script": {
+                    "type": "string"
+                },
+                "storage": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "handlers.getBigMapByKeyRequest": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
         "handlers.getEntrypointDataRequest": {
             "type": "object",
             "required": [
@@ -4582,6 +5869,9 @@ var doc = `{
                 "name": {
                     "type": "string"
                 },
+                "profile": {
+                    "type": "boolean"
+                },
                 "sender": {
                     "type": "string"
                 },
@@ -4590,6 +5880,61 @@ var doc = `{
                 }
             }
         },
+        "handlers.runOperationRequest": {
+            "type": "object",
+            "required": [
+                "data",
+                "name"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.simulateBatchRequest": {
+            "type": "object",
+            "required": [
+                "operations",
+                "source"
+            ],
+            "properties": {
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.batchOperationRequest"
+                    }
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.unforgeRequest": {
+            "type": "object",
+            "required": [
+                "data",
+                "network"
+            ],
+            "properties": {
+                "data": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                }
+            }
+        },
         "models.Group": {
             "type": "object",
             "properties": {
@@ -4672,6 +6017,96 @@ var doc = `{
                 }
             }
         },
+        "profiler.Block": {
+            "type": "object",
+            "properties": {
+                "consumed_milligas": {
+                    "type": "integer"
+                },
+                "end_col": {
+                    "type": "integer"
+                },
+                "instructions": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "integer"
+                },
+                "max_stack_depth": {
+                    "type": "integer"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "start_col": {
+                    "type": "integer"
+                }
+            }
+        },
+        "profiler.Instruction": {
+            "type": "object",
+            "properties": {
+                "consumed_milligas": {
+                    "type": "integer"
+                },
+                "end_col": {
+                    "type": "integer"
+                },
+                "executions": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "integer"
+                },
+                "max_stack_depth": {
+                    "type": "integer"
+                },
+                "prim": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "start_col": {
+                    "type": "integer"
+                }
+            }
+        },
+        "profiler.Profile": {
+            "type": "object",
+            "properties": {
+                "blocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/profiler.Block"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "consumed_milligas": {
+                    "type": "integer"
+                },
+                "gas_limit": {
+                    "type": "integer"
+                },
+                "instructions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/profiler.Instruction"
+                    }
+                },
+                "max_stack_depth": {
+                    "type": "integer"
+                },
+                "steps": {
+                    "type": "integer"
+                },
+                "unaccounted_milligas": {
+                    "type": "integer"
+                }
+            }
+        },
         "tezerrors.Error": {
             "type": "object",
             "properties": {
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/database"
+	"github.com/baking-bad/bcdhub/internal/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+)
+
+const defaultAPIKeyUsageDays = 30
+
+// ListAPIKeys -
+func (ctx *Context) ListAPIKeys(c *gin.Context) {
+	userID := CurrentUserID(c)
+	if userID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user"})
+		return
+	}
+
+	keys, err := ctx.DB.ListAPIKeys(userID)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// CreateAPIKey - issues new API key. The key is returned only in this response.
+func (ctx *Context) CreateAPIKey(c *gin.Context) {
+	var req createAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	userID := CurrentUserID(c)
+	if userID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user"})
+		return
+	}
+
+	key, hash, prefix, err := ratelimit.GenerateKey()
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	apiKey := database.APIKey{
+		UserID: userID,
+		Name:   req.Name,
+		Prefix: prefix,
+		Hash:   hash,
+	}
+	if err := ctx.DB.CreateAPIKey(&apiKey); ctx.handleError(c, err, 0) {
+		return
+	}
+
+	c.JSON(http.StatusOK, CreatedAPIKey{
+		APIKey: apiKey,
+		Key:    key,
+	})
+}
+
+// DeleteAPIKey -
+func (ctx *Context) DeleteAPIKey(c *gin.Context) {
+	userID := CurrentUserID(c)
+	if userID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user"})
+		return
+	}
+
+	var req apiKeyRequest
+	if err := c.BindUri(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	apiKey, err := ctx.getUserAPIKey(userID, req.ID)
+	if gorm.IsRecordNotFoundError(err) {
+		ctx.handleError(c, err, http.StatusNotFound)
+		return
+	}
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	if err := ctx.DB.DeleteAPIKey(userID, apiKey.ID); ctx.handleError(c, err, 0) {
+		return
+	}
+	if ctx.APIKeys != nil {
+		ctx.APIKeys.Delete(apiKey.Hash)
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetAPIKeyUsage - returns daily usage of API key for last `days` days. Requests of the last minute may be not counted yet.
+func (ctx *Context) GetAPIKeyUsage(c *gin.Context) {
+	userID := CurrentUserID(c)
+	if userID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user"})
+		return
+	}
+
+	var req apiKeyRequest
+	if err := c.BindUri(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+	var usageReq apiKeyUsageRequest
+	if err := c.BindQuery(&usageReq); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+	if usageReq.Days == 0 {
+		usageReq.Days = defaultAPIKeyUsageDays
+	}
+
+	apiKey, err := ctx.getUserAPIKey(userID, req.ID)
+	if gorm.IsRecordNotFoundError(err) {
+		ctx.handleError(c, err, http.StatusNotFound)
+		return
+	}
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, 1-int(usageReq.Days)).Truncate(24 * time.Hour)
+	usage, err := ctx.DB.ListAPIKeyUsage(apiKey.ID, since)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+func (ctx *Context) getUserAPIKey(userID, id uint) (*database.APIKey, error) {
+	keys, err := ctx.DB.ListAPIKeys(userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range keys {
+		if keys[i].ID == id {
+			return &keys[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
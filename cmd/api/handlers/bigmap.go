@@ -3,6 +3,7 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"sort"
 
 	"github.com/baking-bad/bcdhub/internal/bcd"
 	"github.com/baking-bad/bcdhub/internal/bcd/ast"
@@ -11,8 +12,12 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/bigmapaction"
 	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 )
 
+// maxBigMapRangeKeys - max count of big map keys which are sorted by range query
+const maxBigMapRangeKeys = 10000
+
 // GetBigMap godoc
 // @Summary Get big map info by pointer
 // @Description Get big map info by pointer
@@ -237,6 +242,182 @@ func (ctx *Context) GetBigMapByKeyHash(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetBigMapByKey godoc
+// @Summary Get big map value and its history by key
+// @Description Key is passed as JSON schema form data of big map key type. It's packed and hashed on server side.
+// @Tags bigmap
+// @ID get-bigmap-key
+// @Param network path string true "Network"
+// @Param ptr path integer true "Big map pointer"
+// @Param body body getBigMapByKeyRequest true "Request body"
+// @Param offset query integer false "Offset"
+// @Param size query integer false "Requested count" mininum(1) maximum(10)
+// @Accept json
+// @Produce json
+// @Success 200 {object} BigMapKeyResponse
+// @Failure 400 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/bigmap/{network}/{ptr}/keys/find [post]
+func (ctx *Context) GetBigMapByKey(c *gin.Context) {
+	var req getBigMapRequest
+	if err := c.BindUri(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	var pageReq pageableRequest
+	if err := c.BindQuery(&pageReq); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	var reqData getBigMapByKeyRequest
+	if err := c.BindJSON(&reqData); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	bigMapType, err := ctx.getBigMapTypeByPtr(req.Network, req.Ptr)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+	if bigMapType == nil {
+		ctx.handleError(c, errors.Errorf("Big map %d has no keys", req.Ptr), http.StatusNotFound)
+		return
+	}
+
+	key, err := bigMapType.KeyFromJSONSchema(reqData.Data)
+	if ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+	keyHash, err := ast.BigMapKeyHashFromNode(key)
+	if ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	current, err := ctx.BigMapDiffs.CurrentByKey(req.Network, keyHash, req.Ptr)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	bm, total, err := ctx.BigMapDiffs.GetByPtrAndKeyHash(req.Ptr, req.Network, keyHash, pageReq.Size, pageReq.Offset)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	history, err := ctx.prepareBigMapItem(bm, keyHash)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+	history.Total = total
+
+	keyMiguel, value, _, err := prepareItem(current, bigMapType)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+	history.Key = keyMiguel
+
+	response := BigMapKeyResponse{
+		BigMapDiffByKeyResponse: history,
+		Current: BigMapDiffItem{
+			Level:     current.Level,
+			Timestamp: current.Timestamp,
+		},
+	}
+	if value != nil {
+		response.Current.Value = value
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBigMapKeysRange godoc
+// @Summary Get active big map keys in range
+// @Description Keys are sorted in ascending order. Available for big maps with keys of nat, int, mutez, timestamp, address, key_hash, string or bytes type. Timestamp bounds can be passed in RFC3339 format or as unix time.
+// @Tags bigmap
+// @ID get-bigmap-keys-range
+// @Param network path string true "Network"
+// @Param ptr path integer true "Big map pointer"
+// @Param from query string false "Lower bound of keys (inclusive)"
+// @Param to query string false "Upper bound of keys (inclusive)"
+// @Param offset query integer false "Offset"
+// @Param size query integer false "Requested count" mininum(1) maximum(10)
+// @Accept json
+// @Produce json
+// @Success 200 {array} BigMapResponseItem
+// @Failure 400 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/bigmap/{network}/{ptr}/range [get]
+func (ctx *Context) GetBigMapKeysRange(c *gin.Context) {
+	var req getBigMapRequest
+	if err := c.BindUri(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	var rangeReq bigMapRangeRequest
+	if err := c.BindQuery(&rangeReq); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	bigMapType, err := ctx.getBigMapTypeByPtr(req.Network, req.Ptr)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+	if bigMapType == nil {
+		ctx.handleError(c, errors.Errorf("Big map %d has no keys", req.Ptr), http.StatusNotFound)
+		return
+	}
+	if !bigMapType.HasOrderedKeys() {
+		ctx.handleError(c, errors.Errorf("Range queries are not supported for big map keys of type %s", bigMapType.KeyType.GetPrim()), http.StatusBadRequest)
+		return
+	}
+
+	var from, to ast.Node
+	if rangeReq.From != "" {
+		from, err = bigMapType.KeyFromString(rangeReq.From)
+		if ctx.handleError(c, err, http.StatusBadRequest) {
+			return
+		}
+	}
+	if rangeReq.To != "" {
+		to, err = bigMapType.KeyFromString(rangeReq.To)
+		if ctx.handleError(c, err, http.StatusBadRequest) {
+			return
+		}
+	}
+
+	bm, err := ctx.BigMapDiffs.Get(bigmapdiff.GetContext{
+		Ptr:     &req.Ptr,
+		Network: req.Network,
+		Size:    maxBigMapRangeKeys,
+	})
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	keys, err := filterBigMapKeys(bm, bigMapType, from, to)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	if rangeReq.Size == 0 {
+		rangeReq.Size = 10
+	}
+	if rangeReq.Offset >= int64(len(keys)) {
+		c.JSON(http.StatusOK, []BigMapResponseItem{})
+		return
+	}
+	end := rangeReq.Offset + rangeReq.Size
+	if end > int64(len(keys)) {
+		end = int64(len(keys))
+	}
+
+	response, err := prepareBigMapKeysWithType(keys[rangeReq.Offset:end], bigMapType)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // GetBigMapDiffCount godoc
 // @Summary Get big map diffs count info by pointer
 // @Description Get big map diffs count info by pointer
@@ -278,6 +459,10 @@ func (ctx *Context) prepareBigMapKeys(data []bigmapdiff.Bucket) ([]BigMapRespons
 		return nil, err
 	}
 
+	return prepareBigMapKeysWithType(data, bigMapType)
+}
+
+func prepareBigMapKeysWithType(data []bigmapdiff.Bucket, bigMapType *ast.BigMap) ([]BigMapResponseItem, error) {
 	res := make([]BigMapResponseItem, len(data))
 	for i := range data {
 		key, value, keyString, err := prepareItem(data[i].BigMapDiff, bigMapType)
@@ -380,6 +565,62 @@ func createMiguelForType(typ ast.Node, raw []byte) (*ast.MiguelNode, error) {
 	return typ.ToMiguel()
 }
 
+// filterBigMapKeys - returns active keys of big map which are in range [from, to] sorted in ascending order. Nil bound is not checked.
+func filterBigMapKeys(data []bigmapdiff.Bucket, bigMapType *ast.BigMap, from, to ast.Node) ([]bigmapdiff.Bucket, error) {
+	type orderedKey struct {
+		bucket bigmapdiff.Bucket
+		key    ast.Node
+	}
+
+	keys := make([]orderedKey, 0, len(data))
+	for i := range data {
+		if data[i].Value == nil {
+			continue
+		}
+		key, err := bigMapType.KeyFromBytes(data[i].Key)
+		if err != nil {
+			return nil, err
+		}
+		if from != nil {
+			cmp, err := key.Compare(from)
+			if err != nil {
+				return nil, err
+			}
+			if cmp < 0 {
+				continue
+			}
+		}
+		if to != nil {
+			cmp, err := key.Compare(to)
+			if err != nil {
+				return nil, err
+			}
+			if cmp > 0 {
+				continue
+			}
+		}
+		keys = append(keys, orderedKey{data[i], key})
+	}
+
+	var sortErr error
+	sort.SliceStable(keys, func(i, j int) bool {
+		cmp, err := keys[i].key.Compare(keys[j].key)
+		if err != nil {
+			sortErr = err
+		}
+		return cmp < 0
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	result := make([]bigmapdiff.Bucket, len(keys))
+	for i := range keys {
+		result[i] = keys[i].bucket
+	}
+	return result, nil
+}
+
 func prepareBigMapHistory(arr []bigmapaction.BigMapAction, ptr int64) BigMapHistoryResponse {
 	if len(arr) == 0 {
 		return BigMapHistoryResponse{}
@@ -416,6 +657,22 @@ func findBigMapType(storage *ast.TypedAst, ptr int64) *ast.BigMap {
 	return nil
 }
 
+// getBigMapTypeByPtr - returns type of big map by pointer. If big map has no keys nil is returned.
+func (ctx *Context) getBigMapTypeByPtr(network string, ptr int64) (*ast.BigMap, error) {
+	bm, err := ctx.BigMapDiffs.Get(bigmapdiff.GetContext{
+		Ptr:     &ptr,
+		Network: network,
+		Size:    1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(bm) == 0 {
+		return nil, nil
+	}
+	return ctx.getBigMapType(network, bm[0].Address, bm[0].Protocol, ptr)
+}
+
 func (ctx *Context) getBigMapType(network, address, protocol string, ptr int64) (*ast.BigMap, error) {
 	storage, err := ctx.getStorageType(address, network, bcd.GetCurrentProtocol())
 	if err != nil {
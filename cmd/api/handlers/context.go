@@ -1,9 +1,18 @@
 package handlers
 
 import (
+	"fmt"
+	"net"
+	"os"
+
 	"github.com/baking-bad/bcdhub/cmd/api/oauth"
 	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/mempool"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/ratelimit"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/karlseguin/ccache"
 )
@@ -13,8 +22,15 @@ var json = jsoniter.ConfigCompatibleWithStandardLibrary
 // Context -
 type Context struct {
 	*config.Context
-	OAUTH oauth.Config
-	Cache *ccache.Cache
+	OAUTH   oauth.Config
+	Cache   *ccache.Cache
+	Stream  *StreamHub
+	Mempool *mempool.Monitor
+	Limiter *ratelimit.Limiter
+	APIKeys *ccache.Cache
+
+	streamUpgrader websocket.Upgrader
+	trustedProxies []*net.IPNet
 }
 
 // NewContext -
@@ -28,23 +44,92 @@ func NewContext(cfg config.Config) (*Context, error) {
 		}
 	}
 
-	ctx := config.NewContext(
+	opts := []config.ContextOption{
 		config.WithStorage(cfg.Storage),
 		config.WithRPC(cfg.RPC),
 		config.WithDatabase(cfg.DB),
 		config.WithShare(cfg.SharePath),
 		config.WithTzKTServices(cfg.TzKT),
 		config.WithLoadErrorDescriptions(),
+		config.WithLoadContractInterfaces(cfg.SharePath),
 		config.WithConfigCopy(cfg),
 		config.WithPinata(cfg.API.Pinata),
 		config.WithTzipSchema("data/tzip-16-schema.json"),
-	)
+	}
+	if len(cfg.API.MQ.Queues) > 0 {
+		opts = append(opts, config.WithRabbit(cfg.RabbitMQ, getStreamServiceName(cfg.API.ProjectName), cfg.API.MQ))
+	}
 
-	return &Context{
-		Context: ctx,
+	ctx := &Context{
+		Context: config.NewContext(opts...),
 		OAUTH:   oauthCfg,
 		Cache:   ccache.New(ccache.Configure().MaxSize(10)),
-	}, nil
+		Stream:  NewStreamHub(),
+
+		streamUpgrader: newStreamUpgrader(cfg.API.StreamOrigins),
+	}
+
+	if ctx.MQ != nil {
+		for _, queue := range ctx.MQ.GetQueues() {
+			go ctx.listenStream(queue)
+		}
+	}
+
+	if cfg.API.Frontend.MempoolEnabled {
+		ctx.Mempool = newMempoolMonitor(ctx.RPC, cfg.API.Networks)
+		ctx.Mempool.Start()
+	}
+
+	if cfg.API.RateLimit.Enabled {
+		trustedProxies, err := parseTrustedProxies(cfg.API.RateLimit.TrustedProxies)
+		if err != nil {
+			return nil, err
+		}
+		ctx.trustedProxies = trustedProxies
+		ctx.Limiter = newRateLimiter(ctx.DB, cfg.API.RateLimit)
+		ctx.Limiter.Start()
+		ctx.APIKeys = ccache.New(ccache.Configure())
+	}
+
+	return ctx, nil
+}
+
+// Close -
+func (ctx *Context) Close() {
+	if ctx.Mempool != nil {
+		if err := ctx.Mempool.Close(); err != nil {
+			logger.Error(err)
+		}
+	}
+	if ctx.Limiter != nil {
+		if err := ctx.Limiter.Close(); err != nil {
+			logger.Error(err)
+		}
+	}
+	ctx.Context.Close()
+}
+
+func newMempoolMonitor(rpc map[string]noderpc.INode, networks []string) *mempool.Monitor {
+	nodes := make(map[string]noderpc.IMempoolMonitor)
+	for _, network := range networks {
+		node, ok := rpc[network]
+		if !ok {
+			continue
+		}
+		if monitor, ok := node.(noderpc.IMempoolMonitor); ok {
+			nodes[network] = monitor
+		}
+	}
+	return mempool.NewMonitor(nodes)
+}
+
+// getStreamServiceName - every API instance needs its own queues to receive all events, so host name is added to service name
+func getStreamServiceName(projectName string) string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return projectName
+	}
+	return fmt.Sprintf("%s.%s", projectName, hostname)
 }
 
 // CurrentUserID - return userID (uint) from gin context
@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"net/http"
-	"time"
 
 	"github.com/baking-bad/bcdhub/internal/bcd"
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
@@ -10,6 +9,8 @@ import (
 	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/baking-bad/bcdhub/internal/database"
 	"github.com/baking-bad/bcdhub/internal/elastic/core"
+	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/baking-bad/bcdhub/internal/mempool"
 	"github.com/baking-bad/bcdhub/internal/models"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
 	"github.com/gin-gonic/gin"
@@ -100,15 +101,12 @@ func (ctx *Context) getMempoolEvents(subscriptions []database.Subscription) ([]m
 			continue
 		}
 
-		api, err := ctx.GetTzKTService(sub.Network)
+		pool, err := ctx.getMempool(sub.Network)
 		if err != nil {
-			return events, err
+			continue
 		}
 
-		res, err := api.GetMempool(sub.Address)
-		if err != nil {
-			return events, err
-		}
+		res := pool.GetByAddress(sub.Address)
 		if len(res) == 0 {
 			continue
 		}
@@ -122,35 +120,39 @@ func (ctx *Context) getMempoolEvents(subscriptions []database.Subscription) ([]m
 		}
 
 		for _, item := range res {
-			status := item.Body.Status
+			if !helpers.StringInArray(item.Kind, mempool.Kinds) {
+				continue
+			}
+
+			status := item.Status
 			if status == consts.Applied {
 				status = consts.Pending
 			}
 
 			op := core.EventOperation{
 				Network:     sub.Network,
-				Hash:        item.Body.Hash,
+				Hash:        item.Hash,
 				Status:      status,
-				Timestamp:   time.Unix(item.Body.Timestamp, 0).UTC(),
-				Kind:        item.Body.Kind,
-				Fee:         item.Body.Fee,
-				Amount:      item.Body.Amount,
-				Source:      item.Body.Source,
-				Destination: item.Body.Destination,
+				Timestamp:   item.Timestamp,
+				Kind:        item.Kind,
+				Fee:         item.Fee,
+				Amount:      item.Amount,
+				Source:      item.Source,
+				Destination: item.Destination,
 			}
 
 			op.SourceAlias = aliases[op.Source]
 			op.DestinationAlias = aliases[op.Destination]
-			op.Errors, err = tezerrors.ParseArray(item.Body.Errors)
+			op.Errors, err = tezerrors.ParseArray(item.Errors)
 			if err != nil {
 				return nil, err
 			}
 
-			if bcd.IsContract(op.Destination) && item.Body.Protocol != "" {
-				if len(item.Body.Parameters) > 0 {
-					p := types.NewParameters(item.Body.Parameters)
+			if bcd.IsContract(op.Destination) && item.Protocol != "" {
+				if len(item.Parameters) > 0 {
+					p := types.NewParameters(item.Parameters)
 
-					parameter, err := ctx.getParameterType(op.Destination, op.Network, item.Body.Protocol)
+					parameter, err := ctx.getParameterType(op.Destination, op.Network, item.Protocol)
 					if err != nil {
 						return events, err
 					}
@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"net/http"
+
+	"github.com/baking-bad/bcdhub/internal/bcd"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// ForgeOperation godoc
+// @Summary Forge entrypoint call
+// @Description Build transaction which calls entrypoint with passed arguments and forge it to bytes. The same transaction is simulated by `run_operation`. Gas and storage limits are set by simulation result and fee is estimated by minimal fees of bakers.
+// @Tags contract
+// @ID forge-operation
+// @Param network path string true "Network"
+// @Param address path string true "KT address" minlength(36) maxlength(36)
+// @Param body body runOperationRequest true "Request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} ForgedOperation
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/contract/{network}/{address}/entrypoints/forge [post]
+func (ctx *Context) ForgeOperation(c *gin.Context) {
+	var req getContractRequest
+	if err := c.BindUri(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+	var reqForge runOperationRequest
+	if err := c.BindJSON(&reqForge); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	state, err := ctx.Blocks.Last(req.Network)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	rpc, err := ctx.GetRPC(req.Network)
+	if ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	protocol, err := ctx.Protocols.GetProtocol(req.Network, "", -1)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	transaction, err := ctx.buildTransaction(rpc, state, protocol.Constants, req.Address, reqForge)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	forged, err := estimateOperation(rpc, state, &transaction)
+	if err != nil {
+		code := 0
+		if errors.Is(err, errSimulationFailed) {
+			code = http.StatusBadRequest
+		}
+		ctx.handleError(c, err, code)
+		return
+	}
+
+	c.JSON(http.StatusOK, ForgedOperation{
+		Bytes:        hex.EncodeToString(forged),
+		Branch:       state.Hash,
+		Fee:          transaction.Fee,
+		Counter:      transaction.Counter,
+		GasLimit:     transaction.GasLimit,
+		StorageLimit: transaction.StorageLimit,
+	})
+}
+
+// UnforgeOperation godoc
+// @Summary Unforge operation group
+// @Description Decode forged operation group (signed or not) and show what it does
+// @Tags operations
+// @ID unforge-operation
+// @Param body body unforgeRequest true "Request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} UnforgedOperationGroup
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/unforge [post]
+func (ctx *Context) UnforgeOperation(c *gin.Context) {
+	var req unforgeRequest
+	if err := c.BindJSON(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	group, err := noderpc.UnforgeOperationGroupString(req.Data)
+	if ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	state, err := ctx.Blocks.Last(req.Network)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	response := UnforgedOperationGroup{
+		Hash:      group.Hash,
+		Branch:    group.Branch,
+		Signature: group.Signature,
+		Contents:  make([]Operation, len(group.Contents)),
+	}
+	for i := range group.Contents {
+		op, err := ctx.prepareUnforgedOperation(group.Contents[i], state)
+		if ctx.handleError(c, err, 0) {
+			return
+		}
+		op.Hash = group.Hash
+		op.ContentIndex = int64(i)
+		response.Contents[i] = op
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// buildTransaction - builds transaction which calls entrypoint of `address`. It's simulated by `run_operation` and forged by `forge`.
+func (ctx *Context) buildTransaction(rpc noderpc.INode, state block.Block, constants protocol.Constants, address string, req runOperationRequest) (noderpc.Operation, error) {
+	counter, err := rpc.GetCounter(req.Source)
+	if err != nil {
+		return noderpc.Operation{}, err
+	}
+	return ctx.buildCall(state, constants, req.Source, address, req.Name, req.Data, req.Amount, counter+1)
+}
+
+// buildCall - builds transaction with `counter` from `source` to `destination`. Parameters are set only if `entrypoint` is not empty.
+func (ctx *Context) buildCall(state block.Block, constants protocol.Constants, source, destination, entrypoint string, data map[string]interface{}, amount, counter int64) (noderpc.Operation, error) {
+	operation := noderpc.Operation{
+		Kind:         consts.Transaction,
+		Source:       source,
+		Destination:  &destination,
+		Fee:          0,
+		Counter:      counter,
+		GasLimit:     constants.HardGasLimitPerOperation,
+		StorageLimit: constants.HardStorageLimitPerOperation,
+		Amount:       &amount,
+	}
+	if entrypoint == "" {
+		return operation, nil
+	}
+
+	value, err := ctx.buildParametersForExecution(state.Network, destination, state.Protocol, entrypoint, data)
+	if err != nil {
+		return noderpc.Operation{}, err
+	}
+	parameters, err := json.Marshal(types.Parameters{
+		Entrypoint: entrypoint,
+		Value:      value,
+	})
+	if err != nil {
+		return noderpc.Operation{}, err
+	}
+	operation.Parameters = parameters
+	return operation, nil
+}
+
+// Default minimal fees of bakers: `minimalFees` + `minimalMutezPerByte` for every byte of signed operation + `minimalNanotezPerGasUnit` for every unit of gas limit
+const (
+	minimalFees              = 100
+	minimalMutezPerByte      = 1
+	minimalNanotezPerGasUnit = 100
+
+	signatureSize         = 64
+	gasReserve            = 100
+	allocationStorageSize = 257
+)
+
+var errSimulationFailed = errors.New("simulation failed")
+
+// estimateOperation - simulates `operation`, sets its gas limit, storage limit and fee and returns forged operation group
+func estimateOperation(rpc noderpc.INode, state block.Block, operation *noderpc.Operation) ([]byte, error) {
+	response, err := rpc.RunOperation(state.ChainID, state.Hash, []noderpc.Operation{*operation})
+	if err != nil {
+		var e noderpc.InvalidNodeResponse
+		if errors.As(err, &e) {
+			return nil, errors.Wrap(errSimulationFailed, string(e.Raw))
+		}
+		return nil, err
+	}
+	if len(response.Contents) != 1 {
+		return nil, errors.Errorf("invalid simulation response: %d operations", len(response.Contents))
+	}
+
+	gas, storage, err := getConsumedLimits(response.Contents[0])
+	if err != nil {
+		return nil, err
+	}
+	operation.GasLimit = gas + gasReserve
+	operation.StorageLimit = storage
+	operation.Fee = 0
+
+	// fee changes size of forged operation, so it's recalculated until it covers the size
+	for {
+		forged, err := noderpc.ForgeOperationGroup(noderpc.OperationGroup{
+			Branch:   state.Hash,
+			Contents: []noderpc.Operation{*operation},
+		})
+		if err != nil {
+			return nil, err
+		}
+		fee := minimalFees + minimalMutezPerByte*int64(len(forged)+signatureSize) + (minimalNanotezPerGasUnit*operation.GasLimit+999)/1000
+		if fee <= operation.Fee {
+			return forged, nil
+		}
+		operation.Fee = fee
+	}
+}
+
+// getConsumedLimits - returns gas and storage consumed by simulated operation and its internal operations
+func getConsumedLimits(operation noderpc.Operation) (gas int64, storage int64, err error) {
+	results := []*noderpc.OperationResult{operation.GetResult()}
+	if operation.Metadata != nil {
+		for i := range operation.Metadata.Internal {
+			results = append(results, operation.Metadata.Internal[i].GetResult())
+		}
+	}
+
+	for i := range results {
+		if results[i] == nil {
+			return 0, 0, errors.New("invalid simulation response: operation without result")
+		}
+		if results[i].Status != consts.Applied {
+			return 0, 0, errors.Wrapf(errSimulationFailed, "operation is %s: %s", results[i].Status, string(results[i].Errors))
+		}
+
+		if results[i].ConsumedMilligas != nil {
+			gas += (*results[i].ConsumedMilligas + 999) / 1000
+		} else {
+			gas += results[i].ConsumedGas
+		}
+		if results[i].PaidStorageSizeDiff != nil {
+			storage += *results[i].PaidStorageSizeDiff
+		}
+		if results[i].AllocatedDestinationContract != nil && *results[i].AllocatedDestinationContract {
+			storage += allocationStorageSize
+		}
+		storage += int64(len(results[i].Originated)) * allocationStorageSize
+	}
+	return
+}
+
+func (ctx *Context) prepareUnforgedOperation(content noderpc.Operation, state block.Block) (Operation, error) {
+	op := Operation{
+		Network:      state.Network,
+		Protocol:     state.Protocol,
+		Timestamp:    state.Timestamp,
+		Kind:         content.Kind,
+		Source:       content.Source,
+		Fee:          content.Fee,
+		Counter:      content.Counter,
+		GasLimit:     content.GasLimit,
+		StorageLimit: content.StorageLimit,
+		PublicKey:    content.PublicKey,
+		Delegate:     content.Delegate,
+	}
+	if content.Amount != nil {
+		op.Amount = *content.Amount
+	}
+	if content.Balance != nil {
+		op.Balance = *content.Balance
+	}
+	if content.Destination != nil {
+		op.Destination = *content.Destination
+	}
+
+	if len(content.Parameters) == 0 || !bcd.IsContract(op.Destination) {
+		return op, nil
+	}
+
+	script, err := ctx.getScript(op.Destination, state.Network, state.Protocol)
+	if err != nil {
+		return op, err
+	}
+	if err := ctx.setParameters(string(content.Parameters), script, &op); err != nil {
+		// destination is unknown or parameters don't match its type: show them as is
+		params := types.NewParameters(content.Parameters)
+		op.Entrypoint = params.Entrypoint
+		op.Parameters = params.Value
+	}
+	return op, nil
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestInt64(value int64) *int64 {
+	return &value
+}
+
+func Test_estimateOperation(t *testing.T) {
+	destination := "KT1Dc6A6jTY9sG4UvqKciqbJNAGtXqb4n7vZ"
+	state := block.Block{
+		Network: "mainnet",
+		ChainID: "NetXdQprcVkpaWU",
+		Hash:    "BLYntWHzaTZFq2JS7ZoSsGB5sSK34ETXsjuVLrHPR23qu1g8XJu",
+	}
+	newTransaction := func() noderpc.Operation {
+		return noderpc.Operation{
+			Kind:         "transaction",
+			Source:       "tz1djN1zPWUYpanMS1YhKJ2EmFSYs6qjf4bW",
+			Destination:  &destination,
+			Counter:      554732,
+			GasLimit:     1040000,
+			StorageLimit: 60000,
+			Amount:       newTestInt64(0),
+			Parameters:   []byte(`{"entrypoint":"mint","value":{"prim":"Pair","args":[{"string":"tz1XvMBRHwmXtXS2K6XYZdmcc5kdwB9STFJu"},{"int":"8500"}]}}`),
+		}
+	}
+
+	tests := []struct {
+		name             string
+		result           noderpc.OperationResult
+		internal         []noderpc.Operation
+		wantGasLimit     int64
+		wantStorageLimit int64
+		wantErr          error
+	}{
+		{
+			name: "applied",
+			result: noderpc.OperationResult{
+				Status:              "applied",
+				ConsumedGas:         622730,
+				PaidStorageSizeDiff: newTestInt64(154),
+			},
+			wantGasLimit:     622830,
+			wantStorageLimit: 154,
+		}, {
+			name: "internal operations and milligas",
+			result: noderpc.OperationResult{
+				Status:           "applied",
+				ConsumedGas:      1000,
+				ConsumedMilligas: newTestInt64(1000001),
+			},
+			internal: []noderpc.Operation{
+				{
+					Kind: "origination",
+					Result: &noderpc.OperationResult{
+						Status:              "applied",
+						ConsumedGas:         500,
+						PaidStorageSizeDiff: newTestInt64(100),
+						Originated:          []string{"KT1C2MfcjWb5R1ZDDxVULCsGuxrf5fEn5264"},
+					},
+				},
+			},
+			wantGasLimit:     1001 + 500 + gasReserve,
+			wantStorageLimit: 100 + allocationStorageSize,
+		}, {
+			name: "failed",
+			result: noderpc.OperationResult{
+				Status: "failed",
+				Errors: []byte(`[{"kind":"temporary","id":"proto.008-PtEdo2Zk.michelson_v1.script_rejected"}]`),
+			},
+			wantErr: errSimulationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			result := tt.result
+			rpc := noderpc.NewMockINode(ctrl)
+			rpc.EXPECT().RunOperation(state.ChainID, state.Hash, gomock.Any()).Return(noderpc.OperationGroup{
+				Contents: []noderpc.Operation{
+					{
+						Kind: "transaction",
+						Metadata: &noderpc.OperationMetadata{
+							OperationResult: &result,
+							Internal:        tt.internal,
+						},
+					},
+				},
+			}, nil).Times(1)
+
+			transaction := newTransaction()
+			forged, err := estimateOperation(rpc, state, &transaction)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "estimateOperation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tt.wantGasLimit, transaction.GasLimit)
+			assert.Equal(t, tt.wantStorageLimit, transaction.StorageLimit)
+
+			size := int64(len(forged) + signatureSize)
+			assert.Equal(t, minimalFees+minimalMutezPerByte*size+(minimalNanotezPerGasUnit*transaction.GasLimit+999)/1000, transaction.Fee)
+
+			forgedAgain, err := noderpc.ForgeOperationGroup(noderpc.OperationGroup{
+				Branch:   state.Hash,
+				Contents: []noderpc.Operation{transaction},
+			})
+			if assert.NoError(t, err) {
+				assert.Equal(t, forged, forgedAgain, "returned bytes must contain estimated fee and limits")
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/ast/interfaces"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/gin-gonic/gin"
+)
+
+// GetContractInterfaces godoc
+// @Summary Get contract interfaces
+// @Description Get all built-in and registered contract interfaces. Contracts implementing interface are tagged by its name.
+// @Tags contract
+// @ID get-contract-interfaces
+// @Accept json
+// @Produce json
+// @Success 200 {array} ContractInterface
+// @Failure 500 {object} Error
+// @Router /v1/interfaces [get]
+func (ctx *Context) GetContractInterfaces(c *gin.Context) {
+	all, err := interfaces.GetAll()
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	response := make([]ContractInterface, 0, len(all))
+	for _, name := range interfaces.Names() {
+		ci, ok := all[name]
+		if !ok {
+			continue
+		}
+		var item ContractInterface
+		item.FromModel(ci)
+		response = append(response, item)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetContractInterfaceMatches godoc
+// @Summary Get interfaces implemented by contract
+// @Description Get interfaces implemented by contract with score. Score is share of interface entrypoints, views and storage fields found in contract.
+// @Tags contract
+// @ID get-contract-interface-matches
+// @Param network path string true "Network"
+// @Param address path string true "KT address" minlength(36) maxlength(36)
+// @Accept json
+// @Produce json
+// @Success 200 {array} InterfaceMatch
+// @Failure 400 {object} Error
+// @Failure 404 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/contract/{network}/{address}/interfaces [get]
+func (ctx *Context) GetContractInterfaceMatches(c *gin.Context) {
+	var req getContractRequest
+	if err := c.BindUri(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	cntr := contract.NewEmptyContract(req.Network, req.Address)
+	if err := ctx.Storage.GetByID(&cntr); ctx.handleError(c, err, 0) {
+		return
+	}
+
+	script, err := ctx.getScript(req.Address, req.Network, "")
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+	parameter, err := script.ParameterType()
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+	storage, err := script.StorageType()
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	matches := ast.MatchContractInterfaces(parameter, storage)
+	response := make([]InterfaceMatch, len(matches))
+	for i := range matches {
+		response[i] = InterfaceMatch(matches[i])
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/interpreter"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+)
+
+// bigMapGetter - receives current values of big maps from the index
+type bigMapGetter struct {
+	repository bigmapdiff.Repository
+	storage    models.GeneralRepository
+	network    string
+}
+
+// GetBigMapValue -
+func (g bigMapGetter) GetBigMapValue(ptr int64, keyHash string) (*base.Node, error) {
+	diff, err := g.repository.CurrentByKey(g.network, keyHash, ptr)
+	if err != nil {
+		if g.storage.IsRecordNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(diff.Value) == 0 {
+		return nil, nil
+	}
+	var value base.Node
+	if err := json.Unmarshal(diff.Value, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// getInterpreter - returns node which executes scripts locally and requests `network` node only if it's necessary
+func (ctx *Context) getInterpreter(network string) (noderpc.INode, error) {
+	rpc, err := ctx.GetRPC(network)
+	if err != nil {
+		return nil, err
+	}
+	return noderpc.NewInterpreter(rpc, interpreter.WithBigMapGetter(bigMapGetter{
+		repository: ctx.BigMapDiffs,
+		storage:    ctx.Storage,
+		network:    network,
+	})), nil
+}
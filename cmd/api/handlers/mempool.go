@@ -2,15 +2,15 @@ package handlers
 
 import (
 	"net/http"
-	"time"
 
 	"github.com/baking-bad/bcdhub/internal/bcd"
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
 	"github.com/baking-bad/bcdhub/internal/bcd/tezerrors"
 	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/baking-bad/bcdhub/internal/helpers"
-	"github.com/baking-bad/bcdhub/internal/tzkt"
+	"github.com/baking-bad/bcdhub/internal/mempool"
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 )
 
 // GetMempool godoc
@@ -32,68 +32,64 @@ func (ctx *Context) GetMempool(c *gin.Context) {
 		return
 	}
 
-	api, err := ctx.GetTzKTService(req.Network)
+	pool, err := ctx.getMempool(req.Network)
 	if err != nil {
 		c.JSON(http.StatusNoContent, []Operation{})
 		return
 	}
 
-	res, err := api.GetMempool(req.Address)
-	if ctx.handleError(c, err, 0) {
-		return
-	}
-
-	c.JSON(http.StatusOK, ctx.mempoolPostprocessing(res, req.Network))
+	c.JSON(http.StatusOK, ctx.mempoolPostprocessing(pool.GetByAddress(req.Address)))
 }
 
-func (ctx *Context) mempoolPostprocessing(res []tzkt.MempoolOperation, network string) []Operation {
-	ret := make([]Operation, len(res))
-	if len(res) == 0 {
-		return ret
+func (ctx *Context) getMempool(network string) (*mempool.Pool, error) {
+	if ctx.Mempool == nil {
+		return nil, errors.Wrap(mempool.ErrUnknownNetwork, network)
 	}
+	return ctx.Mempool.Get(network)
+}
 
-	for i := len(res) - 1; i >= 0; i-- {
-		item := ctx.prepareMempoolOperation(res[i], network, res[i].Body)
-		if item != nil {
-			ret[i] = *item
+func (ctx *Context) mempoolPostprocessing(res []mempool.Operation) []Operation {
+	ret := make([]Operation, 0, len(res))
+	for i := range res {
+		if item := ctx.prepareMempoolOperation(res[i]); item != nil {
+			ret = append(ret, *item)
 		}
 	}
-
 	return ret
 }
 
-func (ctx *Context) prepareMempoolOperation(item tzkt.MempoolOperation, network string, raw interface{}) *Operation {
-	status := item.Body.Status
+func (ctx *Context) prepareMempoolOperation(item mempool.Operation) *Operation {
+	status := item.Status
 	if status == consts.Applied {
 		status = consts.Pending
 	}
 
-	if !helpers.StringInArray(item.Body.Kind, []string{consts.Transaction, consts.Origination, consts.OriginationNew}) {
+	if !helpers.StringInArray(item.Kind, mempool.Kinds) {
 		return nil
 	}
 
 	op := Operation{
-		Protocol:  item.Body.Protocol,
-		Hash:      item.Body.Hash,
-		Network:   network,
-		Timestamp: time.Unix(item.Body.Timestamp, 0).UTC(),
-
-		SourceAlias:      ctx.getAlias(network, item.Body.Source),
-		DestinationAlias: ctx.getAlias(network, item.Body.Destination),
-		Kind:             item.Body.Kind,
-		Source:           item.Body.Source,
-		Fee:              item.Body.Fee,
-		Counter:          item.Body.Counter,
-		GasLimit:         item.Body.GasLimit,
-		StorageLimit:     item.Body.StorageLimit,
-		Amount:           item.Body.Amount,
-		Destination:      item.Body.Destination,
+		Protocol:  item.Protocol,
+		Hash:      item.Hash,
+		Network:   item.Network,
+		Timestamp: item.Timestamp,
+
+		SourceAlias:      ctx.getAlias(item.Network, item.Source),
+		DestinationAlias: ctx.getAlias(item.Network, item.Destination),
+		Kind:             item.Kind,
+		Source:           item.Source,
+		Fee:              item.Fee,
+		Counter:          item.Counter,
+		GasLimit:         item.GasLimit,
+		StorageLimit:     item.StorageLimit,
+		Amount:           item.Amount,
+		Destination:      item.Destination,
 		Mempool:          true,
 		Status:           status,
-		RawMempool:       raw,
+		RawMempool:       item.Raw,
 	}
 
-	errs, err := tezerrors.ParseArray(item.Body.Errors)
+	errs, err := tezerrors.ParseArray(item.Errors)
 	if err != nil {
 		return nil
 	}
@@ -104,8 +100,8 @@ func (ctx *Context) prepareMempoolOperation(item tzkt.MempoolOperation, network
 	}
 
 	if bcd.IsContract(op.Destination) && op.Protocol != "" && op.Status == consts.Pending {
-		if len(item.Body.Parameters) > 0 {
-			_ = ctx.buildOperationParameters(item.Body.Parameters, &op)
+		if len(item.Parameters) > 0 {
+			_ = ctx.buildOperationParameters(item.Parameters, &op)
 		} else {
 			op.Entrypoint = consts.DefaultEntrypoint
 		}
@@ -3,7 +3,6 @@ package handlers
 import (
 	"net/http"
 	"strings"
-	"sync"
 
 	"github.com/baking-bad/bcdhub/internal/bcd"
 	"github.com/baking-bad/bcdhub/internal/bcd/ast"
@@ -167,50 +166,26 @@ func (ctx *Context) GetOperationErrorLocation(c *gin.Context) {
 }
 
 func (ctx *Context) getOperationFromMempool(hash string) *Operation {
-	var wg sync.WaitGroup
-	var opCh = make(chan *Operation, len(ctx.TzKTServices))
-
-	defer close(opCh)
-
-	for network := range ctx.TzKTServices {
-		wg.Add(1)
-		go ctx.getOperation(network, hash, opCh, &wg)
+	if ctx.Mempool == nil {
+		return nil
 	}
 
-	wg.Wait()
+	for _, network := range ctx.Config.API.Networks {
+		pool, err := ctx.Mempool.Get(network)
+		if err != nil {
+			continue
+		}
 
-	for i := 0; i < len(ctx.TzKTServices); i++ {
-		if op := <-opCh; op != nil {
-			return op
+		res := ctx.mempoolPostprocessing(pool.GetByHash(hash))
+		if len(res) > 0 {
+			// contents of group have the same timestamp and are sorted by counter descending, so the last one is the first content
+			return &res[len(res)-1]
 		}
 	}
 
 	return nil
 }
 
-func (ctx *Context) getOperation(network, hash string, ops chan<- *Operation, wg *sync.WaitGroup) {
-	defer wg.Done()
-
-	api, err := ctx.GetTzKTService(network)
-	if err != nil {
-		ops <- nil
-		return
-	}
-
-	res, err := api.GetMempool(hash)
-	if err != nil {
-		ops <- nil
-		return
-	}
-
-	if len(res) == 0 {
-		ops <- nil
-		return
-	}
-
-	ops <- ctx.prepareMempoolOperation(res[0], network, string(res[0].Raw))
-}
-
 func prepareFilters(req operationsRequest) map[string]interface{} {
 	filters := map[string]interface{}{}
 
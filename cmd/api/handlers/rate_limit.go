@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/database"
+	"github.com/baking-bad/bcdhub/internal/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	apiKeyHeader = "X-API-Key"
+	apiKeysTTL   = time.Minute
+)
+
+// RateLimit - limits requests of API key from `X-API-Key` header or of IP if key is not passed. Routes of `expensive` list (full paths) have their own limits.
+func (ctx *Context) RateLimit(expensive ...string) gin.HandlerFunc {
+	routes := make(map[string]struct{}, len(expensive))
+	for i := range expensive {
+		routes[expensive[i]] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		_, isExpensive := routes[c.FullPath()]
+
+		var result ratelimit.Result
+		if key := c.GetHeader(apiKeyHeader); key != "" {
+			apiKey, err := ctx.getAPIKey(key)
+			if ctx.handleError(c, err, 0) {
+				return
+			}
+			if apiKey == nil {
+				ctx.handleError(c, errors.New("Invalid API key"), http.StatusUnauthorized)
+				return
+			}
+
+			var quota ratelimit.Quota
+			result, quota, err = ctx.Limiter.AllowKey(apiKey.ID, isExpensive)
+			if ctx.handleError(c, err, 0) {
+				return
+			}
+			if quota.Limit > 0 {
+				c.Header("X-RateLimit-Quota-Limit", strconv.FormatUint(uint64(quota.Limit), 10))
+				c.Header("X-RateLimit-Quota-Remaining", strconv.FormatUint(uint64(quota.Remaining), 10))
+				c.Header("X-RateLimit-Quota-Reset", formatSeconds(quota.Reset))
+			}
+		} else {
+			result = ctx.Limiter.AllowIP(ctx.clientIP(c.Request), isExpensive)
+		}
+
+		if result.Limit > 0 {
+			c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+			c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			c.Header("X-RateLimit-Reset", formatSeconds(result.Reset))
+		}
+
+		if !result.Allowed {
+			c.Header("Retry-After", formatSeconds(result.RetryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, Error{Message: "Too many requests"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// clientIP - returns IP of request sender. `X-Forwarded-For` header is used only if sender is trusted proxy:
+// addresses of header are checked from the right (they are appended by proxies) and the first untrusted one is client.
+func (ctx *Context) clientIP(req *http.Request) string {
+	ip, _, err := net.SplitHostPort(strings.TrimSpace(req.RemoteAddr))
+	if err != nil {
+		ip = strings.TrimSpace(req.RemoteAddr)
+	}
+	if !ctx.isTrustedProxy(ip) {
+		return ip
+	}
+
+	forwarded := strings.Split(strings.Join(req.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		address := strings.TrimSpace(forwarded[i])
+		if address == "" {
+			continue
+		}
+		if !ctx.isTrustedProxy(address) {
+			return address
+		}
+		ip = address
+	}
+	return ip
+}
+
+func (ctx *Context) isTrustedProxy(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for i := range ctx.trustedProxies {
+		if ctx.trustedProxies[i].Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxies - parses IPs and CIDRs of proxies
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for i := range proxies {
+		proxy := strings.TrimSpace(proxies[i])
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: %s", proxies[i])
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// getAPIKey - returns nil if key does not exist. Absent keys are cached too, so brute force does not reach database.
+func (ctx *Context) getAPIKey(key string) (*database.APIKey, error) {
+	item, err := ctx.APIKeys.Fetch(ratelimit.HashKey(key), apiKeysTTL, func() (interface{}, error) {
+		apiKey, err := ctx.DB.GetAPIKeyByHash(ratelimit.HashKey(key))
+		if gorm.IsRecordNotFoundError(err) {
+			return (*database.APIKey)(nil), nil
+		}
+		return apiKey, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item.Value().(*database.APIKey), nil
+}
+
+func newRateLimiter(db ratelimit.UsageStorage, cfg config.RateLimitConfig) *ratelimit.Limiter {
+	return ratelimit.NewLimiter(db,
+		ratelimit.WithIPLimits(newLimits(cfg.IP), newLimits(cfg.ExpensiveIP)),
+		ratelimit.WithKeyLimits(newLimits(cfg.Key), newLimits(cfg.ExpensiveKey)),
+		ratelimit.WithDailyQuota(cfg.DailyQuota),
+		ratelimit.WithPeriod(time.Duration(cfg.FlushInterval)*time.Second),
+	)
+}
+
+func newLimits(cfg config.LimitConfig) ratelimit.Limits {
+	return ratelimit.Limits{
+		Rate:  cfg.Rate,
+		Burst: cfg.Burst,
+	}
+}
+
+// formatSeconds - rounds duration up to seconds
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_clientIP(t *testing.T) {
+	trustedProxies, err := parseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", "fd00::/8"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := &Context{trustedProxies: trustedProxies}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		{
+			name:       "direct request",
+			remoteAddr: "1.2.3.4:5678",
+			want:       "1.2.3.4",
+		}, {
+			name:       "header of untrusted sender is ignored",
+			remoteAddr: "1.2.3.4:5678",
+			forwarded:  []string{"5.6.7.8"},
+			want:       "1.2.3.4",
+		}, {
+			name:       "trusted proxy",
+			remoteAddr: "10.0.0.2:5678",
+			forwarded:  []string{"5.6.7.8"},
+			want:       "5.6.7.8",
+		}, {
+			name:       "spoofed addresses before client are ignored",
+			remoteAddr: "10.0.0.2:5678",
+			forwarded:  []string{"9.9.9.9, 5.6.7.8", "192.168.1.1"},
+			want:       "5.6.7.8",
+		}, {
+			name:       "IPv6 proxy",
+			remoteAddr: "[fd00::1]:5678",
+			forwarded:  []string{"2001:db8::1"},
+			want:       "2001:db8::1",
+		}, {
+			name:       "trusted proxy without header",
+			remoteAddr: "192.168.1.1:5678",
+			want:       "192.168.1.1",
+		}, {
+			name:       "all addresses are trusted",
+			remoteAddr: "10.0.0.2:5678",
+			forwarded:  []string{"10.0.0.3, 10.0.0.4"},
+			want:       "10.0.0.3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/v1/head", nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			req.RemoteAddr = tt.remoteAddr
+			for i := range tt.forwarded {
+				req.Header.Add("X-Forwarded-For", tt.forwarded[i])
+			}
+			assert.Equal(t, tt.want, ctx.clientIP(req))
+		})
+	}
+}
+
+func Test_parseTrustedProxies(t *testing.T) {
+	_, err := parseTrustedProxies([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+	_, err = parseTrustedProxies([]string{"proxy"})
+	assert.Error(t, err)
+}
@@ -1,6 +1,11 @@
 package handlers
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
+	"github.com/baking-bad/bcdhub/internal/database"
+)
 
 type getContractRequest struct {
 	Address string `uri:"address" binding:"required,address"`
@@ -83,18 +88,19 @@ type searchRequest struct {
 	Grouping  uint   `form:"g,omitempty"`
 	Indices   string `form:"i,omitempty"`
 	Languages string `form:"l,omitempty"`
+	Tags      string `form:"t,omitempty"`
 }
 
 // Subscription flags
 const (
-	WatchSame uint = 1 << iota
-	WatchSimilar
-	WatchMempool
-	WatchMigrations
-	WatchDeployments
-	WatchCalls
-	WatchErrors
-	SentryEnabled
+	WatchSame        = database.WatchSame
+	WatchSimilar     = database.WatchSimilar
+	WatchMempool     = database.WatchMempool
+	WatchMigrations  = database.WatchMigrations
+	WatchDeployments = database.WatchDeployments
+	WatchCalls       = database.WatchCalls
+	WatchErrors      = database.WatchErrors
+	SentryEnabled    = database.SentryEnabled
 )
 
 type subRequest struct {
@@ -109,6 +115,8 @@ type subRequest struct {
 	WatchErrors      bool   `json:"watch_errors"`
 	SentryEnabled    bool   `json:"sentry_enabled"`
 	SentryDSN        string `json:"sentry_dsn,omitempty"`
+	WebhookURL       string `json:"webhook_url,omitempty" binding:"omitempty,url"`
+	WebhookSecret    string `json:"webhook_secret,omitempty" binding:"omitempty,max=128"`
 }
 
 func newSubscriptionWithMask(mask uint) Subscription {
@@ -203,6 +211,16 @@ type bigMapSearchRequest struct {
 	MinLevel *int64 `form:"min_level,omitempty" binding:"omitempty"`
 }
 
+type getBigMapByKeyRequest struct {
+	Data map[string]interface{} `json:"data" binding:"required"`
+}
+
+type bigMapRangeRequest struct {
+	pageableRequest
+	From string `form:"from"`
+	To   string `form:"to"`
+}
+
 type opgRequest struct {
 	WithMempool bool `form:"with_mempool"`
 }
@@ -219,6 +237,11 @@ type getSeriesRequest struct {
 	Address string `form:"address,omitempty" binding:"omitempty"`
 }
 
+type getSaplingSeriesRequest struct {
+	Name   string `form:"name" binding:"oneof=commitments nullifiers updates" example:"commitments"`
+	Period string `form:"period" binding:"oneof=year month week day" example:"year"`
+}
+
 type getBySlugRequest struct {
 	Slug string `uri:"slug"  binding:"required"`
 }
@@ -241,6 +264,7 @@ type runCodeRequest struct {
 	GasLimit int64                  `json:"gas_limit,omitempty"`
 	Source   string                 `json:"source,omitempty" binding:"omitempty,address"`
 	Sender   string                 `json:"sender,omitempty" binding:"omitempty,address"`
+	Profile  bool                   `json:"profile,omitempty"`
 }
 
 type markReadRequest struct {
@@ -264,6 +288,20 @@ type forkRequest struct {
 	Storage map[string]interface{} `json:"storage" binding:"required"`
 }
 
+type simulateBatchRequest struct {
+	Source     string                  `json:"source" binding:"required,address"`
+	Operations []batchOperationRequest `json:"operations" binding:"required,min=1,max=10,dive"`
+}
+
+type batchOperationRequest struct {
+	Kind        string                 `json:"kind" binding:"required,oneof=transaction origination"`
+	Destination string                 `json:"destination,omitempty" binding:"omitempty,address"`
+	Name        string                 `json:"name,omitempty"`
+	Data        map[string]interface{} `json:"data,omitempty"`
+	Amount      int64                  `json:"amount,omitempty" binding:"gte=0"`
+	Fork        *forkRequest           `json:"fork,omitempty"`
+}
+
 type storageRequest struct {
 	Level int `form:"level" binding:"omitempty,gte=1"`
 }
@@ -285,7 +323,7 @@ func (req GetTokenStatsRequest) Addresses() []string {
 type getTokenSeriesRequest struct {
 	Contract string `form:"contract" binding:"required,address"`
 	Period   string `form:"period" binding:"oneof=year month week day" example:"year"`
-	TokenID  uint   `form:"token_id"`
+	TokenID  string `form:"token_id" binding:"omitempty,token_id"`
 	Slug     string `form:"slug" binding:"required"`
 }
 
@@ -312,6 +350,10 @@ type compilationTasksRequest struct {
 	Kind string `form:"kind" binding:"omitempty,compilation_kind"`
 }
 
+type webhookDeliveryRequest struct {
+	ID uint `uri:"id" binding:"required,min=1"`
+}
+
 type publicReposRequest struct {
 	Login string `form:"login" binding:"required"`
 }
@@ -327,20 +369,28 @@ type getDappRequest struct {
 
 type getContractTransfers struct {
 	pageableRequest
-	TokenID *uint `form:"token_id"  binding:"omitempty,min=0"`
+	TokenID *string `form:"token_id"  binding:"omitempty,token_id"`
 }
 
 type getTransfersRequest struct {
 	cursorRequest
-	Start     uint   `form:"start"  binding:"omitempty,min=1"`
-	End       uint   `form:"end"  binding:"omitempty,min=1,gtfield=Start"`
-	Contracts string `form:"contracts"  binding:"omitempty"`
-	Sort      string `form:"sort" binding:"omitempty,oneof=asc desc"`
-	TokenID   *int64 `form:"token_id" binding:"omitempty,min=0"`
+	Start     uint    `form:"start"  binding:"omitempty,min=1"`
+	End       uint    `form:"end"  binding:"omitempty,min=1,gtfield=Start"`
+	Contracts string  `form:"contracts"  binding:"omitempty"`
+	Sort      string  `form:"sort" binding:"omitempty,oneof=asc desc"`
+	TokenID   *string `form:"token_id" binding:"omitempty,token_id"`
 }
 
 type getTokenHolders struct {
-	TokenID *int64 `form:"token_id" binding:"min=0"`
+	TokenID string `form:"token_id" binding:"required,token_id"`
+}
+
+// parseTokenID - converts validated `token_id` query argument to big integer. Returns nil if argument is not set.
+func parseTokenID(tokenID *string) *types.BigInt {
+	if tokenID == nil {
+		return nil
+	}
+	return types.NewBigIntFromString(*tokenID)
 }
 
 type resolveDomainRequest struct {
@@ -367,3 +417,29 @@ type tokenRequest struct {
 	MaxLevel int64 `form:"max_level,omitempty" binding:"omitempty,gt_int64_ptr=MinLevel"`
 	MinLevel int64 `form:"min_level,omitempty" binding:"omitempty"`
 }
+
+type streamRequest struct {
+	Network    string `form:"network" binding:"required,network"`
+	Address    string `form:"address" binding:"omitempty,address"`
+	Entrypoint string `form:"entrypoint"`
+	Ptr        *int64 `form:"ptr" binding:"omitempty,min=0"`
+	Contract   string `form:"contract" binding:"omitempty,address"`
+	Types      string `form:"types"`
+}
+
+type unforgeRequest struct {
+	Network string `json:"network" binding:"required,network"`
+	Data    string `json:"data" binding:"required,hexadecimal"`
+}
+
+type createAPIKeyRequest struct {
+	Name string `json:"name" binding:"required,max=64"`
+}
+
+type apiKeyRequest struct {
+	ID uint `uri:"id" binding:"required,min=1"`
+}
+
+type apiKeyUsageRequest struct {
+	Days uint `form:"days" binding:"omitempty,min=1,max=90"`
+}
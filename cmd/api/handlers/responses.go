@@ -5,12 +5,17 @@ import (
 	"time"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/ast/interfaces"
 	"github.com/baking-bad/bcdhub/internal/bcd/formatter"
+	"github.com/baking-bad/bcdhub/internal/bcd/profiler"
 	"github.com/baking-bad/bcdhub/internal/bcd/tezerrors"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
+	"github.com/baking-bad/bcdhub/internal/database"
 	"github.com/baking-bad/bcdhub/internal/models/block"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/models/saplingdiff"
 	"github.com/baking-bad/bcdhub/internal/models/tezosdomain"
 	"github.com/baking-bad/bcdhub/internal/models/tokenmetadata"
 	"github.com/baking-bad/bcdhub/internal/models/transfer"
@@ -39,6 +44,7 @@ type Operation struct {
 	Result                             *OperationResult   `json:"result,omitempty" extensions:"x-nullable"`
 	Parameters                         interface{}        `json:"parameters,omitempty" extensions:"x-nullable"`
 	StorageDiff                        interface{}        `json:"storage_diff,omitempty" extensions:"x-nullable"`
+	Profile                            *profiler.Profile  `json:"profile,omitempty" extensions:"x-nullable"`
 	RawMempool                         interface{}        `json:"rawMempool,omitempty" extensions:"x-nullable"`
 	Timestamp                          time.Time          `json:"timestamp"`
 	ID                                 string             `json:"id,omitempty" extensions:"x-nullable"`
@@ -240,6 +246,8 @@ type Subscription struct {
 	WatchErrors      bool      `json:"watch_errors"`
 	SentryEnabled    bool      `json:"sentry_enabled"`
 	SentryDSN        string    `json:"sentry_dsn,omitempty" extensions:"x-nullable"`
+	WebhookURL       string    `json:"webhook_url,omitempty" extensions:"x-nullable"`
+	WebhookSecret    string    `json:"webhook_secret,omitempty" extensions:"x-nullable"`
 }
 
 // Event -
@@ -277,6 +285,15 @@ type BigMapItem struct {
 	Timestamp time.Time   `json:"timestamp"`
 }
 
+// StreamBigMapDiff -
+type StreamBigMapDiff struct {
+	BigMapItem
+	Ptr         int64  `json:"ptr"`
+	Network     string `json:"network"`
+	Address     string `json:"address"`
+	OperationID string `json:"operation_id"`
+}
+
 // BigMapResponseItem -
 type BigMapResponseItem struct {
 	Item  BigMapItem `json:"data"`
@@ -371,6 +388,12 @@ type BigMapDiffByKeyResponse struct {
 	Total   int64            `json:"total"`
 }
 
+// BigMapKeyResponse -
+type BigMapKeyResponse struct {
+	BigMapDiffByKeyResponse
+	Current BigMapDiffItem `json:"current"`
+}
+
 // CodeDiffResponse -
 type CodeDiffResponse struct {
 	Left  CodeDiffLeg          `json:"left"`
@@ -562,7 +585,7 @@ type Transfer struct {
 	Level          int64          `json:"level"`
 	From           string         `json:"from"`
 	To             string         `json:"to"`
-	TokenID        int64          `json:"token_id"`
+	TokenID        *types.BigInt  `json:"token_id"`
 	Amount         string         `json:"amount"`
 	Counter        int64          `json:"counter"`
 	Nonce          *int64         `json:"nonce,omitempty" extensions:"x-nullable"`
@@ -684,7 +707,7 @@ type TokenMetadata struct {
 	Contract           string                 `json:"contract"`
 	Network            string                 `json:"network,omitempty"`
 	Level              int64                  `json:"level,omitempty" extensions:"x-nullable"`
-	TokenID            int64                  `json:"token_id"`
+	TokenID            *types.BigInt          `json:"token_id"`
 	Symbol             string                 `json:"symbol,omitempty" extensions:"x-nullable"`
 	Name               string                 `json:"name,omitempty" extensions:"x-nullable"`
 	Decimals           *int64                 `json:"decimals,omitempty" extensions:"x-nullable"`
@@ -746,6 +769,7 @@ type ViewSchema struct {
 	Name           string          `json:"name"`
 	Implementation int             `json:"implementation"`
 	Description    string          `json:"description"`
+	Kind           string          `json:"kind"`
 	Schema         *ast.JSONSchema `json:"schema"`
 	DefaultModel   interface{}     `json:"default_model,omitempty" extensions:"x-nullable"`
 }
@@ -756,6 +780,13 @@ type ForkResponse struct {
 	Storage stdJSON.RawMessage `json:"storage"`
 }
 
+// SimulationResponse -
+type SimulationResponse struct {
+	Operations []Operation `json:"operations"`
+	Transfers  []Transfer  `json:"transfers"`
+	Failure    *Operation  `json:"failure,omitempty" extensions:"x-nullable"`
+}
+
 // TZIPResponse -
 type TZIPResponse struct {
 	Address string                          `json:"address,omitempty"`
@@ -780,3 +811,121 @@ type HeadResponse struct {
 	TotalWithdrawn  int64     `json:"total_withdrawn"`
 	FACount         int64     `json:"fa_count"`
 }
+
+// ForgedOperation -
+type ForgedOperation struct {
+	Bytes        string `json:"bytes"`
+	Branch       string `json:"branch"`
+	Fee          int64  `json:"fee"`
+	Counter      int64  `json:"counter"`
+	GasLimit     int64  `json:"gas_limit"`
+	StorageLimit int64  `json:"storage_limit"`
+}
+
+// UnforgedOperationGroup -
+type UnforgedOperationGroup struct {
+	Hash      string      `json:"hash,omitempty" extensions:"x-nullable"`
+	Branch    string      `json:"branch"`
+	Signature string      `json:"signature,omitempty" extensions:"x-nullable"`
+	Contents  []Operation `json:"contents"`
+}
+
+// Ticket -
+type Ticket struct {
+	Holder      string             `json:"holder"`
+	Ticketer    string             `json:"ticketer"`
+	ContentType stdJSON.RawMessage `json:"content_type" swaggertype:"object"`
+	Content     stdJSON.RawMessage `json:"content" swaggertype:"object"`
+	Amount      string             `json:"amount"`
+}
+
+// SaplingState -
+type SaplingState struct {
+	Network         string `json:"network"`
+	Ptr             int64  `json:"ptr"`
+	Address         string `json:"address"`
+	SourcePtr       *int64 `json:"source_ptr,omitempty" extensions:"x-nullable"`
+	MemoSize        *int64 `json:"memo_size,omitempty" extensions:"x-nullable"`
+	TreeSize        int64  `json:"tree_size"`
+	NullifiersCount int64  `json:"nullifiers_count"`
+	UpdatesCount    int64  `json:"updates_count"`
+	FirstLevel      int64  `json:"first_level"`
+	LastLevel       int64  `json:"last_level"`
+}
+
+// SaplingUpdate -
+type SaplingUpdate struct {
+	Level       int64               `json:"level"`
+	Timestamp   time.Time           `json:"timestamp"`
+	Address     string              `json:"address"`
+	Action      string              `json:"action"`
+	SourcePtr   *int64              `json:"source_ptr,omitempty" extensions:"x-nullable"`
+	MemoSize    *int64              `json:"memo_size,omitempty" extensions:"x-nullable"`
+	Commitments []string            `json:"commitments"`
+	Ciphertexts []SaplingCiphertext `json:"ciphertexts"`
+	Nullifiers  []string            `json:"nullifiers"`
+}
+
+// FromModel -
+func (u *SaplingUpdate) FromModel(diff saplingdiff.SaplingDiff) {
+	u.Level = diff.Level
+	u.Timestamp = diff.Timestamp
+	u.Address = diff.Address
+	u.Action = diff.Action
+	u.SourcePtr = diff.SourcePtr
+	u.MemoSize = diff.MemoSize
+	u.Commitments = diff.Commitments
+	u.Nullifiers = diff.Nullifiers
+	u.Ciphertexts = make([]SaplingCiphertext, len(diff.Ciphertexts))
+	for i := range diff.Ciphertexts {
+		u.Ciphertexts[i] = SaplingCiphertext(diff.Ciphertexts[i])
+	}
+}
+
+// SaplingCiphertext -
+type SaplingCiphertext struct {
+	CV         string `json:"cv"`
+	EPK        string `json:"epk"`
+	PayloadEnc string `json:"payload_enc"`
+	NonceEnc   string `json:"nonce_enc"`
+	PayloadOut string `json:"payload_out"`
+	NonceOut   string `json:"nonce_out"`
+}
+
+// ContractInterface -
+type ContractInterface struct {
+	Name        string                        `json:"name"`
+	Description string                        `json:"description,omitempty"`
+	IsRoot      bool                          `json:"is_root,omitempty"`
+	Entrypoints map[string]stdJSON.RawMessage `json:"entrypoints,omitempty" swaggertype:"object"`
+	Optional    map[string]stdJSON.RawMessage `json:"optional_entrypoints,omitempty" swaggertype:"object"`
+	Views       map[string]interfaces.View    `json:"views,omitempty" swaggertype:"object"`
+	Storage     map[string]stdJSON.RawMessage `json:"storage,omitempty" swaggertype:"object"`
+	MinScore    float64                       `json:"min_score,omitempty"`
+	Methods     []string                      `json:"methods"`
+}
+
+// FromModel -
+func (ci *ContractInterface) FromModel(model interfaces.ContractInterface) {
+	ci.Name = model.Name
+	ci.Description = model.Description
+	ci.IsRoot = model.IsRoot
+	ci.Entrypoints = model.Entrypoints
+	ci.Optional = model.Optional
+	ci.Views = model.Views
+	ci.Storage = model.Storage
+	ci.MinScore = model.MinScore
+	ci.Methods = model.Methods()
+}
+
+// InterfaceMatch -
+type InterfaceMatch struct {
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+// CreatedAPIKey - API key with its secret value which is shown only once
+type CreatedAPIKey struct {
+	database.APIKey
+	Key string `json:"key"`
+}
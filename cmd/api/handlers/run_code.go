@@ -7,14 +7,16 @@ import (
 	"github.com/baking-bad/bcdhub/internal/bcd"
 	"github.com/baking-bad/bcdhub/internal/bcd/ast"
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/formatter"
+	"github.com/baking-bad/bcdhub/internal/bcd/profiler"
 	"github.com/baking-bad/bcdhub/internal/bcd/tezerrors"
 	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
-	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/block"
 	"github.com/baking-bad/bcdhub/internal/noderpc"
-	"github.com/baking-bad/bcdhub/internal/parsers/operations"
 	"github.com/baking-bad/bcdhub/internal/parsers/storage"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
 )
 
 // RunOperation -
@@ -33,86 +35,34 @@ func (ctx *Context) RunOperation(c *gin.Context) {
 		return
 	}
 
-	parameters, err := ctx.buildParametersForExecution(req.Network, req.Address, state.Protocol, reqRunOp.Name, reqRunOp.Data)
-	if ctx.handleError(c, err, 0) {
-		return
-	}
-
 	rpc, err := ctx.GetRPC(req.Network)
 	if ctx.handleError(c, err, http.StatusBadRequest) {
 		return
 	}
 
-	counter, err := rpc.GetCounter(reqRunOp.Source)
+	protocol, err := ctx.Protocols.GetProtocol(req.Network, "", -1)
 	if ctx.handleError(c, err, 0) {
 		return
 	}
 
-	protocol, err := ctx.Protocols.GetProtocol(req.Network, "", -1)
+	transaction, err := ctx.buildTransaction(rpc, state, protocol.Constants, req.Address, reqRunOp)
 	if ctx.handleError(c, err, 0) {
 		return
 	}
 
-	response, err := rpc.RunOperation(
-		state.ChainID,
-		state.Hash,
-		reqRunOp.Source,
-		req.Address,
-		0, // fee
-		protocol.Constants.HardGasLimitPerOperation,
-		protocol.Constants.HardStorageLimitPerOperation,
-		counter+1,
-		reqRunOp.Amount,
-		parameters,
-	)
+	response, err := rpc.RunOperation(state.ChainID, state.Hash, []noderpc.Operation{transaction})
 	if ctx.handleError(c, err, 0) {
 		return
 	}
 
-	header := noderpc.Header{
-		Level:       state.Level,
-		Protocol:    state.Protocol,
-		Timestamp:   state.Timestamp,
-		ChainID:     state.ChainID,
-		Hash:        state.Hash,
-		Predecessor: state.Predecessor,
-	}
-
-	parser := operations.NewGroup(operations.NewParseParams(
-		rpc,
-		ctx.Storage, ctx.BigMapDiffs, ctx.Blocks, ctx.TZIP, ctx.TokenBalances,
-		operations.WithConstants(protocol.Constants),
-		operations.WithHead(header),
-		operations.WithShareDirectory(ctx.SharePath),
-		operations.WithNetwork(req.Network),
-	))
-
-	parsedModels, err := parser.Parse(response)
+	simulation, err := ctx.parseSimulation(rpc, state, protocol.Constants, response)
 	if ctx.handleError(c, err, 0) {
 		return
 	}
 
-	operations := make([]*operation.Operation, 0)
-	diffs := make([]*bigmapdiff.BigMapDiff, 0)
-
-	for i := range parsedModels {
-		switch val := parsedModels[i].(type) {
-		case *operation.Operation:
-			operations = append(operations, val)
-		case *bigmapdiff.BigMapDiff:
-			diffs = append(diffs, val)
-		}
-	}
-
-	resp := make([]Operation, len(operations))
-	for i := range operations {
-		bmd := make([]bigmapdiff.BigMapDiff, 0)
-		for j := range diffs {
-			if diffs[j].OperationID == operations[i].ID {
-				bmd = append(bmd, *diffs[j])
-			}
-		}
-		op, err := ctx.prepareOperation(*operations[i], bmd, true)
+	resp := make([]Operation, len(simulation.operations))
+	for i := range simulation.operations {
+		op, err := ctx.prepareOperation(*simulation.operations[i], simulation.getBigMapDiffs(simulation.operations[i].ID), true)
 		if ctx.handleError(c, err, 0) {
 			return
 		}
@@ -124,7 +74,7 @@ func (ctx *Context) RunOperation(c *gin.Context) {
 
 // RunCode godoc
 // @Summary Execute entrypoint with passed arguments
-// @Description Execute entrypoint with passed arguments
+// @Description Execute entrypoint with passed arguments. If `profile` is set, gas consumption and stack depth of every executed instruction and code block are returned in `profile` field of the first operation. Positions refer to Michelson code in the profile.
 // @Tags contract
 // @ID run-code
 // @Param network path string true "Network"
@@ -161,7 +111,7 @@ func (ctx *Context) RunCode(c *gin.Context) {
 		return
 	}
 
-	rpc, err := ctx.GetRPC(req.Network)
+	rpc, err := ctx.getInterpreter(req.Network)
 	if ctx.handleError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -186,7 +136,12 @@ func (ctx *Context) RunCode(c *gin.Context) {
 		Entrypoint:  reqRunCode.Name,
 	}
 
-	response, err := rpc.RunCode(scriptBytes, storage, input, state.ChainID, reqRunCode.Source, reqRunCode.Sender, reqRunCode.Name, state.Protocol, reqRunCode.Amount, reqRunCode.GasLimit)
+	var response noderpc.RunCodeResponse
+	if reqRunCode.Profile {
+		response, main.Profile, err = ctx.traceCode(rpc, state, scriptBytes, storage, input, reqRunCode)
+	} else {
+		response, err = rpc.RunCode(scriptBytes, storage, input, state.ChainID, reqRunCode.Source, reqRunCode.Sender, reqRunCode.Name, state.Protocol, reqRunCode.Amount, reqRunCode.GasLimit)
+	}
 	if err != nil {
 		var e noderpc.InvalidNodeResponse
 		if errors.As(err, &e) {
@@ -226,6 +181,42 @@ func (ctx *Context) RunCode(c *gin.Context) {
 	c.JSON(http.StatusOK, operations)
 }
 
+// traceCode - executes script and builds gas profile of execution. Gas limit of request or hard gas limit per operation is used.
+func (ctx *Context) traceCode(rpc noderpc.INode, state block.Block, script, storage, input []byte, req runCodeRequest) (noderpc.RunCodeResponse, *profiler.Profile, error) {
+	gasLimit := req.GasLimit
+	if gasLimit == 0 {
+		protocol, err := ctx.Protocols.GetProtocol(state.Network, "", -1)
+		if err != nil {
+			return noderpc.RunCodeResponse{}, nil, err
+		}
+		gasLimit = protocol.Constants.HardGasLimitPerOperation
+	}
+
+	response, err := rpc.TraceCode(script, storage, input, state.ChainID, req.Source, req.Sender, req.Name, state.Protocol, req.Amount, gasLimit)
+	if err != nil {
+		return noderpc.RunCodeResponse{}, nil, err
+	}
+
+	code, locations, err := formatter.LocateNodes(gjson.ParseBytes(script), formatter.DefLineSize)
+	if err != nil {
+		return noderpc.RunCodeResponse{}, nil, err
+	}
+
+	steps := make([]profiler.Step, len(response.Trace))
+	for i := range response.Trace {
+		steps[i] = profiler.Step{
+			Location:   response.Trace[i].Location,
+			Gas:        response.Trace[i].Gas,
+			StackDepth: len(response.Trace[i].Stack),
+		}
+	}
+	profile, err := profiler.New(code, locations, steps, gasLimit)
+	if err != nil {
+		return noderpc.RunCodeResponse{}, nil, err
+	}
+	return response.RunCodeResponse, profile, nil
+}
+
 func (ctx *Context) parseAppliedRunCode(response noderpc.RunCodeResponse, script *ast.Script, main *Operation) ([]Operation, error) {
 	operations := []Operation{*main}
 
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/saplingdiff"
+	"github.com/gin-gonic/gin"
+)
+
+// maxSaplingCopyDepth - limits walking through chain of copied sapling states
+const maxSaplingCopyDepth = 16
+
+// GetSaplingState godoc
+// @Summary Get sapling pool state
+// @Description Get sapling pool state: commitment tree size, count of spent nullifiers and updates. Pool copied from another one includes its state at the moment of copy.
+// @Tags sapling
+// @ID get-sapling-state
+// @Param network path string true "Network"
+// @Param ptr path integer true "Sapling state pointer"
+// @Accept json
+// @Produce json
+// @Success 200 {object} SaplingState
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/sapling/{network}/{ptr} [get]
+func (ctx *Context) GetSaplingState(c *gin.Context) {
+	var req getBigMapRequest
+	if err := c.BindUri(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	first, err := ctx.SaplingDiffs.First(req.Network, req.Ptr)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	stats, err := ctx.SaplingDiffs.GetStats(req.Network, req.Ptr, 0)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	state := SaplingState{
+		Network:         req.Network,
+		Ptr:             req.Ptr,
+		Address:         first.Address,
+		SourcePtr:       first.SourcePtr,
+		MemoSize:        first.MemoSize,
+		TreeSize:        stats.Commitments,
+		NullifiersCount: stats.Nullifiers,
+		UpdatesCount:    stats.Count,
+		FirstLevel:      stats.FirstLevel,
+		LastLevel:       stats.LastLevel,
+	}
+
+	if err := ctx.addCopiedSaplingState(&state, first, 0); ctx.handleError(c, err, 0) {
+		return
+	}
+
+	c.JSON(http.StatusOK, state)
+}
+
+// addCopiedSaplingState - adds state of source pool at the moment of copy to `state`
+func (ctx *Context) addCopiedSaplingState(state *SaplingState, first saplingdiff.SaplingDiff, depth int) error {
+	if depth >= maxSaplingCopyDepth || first.SourcePtr == nil {
+		return nil
+	}
+
+	source, err := ctx.SaplingDiffs.First(state.Network, *first.SourcePtr)
+	if err != nil {
+		if ctx.Storage.IsRecordNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	stats, err := ctx.SaplingDiffs.GetStats(state.Network, *first.SourcePtr, first.Level)
+	if err != nil {
+		return err
+	}
+	state.TreeSize += stats.Commitments
+	state.NullifiersCount += stats.Nullifiers
+	if state.MemoSize == nil {
+		state.MemoSize = source.MemoSize
+	}
+
+	return ctx.addCopiedSaplingState(state, source, depth+1)
+}
+
+// GetSaplingHistory godoc
+// @Summary Get sapling pool updates
+// @Description Get sapling pool updates: allocations, copies and shielded transactions with their commitments, ciphertexts and nullifiers
+// @Tags sapling
+// @ID get-sapling-history
+// @Param network path string true "Network"
+// @Param ptr path integer true "Sapling state pointer"
+// @Param size query integer false "Updates count" mininum(1) maximum(10)
+// @Param offset query integer false "Offset" mininum(1)
+// @Accept json
+// @Produce json
+// @Success 200 {array} SaplingUpdate
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/sapling/{network}/{ptr}/history [get]
+func (ctx *Context) GetSaplingHistory(c *gin.Context) {
+	var req getBigMapRequest
+	if err := c.BindUri(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+	var page pageableRequest
+	if err := c.BindQuery(&page); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	diffs, err := ctx.SaplingDiffs.Get(req.Network, req.Ptr, page.Size, page.Offset)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	updates := make([]SaplingUpdate, len(diffs))
+	for i := range diffs {
+		updates[i].FromModel(diffs[i])
+	}
+	c.JSON(http.StatusOK, updates)
+}
+
+// GetSaplingSeries godoc
+// @Summary Get sapling pool activity series
+// @Description Get series of added commitments, spent nullifiers or updates count of sapling pool
+// @Tags sapling
+// @ID get-sapling-series
+// @Param network path string true "Network"
+// @Param ptr path integer true "Sapling state pointer"
+// @Param name query string true "One of names" Enums(commitments, nullifiers, updates)
+// @Param period query string true "One of periods"  Enums(year, month, week, day)
+// @Accept json
+// @Produce json
+// @Success 200 {object} Series
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/sapling/{network}/{ptr}/series [get]
+func (ctx *Context) GetSaplingSeries(c *gin.Context) {
+	var req getBigMapRequest
+	if err := c.BindUri(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+	var reqArgs getSaplingSeriesRequest
+	if err := c.BindQuery(&reqArgs); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	options := []models.HistogramOption{
+		models.WithHistogramIndices(models.DocSaplingDiff),
+		models.WithHistogramFilters([]models.HistogramFilter{
+			{
+				Field: "network",
+				Value: req.Network,
+				Kind:  models.HistogramFilterKindMatch,
+			},
+			{
+				Field: "ptr",
+				Value: req.Ptr,
+				Kind:  models.HistogramFilterKindMatch,
+			},
+		}),
+	}
+	switch reqArgs.Name {
+	case "commitments":
+		options = append(options, models.WithHistogramFunction("sum", "commitments_count"))
+	case "nullifiers":
+		options = append(options, models.WithHistogramFunction("sum", "nullifiers_count"))
+	}
+
+	series, err := ctx.Storage.GetDateHistogram(reqArgs.Period, options...)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
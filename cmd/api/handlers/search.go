@@ -27,6 +27,7 @@ import (
 // @Param g query integer false "Grouping by contracts similarity. 0 - false, any others - true" Enums(0, 1)
 // @Param i query string false "Comma-separated list of indices for searching. Values: contract, operation, bigmapdiff""
 // @Param l query string false "Comma-separated list of languages for searching. Values: smartpy, liquidity, ligo, lorentz, michelson"
+// @Param t query string false "Comma-separated list of contract tags or interfaces for searching, e.g. fa2,multisig"
 // @Accept  json
 // @Produce  json
 // @Success 200 {object} models.Result
@@ -88,6 +89,10 @@ func getSearchFilters(req searchRequest) map[string]interface{} {
 		filters["languages"] = strings.Split(req.Languages, ",")
 	}
 
+	if req.Tags != "" {
+		filters["tags"] = strings.Split(req.Tags, ",")
+	}
+
 	return filters
 }
 
@@ -0,0 +1,350 @@
+package handlers
+
+import (
+	stdJSON "encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd"
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/tezerrors"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/models/tokenbalance"
+	"github.com/baking-bad/bcdhub/internal/models/transfer"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers/operations"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// SimulateBatch godoc
+// @Summary Simulate operation group
+// @Description Simulate ordered list of transactions and originations from one source as one operation group. Contents are simulated in passed order. Originations are built like `fork` does. Response contains applied operations with internal ones, token transfers and the first failed operation with formatted errors.
+// @Tags operations
+// @ID simulate-batch
+// @Param network path string true "Network"
+// @Param body body simulateBatchRequest true "Request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} SimulationResponse
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/simulate/{network} [post]
+func (ctx *Context) SimulateBatch(c *gin.Context) {
+	var req getByNetwork
+	if err := c.BindUri(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+	var reqBatch simulateBatchRequest
+	if err := c.BindJSON(&reqBatch); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+	for i := range reqBatch.Operations {
+		if err := reqBatch.Operations[i].validate(); ctx.handleError(c, errors.Wrapf(err, "operation %d", i), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	state, err := ctx.Blocks.Last(req.Network)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	rpc, err := ctx.GetRPC(req.Network)
+	if ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	protocol, err := ctx.Protocols.GetProtocol(req.Network, "", -1)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	counter, err := rpc.GetCounter(reqBatch.Source)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	contents := make([]noderpc.Operation, len(reqBatch.Operations))
+	scripts := make(map[int64]*ast.Script)
+	for i, item := range reqBatch.Operations {
+		counter++
+		switch item.Kind {
+		case consts.Transaction:
+			contents[i], err = ctx.buildCall(state, protocol.Constants, reqBatch.Source, item.Destination, item.Name, item.Data, item.Amount, counter)
+		case consts.Origination:
+			var script *ast.Script
+			contents[i], script, err = ctx.buildOrigination(protocol.Constants, reqBatch.Source, *item.Fork, item.Amount, counter)
+			scripts[int64(i)] = script
+		}
+		if ctx.handleError(c, err, 0) {
+			return
+		}
+	}
+
+	response, err := rpc.RunOperation(state.ChainID, state.Hash, contents)
+	if err != nil {
+		var e noderpc.InvalidNodeResponse
+		if !errors.As(err, &e) {
+			ctx.handleError(c, err, 0)
+			return
+		}
+		failure, err := newSimulationFailure(state, reqBatch.Source, e)
+		if ctx.handleError(c, err, 0) {
+			return
+		}
+		c.JSON(http.StatusOK, SimulationResponse{
+			Operations: make([]Operation, 0),
+			Transfers:  make([]Transfer, 0),
+			Failure:    failure,
+		})
+		return
+	}
+
+	simulation, err := ctx.parseSimulation(rpc, state, protocol.Constants, response)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	var result SimulationResponse
+	result.Operations = make([]Operation, len(simulation.operations))
+	for i, model := range simulation.operations {
+		bmd := simulation.getBigMapDiffs(model.ID)
+
+		// scripts of simulated originations are not indexed, so storage diff is built from the script of request
+		script, isRequested := scripts[model.ContentIndex]
+		isRequested = isRequested && model.IsOrigination() && !model.Internal
+
+		op, err := ctx.prepareOperation(*model, bmd, !model.IsOrigination())
+		if ctx.handleError(c, err, 0) {
+			return
+		}
+		if isRequested && model.IsApplied() && model.DeffatedStorage != "" {
+			if err := ctx.setStorageDiff(op.Destination, model.DeffatedStorage, &op, bmd, script); ctx.handleError(c, err, 0) {
+				return
+			}
+		}
+		result.Operations[i] = op
+
+		if result.Failure == nil && op.Status == consts.Failed {
+			failure := op
+			result.Failure = &failure
+		}
+	}
+
+	transfers, err := ctx.transfersPostprocessing(transfer.Pageable{
+		Transfers: simulation.getTransfers(),
+	}, false)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+	result.Transfers = transfers.Transfers
+
+	c.JSON(http.StatusOK, result)
+}
+
+// buildOrigination - builds origination of contract received from fork request. Returns origination and its script.
+func (ctx *Context) buildOrigination(constants protocol.Constants, source string, req forkRequest, balance, counter int64) (noderpc.Operation, *ast.Script, error) {
+	fork, err := ctx.buildStorageDataFromForkRequest(req)
+	if err != nil {
+		return noderpc.Operation{}, nil, err
+	}
+	script, err := ast.NewScript(fork.Script)
+	if err != nil {
+		return noderpc.Operation{}, nil, err
+	}
+	code, err := json.Marshal(noderpc.Script{
+		Code:    script,
+		Storage: fork.Storage,
+	})
+	if err != nil {
+		return noderpc.Operation{}, nil, err
+	}
+
+	return noderpc.Operation{
+		Kind:         consts.Origination,
+		Source:       source,
+		Fee:          0,
+		Counter:      counter,
+		GasLimit:     constants.HardGasLimitPerOperation,
+		StorageLimit: constants.HardStorageLimitPerOperation,
+		Balance:      &balance,
+		Script:       code,
+	}, script, nil
+}
+
+// newSimulationFailure - converts error of operation group prevalidation to failed operation
+func newSimulationFailure(state block.Block, source string, e noderpc.InvalidNodeResponse) (*Operation, error) {
+	errs, err := tezerrors.ParseArray(e.Raw)
+	if err != nil {
+		return nil, err
+	}
+	failure := Operation{
+		Protocol:  state.Protocol,
+		Network:   state.Network,
+		Timestamp: time.Now().UTC(),
+		Level:     state.Level,
+		Source:    source,
+		Status:    consts.Failed,
+	}
+	if err := formatErrors(errs, &failure); err != nil {
+		return nil, err
+	}
+	return &failure, nil
+}
+
+// simulation - models parsed from result of simulated operation group
+type simulation struct {
+	operations []*operation.Operation
+	diffs      []*bigmapdiff.BigMapDiff
+	transfers  []*transfer.Transfer
+}
+
+func (s simulation) getBigMapDiffs(operationID string) []bigmapdiff.BigMapDiff {
+	bmd := make([]bigmapdiff.BigMapDiff, 0)
+	for i := range s.diffs {
+		if s.diffs[i].OperationID == operationID {
+			bmd = append(bmd, *s.diffs[i])
+		}
+	}
+	return bmd
+}
+
+func (s simulation) getTransfers() []transfer.Transfer {
+	transfers := make([]transfer.Transfer, len(s.transfers))
+	for i := range s.transfers {
+		transfers[i] = *s.transfers[i]
+	}
+	return transfers
+}
+
+// parseSimulation - parses result of `run_operation`. Simulated operations don't change token balances and share directory.
+func (ctx *Context) parseSimulation(rpc noderpc.INode, state block.Block, constants protocol.Constants, group noderpc.OperationGroup) (simulation, error) {
+	header := noderpc.Header{
+		Level:       state.Level,
+		Protocol:    state.Protocol,
+		Timestamp:   state.Timestamp,
+		ChainID:     state.ChainID,
+		Hash:        state.Hash,
+		Predecessor: state.Predecessor,
+	}
+
+	parser := operations.NewGroup(operations.NewParseParams(
+		newSimulatedNode(rpc, group),
+		ctx.Storage, ctx.BigMapDiffs, ctx.Blocks, ctx.TZIP, simulatedTokenBalances{ctx.TokenBalances},
+		operations.WithConstants(constants),
+		operations.WithHead(header),
+		operations.WithShareDirectory(ctx.SharePath),
+		operations.WithoutScriptSaving(),
+		operations.WithImplicitTransactions(),
+		operations.WithNetwork(state.Network),
+	))
+
+	parsedModels, err := parser.Parse(group)
+	if err != nil {
+		return simulation{}, err
+	}
+
+	result := simulation{
+		operations: make([]*operation.Operation, 0),
+		diffs:      make([]*bigmapdiff.BigMapDiff, 0),
+		transfers:  make([]*transfer.Transfer, 0),
+	}
+	for i := range parsedModels {
+		switch val := parsedModels[i].(type) {
+		case *operation.Operation:
+			result.operations = append(result.operations, val)
+		case *bigmapdiff.BigMapDiff:
+			result.diffs = append(result.diffs, val)
+		case *transfer.Transfer:
+			result.transfers = append(result.transfers, val)
+		}
+	}
+	return result, nil
+}
+
+// simulatedNode - node which returns initial storage of contracts originated in simulated group. They don't exist in node context.
+type simulatedNode struct {
+	noderpc.INode
+
+	storages map[string][]byte
+}
+
+func newSimulatedNode(rpc noderpc.INode, group noderpc.OperationGroup) simulatedNode {
+	node := simulatedNode{
+		INode:    rpc,
+		storages: make(map[string][]byte),
+	}
+	for i := range group.Contents {
+		node.addOrigination(group.Contents[i])
+		if group.Contents[i].Metadata == nil {
+			continue
+		}
+		for j := range group.Contents[i].Metadata.Internal {
+			node.addOrigination(group.Contents[i].Metadata.Internal[j])
+		}
+	}
+	return node
+}
+
+func (node simulatedNode) addOrigination(op noderpc.Operation) {
+	if op.Kind != consts.Origination || op.Script == nil {
+		return
+	}
+	result := op.GetResult()
+	if result == nil || len(result.Originated) == 0 {
+		return
+	}
+	var script struct {
+		Storage stdJSON.RawMessage `json:"storage"`
+	}
+	if err := json.Unmarshal(op.Script, &script); err != nil {
+		return
+	}
+	node.storages[result.Originated[0]] = script.Storage
+}
+
+// GetScriptStorageRaw -
+func (node simulatedNode) GetScriptStorageRaw(address string, level int64) ([]byte, error) {
+	if storage, ok := node.storages[address]; ok {
+		return storage, nil
+	}
+	return node.INode.GetScriptStorageRaw(address, level)
+}
+
+// simulatedTokenBalances - token balance repository which ignores balance updates of simulated transfers
+type simulatedTokenBalances struct {
+	tokenbalance.Repository
+}
+
+// Update -
+func (simulatedTokenBalances) Update(updates []*tokenbalance.TokenBalance) error {
+	return nil
+}
+
+func (req batchOperationRequest) validate() error {
+	switch req.Kind {
+	case consts.Transaction:
+		if req.Destination == "" {
+			return errors.New("destination is required for transaction")
+		}
+		if req.Name != "" && !bcd.IsContract(req.Destination) {
+			return errors.Errorf("entrypoint can be called only on contract: %s", req.Destination)
+		}
+		if req.Fork != nil {
+			return errors.New("fork can be passed only for origination")
+		}
+	case consts.Origination:
+		if req.Fork == nil {
+			return errors.New("fork is required for origination")
+		}
+		if req.Destination != "" || req.Name != "" {
+			return errors.New("destination and entrypoint can not be passed for origination")
+		}
+	}
+	return nil
+}
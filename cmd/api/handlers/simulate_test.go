@@ -0,0 +1,359 @@
+package handlers
+
+import (
+	"bytes"
+	stdJSON "encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/cmd/api/validations"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/golang/mock/gomock"
+	"github.com/karlseguin/ccache"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/go-playground/validator.v9"
+
+	mock_general "github.com/baking-bad/bcdhub/internal/models/mock"
+	mock_bmd "github.com/baking-bad/bcdhub/internal/models/mock/bigmapdiff"
+	mock_block "github.com/baking-bad/bcdhub/internal/models/mock/block"
+	mock_operation "github.com/baking-bad/bcdhub/internal/models/mock/operation"
+	mock_protocol "github.com/baking-bad/bcdhub/internal/models/mock/protocol"
+	mock_token_balance "github.com/baking-bad/bcdhub/internal/models/mock/tokenbalance"
+	mock_token_metadata "github.com/baking-bad/bcdhub/internal/models/mock/tokenmetadata"
+	mock_tzip "github.com/baking-bad/bcdhub/internal/models/mock/tzip"
+)
+
+const (
+	testSimulationNetwork    = "edo2net"
+	testSimulationSource     = "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"
+	testSimulationReceiver   = "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+	testSimulationOriginated = "KT1C2MfcjWb5R1ZDDxVULCsGuxrf5fEn5264"
+	testSimulationCounter    = int64(554730)
+
+	testForkScript  = `[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"pair","args":[{"prim":"nat","annots":["%counter"]},{"prim":"address","annots":["%owner"]}]}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`
+	testForkStorage = `{"prim":"Pair","args":[{"int":"7"},{"string":"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"}]}`
+)
+
+var registerTestValidations sync.Once
+
+type testSimulation struct {
+	ctx *Context
+	rpc *noderpc.MockINode
+
+	constants protocol.Constants
+	state     block.Block
+}
+
+func newTestSimulation(t *testing.T, ctrl *gomock.Controller) testSimulation {
+	registerTestValidations.Do(func() {
+		if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+			if err := validations.Register(v, []string{testSimulationNetwork}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	sharePath, err := ioutil.TempDir("", "simulate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(sharePath)
+	})
+
+	ts := testSimulation{
+		rpc: noderpc.NewMockINode(ctrl),
+		constants: protocol.Constants{
+			CostPerByte:                  250,
+			HardGasLimitPerOperation:     1040000,
+			HardStorageLimitPerOperation: 60000,
+			TimeBetweenBlocks:            30,
+		},
+		state: block.Block{
+			Network:  testSimulationNetwork,
+			Hash:     "BLYntWHzaTZFq2JS7ZoSsGB5sSK34ETXsjuVLrHPR23qu1g8XJu",
+			ChainID:  "NetXSp4gfdanies",
+			Level:    100,
+			Protocol: "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA",
+		},
+	}
+
+	blocks := mock_block.NewMockRepository(ctrl)
+	blocks.EXPECT().Last(testSimulationNetwork).Return(ts.state, nil).AnyTimes()
+
+	protocols := mock_protocol.NewMockRepository(ctrl)
+	protocols.EXPECT().GetProtocol(testSimulationNetwork, "", int64(-1)).Return(protocol.Protocol{
+		Hash:      ts.state.Protocol,
+		Network:   testSimulationNetwork,
+		Constants: ts.constants,
+	}, nil).AnyTimes()
+
+	storage := mock_general.NewMockGeneralRepository(ctrl)
+	storage.EXPECT().GetByID(gomock.Any()).Return(errors.New("record not found")).AnyTimes()
+	storage.EXPECT().IsRecordNotFound(gomock.Any()).Return(true).AnyTimes()
+
+	operations := mock_operation.NewMockRepository(ctrl)
+	operations.EXPECT().Last(testSimulationNetwork, gomock.Any(), gomock.Any()).Return(operation.Operation{}, errors.New("record not found")).AnyTimes()
+
+	tzip := mock_tzip.NewMockRepository(ctrl)
+	tzip.EXPECT().GetWithEventsCounts().Return(int64(0), nil).AnyTimes()
+
+	tokenMetadata := mock_token_metadata.NewMockRepository(ctrl)
+	tokenMetadata.EXPECT().GetAll(gomock.Any()).Return(nil, nil).AnyTimes()
+
+	ts.ctx = &Context{
+		Context: &config.Context{
+			RPC:           map[string]noderpc.INode{testSimulationNetwork: ts.rpc},
+			SharePath:     sharePath,
+			Storage:       storage,
+			BigMapDiffs:   mock_bmd.NewMockRepository(ctrl),
+			Blocks:        blocks,
+			Operations:    operations,
+			Protocols:     protocols,
+			TokenBalances: mock_token_balance.NewMockRepository(ctrl),
+			TokenMetadata: tokenMetadata,
+			TZIP:          tzip,
+		},
+		Cache: ccache.New(ccache.Configure().MaxSize(10)),
+	}
+	return ts
+}
+
+func (ts testSimulation) simulate(t *testing.T, body string) (int, SimulationResponse) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "network", Value: testSimulationNetwork}}
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/simulate/"+testSimulationNetwork, bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	ts.ctx.SimulateBatch(c)
+
+	var response SimulationResponse
+	if w.Code == http.StatusOK {
+		if err := stdJSON.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Unmarshal() error = %v: %s", err, w.Body.String())
+		}
+	}
+	return w.Code, response
+}
+
+// simulatedGroup - returns simulation result of `contents` with `statuses` in the same order
+func simulatedGroup(chainID, branch string, contents []noderpc.Operation, statuses []string) noderpc.OperationGroup {
+	group := noderpc.OperationGroup{
+		Protocol: "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA",
+		ChainID:  chainID,
+		Branch:   branch,
+		Contents: make([]noderpc.Operation, len(contents)),
+	}
+	for i := range contents {
+		result := noderpc.OperationResult{
+			Status:      statuses[i],
+			ConsumedGas: 1427,
+		}
+		switch {
+		case statuses[i] == consts.Failed:
+			result.Errors = []byte(`[{"kind":"temporary","id":"proto.008-PtEdo2Zk.contract.balance_too_low","contract":"` + testSimulationSource + `","balance":"10","amount":"1000000000"}]`)
+		case statuses[i] == consts.Applied && contents[i].Kind == consts.Origination:
+			result.Originated = []string{testSimulationOriginated}
+		}
+		group.Contents[i] = contents[i]
+		group.Contents[i].Metadata = &noderpc.OperationMetadata{
+			OperationResult: &result,
+		}
+	}
+	return group
+}
+
+func TestContext_SimulateBatch(t *testing.T) {
+	batch, err := stdJSON.Marshal(simulateBatchRequest{
+		Source: testSimulationSource,
+		Operations: []batchOperationRequest{
+			{
+				Kind:        consts.Transaction,
+				Destination: testSimulationReceiver,
+				Amount:      100,
+			}, {
+				Kind:   consts.Origination,
+				Amount: 5,
+				Fork: &forkRequest{
+					Script: testForkScript,
+					Storage: map[string]interface{}{
+						"counter": 7,
+						"owner":   testSimulationSource,
+					},
+				},
+			}, {
+				Kind:        consts.Transaction,
+				Destination: testSimulationReceiver,
+				Amount:      200,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		statuses    []string
+		wantFailure int
+	}{
+		{
+			name:        "applied group",
+			statuses:    []string{consts.Applied, consts.Applied, consts.Applied},
+			wantFailure: -1,
+		}, {
+			name:        "failure part-way through the group",
+			statuses:    []string{consts.Backtracked, consts.Failed, consts.Skipped},
+			wantFailure: 1,
+		}, {
+			name:        "failed transfer between implicit accounts",
+			statuses:    []string{consts.Backtracked, consts.Backtracked, consts.Failed},
+			wantFailure: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ts := newTestSimulation(t, ctrl)
+			ts.rpc.EXPECT().GetCounter(testSimulationSource).Return(testSimulationCounter, nil).Times(1)
+
+			var contents []noderpc.Operation
+			ts.rpc.EXPECT().
+				RunOperation(ts.state.ChainID, ts.state.Hash, gomock.Any()).
+				DoAndReturn(func(chainID, branch string, ops []noderpc.Operation) (noderpc.OperationGroup, error) {
+					contents = ops
+					if len(ops) != len(tt.statuses) {
+						return noderpc.OperationGroup{}, errors.Errorf("invalid contents count: %d", len(ops))
+					}
+					return simulatedGroup(chainID, branch, ops, tt.statuses), nil
+				}).
+				Times(1)
+
+			code, response := ts.simulate(t, string(batch))
+			if !assert.Equal(t, http.StatusOK, code) {
+				return
+			}
+
+			// contents are sent in requested order with sequential counters
+			if assert.Len(t, contents, 3) {
+				for i, kind := range []string{consts.Transaction, consts.Origination, consts.Transaction} {
+					assert.Equal(t, kind, contents[i].Kind)
+					assert.Equal(t, testSimulationSource, contents[i].Source)
+					assert.Equal(t, testSimulationCounter+int64(i)+1, contents[i].Counter)
+					assert.Equal(t, ts.constants.HardGasLimitPerOperation, contents[i].GasLimit)
+					assert.Equal(t, ts.constants.HardStorageLimitPerOperation, contents[i].StorageLimit)
+				}
+				assert.Equal(t, int64(100), *contents[0].Amount)
+				assert.Equal(t, int64(5), *contents[1].Balance)
+				assert.Equal(t, int64(200), *contents[2].Amount)
+
+				// origination is built like `fork` does
+				var script noderpc.Script
+				if assert.NoError(t, stdJSON.Unmarshal(contents[1].Script, &script)) {
+					assert.JSONEq(t, testForkStorage, string(script.Storage))
+				}
+			}
+
+			if !assert.Len(t, response.Operations, 3) {
+				return
+			}
+			for i := range response.Operations {
+				assert.Equal(t, contents[i].Kind, response.Operations[i].Kind)
+				assert.Equal(t, contents[i].Counter, response.Operations[i].Counter)
+				assert.Equal(t, tt.statuses[i], response.Operations[i].Status)
+			}
+			assert.Empty(t, response.Transfers)
+
+			if tt.wantFailure < 0 {
+				assert.Nil(t, response.Failure)
+				assert.Equal(t, testSimulationOriginated, response.Operations[1].Destination)
+				assert.NotNil(t, response.Operations[1].StorageDiff, "storage diff of originated contract")
+				return
+			}
+			if assert.NotNil(t, response.Failure) {
+				assert.Equal(t, consts.Failed, response.Failure.Status)
+				assert.Equal(t, contents[tt.wantFailure].Counter, response.Failure.Counter)
+				if assert.Len(t, response.Failure.Errors, 1) {
+					assert.Equal(t, "proto.008-PtEdo2Zk.contract.balance_too_low", response.Failure.Errors[0].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestContext_SimulateBatch_Prevalidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ts := newTestSimulation(t, ctrl)
+	ts.rpc.EXPECT().GetCounter(testSimulationSource).Return(testSimulationCounter, nil).Times(1)
+	ts.rpc.EXPECT().
+		RunOperation(ts.state.ChainID, ts.state.Hash, gomock.Any()).
+		Return(noderpc.OperationGroup{}, noderpc.InvalidNodeResponse{
+			Raw: []byte(`[{"kind":"temporary","id":"failure","msg":"Error while applying operation"}]`),
+		}).
+		Times(1)
+
+	code, response := ts.simulate(t, `{
+		"source": "`+testSimulationSource+`",
+		"operations": [
+			{"kind": "transaction", "destination": "`+testSimulationReceiver+`", "amount": 100}
+		]
+	}`)
+	if !assert.Equal(t, http.StatusOK, code) {
+		return
+	}
+	assert.Empty(t, response.Operations)
+	if assert.NotNil(t, response.Failure) {
+		assert.Equal(t, consts.Failed, response.Failure.Status)
+		assert.Equal(t, testSimulationSource, response.Failure.Source)
+		assert.Len(t, response.Failure.Errors, 1)
+	}
+}
+
+func TestContext_SimulateBatch_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "origination without fork",
+			body: `{"source": "` + testSimulationSource + `", "operations": [{"kind": "origination"}]}`,
+		}, {
+			name: "fork of transaction",
+			body: `{"source": "` + testSimulationSource + `", "operations": [{"kind": "transaction", "destination": "` + testSimulationReceiver + `", "fork": {"storage": {}}}]}`,
+		}, {
+			name: "entrypoint of implicit account",
+			body: `{"source": "` + testSimulationSource + `", "operations": [{"kind": "transaction", "destination": "` + testSimulationReceiver + `", "name": "transfer"}]}`,
+		}, {
+			name: "empty group",
+			body: `{"source": "` + testSimulationSource + `", "operations": []}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ts := newTestSimulation(t, ctrl)
+			code, _ := ts.simulate(t, tt.body)
+			assert.Equal(t, http.StatusBadRequest, code)
+		})
+	}
+}
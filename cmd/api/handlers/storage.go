@@ -5,12 +5,13 @@ import (
 
 	"github.com/baking-bad/bcdhub/internal/bcd/ast"
 	"github.com/baking-bad/bcdhub/internal/bcd/formatter"
+	"github.com/baking-bad/bcdhub/internal/parsers/storage"
 	"github.com/gin-gonic/gin"
 )
 
 // GetContractStorage godoc
 // @Summary Get contract storage
-// @Description Get contract storage
+// @Description Get contract storage. Storage at `level` is rebuilt from indexed data, so it's available even if node has pruned that state.
 // @Tags contract
 // @ID get-contract-storage
 // @Param network path string true "Network"
@@ -32,39 +33,18 @@ func (ctx *Context) GetContractStorage(c *gin.Context) {
 	if err := c.BindQuery(&sReq); ctx.handleError(c, err, http.StatusBadRequest) {
 		return
 	}
+
 	rpc, err := ctx.GetRPC(req.Network)
 	if ctx.handleError(c, err, http.StatusBadRequest) {
 		return
 	}
-	if sReq.Level == 0 {
-		block, err := ctx.Blocks.Last(req.Network)
-		if ctx.handleError(c, err, 0) {
-			return
-		}
-		sReq.Level = int(block.Level)
-	}
 
-	deffatedStorage, err := rpc.GetScriptStorageRaw(req.Address, int64(sReq.Level))
-	if ctx.handleError(c, err, 0) {
-		return
-	}
-	header, err := rpc.GetHeader(int64(sReq.Level))
-	if ctx.handleError(c, err, 0) {
-		return
-	}
-	storageType, err := ctx.getStorageType(req.Address, req.Network, header.Protocol)
+	historical := storage.NewHistorical(ctx.Operations, ctx.BigMapDiffs, ctx.Migrations, rpc, ctx.SharePath)
+	storageType, _, err := historical.Storage(req.Network, req.Address, int64(sReq.Level))
 	if ctx.handleError(c, err, 0) {
 		return
 	}
 
-	var data ast.UntypedAST
-	if err := json.Unmarshal(deffatedStorage, &data); ctx.handleError(c, err, 0) {
-		return
-	}
-	if err := storageType.Settle(data); ctx.handleError(c, err, 0) {
-		return
-	}
-
 	resp, err := storageType.ToMiguel()
 	if ctx.handleError(c, err, 0) {
 		return
@@ -124,7 +104,7 @@ func (ctx *Context) GetContractStorageRaw(c *gin.Context) {
 
 // GetContractStorageRich godoc
 // @Summary Get contract rich storage
-// @Description Get contract rich storage
+// @Description Get contract storage with big map contents. Storage and big maps at `level` are rebuilt from indexed data, so they are available even if node has pruned that state. Storage of contracts rewritten by protocol migration after their last operation is requested from node.
 // @Tags contract
 // @ID get-contract-storage-rich
 // @Param network path string true "Network"
@@ -146,34 +126,20 @@ func (ctx *Context) GetContractStorageRich(c *gin.Context) {
 	if err := c.BindQuery(&sReq); ctx.handleError(c, err, http.StatusBadRequest) {
 		return
 	}
-	filters := map[string]interface{}{
-		"destination": req.Address,
-		"network":     req.Network,
-	}
-	if sReq.Level > 0 {
-		filters["level"] = sReq.Level
-	}
-
-	ops, err := ctx.Operations.Get(filters, 2, true)
-	if ctx.handleError(c, err, 0) {
-		return
-	}
-	if len(ops) == 0 {
-		c.JSON(http.StatusNoContent, gin.H{})
-		return
-	}
 
-	bmd, err := ctx.BigMapDiffs.GetForAddress(req.Address)
-	if ctx.handleError(c, err, 0) {
-		return
-	}
-
-	storageType, err := ctx.getStorageType(req.Address, req.Network, ops[0].Protocol)
-	if ctx.handleError(c, err, 0) {
+	rpc, err := ctx.GetRPC(req.Network)
+	if ctx.handleError(c, err, http.StatusBadRequest) {
 		return
 	}
 
-	if err := prepareStorage(storageType, ops[0].DeffatedStorage, bmd); ctx.handleError(c, err, 0) {
+	historical := storage.NewHistorical(ctx.Operations, ctx.BigMapDiffs, ctx.Migrations, rpc, ctx.SharePath)
+	storageType, _, err := historical.RichStorage(req.Network, req.Address, int64(sReq.Level))
+	if err != nil {
+		if ctx.Storage.IsRecordNotFound(err) {
+			c.JSON(http.StatusNoContent, gin.H{})
+			return
+		}
+		ctx.handleError(c, err, 0)
 		return
 	}
 
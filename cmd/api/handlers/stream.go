@@ -0,0 +1,528 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/transfer"
+	"github.com/baking-bad/bcdhub/internal/mq"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+)
+
+// Stream event types
+const (
+	StreamEventOperation  = "operation"
+	StreamEventBigMapDiff = "big_map_diff"
+	StreamEventTransfer   = "transfer"
+	StreamEventContract   = "contract"
+)
+
+const streamBufferSize = 256
+
+// StreamEvent -
+type StreamEvent struct {
+	Type string      `json:"type"`
+	Body interface{} `json:"body"`
+
+	network    string
+	addresses  []string
+	entrypoint string
+	ptr        *int64
+	contract   string
+}
+
+type streamFilter struct {
+	network    string
+	address    string
+	entrypoint string
+	ptr        *int64
+	contract   string
+	types      map[string]struct{}
+}
+
+func newStreamFilter(req streamRequest) (streamFilter, error) {
+	filter := streamFilter{
+		network:    req.Network,
+		address:    req.Address,
+		entrypoint: req.Entrypoint,
+		ptr:        req.Ptr,
+		contract:   req.Contract,
+	}
+
+	if req.Types == "" {
+		return filter, nil
+	}
+
+	filter.types = make(map[string]struct{})
+	for _, typ := range strings.Split(req.Types, ",") {
+		switch typ {
+		case StreamEventOperation, StreamEventBigMapDiff, StreamEventTransfer, StreamEventContract:
+			filter.types[typ] = struct{}{}
+		default:
+			return filter, errors.Errorf("Unknown stream event type: %s", typ)
+		}
+	}
+	return filter, nil
+}
+
+func (f streamFilter) match(event StreamEvent) bool {
+	if f.network != event.network {
+		return false
+	}
+	if f.types != nil {
+		if _, ok := f.types[event.Type]; !ok {
+			return false
+		}
+	}
+	if f.address != "" && !helpers.StringInArray(f.address, event.addresses) {
+		return false
+	}
+	if f.entrypoint != "" && f.entrypoint != event.entrypoint {
+		return false
+	}
+	if f.ptr != nil && (event.ptr == nil || *f.ptr != *event.ptr) {
+		return false
+	}
+	if f.contract != "" && f.contract != event.contract {
+		return false
+	}
+	return true
+}
+
+type streamSubscriber struct {
+	filter streamFilter
+	events chan StreamEvent
+}
+
+// StreamHub - dispatches indexed entities received from message queue to stream subscribers
+type StreamHub struct {
+	subscribers map[*streamSubscriber]struct{}
+	mx          sync.RWMutex
+}
+
+// NewStreamHub -
+func NewStreamHub() *StreamHub {
+	return &StreamHub{
+		subscribers: make(map[*streamSubscriber]struct{}),
+	}
+}
+
+func (hub *StreamHub) subscribe(filter streamFilter) *streamSubscriber {
+	sub := &streamSubscriber{
+		filter: filter,
+		events: make(chan StreamEvent, streamBufferSize),
+	}
+
+	hub.mx.Lock()
+	hub.subscribers[sub] = struct{}{}
+	hub.mx.Unlock()
+	return sub
+}
+
+func (hub *StreamHub) unsubscribe(sub *streamSubscriber) {
+	hub.mx.Lock()
+	defer hub.mx.Unlock()
+
+	if _, ok := hub.subscribers[sub]; ok {
+		delete(hub.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+func (hub *StreamHub) isEmpty() bool {
+	hub.mx.RLock()
+	defer hub.mx.RUnlock()
+	return len(hub.subscribers) == 0
+}
+
+// broadcast - sends events to matched subscribers. Subscribers which do not keep up with the stream are disconnected.
+func (hub *StreamHub) broadcast(events ...StreamEvent) {
+	hub.mx.Lock()
+	defer hub.mx.Unlock()
+
+	for sub := range hub.subscribers {
+		for i := range events {
+			if !sub.filter.match(events[i]) {
+				continue
+			}
+			select {
+			case sub.events <- events[i]:
+			default:
+				logger.Warning("Stream subscriber is too slow. Disconnecting...")
+				delete(hub.subscribers, sub)
+				close(sub.events)
+			}
+			if _, ok := hub.subscribers[sub]; !ok {
+				break
+			}
+		}
+	}
+}
+
+func (ctx *Context) listenStream(queue string) {
+	msgs, err := ctx.MQ.Consume(queue)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	logger.Info("Streaming %s queue", queue)
+	for msg := range msgs {
+		if msg.GetKey() == "" {
+			logger.Warning("[%s] Rabbit MQ server stopped! Streaming is stopped.", queue)
+			return
+		}
+
+		if err := msg.Ack(false); err != nil {
+			logger.Errorf("Error acknowledging message: %s", err)
+			continue
+		}
+
+		if ctx.Stream.isEmpty() {
+			continue
+		}
+
+		events, err := ctx.getStreamEvents(msg.GetKey(), parseStreamID(msg.GetBody()))
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+		ctx.Stream.broadcast(events...)
+	}
+}
+
+func parseStreamID(data []byte) string {
+	return strings.Trim(string(data), `"`)
+}
+
+func (ctx *Context) getStreamEvents(queue, id string) ([]StreamEvent, error) {
+	switch queue {
+	case mq.QueueOperations:
+		return ctx.getOperationStreamEvents(id)
+	case mq.QueueBigMapDiffs:
+		return ctx.getBigMapDiffStreamEvents(id)
+	case mq.QueueContracts:
+		return ctx.getContractStreamEvents(id)
+	default:
+		return nil, nil
+	}
+}
+
+func (ctx *Context) getOperationStreamEvents(id string) ([]StreamEvent, error) {
+	operations := make([]operation.Operation, 0)
+	if err := ctx.Storage.GetByIDs(&operations, id); err != nil {
+		return nil, errors.Errorf("[getOperationStreamEvents] Find operation error for ID %s: %s", id, err)
+	}
+
+	events := make([]StreamEvent, 0)
+	for i := range operations {
+		bmd, err := ctx.BigMapDiffs.GetUniqueByOperationID(operations[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		op, err := ctx.prepareOperation(operations[i], bmd, true)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, StreamEvent{
+			Type:       StreamEventOperation,
+			Body:       op,
+			network:    operations[i].Network,
+			addresses:  []string{operations[i].Source, operations[i].Destination},
+			entrypoint: operations[i].Entrypoint,
+		})
+
+		transfers, err := ctx.getOperationStreamTransfers(operations[i])
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, transfers...)
+	}
+	return events, nil
+}
+
+func (ctx *Context) getOperationStreamTransfers(op operation.Operation) ([]StreamEvent, error) {
+	if op.Kind != consts.Transaction || op.Status != consts.Applied || !bcd.IsContract(op.Destination) {
+		return nil, nil
+	}
+
+	transfers, err := ctx.Transfers.Get(transfer.GetContext{
+		Network: op.Network,
+		Hash:    op.Hash,
+		Counter: &op.Counter,
+		Nonce:   op.Nonce,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// transfers of internal operations have the same hash and counter, so nonces are compared explicitly
+	filtered := make([]transfer.Transfer, 0, len(transfers.Transfers))
+	for i := range transfers.Transfers {
+		if equalNonce(op.Nonce, transfers.Transfers[i].Nonce) {
+			filtered = append(filtered, transfers.Transfers[i])
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, nil
+	}
+
+	response, err := ctx.transfersPostprocessing(transfer.Pageable{
+		Transfers: filtered,
+		Total:     int64(len(filtered)),
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]StreamEvent, len(response.Transfers))
+	for i := range response.Transfers {
+		events[i] = StreamEvent{
+			Type:      StreamEventTransfer,
+			Body:      response.Transfers[i],
+			network:   filtered[i].Network,
+			addresses: []string{filtered[i].From, filtered[i].To, filtered[i].Initiator},
+			contract:  filtered[i].Contract,
+		}
+	}
+	return events, nil
+}
+
+func equalNonce(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (ctx *Context) getBigMapDiffStreamEvents(id string) ([]StreamEvent, error) {
+	bmd := make([]bigmapdiff.BigMapDiff, 0)
+	if err := ctx.Storage.GetByIDs(&bmd, id); err != nil {
+		return nil, errors.Errorf("[getBigMapDiffStreamEvents] Find big map diff error for ID %s: %s", id, err)
+	}
+
+	events := make([]StreamEvent, 0, len(bmd))
+	for i := range bmd {
+		bigMapType, err := ctx.getBigMapType(bmd[i].Network, bmd[i].Address, bmd[i].Protocol, bmd[i].Ptr)
+		if err != nil {
+			return nil, err
+		}
+		key, value, keyString, err := prepareItem(bmd[i], bigMapType)
+		if err != nil {
+			return nil, err
+		}
+
+		ptr := bmd[i].Ptr
+		events = append(events, StreamEvent{
+			Type: StreamEventBigMapDiff,
+			Body: StreamBigMapDiff{
+				BigMapItem: BigMapItem{
+					Key:       key,
+					Value:     value,
+					KeyHash:   bmd[i].KeyHash,
+					KeyString: keyString,
+					Level:     bmd[i].Level,
+					Timestamp: bmd[i].Timestamp,
+				},
+				Ptr:         ptr,
+				Network:     bmd[i].Network,
+				Address:     bmd[i].Address,
+				OperationID: bmd[i].OperationID,
+			},
+			network:   bmd[i].Network,
+			addresses: []string{bmd[i].Address},
+			ptr:       &ptr,
+		})
+	}
+	return events, nil
+}
+
+func (ctx *Context) getContractStreamEvents(id string) ([]StreamEvent, error) {
+	contracts := make([]contract.Contract, 0)
+	if err := ctx.Storage.GetByIDs(&contracts, id); err != nil {
+		return nil, errors.Errorf("[getContractStreamEvents] Find contract error for ID %s: %s", id, err)
+	}
+
+	events := make([]StreamEvent, len(contracts))
+	for i := range contracts {
+		var res Contract
+		res.FromModel(contracts[i])
+
+		events[i] = StreamEvent{
+			Type:      StreamEventContract,
+			Body:      res,
+			network:   contracts[i].Network,
+			addresses: []string{contracts[i].Address, contracts[i].Manager},
+		}
+	}
+	return events, nil
+}
+
+const streamPingPeriod = 30 * time.Second
+
+func newStreamUpgrader(origins []string) websocket.Upgrader {
+	return websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     checkStreamOrigin(origins),
+	}
+}
+
+// checkStreamOrigin - allows browser connections from configured origins only (`*` allows any). If no origins are configured only same-origin connections are allowed. Requests without `Origin` header are sent by non-browser clients and always allowed.
+func checkStreamOrigin(origins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if len(origins) == 0 {
+			u, err := url.Parse(origin)
+			return err == nil && strings.EqualFold(u.Host, r.Host)
+		}
+		for i := range origins {
+			if origins[i] == "*" || strings.EqualFold(strings.TrimSuffix(origins[i], "/"), origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (ctx *Context) subscribeStream(c *gin.Context) (*streamSubscriber, bool) {
+	if ctx.MQ == nil {
+		ctx.handleError(c, errors.New("Streaming is disabled"), http.StatusServiceUnavailable)
+		return nil, false
+	}
+
+	var req streamRequest
+	if err := c.BindQuery(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return nil, false
+	}
+
+	filter, err := newStreamFilter(req)
+	if ctx.handleError(c, err, http.StatusBadRequest) {
+		return nil, false
+	}
+
+	return ctx.Stream.subscribe(filter), true
+}
+
+// StreamSSE godoc
+// @Summary Stream indexed events via Server-Sent Events
+// @Description Pushes new operations, big map diffs, token transfers and originated contracts matching the filter. SSE event name equals the event type.
+// @Tags stream
+// @ID stream-sse
+// @Param network query string true "Network"
+// @Param address query string false "Address of operation participant, big map owner, transfer participant or contract" minlength(36) maxlength(36)
+// @Param entrypoint query string false "Entrypoint of operation"
+// @Param ptr query integer false "Big map pointer"
+// @Param contract query string false "Token contract of transfer" minlength(36) maxlength(36)
+// @Param types query string false "Comma-separated list of event types: operation, big_map_diff, transfer, contract"
+// @Produce  text/event-stream
+// @Success 200 {object} StreamEvent
+// @Failure 400 {object} Error
+// @Failure 503 {object} Error
+// @Router /v1/stream/sse [get]
+func (ctx *Context) StreamSSE(c *gin.Context) {
+	sub, ok := ctx.subscribeStream(c)
+	if !ok {
+		return
+	}
+	defer ctx.Stream.unsubscribe(sub)
+
+	ticker := time.NewTicker(streamPingPeriod)
+	defer ticker.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-sub.events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type, event)
+			return true
+		case <-ticker.C:
+			_, err := w.Write([]byte(": ping\n\n"))
+			return err == nil
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+// StreamWebSocket godoc
+// @Summary Stream indexed events via WebSocket
+// @Description Pushes new operations, big map diffs, token transfers and originated contracts matching the filter. Each message is JSON-encoded event.
+// @Tags stream
+// @ID stream-ws
+// @Param network query string true "Network"
+// @Param address query string false "Address of operation participant, big map owner, transfer participant or contract" minlength(36) maxlength(36)
+// @Param entrypoint query string false "Entrypoint of operation"
+// @Param ptr query integer false "Big map pointer"
+// @Param contract query string false "Token contract of transfer" minlength(36) maxlength(36)
+// @Param types query string false "Comma-separated list of event types: operation, big_map_diff, transfer, contract"
+// @Produce  json
+// @Success 101 {object} StreamEvent
+// @Failure 400 {object} Error
+// @Failure 503 {object} Error
+// @Router /v1/stream/ws [get]
+func (ctx *Context) StreamWebSocket(c *gin.Context) {
+	sub, ok := ctx.subscribeStream(c)
+	if !ok {
+		return
+	}
+	defer ctx.Stream.unsubscribe(sub)
+
+	conn, err := ctx.streamUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	defer conn.Close()
+
+	// incoming messages are ignored, reading is needed to process control frames and detect disconnection
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(streamPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.events:
+			if !ok {
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscriber is too slow"))
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second*10)); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
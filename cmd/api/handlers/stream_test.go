@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPtr(ptr int64) *int64 {
+	return &ptr
+}
+
+func Test_newStreamFilter(t *testing.T) {
+	filter, err := newStreamFilter(streamRequest{Network: "mainnet", Types: "operation,transfer"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]struct{}{StreamEventOperation: {}, StreamEventTransfer: {}}, filter.types)
+	}
+
+	filter, err = newStreamFilter(streamRequest{Network: "mainnet"})
+	if assert.NoError(t, err) {
+		assert.Nil(t, filter.types)
+	}
+
+	_, err = newStreamFilter(streamRequest{Network: "mainnet", Types: "operation,block"})
+	assert.Error(t, err)
+}
+
+func Test_streamFilter_match(t *testing.T) {
+	operation := StreamEvent{
+		Type:       StreamEventOperation,
+		network:    "mainnet",
+		addresses:  []string{"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"},
+		entrypoint: "transfer",
+	}
+	bmd := StreamEvent{
+		Type:      StreamEventBigMapDiff,
+		network:   "mainnet",
+		addresses: []string{"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"},
+		ptr:       newTestPtr(31),
+	}
+	transfer := StreamEvent{
+		Type:      StreamEventTransfer,
+		network:   "mainnet",
+		addresses: []string{"tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6", ""},
+		contract:  "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn",
+	}
+
+	tests := []struct {
+		name   string
+		filter streamFilter
+		event  StreamEvent
+		want   bool
+	}{
+		{
+			name:   "network only",
+			filter: streamFilter{network: "mainnet"},
+			event:  operation,
+			want:   true,
+		}, {
+			name:   "another network",
+			filter: streamFilter{network: "edo2net"},
+			event:  operation,
+			want:   false,
+		}, {
+			name:   "type matched",
+			filter: streamFilter{network: "mainnet", types: map[string]struct{}{StreamEventOperation: {}, StreamEventContract: {}}},
+			event:  operation,
+			want:   true,
+		}, {
+			name:   "type mismatched",
+			filter: streamFilter{network: "mainnet", types: map[string]struct{}{StreamEventContract: {}}},
+			event:  operation,
+			want:   false,
+		}, {
+			name:   "address of participant",
+			filter: streamFilter{network: "mainnet", address: "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"},
+			event:  transfer,
+			want:   true,
+		}, {
+			name:   "address of another account",
+			filter: streamFilter{network: "mainnet", address: "tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb"},
+			event:  operation,
+			want:   false,
+		}, {
+			name:   "entrypoint matched",
+			filter: streamFilter{network: "mainnet", entrypoint: "transfer"},
+			event:  operation,
+			want:   true,
+		}, {
+			name:   "entrypoint mismatched",
+			filter: streamFilter{network: "mainnet", entrypoint: "mint"},
+			event:  operation,
+			want:   false,
+		}, {
+			name:   "entrypoint of event without entrypoint",
+			filter: streamFilter{network: "mainnet", entrypoint: "transfer"},
+			event:  bmd,
+			want:   false,
+		}, {
+			name:   "ptr matched",
+			filter: streamFilter{network: "mainnet", ptr: newTestPtr(31)},
+			event:  bmd,
+			want:   true,
+		}, {
+			name:   "ptr mismatched",
+			filter: streamFilter{network: "mainnet", ptr: newTestPtr(32)},
+			event:  bmd,
+			want:   false,
+		}, {
+			name:   "ptr of event without ptr",
+			filter: streamFilter{network: "mainnet", ptr: newTestPtr(0)},
+			event:  operation,
+			want:   false,
+		}, {
+			name:   "contract matched",
+			filter: streamFilter{network: "mainnet", contract: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"},
+			event:  transfer,
+			want:   true,
+		}, {
+			name:   "contract of event without contract",
+			filter: streamFilter{network: "mainnet", contract: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"},
+			event:  operation,
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.match(tt.event))
+		})
+	}
+}
+
+func TestStreamHub_broadcast(t *testing.T) {
+	hub := NewStreamHub()
+	operations := hub.subscribe(streamFilter{network: "mainnet", types: map[string]struct{}{StreamEventOperation: {}}})
+	all := hub.subscribe(streamFilter{network: "mainnet"})
+	other := hub.subscribe(streamFilter{network: "edo2net"})
+
+	hub.broadcast(
+		StreamEvent{Type: StreamEventOperation, network: "mainnet"},
+		StreamEvent{Type: StreamEventContract, network: "mainnet"},
+	)
+
+	if assert.Len(t, operations.events, 1) {
+		assert.Equal(t, StreamEventOperation, (<-operations.events).Type)
+	}
+	if assert.Len(t, all.events, 2) {
+		assert.Equal(t, StreamEventOperation, (<-all.events).Type)
+		assert.Equal(t, StreamEventContract, (<-all.events).Type)
+	}
+	assert.Len(t, other.events, 0)
+
+	hub.unsubscribe(operations)
+	hub.unsubscribe(all)
+	hub.unsubscribe(other)
+	assert.True(t, hub.isEmpty())
+
+	_, ok := <-all.events
+	assert.False(t, ok, "events channel should be closed")
+}
+
+func TestStreamHub_slowSubscriber(t *testing.T) {
+	hub := NewStreamHub()
+	slow := hub.subscribe(streamFilter{network: "mainnet"})
+	fast := hub.subscribe(streamFilter{network: "mainnet"})
+
+	event := StreamEvent{Type: StreamEventOperation, network: "mainnet"}
+	for i := 0; i < streamBufferSize; i++ {
+		hub.broadcast(event)
+		<-fast.events
+	}
+	assert.Len(t, slow.events, streamBufferSize)
+
+	// buffer of slow subscriber is full: it's disconnected, fast one keeps receiving
+	hub.broadcast(event, event)
+	assert.Len(t, fast.events, 2)
+
+	received := 0
+	for range slow.events {
+		received++
+	}
+	assert.Equal(t, streamBufferSize, received)
+
+	hub.mx.RLock()
+	_, slowOk := hub.subscribers[slow]
+	_, fastOk := hub.subscribers[fast]
+	hub.mx.RUnlock()
+	assert.False(t, slowOk)
+	assert.True(t, fastOk)
+
+	// unsubscribe of disconnected subscriber must not close channel twice
+	assert.NotPanics(t, func() {
+		hub.unsubscribe(slow)
+		hub.unsubscribe(fast)
+	})
+	assert.True(t, hub.isEmpty())
+}
+
+func Test_checkStreamOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		origins []string
+		host    string
+		origin  string
+		want    bool
+	}{
+		{
+			name: "non-browser client",
+			host: "api.better-call.dev",
+			want: true,
+		}, {
+			name:   "same origin by default",
+			host:   "api.better-call.dev",
+			origin: "https://api.better-call.dev",
+			want:   true,
+		}, {
+			name:   "cross origin by default",
+			host:   "api.better-call.dev",
+			origin: "https://evil.example.com",
+			want:   false,
+		}, {
+			name:    "configured origin",
+			origins: []string{"https://better-call.dev/"},
+			host:    "api.better-call.dev",
+			origin:  "https://better-call.dev",
+			want:    true,
+		}, {
+			name:    "not configured origin",
+			origins: []string{"https://better-call.dev"},
+			host:    "api.better-call.dev",
+			origin:  "https://api.better-call.dev",
+			want:    false,
+		}, {
+			name:    "any origin",
+			origins: []string{"*"},
+			host:    "api.better-call.dev",
+			origin:  "https://evil.example.com",
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := http.NewRequest(http.MethodGet, "/v1/stream/ws", nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			r.Host = tt.host
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			assert.Equal(t, tt.want, checkStreamOrigin(tt.origins)(r))
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"github.com/baking-bad/bcdhub/internal/database"
+	"github.com/baking-bad/bcdhub/internal/notifier"
+	"github.com/jinzhu/gorm"
 
 	"github.com/gin-gonic/gin"
 )
@@ -35,6 +37,11 @@ func (ctx *Context) CreateSubscription(c *gin.Context) {
 		ctx.handleError(c, fmt.Errorf("You have to set `Sentry DSN` when sentry notifications is enabled"), http.StatusBadRequest)
 		return
 	}
+	if sub.WebhookURL != "" {
+		if err := notifier.ValidateURL(sub.WebhookURL); ctx.handleError(c, err, http.StatusBadRequest) {
+			return
+		}
+	}
 
 	userID := CurrentUserID(c)
 	if userID == 0 {
@@ -43,12 +50,21 @@ func (ctx *Context) CreateSubscription(c *gin.Context) {
 	}
 
 	subscription := database.Subscription{
-		UserID:    userID,
-		Address:   sub.Address,
-		Network:   sub.Network,
-		Alias:     sub.Alias,
-		WatchMask: sub.getMask(),
-		SentryDSN: sub.SentryDSN,
+		UserID:     userID,
+		Address:    sub.Address,
+		Network:    sub.Network,
+		Alias:      sub.Alias,
+		WatchMask:  sub.getMask(),
+		SentryDSN:  sub.SentryDSN,
+		WebhookURL: sub.WebhookURL,
+	}
+
+	if sub.WebhookURL != "" {
+		secret, err := ctx.getWebhookSecret(userID, sub)
+		if ctx.handleError(c, err, 0) {
+			return
+		}
+		subscription.WebhookSecret = secret
 	}
 
 	if err := ctx.DB.UpsertSubscription(&subscription); ctx.handleError(c, err, 0) {
@@ -92,6 +108,8 @@ func PrepareSubscription(sub database.Subscription) (res Subscription) {
 	res.Alias = sub.Alias
 	res.SubscribedAt = sub.CreatedAt
 	res.SentryDSN = sub.SentryDSN
+	res.WebhookURL = sub.WebhookURL
+	res.WebhookSecret = sub.WebhookSecret
 	return
 }
 
@@ -105,3 +123,19 @@ func PrepareSubscriptions(subs []database.Subscription) []Subscription {
 
 	return res
 }
+
+// getWebhookSecret - returns secret passed by user. Otherwise the secret of existing subscription is kept or the new one is generated.
+func (ctx *Context) getWebhookSecret(userID uint, sub subRequest) (string, error) {
+	if sub.WebhookSecret != "" {
+		return sub.WebhookSecret, nil
+	}
+
+	existing, err := ctx.DB.GetSubscription(userID, sub.Address, sub.Network)
+	switch {
+	case err == nil && existing.WebhookSecret != "":
+		return existing.WebhookSecret, nil
+	case err != nil && !gorm.IsRecordNotFoundError(err):
+		return "", err
+	}
+	return notifier.GenerateSecret()
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+	"sort"
+
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/gin-gonic/gin"
+)
+
+// GetContractTickets godoc
+// @Summary Get tickets held by contract
+// @Description Get tickets held by contract in its storage and big maps. Tickets with the same ticketer and content are summed up.
+// @Tags contract
+// @ID get-contract-tickets
+// @Param network path string true "Network"
+// @Param address path string true "KT address" minlength(36) maxlength(36)
+// @Accept json
+// @Produce json
+// @Success 200 {array} Ticket
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/contract/{network}/{address}/tickets [get]
+func (ctx *Context) GetContractTickets(c *gin.Context) {
+	var req getContractRequest
+	if err := c.BindUri(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	tickets, err := ctx.Tickets.GetByHolder(req.Network, req.Address)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	c.JSON(http.StatusOK, prepareTickets(tickets))
+}
+
+// GetTicketerTickets godoc
+// @Summary Get tickets created by contract
+// @Description Get holders of tickets created by contract. Tickets with the same holder and content are summed up.
+// @Tags tickets
+// @ID get-ticketer-tickets
+// @Param network path string true "Network"
+// @Param address path string true "KT address of ticketer" minlength(36) maxlength(36)
+// @Accept json
+// @Produce json
+// @Success 200 {array} Ticket
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /v1/tickets/{network}/{address} [get]
+func (ctx *Context) GetTicketerTickets(c *gin.Context) {
+	var req getContractRequest
+	if err := c.BindUri(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	tickets, err := ctx.Tickets.GetByTicketer(req.Network, req.Address)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	c.JSON(http.StatusOK, prepareTickets(tickets))
+}
+
+// prepareTickets - sums amounts of tickets stored by holder in different locations
+func prepareTickets(tickets []ticket.Ticket) []Ticket {
+	type key struct {
+		holder, ticketer, contentHash string
+	}
+
+	amounts := make(map[key]*big.Int)
+	result := make([]Ticket, 0)
+	keys := make([]key, 0)
+	for i := range tickets {
+		k := key{tickets[i].Holder, tickets[i].Ticketer, tickets[i].ContentHash}
+		if amount, ok := amounts[k]; ok {
+			amount.Add(amount, tickets[i].Value)
+			continue
+		}
+		amounts[k] = new(big.Int).Set(tickets[i].Value)
+		keys = append(keys, k)
+		result = append(result, Ticket{
+			Holder:      tickets[i].Holder,
+			Ticketer:    tickets[i].Ticketer,
+			ContentType: []byte(tickets[i].ContentType),
+			Content:     []byte(tickets[i].Content),
+		})
+	}
+
+	for i := range result {
+		result[i].Amount = amounts[keys[i]].String()
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Ticketer != result[j].Ticketer {
+			return result[i].Ticketer < result[j].Ticketer
+		}
+		if result[i].Holder != result[j].Holder {
+			return result[i].Holder < result[j].Holder
+		}
+		return string(result[i].Content) < string(result[j].Content)
+	})
+	return result
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/baking-bad/bcdhub/internal/bcd/ast/interfaces"
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
 	"github.com/baking-bad/bcdhub/internal/models/tokenmetadata"
 	"github.com/baking-bad/bcdhub/internal/models/transfer"
@@ -55,11 +56,11 @@ func (ctx *Context) GetFA(c *gin.Context) {
 
 // GetFAByVersion godoc
 // @Summary Get all contracts that implement FA1/FA1.2 standard by version
-// @Description Get all contracts that implement FA1/FA1.2 standard by version
+// @Description Get all contracts that implement FA1/FA1.2 standard by version or any contract interface from `/v1/interfaces`
 // @Tags tokens
 // @ID get-fa-version
 // @Param network path string true "Network"
-// @Param faversion path string true "FA token version" Enums(fa1, fa12, fa2)
+// @Param faversion path string true "FA token version (fa1, fa12, fa2) or name of contract interface"
 // @Param offset query integer false "Offset"
 // @Param size query integer false "Requested count" minimum(0) maximum(10)
 // @Accept json
@@ -110,7 +111,7 @@ func (ctx *Context) GetFAByVersion(c *gin.Context) {
 // @Param start query integer false "Timestamp in seconds" mininum(1)
 // @Param end query integer false "Timestamp in seconds" mininum(1)
 // @Param contracts query string false "Comma-separated list of contracts which tokens will be requested"
-// @Param token_id query integer false "Token ID" mininum(0)
+// @Param token_id query string false "Token ID"
 // @Accept json
 // @Produce json
 // @Success 200 {object} TransferResponse
@@ -133,11 +134,6 @@ func (ctx *Context) GetFA12OperationsForAddress(c *gin.Context) {
 		contracts = strings.Split(ctxReq.Contracts, ",")
 	}
 
-	tokenID := int64(-1)
-	if ctxReq.TokenID != nil {
-		tokenID = *ctxReq.TokenID
-	}
-
 	transfers, err := ctx.Transfers.Get(transfer.GetContext{
 		Network:   req.Network,
 		Address:   req.Address,
@@ -147,7 +143,7 @@ func (ctx *Context) GetFA12OperationsForAddress(c *gin.Context) {
 		LastID:    ctxReq.LastID,
 		SortOrder: ctxReq.Sort,
 		Size:      ctxReq.Size,
-		TokenID:   tokenID,
+		TokenID:   parseTokenID(ctxReq.TokenID),
 	})
 	if ctx.handleError(c, err, 0) {
 		return
@@ -169,7 +165,7 @@ func (ctx *Context) GetFA12OperationsForAddress(c *gin.Context) {
 // @Param network path string true "Network"
 // @Param period query string true "One of periods"  Enums(year, month, week, day)
 // @Param contract path string true "KT address" minlength(36) maxlength(36)
-// @Param token_id query int true "Token ID" minimum(0)
+// @Param token_id query string true "Token ID"
 // @Accept json
 // @Produce  json
 // @Success 200 {object} SeriesFloat
@@ -192,7 +188,12 @@ func (ctx *Context) GetTokenVolumeSeries(c *gin.Context) {
 		return
 	}
 
-	series, err := ctx.Transfers.GetTokenVolumeSeries(req.Network, args.Period, []string{args.Contract}, dapp.Contracts, args.TokenID)
+	tokenID := types.NewBigInt(0)
+	if args.TokenID != "" {
+		tokenID = parseTokenID(&args.TokenID)
+	}
+
+	series, err := ctx.Transfers.GetTokenVolumeSeries(req.Network, args.Period, []string{args.Contract}, dapp.Contracts, tokenID)
 	if ctx.handleError(c, err, 0) {
 		return
 	}
@@ -229,6 +230,9 @@ func (ctx *Context) contractToTokens(contracts []contract.Contract, network, ver
 				tokens[i].Type = consts.FA1Tag
 			}
 		}
+		if tokens[i].Type == "" {
+			tokens[i].Type = version
+		}
 		addresses[i] = tokens[i].Address
 	}
 
@@ -313,7 +317,6 @@ func (ctx *Context) getTokens(network, address string, size, offset, minLevel, m
 		{
 			Contract: address,
 			Network:  network,
-			TokenID:  -1,
 			MinLevel: minLevel,
 			MaxLevel: maxLevel,
 		},
@@ -347,7 +350,7 @@ func (ctx *Context) getTokens(network, address string, size, offset, minLevel, m
 // @ID get-token-holders
 // @Param network path string true "Network"
 // @Param address path string true "KT address" minlength(36) maxlength(36)
-// @Param token_id query int true "Token ID" minimum(0)
+// @Param token_id query string true "Token ID"
 // @Accept  json
 // @Produce  json
 // @Success 200 {array} gin.H
@@ -364,7 +367,7 @@ func (ctx *Context) GetTokenHolders(c *gin.Context) {
 		return
 	}
 
-	balances, err := ctx.TokenBalances.GetHolders(req.Network, req.Address, *reqArgs.TokenID)
+	balances, err := ctx.TokenBalances.GetHolders(req.Network, req.Address, parseTokenID(&reqArgs.TokenID))
 	if ctx.handleError(c, err, 0) {
 		return
 	}
@@ -17,7 +17,7 @@ import (
 // @Param address path string true "KT address" minlength(36) maxlength(36)
 // @Param size query integer false "Transfers count" mininum(1) maximum(10)
 // @Param offset query integer false "Offset" mininum(1)
-// @Param token_id query integer false "Token ID" mininum(1)
+// @Param token_id query string false "Token ID"
 // @Accept  json
 // @Produce  json
 // @Success 200 {object} TransferResponse
@@ -34,17 +34,12 @@ func (ctx *Context) GetContractTransfers(c *gin.Context) {
 		return
 	}
 
-	tokenID := int64(-1)
-	if req.TokenID != nil {
-		tokenID = int64(*req.TokenID)
-	}
-
 	transfers, err := ctx.Transfers.Get(transfer.GetContext{
 		Network:   contractRequest.Network,
 		Contracts: []string{contractRequest.Address},
 		Size:      req.Size,
 		Offset:    req.Offset,
-		TokenID:   tokenID,
+		TokenID:   parseTokenID(req.TokenID),
 	})
 	if ctx.handleError(c, err, 0) {
 		return
@@ -59,7 +54,7 @@ func (ctx *Context) GetContractTransfers(c *gin.Context) {
 type tokenKey struct {
 	Network  string
 	Contract string
-	TokenID  int64
+	TokenID  string
 }
 
 func (ctx *Context) transfersPostprocessing(transfers transfer.Pageable, withLastID bool) (response TransferResponse, err error) {
@@ -70,9 +65,7 @@ func (ctx *Context) transfersPostprocessing(transfers transfer.Pageable, withLas
 	}
 
 	mapTokens := make(map[tokenKey]*TokenMetadata)
-	tokens, err := ctx.TokenMetadata.GetAll(tokenmetadata.GetContext{
-		TokenID: -1,
-	})
+	tokens, err := ctx.TokenMetadata.GetAll(tokenmetadata.GetContext{})
 	if err != nil {
 		if !ctx.Storage.IsRecordNotFound(err) {
 			return
@@ -82,7 +75,7 @@ func (ctx *Context) transfersPostprocessing(transfers transfer.Pageable, withLas
 			mapTokens[tokenKey{
 				Network:  tokens[i].Network,
 				Contract: tokens[i].Contract,
-				TokenID:  tokens[i].TokenID,
+				TokenID:  tokens[i].TokenID.String(),
 			}] = &TokenMetadata{
 				Contract: tokens[i].Contract,
 				TokenID:  tokens[i].TokenID,
@@ -98,7 +91,7 @@ func (ctx *Context) transfersPostprocessing(transfers transfer.Pageable, withLas
 		token := mapTokens[tokenKey{
 			Network:  transfers.Transfers[i].Network,
 			Contract: transfers.Transfers[i].Contract,
-			TokenID:  transfers.Transfers[i].TokenID,
+			TokenID:  transfers.Transfers[i].TokenID.String(),
 		}]
 
 		response.Transfers[i] = TransferFromElasticModel(transfers.Transfers[i])
@@ -1,13 +1,17 @@
 package handlers
 
 import (
+	stdJSON "encoding/json"
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/ast"
 	"github.com/baking-bad/bcdhub/internal/bcd/base"
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
 	"github.com/baking-bad/bcdhub/internal/models/tzip"
+	"github.com/baking-bad/bcdhub/internal/parsers/storage"
+	tzipStorage "github.com/baking-bad/bcdhub/internal/parsers/tzip/storage"
 	"github.com/baking-bad/bcdhub/internal/views"
 	"github.com/gin-gonic/gin"
 )
@@ -20,7 +24,7 @@ var (
 
 // GetViewsSchema godoc
 // @Summary Get view schemas of contract metadata
-// @Description Get view schemas of contract metadata
+// @Description Get view schemas of contract metadata. Views implemented by `michelsonStorageView` and `restApiQuery` are returned. Arguments of `restApiQuery` views are taken from the referenced OpenAPI specification.
 // @Tags contract
 // @ID get-contract-tzip-views-schema
 // @Param network path string true "Network"
@@ -52,6 +56,15 @@ func (ctx *Context) GetViewsSchema(c *gin.Context) {
 	for _, view := range tzip.Views {
 		for i, impl := range view.Implementations {
 			if impl.MichelsonStorageView.Empty() {
+				if impl.RestAPIQuery.Empty() {
+					continue
+				}
+				restView, err := ctx.getRestAPIQueryView(req.Network, req.Address, impl, view.Name)
+				if err != nil {
+					// specification is unavailable or doesn't describe the view: it can't be executed
+					continue
+				}
+				schemas = append(schemas, getRestAPIQuerySchema(restView, view.Description, i))
 				continue
 			}
 
@@ -59,6 +72,7 @@ func (ctx *Context) GetViewsSchema(c *gin.Context) {
 				Name:           view.Name,
 				Description:    view.Description,
 				Implementation: i,
+				Kind:           views.KindMichelsonStorageView,
 			}
 
 			tree, err := getViewTree(impl)
@@ -87,7 +101,7 @@ func (ctx *Context) GetViewsSchema(c *gin.Context) {
 
 // ExecuteView godoc
 // @Summary Execute view of contracts metadata
-// @Description Execute view of contracts metadata
+// @Description Execute view of contracts metadata. `michelsonStorageView` returns Micheline tree of the result. `restApiQuery` returns JSON response of the called API as is, `data` is passed as its parameters.
 // @Tags contract
 // @ID contract-execute-view
 // @Param network path string true "Network"
@@ -109,16 +123,6 @@ func (ctx *Context) ExecuteView(c *gin.Context) {
 		return
 	}
 
-	rpc, err := ctx.GetRPC(req.Network)
-	if ctx.handleError(c, err, http.StatusBadRequest) {
-		return
-	}
-
-	state, err := ctx.Blocks.Last(req.Network)
-	if ctx.handleError(c, err, 0) {
-		return
-	}
-
 	tzipValue, err := ctx.TZIP.Get(req.Network, req.Address)
 	if ctx.handleError(c, err, 0) {
 		return
@@ -143,7 +147,21 @@ func (ctx *Context) ExecuteView(c *gin.Context) {
 		break
 	}
 	if impl.MichelsonStorageView.Empty() {
-		ctx.handleError(c, errEmptyImplementation, 0)
+		if impl.RestAPIQuery.Empty() {
+			ctx.handleError(c, errEmptyImplementation, 0)
+			return
+		}
+		ctx.executeRestAPIQuery(c, req, execView, impl)
+		return
+	}
+
+	rpc, err := ctx.getInterpreter(req.Network)
+	if ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	state, err := ctx.Blocks.Last(req.Network)
+	if ctx.handleError(c, err, 0) {
 		return
 	}
 
@@ -209,3 +227,99 @@ func getViewTree(impl tzip.ViewImplementation) (*ast.TypedAst, error) {
 	}
 	return ast.NewTypedAstFromString(`{"prim":"unit"}`)
 }
+
+func (ctx *Context) executeRestAPIQuery(c *gin.Context, req getContractRequest, execView executeViewRequest, impl tzip.ViewImplementation) {
+	view, err := ctx.getRestAPIQueryView(req.Network, req.Address, impl, execView.Name)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	response, err := views.ExecuteWithoutParsing(nil, view, views.Context{
+		Network:   req.Network,
+		Contract:  req.Address,
+		Arguments: execView.Data,
+	})
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	var result stdJSON.RawMessage
+	if err := view.Parse(response, &result); ctx.handleError(c, err, 0) {
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// getRestAPIQueryView - creates `restApiQuery` view and loads its OpenAPI specification from contract metadata
+func (ctx *Context) getRestAPIQueryView(network, address string, impl tzip.ViewImplementation, name string) (*views.RestAPIQuery, error) {
+	rpc, err := ctx.GetRPC(network)
+	if err != nil {
+		return nil, err
+	}
+
+	view := views.NewRestAPIQuery(impl, name)
+
+	var ptr int64
+	if strings.HasPrefix(view.SpecificationURI, tzipStorage.PrefixTezosStorage) {
+		state, err := ctx.Blocks.Last(network)
+		if err != nil {
+			return nil, err
+		}
+		ptr, err = storage.GetBigMapPtr(rpc, address, "metadata", network, state.Protocol, ctx.SharePath, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	specStorage := tzipStorage.NewFull(ctx.BigMapDiffs, ctx.Blocks, ctx.Storage, rpc, ctx.SharePath, ctx.Config.IPFSGateways...)
+	if err := view.LoadSpecification(specStorage, network, address, ptr); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+func getRestAPIQuerySchema(view *views.RestAPIQuery, description string, implementation int) ViewSchema {
+	if description == "" {
+		description = view.Operation.Description
+	}
+	if description == "" {
+		description = view.Operation.Summary
+	}
+
+	schema := ViewSchema{
+		Name:           view.Name,
+		Description:    description,
+		Implementation: implementation,
+		Kind:           views.KindRestAPIQuery,
+		Schema: &ast.JSONSchema{
+			Type:       ast.JSONSchemaTypeObject,
+			Properties: make(map[string]*ast.JSONSchema),
+		},
+	}
+
+	typedef := ast.Typedef{
+		Name: view.Name,
+		Type: views.KindRestAPIQuery,
+		Args: make([]ast.TypedefArg, 0),
+	}
+	for _, param := range view.Parameters() {
+		typ := param.Schema.Type
+		if typ == "" {
+			typ = ast.JSONSchemaTypeString
+		}
+		schema.Schema.Properties[param.Name] = &ast.JSONSchema{
+			Type:   typ,
+			Title:  param.Name,
+			Format: param.Schema.Format,
+		}
+		if param.Required || param.In == views.OpenAPIInPath {
+			schema.Schema.Required = append(schema.Schema.Required, param.Name)
+		}
+		typedef.Args = append(typedef.Args, ast.TypedefArg{
+			Key:   param.Name,
+			Value: typ,
+		})
+	}
+	schema.Type = []ast.Typedef{typedef}
+	return schema
+}
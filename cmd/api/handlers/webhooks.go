@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/baking-bad/bcdhub/internal/notifier"
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+)
+
+// ListWebhookDeliveries -
+func (ctx *Context) ListWebhookDeliveries(c *gin.Context) {
+	userID := CurrentUserID(c)
+	if userID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user"})
+		return
+	}
+
+	var req compilationRequest
+	if err := c.BindQuery(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	deliveries, err := ctx.DB.ListWebhookDeliveries(userID, req.Limit, req.Offset)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	count, err := ctx.DB.CountWebhookDeliveries(userID)
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+		"total":      count,
+	})
+}
+
+// ReplayWebhookDelivery - schedules the new delivery with the same payload. It's sent by notifier service.
+func (ctx *Context) ReplayWebhookDelivery(c *gin.Context) {
+	userID := CurrentUserID(c)
+	if userID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user"})
+		return
+	}
+
+	var req webhookDeliveryRequest
+	if err := c.BindUri(&req); ctx.handleError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	delivery, err := ctx.DB.GetWebhookDelivery(userID, req.ID)
+	if gorm.IsRecordNotFoundError(err) {
+		ctx.handleError(c, err, http.StatusNotFound)
+		return
+	}
+	if ctx.handleError(c, err, 0) {
+		return
+	}
+
+	replay := notifier.NewReplay(*delivery)
+	if err := ctx.DB.CreateWebhookDelivery(&replay); ctx.handleError(c, err, 0) {
+		return
+	}
+
+	c.JSON(http.StatusOK, replay)
+}
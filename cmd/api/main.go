@@ -12,6 +12,7 @@ import (
 	"github.com/baking-bad/bcdhub/internal/config"
 	"github.com/baking-bad/bcdhub/internal/helpers"
 	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/monitoring"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -37,6 +38,8 @@ func newApp() *app {
 		defer helpers.CatchPanicSentry()
 	}
 
+	monitoring.Serve(cfg.API.Prometheus.Bind)
+
 	ctx, err := handlers.NewContext(cfg)
 	if err != nil {
 		logger.Error(err)
@@ -78,6 +81,10 @@ func (api *app) makeRouter() {
 		r.Use(helpers.SentryMiddleware())
 	}
 
+	if api.Context.Config.API.Prometheus.Bind != "" {
+		r.Use(monitoring.Middleware())
+	}
+
 	r.Use(gin.Recovery())
 
 	if env := os.Getenv(config.EnvironmentVar); env == config.EnvironmentProd {
@@ -87,6 +94,19 @@ func (api *app) makeRouter() {
 	}
 
 	v1 := r.Group("v1")
+	if api.Context.Limiter != nil {
+		v1.Use(api.Context.RateLimit(
+			"/v1/search",
+			"/v1/contract/:network/:address/entrypoints/trace",
+			"/v1/contract/:network/:address/entrypoints/run_operation",
+			"/v1/contract/:network/:address/entrypoints/forge",
+			"/v1/contract/:network/:address/views/execute",
+			"/v1/contract/:network/:address/storage/rich",
+			"/v1/simulate/:network",
+			"/v1/bigmap/:network/:ptr/keys/find",
+			"/v1/bigmap/:network/:ptr/range",
+		))
+	}
 	{
 		v1.GET("swagger.json", api.Context.GetSwaggerDoc)
 
@@ -96,7 +116,10 @@ func (api *app) makeRouter() {
 		v1.GET("pick_random", api.Context.GetRandomContract)
 		v1.GET("search", api.Context.Search)
 		v1.POST("fork", api.Context.ForkContract)
+		v1.POST("unforge", api.Context.UnforgeOperation)
+		v1.POST("simulate/:network", api.Context.SimulateBatch)
 		v1.GET("config", api.Context.GetConfig)
+		v1.GET("interfaces", api.Context.GetContractInterfaces)
 
 		v1.POST("diff", api.Context.GetDiff)
 
@@ -121,13 +144,22 @@ func (api *app) makeRouter() {
 			bigmap.GET("", api.Context.GetBigMap)
 			bigmap.GET("count", api.Context.GetBigMapDiffCount)
 			bigmap.GET("history", api.Context.GetBigMapHistory)
+			bigmap.GET("range", api.Context.GetBigMapKeysRange)
 			keys := bigmap.Group("keys")
 			{
 				keys.GET("", api.Context.GetBigMapKeys)
+				keys.POST("find", api.Context.GetBigMapByKey)
 				keys.GET(":key_hash", api.Context.GetBigMapByKeyHash)
 			}
 		}
 
+		sapling := v1.Group("sapling/:network/:ptr")
+		{
+			sapling.GET("", api.Context.GetSaplingState)
+			sapling.GET("history", api.Context.GetSaplingHistory)
+			sapling.GET("series", api.Context.GetSaplingSeries)
+		}
+
 		contract := v1.Group("contract/:network/:address")
 		contract.Use(api.Context.IsAuthenticated())
 		{
@@ -136,6 +168,8 @@ func (api *app) makeRouter() {
 			contract.GET("operations", api.Context.GetContractOperations)
 			contract.GET("migrations", api.Context.GetContractMigrations)
 			contract.GET("transfers", api.Context.GetContractTransfers)
+			contract.GET("tickets", api.Context.GetContractTickets)
+			contract.GET("interfaces", api.Context.GetContractInterfaceMatches)
 
 			tokens := contract.Group("tokens")
 			{
@@ -161,6 +195,7 @@ func (api *app) makeRouter() {
 				entrypoints.POST("data", api.Context.GetEntrypointData)
 				entrypoints.POST("trace", api.Context.RunCode)
 				entrypoints.POST("run_operation", api.Context.RunOperation)
+				entrypoints.POST("forge", api.Context.ForgeOperation)
 			}
 			views := contract.Group("views")
 			{
@@ -175,6 +210,11 @@ func (api *app) makeRouter() {
 			domains.GET("resolve", api.Context.ResolveDomain)
 		}
 
+		tickets := v1.Group("tickets/:network/:address")
+		{
+			tickets.GET("", api.Context.GetTicketerTickets)
+		}
+
 		account := v1.Group("account/:network/:address")
 		{
 			account.GET("", api.Context.GetInfo)
@@ -221,6 +261,18 @@ func (api *app) makeRouter() {
 					subscriptions.GET("events", api.Context.GetEvents)
 					subscriptions.GET("mempool", api.Context.GetMempoolEvents)
 				}
+				apiKeys := profile.Group("api_keys")
+				{
+					apiKeys.GET("", api.Context.ListAPIKeys)
+					apiKeys.POST("", api.Context.CreateAPIKey)
+					apiKeys.DELETE(":id", api.Context.DeleteAPIKey)
+					apiKeys.GET(":id/usage", api.Context.GetAPIKeyUsage)
+				}
+				webhooks := profile.Group("webhooks")
+				{
+					webhooks.GET("deliveries", api.Context.ListWebhookDeliveries)
+					webhooks.POST("deliveries/:id/replay", api.Context.ReplayWebhookDelivery)
+				}
 				vote := profile.Group("vote")
 				{
 					vote.POST("", api.Context.Vote)
@@ -245,6 +297,12 @@ func (api *app) makeRouter() {
 			}
 		}
 
+		stream := v1.Group("stream")
+		{
+			stream.GET("sse", api.Context.StreamSSE)
+			stream.GET("ws", api.Context.StreamWebSocket)
+		}
+
 		dapps := v1.Group("dapps")
 		{
 			dapps.GET("", api.Context.GetDAppList)
@@ -287,7 +345,8 @@ func corsSettings() gin.HandlerFunc {
 	return cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PATCH"},
-		AllowHeaders:     []string{"X-Requested-With", "Authorization", "Origin", "Content-Length", "Content-Type", "Referer", "Cache-Control", "User-Agent"},
+		AllowHeaders:     []string{"X-Requested-With", "Authorization", "Origin", "Content-Length", "Content-Type", "Referer", "Cache-Control", "User-Agent", "X-API-Key"},
+		ExposeHeaders:    []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "X-RateLimit-Quota-Limit", "X-RateLimit-Quota-Remaining", "X-RateLimit-Quota-Reset", "Retry-After"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	})
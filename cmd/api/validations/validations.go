@@ -1,9 +1,11 @@
 package validations
 
 import (
+	"math/big"
 	"reflect"
 	"strings"
 
+	"github.com/baking-bad/bcdhub/internal/bcd/ast/interfaces"
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
 	"github.com/baking-bad/bcdhub/internal/compiler/compilation"
 	"github.com/baking-bad/bcdhub/internal/helpers"
@@ -49,6 +51,10 @@ func Register(v *validator.Validate, networks []string) error {
 		return err
 	}
 
+	if err := v.RegisterValidation("token_id", tokenIDValidator()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -102,11 +108,7 @@ func statusValidator() validator.Func {
 func faVersionValidator() validator.Func {
 	return func(fl validator.FieldLevel) bool {
 		version := fl.Field().String()
-		return helpers.StringInArray(version, []string{
-			consts.FA1Tag,
-			"fa12",
-			consts.FA2Tag,
-		})
+		return version == "fa12" || interfaces.Has(version)
 	}
 }
 
@@ -136,6 +138,13 @@ func searchStringValidator() validator.Func {
 	}
 }
 
+func tokenIDValidator() validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		tokenID, ok := big.NewInt(0).SetString(fl.Field().String(), 10)
+		return ok && tokenID.Sign() >= 0
+	}
+}
+
 func greatThanInt64PtrValidator() validator.Func {
 	return func(fl validator.FieldLevel) bool {
 		field := fl.Field()
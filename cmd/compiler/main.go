@@ -17,6 +17,7 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/monitoring"
 	"github.com/baking-bad/bcdhub/internal/mq"
 )
 
@@ -37,6 +38,8 @@ func main() {
 		defer helpers.CatchPanicSentry()
 	}
 
+	monitoring.Serve(cfg.Compiler.Prometheus.Bind)
+
 	context := &Context{
 		config.NewContext(
 			config.WithRPC(cfg.RPC),
@@ -1,6 +1,7 @@
 package indexer
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -8,18 +9,6 @@ import (
 	"github.com/baking-bad/bcdhub/internal/bcd"
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
 	"github.com/baking-bad/bcdhub/internal/config"
-	elasticBigMapAction "github.com/baking-bad/bcdhub/internal/elastic/bigmapaction"
-	elasticBigMapDiff "github.com/baking-bad/bcdhub/internal/elastic/bigmapdiff"
-	elasticBlock "github.com/baking-bad/bcdhub/internal/elastic/block"
-	elasticContract "github.com/baking-bad/bcdhub/internal/elastic/contract"
-	"github.com/baking-bad/bcdhub/internal/elastic/core"
-	elasticMigration "github.com/baking-bad/bcdhub/internal/elastic/migration"
-	elasticOperation "github.com/baking-bad/bcdhub/internal/elastic/operation"
-	elasticProtocol "github.com/baking-bad/bcdhub/internal/elastic/protocol"
-	elasticTezosDomain "github.com/baking-bad/bcdhub/internal/elastic/tezosdomain"
-	elasticTokenBalance "github.com/baking-bad/bcdhub/internal/elastic/tokenbalance"
-	elasticTransfer "github.com/baking-bad/bcdhub/internal/elastic/transfer"
-	elasticTZIP "github.com/baking-bad/bcdhub/internal/elastic/tzip"
 	"github.com/baking-bad/bcdhub/internal/helpers"
 	"github.com/baking-bad/bcdhub/internal/index"
 	"github.com/baking-bad/bcdhub/internal/logger"
@@ -32,13 +21,16 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
 	"github.com/baking-bad/bcdhub/internal/models/tezosdomain"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
 	"github.com/baking-bad/bcdhub/internal/models/tokenbalance"
 	"github.com/baking-bad/bcdhub/internal/models/transfer"
 	"github.com/baking-bad/bcdhub/internal/models/tzip"
+	"github.com/baking-bad/bcdhub/internal/monitoring"
 	"github.com/baking-bad/bcdhub/internal/mq"
 	"github.com/baking-bad/bcdhub/internal/noderpc"
 	"github.com/baking-bad/bcdhub/internal/parsers"
 	"github.com/baking-bad/bcdhub/internal/parsers/operations"
+	ticketParsers "github.com/baking-bad/bcdhub/internal/parsers/ticket"
 	"github.com/baking-bad/bcdhub/internal/rollback"
 	"github.com/pkg/errors"
 )
@@ -46,6 +38,7 @@ import (
 var errBcdQuit = errors.New("bcd-quit")
 var errRollback = errors.New("rollback")
 var errSameLevel = errors.New("Same level")
+var errReorgTooDeep = errors.New("reorg is deeper than block window")
 
 // BoostIndexer -
 type BoostIndexer struct {
@@ -58,6 +51,7 @@ type BoostIndexer struct {
 	Operations    operation.Repository
 	Protocols     protocol.Repository
 	TezosDomains  tezosdomain.Repository
+	Tickets       ticket.Repository
 	TokenBalances tokenbalance.Repository
 	Transfers     transfer.Repository
 	TZIP          tzip.Repository
@@ -66,6 +60,7 @@ type BoostIndexer struct {
 	externalIndexer index.Indexer
 	messageQueue    mq.Mediator
 	state           block.Block
+	window          *blockWindow
 	currentProtocol protocol.Protocol
 	cfg             config.Config
 
@@ -73,6 +68,8 @@ type BoostIndexer struct {
 	stop                chan struct{}
 	Network             string
 	boost               bool
+	reorgWindow         int
+	concurrency         int
 	skipDelegatorBlocks bool
 	stopped             bool
 }
@@ -132,32 +129,29 @@ func (bi *BoostIndexer) fetchExternalProtocols() error {
 // NewBoostIndexer -
 func NewBoostIndexer(cfg config.Config, network string, opts ...BoostIndexerOption) (*BoostIndexer, error) {
 	logger.WithNetwork(network).Info("Creating indexer object...")
-	es := core.WaitNew(cfg.Storage.URI, cfg.Storage.Timeout)
+	ctx := config.NewContext(config.WithStorage(cfg.Storage))
 
-	rpcProvider, ok := cfg.RPC[network]
-	if !ok {
-		return nil, errors.Errorf("Unknown network %s", network)
+	rpc, err := newNodeRPC(cfg, network)
+	if err != nil {
+		return nil, err
 	}
-	rpc := noderpc.NewWaitNodeRPC(
-		rpcProvider.URI,
-		noderpc.WithTimeout(time.Duration(rpcProvider.Timeout)*time.Second),
-	)
 
 	messageQueue := mq.New(cfg.RabbitMQ.URI, cfg.Indexer.ProjectName, cfg.Indexer.MQ.NeedPublisher, 10)
 
 	bi := &BoostIndexer{
-		Storage:       es,
-		BigMapActions: elasticBigMapAction.NewStorage(es),
-		BigMapDiffs:   elasticBigMapDiff.NewStorage(es),
-		Blocks:        elasticBlock.NewStorage(es),
-		Contracts:     elasticContract.NewStorage(es),
-		Migrations:    elasticMigration.NewStorage(es),
-		Operations:    elasticOperation.NewStorage(es),
-		Protocols:     elasticProtocol.NewStorage(es),
-		TezosDomains:  elasticTezosDomain.NewStorage(es),
-		TokenBalances: elasticTokenBalance.NewStorage(es),
-		Transfers:     elasticTransfer.NewStorage(es),
-		TZIP:          elasticTZIP.NewStorage(es),
+		Storage:       ctx.Storage,
+		BigMapActions: ctx.BigMapActions,
+		BigMapDiffs:   ctx.BigMapDiffs,
+		Blocks:        ctx.Blocks,
+		Contracts:     ctx.Contracts,
+		Migrations:    ctx.Migrations,
+		Operations:    ctx.Operations,
+		Protocols:     ctx.Protocols,
+		TezosDomains:  ctx.TezosDomains,
+		Tickets:       ctx.Tickets,
+		TokenBalances: ctx.TokenBalances,
+		Transfers:     ctx.Transfers,
+		TZIP:          ctx.TZIP,
 		Network:       network,
 		rpc:           rpc,
 		messageQueue:  messageQueue,
@@ -188,8 +182,13 @@ func (bi *BoostIndexer) init() error {
 		return err
 	}
 	bi.state = currentState
+	monitoring.SetIndexerLevel(bi.Network, currentState.Level)
 	logger.WithNetwork(bi.Network).Infof("Current indexer state: %d", currentState.Level)
 
+	if err := bi.initWindow(); err != nil {
+		return err
+	}
+
 	currentProtocol, err := bi.Protocols.GetProtocol(bi.Network, "", currentState.Level)
 	if err != nil {
 		if !bi.Storage.IsRecordNotFound(err) {
@@ -232,35 +231,56 @@ func (bi *BoostIndexer) Sync(wg *sync.WaitGroup) {
 		return
 	}
 
-	everySecond := false
-	duration := time.Duration(bi.currentProtocol.Constants.TimeBetweenBlocks) * time.Second
-	if duration.Microseconds() <= 0 {
-		duration = 10 * time.Second
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// new blocks are processed as soon as node announces them, ticker is a fallback if stream is not available or loses heads
+	var listener *headsListener
+	var heads <-chan struct{}
+	if monitor, ok := bi.rpc.(noderpc.IHeadMonitor); ok {
+		listener = newHeadsListener(bi.Network, monitor)
+		heads = listener.heads
+		go listener.listen(ctx)
+	} else {
+		logger.WithNetwork(bi.Network).Warning("Heads stream is not available. Node will be polled")
 	}
-	bi.setUpdateTicker(0)
+
+	tickerSeconds := -1
+	resetTicker := func(seconds int) {
+		if seconds != tickerSeconds {
+			tickerSeconds = seconds
+			bi.setUpdateTicker(seconds)
+		}
+	}
+	resetTicker(0)
+
 	for {
 		select {
 		case <-bi.stop:
 			bi.stopped = true
 			bi.messageQueue.Close()
+			bi.closeCassette()
 			return
+		case <-heads:
 		case <-bi.updateTicker.C:
-			if err := bi.process(); err != nil {
-				if errors.Is(err, errSameLevel) {
-					if !everySecond {
-						everySecond = true
-						bi.setUpdateTicker(5)
-					}
-					continue
-				}
-				logger.Error(err)
-				helpers.CatchErrorSentry(err)
-			}
+		}
 
-			if everySecond {
-				everySecond = false
-				bi.setUpdateTicker(0)
-			}
+		err := bi.process()
+		switch {
+		case listener != nil && listener.isConnected():
+			resetTicker(headsFallbackInterval)
+		case errors.Is(err, errSameLevel):
+			resetTicker(5)
+		default:
+			resetTicker(0)
+		}
+
+		if err != nil && !errors.Is(err, errSameLevel) {
+			logger.Error(err)
+			helpers.CatchErrorSentry(err)
+		}
+		if bi.stopped {
+			return
 		}
 	}
 }
@@ -287,36 +307,56 @@ func (bi *BoostIndexer) Stop() {
 	bi.stop <- struct{}{}
 }
 
-// Index -
+// Index - indexes `levels`. Blocks are fetched from node and parsed concurrently and committed strictly in level order.
 func (bi *BoostIndexer) Index(levels []int64) error {
 	if len(levels) == 0 {
 		return nil
 	}
 	helpers.SetTagSentry("network", bi.Network)
 
-	for _, level := range levels {
-		helpers.SetTagSentry("block", fmt.Sprintf("%d", level))
+	done := make(chan struct{})
+	defer close(done)
 
+	queue := newParseQueue(bi.concurrency)
+	defer queue.wait()
+
+	lastLevel, lastHash := bi.state.Level, bi.state.Hash
+	for data := range prefetch(bi.rpc, levels, bi.concurrency, done) {
 		select {
 		case <-bi.stop:
 			bi.stopped = true
 			bi.messageQueue.Close()
+			bi.closeCassette()
 			return errBcdQuit
 		default:
 		}
 
-		currentHead, err := bi.rpc.GetHeader(level)
-		if err != nil {
-			return err
+		if data.err != nil {
+			if err := bi.commitAll(queue); err != nil {
+				return err
+			}
+			return data.err
 		}
+		currentHead := data.head
 
-		if bi.state.Level > 0 && currentHead.Predecessor != bi.state.Hash && !bi.boost {
+		if lastLevel > 0 && currentHead.Predecessor != lastHash && !bi.boost {
+			if err := bi.commitAll(queue); err != nil {
+				return err
+			}
 			return errRollback
 		}
 
-		logger.WithNetwork(bi.Network).Infof("indexing %d block", level)
+		block := newParsedBlock(data, bi.TokenBalances)
+		newProtocol := currentHead.Protocol != bi.currentProtocol.Hash
+		for !queue.isEmpty() && (newProtocol || queue.isFull() || queue.conflicts(block)) {
+			if err := bi.commit(queue.pop()); err != nil {
+				return err
+			}
+		}
 
-		if currentHead.Protocol != bi.currentProtocol.Hash {
+		logger.WithNetwork(bi.Network).Infof("indexing %d block", data.level)
+
+		if newProtocol {
 			logger.WithNetwork(bi.Network).Infof("New protocol detected: %s -> %s", bi.currentProtocol.Hash, currentHead.Protocol)
 			migrationModels, err := bi.migrate(currentHead)
 			if err != nil {
@@ -327,13 +367,38 @@ func (bi *BoostIndexer) Index(levels []int64) error {
 			}
 		}
 
-		parsedModels, err := bi.getDataFromBlock(bi.Network, currentHead)
-		if err != nil {
-			return err
-		}
-		parsedModels = append(parsedModels, bi.createBlock(currentHead))
+		constants := bi.currentProtocol.Constants
+		queue.push(block, func(block *parsedBlock) {
+			block.models, block.err = bi.parseBlock(bi.Network, block.data.head, block.data.opg, constants, block.tokenBalances)
+		})
+		lastLevel, lastHash = currentHead.Level, currentHead.Hash
+	}
+	return bi.commitAll(queue)
+}
 
-		if err := bi.saveModels(parsedModels); err != nil {
+// commit - saves models of parsed block and moves indexer state to it
+func (bi *BoostIndexer) commit(block *parsedBlock) error {
+	helpers.SetTagSentry("block", fmt.Sprintf("%d", block.data.level))
+	if block.err != nil {
+		return block.err
+	}
+	if err := block.tokenBalances.apply(); err != nil {
+		return err
+	}
+
+	parsedModels := append(block.models, bi.createBlock(block.data.head))
+	if err := bi.saveModels(parsedModels); err != nil {
+		return err
+	}
+
+	monitoring.AddIndexedBlock(bi.Network, countOperations(parsedModels))
+	monitoring.SetIndexerLevel(bi.Network, block.data.head.Level)
+	return nil
+}
+
+func (bi *BoostIndexer) commitAll(queue *parseQueue) error {
+	for !queue.isEmpty() {
+		if err := bi.commit(queue.pop()); err != nil {
 			return err
 		}
 	}
@@ -344,12 +409,42 @@ func (bi *BoostIndexer) Index(levels []int64) error {
 func (bi *BoostIndexer) Rollback() error {
 	logger.WithNetwork(bi.Network).Warningf("Rollback from %d", bi.state.Level)
 
-	lastLevel, err := bi.getLastRollbackBlock()
+	head, err := bi.rpc.GetHead()
 	if err != nil {
 		return err
 	}
 
-	manager := rollback.NewManager(bi.Storage, bi.Contracts, bi.Operations, bi.Transfers, bi.TokenBalances, bi.Protocols, bi.messageQueue, bi.rpc, bi.cfg.SharePath)
+	lastLevel, err := bi.findCommonAncestor(helpers.MinInt64(bi.state.Level+1, head.Level))
+	if err != nil {
+		if !errors.Is(err, errReorgTooDeep) {
+			return err
+		}
+		logger.WithNetwork(bi.Network).Warning(err)
+
+		lastLevel, err = bi.getLastRollbackBlock()
+		if err != nil {
+			return err
+		}
+	}
+
+	if lastLevel >= bi.state.Level {
+		logger.WithNetwork(bi.Network).Info("Indexed blocks are in the main chain. Nothing to rollback")
+		return nil
+	}
+
+	if lastLevel == head.Level {
+		// node chain is a prefix of indexed one: node is lagging (e.g. restarted or behind balancer), so wait for it instead of dropping indexed blocks
+		logger.WithNetwork(bi.Network).Warningf("Node head %d is behind indexer state %d. Waiting for node to sync", head.Level, bi.state.Level)
+		return nil
+	}
+
+	// subscribers are notified before data is deleted, so reorg can't be lost if indexer fails in the middle of rollback
+	reorg := bi.createReorg(lastLevel)
+	if err := bi.messageQueue.Send(reorg); err != nil {
+		return err
+	}
+
+	manager := rollback.NewManager(bi.Storage, bi.Contracts, bi.Operations, bi.Transfers, bi.TokenBalances, bi.Tickets, bi.Protocols, bi.messageQueue, bi.rpc, bi.cfg.SharePath)
 	if err := manager.Rollback(bi.state, lastLevel); err != nil {
 		return err
 	}
@@ -361,11 +456,67 @@ func (bi *BoostIndexer) Rollback() error {
 		return err
 	}
 	bi.state = newState
+	bi.window.removeFrom(lastLevel + 1)
+	monitoring.SetIndexerLevel(bi.Network, newState.Level)
+
 	logger.WithNetwork(bi.Network).Infof("New indexer state: %d", bi.state.Level)
 	logger.WithNetwork(bi.Network).Info("Rollback finished")
 	return nil
 }
 
+func (bi *BoostIndexer) initWindow() error {
+	bi.window = newBlockWindow(bi.reorgWindow)
+	for level := helpers.MaxInt64(1, bi.state.Level-int64(bi.window.size)+1); level <= bi.state.Level; level++ {
+		b, err := bi.Blocks.Get(bi.Network, level)
+		if err != nil {
+			if bi.Storage.IsRecordNotFound(err) {
+				continue
+			}
+			return err
+		}
+		bi.window.add(b)
+	}
+	return nil
+}
+
+// findCommonAncestor - walks down node chain from `level` and returns the highest level where node and indexed chains have the same block
+func (bi *BoostIndexer) findCommonAncestor(level int64) (int64, error) {
+	lowest := bi.window.lowest()
+	if lowest < 0 {
+		return 0, errReorgTooDeep
+	}
+
+	for ; level > lowest; level-- {
+		header, err := bi.rpc.GetHeader(level)
+		if err != nil {
+			return 0, err
+		}
+
+		if indexed, ok := bi.window.get(level); ok && indexed.Hash == header.Hash {
+			return level, nil
+		}
+		if indexed, ok := bi.window.get(level - 1); ok && indexed.Hash == header.Predecessor {
+			logger.WithNetwork(bi.Network).Warnf("Found common ancestor at level: %d", indexed.Level)
+			return indexed.Level, nil
+		}
+	}
+	return 0, errors.Wrapf(errReorgTooDeep, "window starts at %d", lowest)
+}
+
+func (bi *BoostIndexer) createReorg(ancestorLevel int64) *block.Reorg {
+	reorg := &block.Reorg{
+		Network:        bi.Network,
+		FromLevel:      ancestorLevel + 1,
+		ToLevel:        bi.state.Level,
+		OrphanedHashes: bi.window.hashesFrom(ancestorLevel + 1),
+		Timestamp:      time.Now().UTC(),
+	}
+	if ancestor, ok := bi.window.get(ancestorLevel); ok {
+		reorg.AncestorHash = ancestor.Hash
+	}
+	return reorg
+}
+
 func (bi *BoostIndexer) getLastRollbackBlock() (int64, error) {
 	var lastLevel int64
 	level := bi.state.Level
@@ -422,6 +573,7 @@ func (bi *BoostIndexer) process() error {
 		return errors.Errorf("Invalid chain_id: %s (state) != %s (head)", bi.state.ChainID, head.ChainID)
 	}
 
+	monitoring.SetNodeHead(bi.Network, head.Level)
 	logger.WithNetwork(bi.Network).Infof("Current node state: %d", head.Level)
 	logger.WithNetwork(bi.Network).Infof("Current indexer state: %d", bi.state.Level)
 
@@ -482,6 +634,7 @@ func (bi *BoostIndexer) createBlock(head noderpc.Header) *block.Block {
 	}
 
 	bi.state = newBlock
+	bi.window.add(newBlock)
 	return &newBlock
 }
 
@@ -499,25 +652,33 @@ func (bi *BoostIndexer) saveModels(items []models.Model) error {
 	return nil
 }
 
-func (bi *BoostIndexer) getDataFromBlock(network string, head noderpc.Header) ([]models.Model, error) {
+func countOperations(items []models.Model) int {
+	var count int
+	for i := range items {
+		if _, ok := items[i].(*operation.Operation); ok {
+			count++
+		}
+	}
+	return count
+}
+
+func (bi *BoostIndexer) parseBlock(network string, head noderpc.Header, opg []noderpc.OperationGroup, constants protocol.Constants, tokenBalances tokenbalance.Repository) ([]models.Model, error) {
 	if head.Level <= 1 {
 		return nil, nil
 	}
-	opg, err := bi.rpc.GetOPG(head.Level)
-	if err != nil {
-		return nil, err
-	}
 
+	tickets := ticketParsers.NewParser(bi.Tickets)
 	parsedModels := make([]models.Model, 0)
 	for i := range opg {
 		parser := operations.NewGroup(operations.NewParseParams(
 			bi.rpc,
-			bi.Storage, bi.BigMapDiffs, bi.Blocks, bi.TZIP, bi.TokenBalances,
-			operations.WithConstants(bi.currentProtocol.Constants),
+			bi.Storage, bi.BigMapDiffs, bi.Blocks, bi.TZIP, tokenBalances,
+			operations.WithConstants(constants),
 			operations.WithHead(head),
 			operations.WithIPFSGateways(bi.cfg.IPFSGateways),
 			operations.WithShareDirectory(bi.cfg.SharePath),
 			operations.WithNetwork(network),
+			operations.WithTickets(tickets),
 		))
 		parsed, err := parser.Parse(opg[i])
 		if err != nil {
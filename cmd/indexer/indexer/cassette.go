@@ -0,0 +1,63 @@
+package indexer
+
+import (
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/pkg/errors"
+)
+
+// Cassette modes
+const (
+	cassetteRecord = "record"
+	cassetteReplay = "replay"
+)
+
+// newNodeRPC - creates node RPC of network. If cassette is set, requests to node are recorded to it or served from it without node at all.
+func newNodeRPC(cfg config.Config, network string) (noderpc.INode, error) {
+	cassette := cfg.Indexer.Networks[network].Cassette
+	if cassette.Mode == cassetteReplay {
+		logger.WithNetwork(network).Infof("Node responses are replayed from %s", cassette.Path)
+		replayer, err := noderpc.NewReplayer(cassette.Path)
+		if err != nil {
+			return nil, err
+		}
+		return replayer, nil
+	}
+
+	rpcProvider, ok := cfg.RPC[network]
+	if !ok {
+		return nil, errors.Errorf("Unknown network %s", network)
+	}
+	rpc := noderpc.NewWaitNodeRPC(
+		rpcProvider.URI,
+		noderpc.WithTimeout(time.Duration(rpcProvider.Timeout)*time.Second),
+	)
+
+	switch cassette.Mode {
+	case "":
+		return rpc, nil
+	case cassetteRecord:
+		logger.WithNetwork(network).Infof("Node responses are recorded to %s", cassette.Path)
+		recorder, err := noderpc.NewRecorder(rpc, cassette.Path)
+		if err != nil {
+			return nil, err
+		}
+		return recorder, nil
+	default:
+		return nil, errors.Errorf("Unsupported cassette mode: %s", cassette.Mode)
+	}
+}
+
+// closeCassette - closes cassette file if node responses are recorded
+func (bi *BoostIndexer) closeCassette() {
+	recorder, ok := bi.rpc.(*noderpc.Recorder)
+	if !ok {
+		return
+	}
+	if err := recorder.Close(); err != nil {
+		logger.WithNetwork(bi.Network).Error(err)
+	}
+}
@@ -0,0 +1,61 @@
+package indexer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCassette(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	rpc := noderpc.NewMockINode(ctrl)
+	mockBlocks(rpc, -1)
+
+	path := filepath.Join(t.TempDir(), "mainnet.jsonl")
+	recorder, err := noderpc.NewRecorder(rpc, path)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	levels := []int64{1, 2, 3, 10, 11}
+	recorded := make([]blockData, 0)
+	for data := range prefetch(recorder, levels, 3, make(chan struct{})) {
+		recorded = append(recorded, data)
+	}
+	if !assert.NoError(t, recorder.Close()) {
+		return
+	}
+
+	var cfg config.Config
+	cfg.Indexer.Networks = map[string]struct {
+		Boost       string                `yaml:"boost"`
+		Concurrency int                   `yaml:"concurrency"`
+		Cassette    config.CassetteConfig `yaml:"cassette"`
+	}{
+		"mainnet": {
+			Cassette: config.CassetteConfig{
+				Mode: cassetteReplay,
+				Path: path,
+			},
+		},
+	}
+	replayer, err := newNodeRPC(cfg, "mainnet")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	replayed := make([]blockData, 0)
+	for data := range prefetch(replayer, levels, 2, make(chan struct{})) {
+		replayed = append(replayed, data)
+	}
+	assert.Equal(t, recorded, replayed)
+
+	_, err = replayer.GetHeader(4)
+	assert.True(t, errors.Is(err, noderpc.ErrNotRecorded))
+}
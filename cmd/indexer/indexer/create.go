@@ -1,8 +1,13 @@
 package indexer
 
 import (
+	"path/filepath"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/ast/interfaces"
 	"github.com/baking-bad/bcdhub/internal/bcd/tezerrors"
 	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/logger"
 )
 
 // CreateIndexers -
@@ -11,6 +16,14 @@ func CreateIndexers(cfg config.Config) ([]Indexer, error) {
 		return nil, err
 	}
 
+	count, err := ast.LoadContractInterfaces(filepath.Join(cfg.SharePath, interfaces.Directory))
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		logger.Info("%d contract interfaces are loaded", count)
+	}
+
 	indexers := make([]Indexer, 0)
 	for network, options := range cfg.Indexer.Networks {
 		boostOptions := make([]BoostIndexerOption, 0)
@@ -20,6 +33,12 @@ func CreateIndexers(cfg config.Config) ([]Indexer, error) {
 		if cfg.Indexer.SkipDelegatorBlocks {
 			boostOptions = append(boostOptions, WithSkipDelegatorBlocks())
 		}
+		if options.Concurrency > 0 {
+			boostOptions = append(boostOptions, WithConcurrency(options.Concurrency))
+		}
+		if cfg.Indexer.ReorgWindow > 0 {
+			boostOptions = append(boostOptions, WithReorgWindow(cfg.Indexer.ReorgWindow))
+		}
 		bi, err := NewBoostIndexer(cfg, network, boostOptions...)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,89 @@
+package indexer
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+)
+
+const (
+	minHeadsRetryDelay = time.Second
+	maxHeadsRetryDelay = time.Minute
+
+	// headsFallbackInterval - update interval in seconds while heads stream is connected. Ticker only guards against lost heads.
+	headsFallbackInterval = 60
+)
+
+// headsListener - subscribes to heads stream of node and signals to `heads` about every new head. Signals are coalesced if receiver is busy.
+// Stream is reopened with exponential backoff after errors.
+type headsListener struct {
+	network string
+	monitor noderpc.IHeadMonitor
+	heads   chan struct{}
+
+	minDelay time.Duration
+	maxDelay time.Duration
+
+	connected int32
+}
+
+func newHeadsListener(network string, monitor noderpc.IHeadMonitor) *headsListener {
+	return &headsListener{
+		network:  network,
+		monitor:  monitor,
+		heads:    make(chan struct{}, 1),
+		minDelay: minHeadsRetryDelay,
+		maxDelay: maxHeadsRetryDelay,
+	}
+}
+
+// isConnected - returns true if stream has delivered at least one head since the last reconnection
+func (l *headsListener) isConnected() bool {
+	return atomic.LoadInt32(&l.connected) == 1
+}
+
+func (l *headsListener) listen(ctx context.Context) {
+	handler := func(head noderpc.MonitoredHead) {
+		atomic.StoreInt32(&l.connected, 1)
+		logger.WithNetwork(l.network).Debugf("New head announced: %d", head.Level)
+		l.notify()
+	}
+
+	delay := l.minDelay
+	for {
+		err := l.monitor.MonitorHeads(ctx, handler)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// backoff is reset if stream delivered heads before it was closed. Receiver is notified to switch to polling until stream is reconnected.
+		if atomic.SwapInt32(&l.connected, 0) == 1 {
+			delay = l.minDelay
+			l.notify()
+		}
+		if err != nil {
+			logger.WithNetwork(l.network).Warnf("Heads stream: %s. Reconnect in %s", err, delay)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > l.maxDelay {
+			delay = l.maxDelay
+		}
+	}
+}
+
+func (l *headsListener) notify() {
+	select {
+	case l.heads <- struct{}{}:
+	default:
+	}
+}
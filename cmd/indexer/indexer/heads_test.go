@@ -0,0 +1,117 @@
+package indexer
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// testHeadMonitor - fails `failures` times, then streams `levels` and keeps stream open until context is cancelled
+type testHeadMonitor struct {
+	failures int32
+	calls    int32
+	levels   []int64
+}
+
+func (m *testHeadMonitor) MonitorHeads(ctx context.Context, handler noderpc.HeadHandler) error {
+	if atomic.AddInt32(&m.calls, 1) <= m.failures {
+		return errors.New("connection refused")
+	}
+	for _, level := range m.levels {
+		handler(noderpc.MonitoredHead{Level: level})
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func TestHeadsListener(t *testing.T) {
+	monitor := &testHeadMonitor{
+		failures: 2,
+		levels:   []int64{10, 11, 12},
+	}
+	listener := newHeadsListener("test", monitor)
+	listener.minDelay = time.Millisecond
+	listener.maxDelay = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		listener.listen(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-listener.heads:
+	case <-time.After(time.Second):
+		t.Fatal("head was not announced")
+	}
+	assert.True(t, listener.isConnected())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&monitor.calls))
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("listener was not stopped")
+	}
+}
+
+// closingHeadMonitor - streams one head and closes stream
+type closingHeadMonitor struct{}
+
+func (closingHeadMonitor) MonitorHeads(ctx context.Context, handler noderpc.HeadHandler) error {
+	handler(noderpc.MonitoredHead{Level: 10})
+	return nil
+}
+
+func TestHeadsListener_Disconnect(t *testing.T) {
+	listener := newHeadsListener("test", closingHeadMonitor{})
+	listener.minDelay = time.Hour
+	listener.maxDelay = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go listener.listen(ctx)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-listener.heads:
+		case <-time.After(time.Second):
+			t.Fatal("receiver was not notified")
+		}
+	}
+	assert.False(t, listener.isConnected(), "receiver has to switch to polling until stream is reconnected")
+}
+
+func TestRecorder_MonitorHeads(t *testing.T) {
+	recorder, err := noderpc.NewRecorder(struct {
+		noderpc.INode
+		*testHeadMonitor
+	}{testHeadMonitor: &testHeadMonitor{levels: []int64{10}}}, filepath.Join(t.TempDir(), "mainnet.jsonl"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer recorder.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var levels []int64
+	err = recorder.MonitorHeads(ctx, func(head noderpc.MonitoredHead) {
+		levels = append(levels, head.Level)
+		cancel()
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{10}, levels)
+
+	recorder, err = noderpc.NewRecorder(nil, filepath.Join(t.TempDir(), "mainnet.jsonl"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer recorder.Close()
+	assert.True(t, errors.Is(recorder.MonitorHeads(context.Background(), nil), noderpc.ErrHeadsNotSupported))
+}
@@ -29,9 +29,23 @@ func WithBoost(externalType, network string, cfg config.Config) BoostIndexerOpti
 	}
 }
 
+// WithReorgWindow -
+func WithReorgWindow(size int) BoostIndexerOption {
+	return func(bi *BoostIndexer) {
+		bi.reorgWindow = size
+	}
+}
+
 // WithSkipDelegatorBlocks -
 func WithSkipDelegatorBlocks() BoostIndexerOption {
 	return func(bi *BoostIndexer) {
 		bi.skipDelegatorBlocks = true
 	}
 }
+
+// WithConcurrency - sets count of blocks which are fetched from node concurrently
+func WithConcurrency(count int) BoostIndexerOption {
+	return func(bi *BoostIndexer) {
+		bi.concurrency = count
+	}
+}
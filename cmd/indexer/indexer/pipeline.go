@@ -0,0 +1,251 @@
+package indexer
+
+import (
+	"sync"
+
+	"github.com/baking-bad/bcdhub/internal/bcd"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/tokenbalance"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+)
+
+const defaultConcurrency = 1
+
+// blockData - header and operation groups of level received from node
+type blockData struct {
+	level int64
+	head  noderpc.Header
+	opg   []noderpc.OperationGroup
+	err   error
+}
+
+// prefetch - fetches blocks of `levels` by `concurrency` workers and sends them to returned channel in order of `levels`.
+// At most `concurrency` blocks are fetched ahead of the consumer. Fetching stops on the first error (it's sent as the last item) or when `done` is closed.
+// Operation groups are not parsed here: it's done by `parseQueue`.
+func prefetch(rpc noderpc.INode, levels []int64, concurrency int, done <-chan struct{}) <-chan blockData {
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	// slot of level with index `i` is `slots[i % concurrency]`. It's free because level `i - concurrency` is already consumed when level `i` is scheduled.
+	slots := make([]chan blockData, concurrency)
+	for i := range slots {
+		slots[i] = make(chan blockData, 1)
+	}
+	tokens := make(chan struct{}, concurrency)
+	jobs := make(chan int)
+	quit := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				slots[idx%concurrency] <- fetchBlock(rpc, levels[idx])
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for idx := range levels {
+			select {
+			case <-quit:
+				return
+			case tokens <- struct{}{}:
+			}
+			select {
+			case <-quit:
+				return
+			case jobs <- idx:
+			}
+		}
+	}()
+
+	result := make(chan blockData)
+	go func() {
+		defer close(result)
+		defer wg.Wait()
+		defer close(quit)
+
+		for idx := range levels {
+			var data blockData
+			select {
+			case <-done:
+				return
+			case data = <-slots[idx%concurrency]:
+			}
+
+			select {
+			case <-done:
+				return
+			case result <- data:
+			}
+			if data.err != nil {
+				return
+			}
+			<-tokens
+		}
+	}()
+	return result
+}
+
+func fetchBlock(rpc noderpc.INode, level int64) blockData {
+	data := blockData{level: level}
+	data.head, data.err = rpc.GetHeader(level)
+	if data.err != nil || level <= 1 {
+		return data
+	}
+	data.opg, data.err = rpc.GetOPG(level)
+	return data
+}
+
+// parsedBlock - block which is parsed in background and waits for commit
+type parsedBlock struct {
+	data          blockData
+	contracts     map[string]struct{}
+	models        []models.Model
+	tokenBalances *deferredTokenBalances
+	err           error
+	done          chan struct{}
+}
+
+func newParsedBlock(data blockData, tokenBalances tokenbalance.Repository) *parsedBlock {
+	return &parsedBlock{
+		data:          data,
+		contracts:     blockContracts(data.opg),
+		tokenBalances: &deferredTokenBalances{Repository: tokenBalances},
+		done:          make(chan struct{}),
+	}
+}
+
+// blockContracts - returns contracts which are called, originated or used as source by operations of block (including internal ones).
+// Parsing of block reads state of these contracts only.
+func blockContracts(opg []noderpc.OperationGroup) map[string]struct{} {
+	contracts := make(map[string]struct{})
+	add := func(address string) {
+		if bcd.IsContract(address) {
+			contracts[address] = struct{}{}
+		}
+	}
+
+	var walk func(ops []noderpc.Operation)
+	walk = func(ops []noderpc.Operation) {
+		for i := range ops {
+			add(ops[i].Source)
+			if ops[i].Destination != nil {
+				add(*ops[i].Destination)
+			}
+			if result := ops[i].GetResult(); result != nil {
+				for j := range result.Originated {
+					add(result.Originated[j])
+				}
+			}
+			if ops[i].Metadata != nil {
+				walk(ops[i].Metadata.Internal)
+				walk(ops[i].Metadata.InternalOperations)
+			}
+		}
+	}
+
+	for i := range opg {
+		walk(opg[i].Contents)
+	}
+	return contracts
+}
+
+// parseQueue - blocks which are parsed concurrently and committed in level order.
+// Parsers read models (storage, big map diffs, scripts) committed by previous levels, so block can be parsed ahead of commit
+// only if it doesn't touch contracts of blocks which are parsed but not committed yet.
+type parseQueue struct {
+	blocks   []*parsedBlock
+	touched  map[string]int
+	capacity int
+}
+
+func newParseQueue(capacity int) *parseQueue {
+	if capacity <= 0 {
+		capacity = defaultConcurrency
+	}
+	return &parseQueue{
+		blocks:   make([]*parsedBlock, 0, capacity),
+		touched:  make(map[string]int),
+		capacity: capacity,
+	}
+}
+
+func (q *parseQueue) isEmpty() bool {
+	return len(q.blocks) == 0
+}
+
+func (q *parseQueue) isFull() bool {
+	return len(q.blocks) >= q.capacity
+}
+
+// conflicts - returns true if block touches contracts of any queued block
+func (q *parseQueue) conflicts(block *parsedBlock) bool {
+	for address := range block.contracts {
+		if q.touched[address] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// push - adds block to the end of queue and runs `parse` in background
+func (q *parseQueue) push(block *parsedBlock, parse func(block *parsedBlock)) {
+	for address := range block.contracts {
+		q.touched[address]++
+	}
+	q.blocks = append(q.blocks, block)
+
+	go func() {
+		defer close(block.done)
+		parse(block)
+	}()
+}
+
+// pop - waits until the first block is parsed and removes it from queue
+func (q *parseQueue) pop() *parsedBlock {
+	block := q.blocks[0]
+	<-block.done
+
+	q.blocks[0] = nil
+	q.blocks = q.blocks[1:]
+	for address := range block.contracts {
+		if q.touched[address]--; q.touched[address] == 0 {
+			delete(q.touched, address)
+		}
+	}
+	return block
+}
+
+// wait - waits until all queued blocks are parsed
+func (q *parseQueue) wait() {
+	for i := range q.blocks {
+		<-q.blocks[i].done
+	}
+}
+
+// deferredTokenBalances - collects token balance updates of block in parsing. They are applied on commit of block. Other calls are passed to underlying repository.
+type deferredTokenBalances struct {
+	tokenbalance.Repository
+
+	updates [][]*tokenbalance.TokenBalance
+}
+
+// Update -
+func (d *deferredTokenBalances) Update(updates []*tokenbalance.TokenBalance) error {
+	d.updates = append(d.updates, updates)
+	return nil
+}
+
+func (d *deferredTokenBalances) apply() error {
+	for i := range d.updates {
+		if err := d.Repository.Update(d.updates[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
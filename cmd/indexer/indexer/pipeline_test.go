@@ -0,0 +1,192 @@
+package indexer
+
+import (
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockBlocks(rpc *noderpc.MockINode, failedLevel int64) {
+	rpc.EXPECT().GetHeader(gomock.Any()).DoAndReturn(func(level int64) (noderpc.Header, error) {
+		time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+		if level == failedLevel {
+			return noderpc.Header{}, errors.New("node error")
+		}
+		return noderpc.Header{Level: level}, nil
+	}).AnyTimes()
+	rpc.EXPECT().GetOPG(gomock.Any()).DoAndReturn(func(level int64) ([]noderpc.OperationGroup, error) {
+		time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+		return []noderpc.OperationGroup{{Hash: fmt.Sprintf("opg%d", level)}}, nil
+	}).AnyTimes()
+}
+
+func TestPrefetch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	rpc := noderpc.NewMockINode(ctrl)
+	mockBlocks(rpc, -1)
+
+	levels := []int64{1, 2, 5, 6, 7, 10, 11, 12, 20, 21, 22, 23, 30}
+	for _, concurrency := range []int{0, 1, 3, 16} {
+		done := make(chan struct{})
+		received := make([]int64, 0)
+		for data := range prefetch(rpc, levels, concurrency, done) {
+			if !assert.NoError(t, data.err) {
+				break
+			}
+			assert.Equal(t, data.level, data.head.Level)
+			if data.level > 1 && assert.Len(t, data.opg, 1) {
+				assert.Equal(t, fmt.Sprintf("opg%d", data.level), data.opg[0].Hash)
+			}
+			received = append(received, data.level)
+		}
+		close(done)
+		assert.Equal(t, levels, received, "concurrency %d", concurrency)
+	}
+}
+
+func TestPrefetch_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	rpc := noderpc.NewMockINode(ctrl)
+	mockBlocks(rpc, 4)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	received := make([]int64, 0)
+	var err error
+	for data := range prefetch(rpc, []int64{1, 2, 3, 4, 5, 6, 7, 8}, 3, done) {
+		if data.err != nil {
+			err = data.err
+			continue
+		}
+		received = append(received, data.level)
+	}
+	assert.Error(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, received)
+}
+
+func TestPrefetch_Done(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	rpc := noderpc.NewMockINode(ctrl)
+	mockBlocks(rpc, -1)
+
+	levels := make([]int64, 100)
+	for i := range levels {
+		levels[i] = int64(i + 1)
+	}
+
+	done := make(chan struct{})
+	result := prefetch(rpc, levels, 4, done)
+	data := <-result
+	assert.Equal(t, int64(1), data.level)
+	close(done)
+
+	count := 0
+	for range result {
+		count++
+	}
+	assert.LessOrEqual(t, count, 1)
+}
+
+func TestBlockContracts(t *testing.T) {
+	destination := "KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9"
+	internalDestination := "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"
+	user := "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"
+
+	opg := []noderpc.OperationGroup{
+		{
+			Contents: []noderpc.Operation{
+				{
+					Kind:        "transaction",
+					Source:      user,
+					Destination: &destination,
+					Metadata: &noderpc.OperationMetadata{
+						OperationResult: &noderpc.OperationResult{Status: "applied"},
+						Internal: []noderpc.Operation{
+							{
+								Kind:        "transaction",
+								Source:      destination,
+								Destination: &internalDestination,
+							},
+							{
+								Kind:   "origination",
+								Source: destination,
+								Result: &noderpc.OperationResult{
+									Originated: []string{"KT1QcxwB4QyPKfmSwjH1VRxa6kquUjeDWeEy"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Contents: []noderpc.Operation{
+				{
+					Kind:        "transaction",
+					Source:      user,
+					Destination: &user,
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, map[string]struct{}{
+		destination:                            {},
+		internalDestination:                    {},
+		"KT1QcxwB4QyPKfmSwjH1VRxa6kquUjeDWeEy": {},
+	}, blockContracts(opg))
+}
+
+func testParsedBlock(level int64, contracts ...string) *parsedBlock {
+	block := newParsedBlock(blockData{level: level}, nil)
+	for i := range contracts {
+		block.contracts[contracts[i]] = struct{}{}
+	}
+	return block
+}
+
+func TestParseQueue(t *testing.T) {
+	queue := newParseQueue(3)
+	assert.True(t, queue.isEmpty())
+
+	var running, maxRunning int32
+	parse := func(block *parsedBlock) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+		time.Sleep(time.Duration(10-block.data.level) * time.Millisecond)
+		block.err = fmt.Errorf("%d", block.data.level)
+		atomic.AddInt32(&running, -1)
+	}
+
+	queue.push(testParsedBlock(1, "KT1"), parse)
+	queue.push(testParsedBlock(2, "KT2"), parse)
+	assert.True(t, queue.conflicts(testParsedBlock(3, "KT3", "KT1")))
+	assert.False(t, queue.conflicts(testParsedBlock(3, "KT3")))
+	queue.push(testParsedBlock(3, "KT3", "KT2"), parse)
+	assert.True(t, queue.isFull())
+
+	committed := make([]string, 0)
+	for !queue.isEmpty() {
+		committed = append(committed, queue.pop().err.Error())
+	}
+	assert.Equal(t, []string{"1", "2", "3"}, committed)
+	assert.Empty(t, queue.touched)
+	assert.False(t, queue.conflicts(testParsedBlock(4, "KT1", "KT2")))
+	assert.True(t, maxRunning > 1, "blocks have to be parsed concurrently")
+}
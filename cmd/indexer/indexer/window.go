@@ -0,0 +1,72 @@
+package indexer
+
+import (
+	"sort"
+
+	"github.com/baking-bad/bcdhub/internal/models/block"
+)
+
+const defaultReorgWindow = 60
+
+// blockWindow - keeps last indexed blocks ordered by level. It's used to find common ancestor of indexed and node chains.
+type blockWindow struct {
+	size   int
+	blocks []block.Block
+}
+
+func newBlockWindow(size int) *blockWindow {
+	if size <= 0 {
+		size = defaultReorgWindow
+	}
+	return &blockWindow{
+		size:   size,
+		blocks: make([]block.Block, 0, size),
+	}
+}
+
+// add - appends block to window. Blocks with greater or equal level are replaced.
+func (w *blockWindow) add(b block.Block) {
+	w.removeFrom(b.Level)
+	w.blocks = append(w.blocks, b)
+	if len(w.blocks) > w.size {
+		w.blocks = w.blocks[len(w.blocks)-w.size:]
+	}
+}
+
+// get - returns block by level if it's in window
+func (w *blockWindow) get(level int64) (block.Block, bool) {
+	idx := w.search(level)
+	if idx < len(w.blocks) && w.blocks[idx].Level == level {
+		return w.blocks[idx], true
+	}
+	return block.Block{}, false
+}
+
+// removeFrom - removes blocks with level greater or equal to `level`
+func (w *blockWindow) removeFrom(level int64) {
+	w.blocks = w.blocks[:w.search(level)]
+}
+
+// hashesFrom - returns hashes of blocks with level greater or equal to `level`
+func (w *blockWindow) hashesFrom(level int64) []string {
+	tail := w.blocks[w.search(level):]
+	hashes := make([]string, len(tail))
+	for i := range tail {
+		hashes[i] = tail[i].Hash
+	}
+	return hashes
+}
+
+// lowest - returns the lowest level in window. If window is empty it returns -1.
+func (w *blockWindow) lowest() int64 {
+	if len(w.blocks) == 0 {
+		return -1
+	}
+	return w.blocks[0].Level
+}
+
+func (w *blockWindow) search(level int64) int {
+	return sort.Search(len(w.blocks), func(i int) bool {
+		return w.blocks[i].Level >= level
+	})
+}
@@ -0,0 +1,134 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWindow(size int, hashes ...string) *blockWindow {
+	w := newBlockWindow(size)
+	for i := range hashes {
+		w.add(block.Block{
+			Level: int64(i + 1),
+			Hash:  hashes[i],
+		})
+	}
+	return w
+}
+
+func TestBlockWindow(t *testing.T) {
+	w := newTestWindow(3, "a", "b", "c", "d")
+	assert.Equal(t, int64(2), w.lowest())
+
+	_, ok := w.get(1)
+	assert.False(t, ok)
+
+	b, ok := w.get(3)
+	assert.True(t, ok)
+	assert.Equal(t, "c", b.Hash)
+	assert.Equal(t, []string{"c", "d"}, w.hashesFrom(3))
+
+	w.add(block.Block{Level: 3, Hash: "x"})
+	assert.Equal(t, []string{"b", "x"}, w.hashesFrom(0))
+
+	w.removeFrom(2)
+	assert.Equal(t, int64(-1), w.lowest())
+}
+
+func TestBoostIndexer_findCommonAncestor(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  []string
+		headers map[int64]noderpc.Header
+		start   int64
+		want    int64
+		wantErr error
+	}{
+		{
+			name:   "one orphaned block",
+			window: []string{"a", "b", "c"},
+			headers: map[int64]noderpc.Header{
+				4: {Level: 4, Hash: "d'", Predecessor: "c'"},
+				3: {Level: 3, Hash: "c'", Predecessor: "b"},
+			},
+			start: 4,
+			want:  2,
+		}, {
+			name:   "node is behind indexer",
+			window: []string{"a", "b", "c"},
+			headers: map[int64]noderpc.Header{
+				2: {Level: 2, Hash: "b", Predecessor: "a"},
+			},
+			start: 2,
+			want:  2,
+		}, {
+			name:   "deep fork",
+			window: []string{"a", "b", "c", "d"},
+			headers: map[int64]noderpc.Header{
+				5: {Level: 5, Hash: "e'", Predecessor: "d'"},
+				4: {Level: 4, Hash: "d'", Predecessor: "c'"},
+				3: {Level: 3, Hash: "c'", Predecessor: "b'"},
+				2: {Level: 2, Hash: "b'", Predecessor: "a"},
+			},
+			start: 5,
+			want:  1,
+		}, {
+			name:   "fork is deeper than window",
+			window: []string{"a", "b"},
+			headers: map[int64]noderpc.Header{
+				3: {Level: 3, Hash: "c'", Predecessor: "b'"},
+				2: {Level: 2, Hash: "b'", Predecessor: "a'"},
+			},
+			start:   3,
+			wantErr: errReorgTooDeep,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rpc := noderpc.NewMockINode(ctrl)
+			for level, header := range tt.headers {
+				rpc.EXPECT().GetHeader(level).Return(header, nil).MaxTimes(1)
+			}
+
+			bi := &BoostIndexer{
+				rpc:    rpc,
+				window: newTestWindow(10, tt.window...),
+			}
+			got, err := bi.findCommonAncestor(tt.start)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "findCommonAncestor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBoostIndexer_Rollback_NodeIsBehind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rpc := noderpc.NewMockINode(ctrl)
+	rpc.EXPECT().GetHead().Return(noderpc.Header{Level: 2, Hash: "b", Predecessor: "a"}, nil).Times(1)
+	rpc.EXPECT().GetHeader(int64(2)).Return(noderpc.Header{Level: 2, Hash: "b", Predecessor: "a"}, nil).Times(1)
+
+	bi := &BoostIndexer{
+		Network: "mainnet",
+		rpc:     rpc,
+		state:   block.Block{Level: 3, Hash: "c"},
+		window:  newTestWindow(10, "a", "b", "c"),
+	}
+	assert.NoError(t, bi.Rollback())
+	assert.Equal(t, int64(3), bi.state.Level)
+	assert.Equal(t, []string{"c"}, bi.window.hashesFrom(3))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/baking-bad/bcdhub/internal/config"
 	"github.com/baking-bad/bcdhub/internal/helpers"
 	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/monitoring"
 )
 
 func main() {
@@ -25,6 +26,8 @@ func main() {
 		defer helpers.CatchPanicSentry()
 	}
 
+	monitoring.Serve(cfg.Indexer.Prometheus.Bind)
+
 	indexers, err := indexer.CreateIndexers(cfg)
 	if err != nil {
 		logger.Error(err)
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/baking-bad/bcdhub/internal/audit"
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/pkg/errors"
+)
+
+func auditContracts(ids []string) error {
+	contracts := make([]contract.Contract, 0)
+	if err := ctx.Storage.GetByIDs(&contracts, ids...); err != nil {
+		return errors.Errorf("[auditContracts] Find contracts error for IDs %v: %s", ids, err)
+	}
+
+	byNetwork := make(map[string][]string)
+	for i := range contracts {
+		byNetwork[contracts[i].Network] = append(byNetwork[contracts[i].Network], contracts[i].Address)
+	}
+
+	for network, addresses := range byNetwork {
+		rpc, err := ctx.GetRPC(network)
+		if err != nil {
+			return err
+		}
+
+		auditor := audit.NewAuditor(
+			rpc, ctx.Storage, ctx.Blocks, ctx.Contracts, ctx.Operations, ctx.BigMapDiffs, ctx.Migrations, ctx.TokenBalances, ctx.SharePath,
+			audit.WithMaxKeys(ctx.Config.Metrics.AuditMaxKeys),
+			audit.WithRepair(ctx.Config.Metrics.AuditRepair),
+		)
+		report, err := auditor.AuditContracts(network, addresses)
+		if err != nil {
+			return errors.Errorf("[auditContracts] Audit error for %s: %s", network, err)
+		}
+
+		if report.HasMismatches() || len(report.Failures) > 0 {
+			logger.InterfaceToJSON(report)
+		}
+		logger.Info("%d contracts of %s are audited: %d mismatches", report.Audited, network, len(report.Mismatches))
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/monitoring"
 	"github.com/baking-bad/bcdhub/internal/mq"
 )
 
@@ -13,6 +14,7 @@ type BulkHandler func(ids []string) error
 
 // BulkManager -
 type BulkManager struct {
+	name    string
 	queue   []mq.Data
 	handler BulkHandler
 
@@ -24,8 +26,9 @@ type BulkManager struct {
 }
 
 // NewBulkManager -
-func NewBulkManager(capacity, timeout int, handler BulkHandler) *BulkManager {
+func NewBulkManager(name string, capacity, timeout int, handler BulkHandler) *BulkManager {
 	return &BulkManager{
+		name:    name,
 		queue:   make([]mq.Data, 0, capacity),
 		stop:    make(chan struct{}),
 		ticker:  newTicker(timeout),
@@ -40,6 +43,7 @@ func (bm *BulkManager) Add(data mq.Data) {
 	{
 		if bm.process(false) {
 			bm.queue = append(bm.queue, data)
+			monitoring.SetQueueDepth(bm.name, len(bm.queue))
 		}
 	}
 }
@@ -70,11 +74,14 @@ func (bm *BulkManager) process(force bool) bool {
 	}
 	bm.ticker.stop()
 
+	start := time.Now()
 	ids := make([]string, len(bm.queue))
 	for i := range bm.queue {
 		ids[i] = parseID(bm.queue[i].GetBody())
 	}
-	if err := bm.handler(ids); err != nil {
+	err := bm.handler(ids)
+	monitoring.ObserveFlush(bm.name, time.Since(start))
+	if err != nil {
 		logger.Error(err)
 		return false
 	}
@@ -85,6 +92,7 @@ func (bm *BulkManager) process(force bool) bool {
 		}
 	}
 	bm.queue = make([]mq.Data, 0, cap(bm.queue))
+	monitoring.SetQueueDepth(bm.name, 0)
 	bm.ticker.start()
 	return true
 }
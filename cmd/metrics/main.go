@@ -11,6 +11,7 @@ import (
 	"github.com/baking-bad/bcdhub/internal/config"
 	"github.com/baking-bad/bcdhub/internal/helpers"
 	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/monitoring"
 	"github.com/baking-bad/bcdhub/internal/mq"
 	"github.com/karlseguin/ccache"
 	"github.com/pkg/errors"
@@ -32,6 +33,7 @@ var handlers = map[string]BulkHandler{
 	mq.QueueBigMapDiffs: getBigMapDiff,
 	mq.QueueRecalc:      recalculateAll,
 	mq.QueueProjects:    getProject,
+	mq.QueueAudit:       auditContracts,
 }
 
 var managers = map[string]*BulkManager{}
@@ -88,6 +90,8 @@ func main() {
 		defer helpers.CatchPanicSentry()
 	}
 
+	monitoring.Serve(cfg.Metrics.Prometheus.Bind)
+
 	configCtx := config.NewContext(
 		config.WithStorage(cfg.Storage),
 		config.WithRPC(cfg.RPC),
@@ -123,7 +127,7 @@ func main() {
 
 	for _, queue := range ctx.MQ.GetQueues() {
 		if handler, ok := handlers[queue]; ok {
-			managers[queue] = NewBulkManager(30, 10, handler)
+			managers[queue] = NewBulkManager(queue, 30, 10, handler)
 			wg.Add(1)
 			go managers[queue].Run()
 		}
@@ -31,6 +31,10 @@ func recalc(contract contract.Contract) error {
 		return err
 	}
 
+	if err := h.SetContractDelegate(&contract); err != nil {
+		return errors.Errorf("[recalc] Set contract delegate error: %s", err)
+	}
+
 	if _, err := h.SetContractAlias(&contract, aliases); err != nil {
 		return err
 	}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/migration"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/mq"
+	"github.com/baking-bad/bcdhub/internal/notifier"
+	"github.com/pkg/errors"
+)
+
+const processPeriod = 5 * time.Second
+
+// Context -
+type Context struct {
+	*config.Context
+	Notifier *notifier.Notifier
+}
+
+func main() {
+	cfg, err := config.LoadDefaultConfig()
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	if cfg.Notifier.SentryEnabled {
+		helpers.InitSentry(cfg.Sentry.Debug, cfg.Sentry.Environment, cfg.Sentry.URI)
+		helpers.SetTagSentry("project", cfg.Notifier.ProjectName)
+		defer helpers.CatchPanicSentry()
+	}
+
+	configCtx := config.NewContext(
+		config.WithStorage(cfg.Storage),
+		config.WithDatabase(cfg.DB),
+		config.WithRabbit(cfg.RabbitMQ, cfg.Notifier.ProjectName, cfg.Notifier.MQ),
+		config.WithConfigCopy(cfg),
+	)
+	defer configCtx.Close()
+
+	ctx := &Context{
+		Context: configCtx,
+		Notifier: notifier.NewNotifier(
+			configCtx.DB,
+			configCtx.Storage,
+			notifier.WithMaxAttempts(cfg.Notifier.MaxAttempts),
+			notifier.WithBackoff(
+				time.Duration(cfg.Notifier.BackoffSeconds)*time.Second,
+				time.Duration(cfg.Notifier.MaxBackoffSeconds)*time.Second,
+			),
+			notifier.WithTimeout(time.Duration(cfg.Notifier.TimeoutSeconds)*time.Second),
+			notifier.WithWorkers(cfg.Notifier.Workers),
+		),
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
+
+	msgs := make(chan mq.Data)
+	closeChan := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, queue := range ctx.MQ.GetQueues() {
+		wg.Add(1)
+		go listenChannel(ctx.MQ, queue, msgs, closeChan, &wg)
+	}
+	wg.Add(1)
+	go ctx.processDeliveries(closeChan, &wg)
+
+	defer wg.Wait()
+	defer close(closeChan)
+
+	for {
+		select {
+		case <-signals:
+			logger.Info("Stopped notifier")
+			return
+		case msg := <-msgs:
+			if msg.GetKey() == "" {
+				logger.Warning("Rabbit MQ server stopped! Notifier service need to be restarted. Closing connection...")
+				return
+			}
+			if err := ctx.handleMessage(msg); err != nil {
+				logger.Error(err)
+			}
+		}
+	}
+}
+
+// listenChannel - forwards messages of `queue` to `msgs`, so all queues are handled one by one in main loop
+func listenChannel(messageQueue mq.IMessageReceiver, queue string, msgs chan<- mq.Data, closeChan chan struct{}, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	queueMsgs, err := messageQueue.Consume(queue)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	logger.Info("Connected to %s queue", queue)
+	for {
+		select {
+		case <-closeChan:
+			logger.Info("Stopped %s queue", queue)
+			return
+		case msg := <-queueMsgs:
+			select {
+			case msgs <- msg:
+			case <-closeChan:
+				return
+			}
+			if msg.GetKey() == "" {
+				return
+			}
+		}
+	}
+}
+
+// processDeliveries - sends pending webhook deliveries every `processPeriod` apart from main loop, so slow webhooks don't block handling of messages and shutdown
+func (ctx *Context) processDeliveries(closeChan chan struct{}, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(processPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closeChan:
+			logger.Info("Stopped webhook deliveries")
+			return
+		case <-ticker.C:
+			if err := ctx.Notifier.Process(closeChan); err != nil {
+				logger.Error(err)
+			}
+		}
+	}
+}
+
+func (ctx *Context) handleMessage(data mq.Data) error {
+	if err := ctx.parseData(data); err != nil {
+		return err
+	}
+	return data.Ack(false)
+}
+
+func (ctx *Context) parseData(data mq.Data) error {
+	id := parseID(data.GetBody())
+
+	switch data.GetKey() {
+	case mq.QueueOperations:
+		operations := make([]operation.Operation, 0)
+		if err := ctx.Storage.GetByIDs(&operations, id); err != nil {
+			return errors.Errorf("[parseData] Find operation error for ID %s: %s", id, err)
+		}
+		return ctx.Notifier.Operations(operations)
+	case mq.QueueMigrations:
+		migrations := make([]migration.Migration, 0)
+		if err := ctx.Storage.GetByIDs(&migrations, id); err != nil {
+			return errors.Errorf("[parseData] Find migration error for ID %s: %s", id, err)
+		}
+		return ctx.Notifier.Migrations(migrations)
+	case mq.QueueContracts:
+		contracts := make([]contract.Contract, 0)
+		if err := ctx.Storage.GetByIDs(&contracts, id); err != nil {
+			return errors.Errorf("[parseData] Find contract error for ID %s: %s", id, err)
+		}
+		return ctx.Notifier.Contracts(contracts)
+	default:
+		logger.Warning("[parseData] Unknown data routing key %s", data.GetKey())
+		return nil
+	}
+}
+
+func parseID(data []byte) string {
+	return strings.Trim(string(data), `"`)
+}
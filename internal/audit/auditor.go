@@ -0,0 +1,334 @@
+package audit
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/migration"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	tbModel "github.com/baking-bad/bcdhub/internal/models/tokenbalance"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/parsers/storage"
+	"github.com/baking-bad/bcdhub/internal/parsers/tokenbalance"
+	"github.com/pkg/errors"
+)
+
+// bigMapPageSize - count of big map keys requested from storage at once
+const bigMapPageSize = 1000
+
+// Auditor - compares indexed contracts, their storage, big maps and ledger balances with node
+type Auditor struct {
+	rpc           noderpc.INode
+	storage       models.GeneralRepository
+	blocks        block.Repository
+	contracts     contract.Repository
+	bigMapDiffs   bigmapdiff.Repository
+	tokenBalances tbModel.Repository
+	historical    storage.Historical
+
+	checks     []string
+	sampleSize int
+	maxKeys    int64
+	repair     bool
+}
+
+// AuditorOption -
+type AuditorOption func(a *Auditor)
+
+// WithChecks - sets checks which are run. By default all checks are run.
+func WithChecks(checks ...string) AuditorOption {
+	return func(a *Auditor) {
+		if len(checks) > 0 {
+			a.checks = checks
+		}
+	}
+}
+
+// WithSampleSize - sets count of random contracts which are audited. If it's 0 all contracts are audited.
+func WithSampleSize(size int) AuditorOption {
+	return func(a *Auditor) {
+		if size > 0 {
+			a.sampleSize = size
+		}
+	}
+}
+
+// WithMaxKeys - sets max count of keys which are checked in every big map. If it's 0 all keys are checked.
+func WithMaxKeys(count int64) AuditorOption {
+	return func(a *Auditor) {
+		if count > 0 {
+			a.maxKeys = count
+		}
+	}
+}
+
+// WithRepair - fixes big map values and ledger balances by node data
+func WithRepair(repair bool) AuditorOption {
+	return func(a *Auditor) {
+		a.repair = repair
+	}
+}
+
+// NewAuditor -
+func NewAuditor(rpc noderpc.INode, repo models.GeneralRepository, blocks block.Repository, contracts contract.Repository, operations operation.Repository, bigMapDiffs bigmapdiff.Repository, migrations migration.Repository, tokenBalances tbModel.Repository, sharePath string, opts ...AuditorOption) *Auditor {
+	a := &Auditor{
+		rpc:           rpc,
+		storage:       repo,
+		blocks:        blocks,
+		contracts:     contracts,
+		bigMapDiffs:   bigMapDiffs,
+		tokenBalances: tokenBalances,
+		historical:    storage.NewHistorical(operations, bigMapDiffs, migrations, rpc, sharePath),
+		checks:        AllChecks(),
+	}
+	for i := range opts {
+		opts[i](a)
+	}
+	return a
+}
+
+// Audit - audits contracts of `network` at the last indexed level
+func (a *Auditor) Audit(network string) (Report, error) {
+	head, err := a.blocks.Last(network)
+	if err != nil {
+		return Report{}, err
+	}
+
+	addresses, err := a.contracts.GetAddressesByNetworkAndLevel(network, head.Level)
+	if err != nil {
+		return Report{}, err
+	}
+
+	indexed := int64(len(addresses))
+	if a.sampleSize > 0 && a.sampleSize < len(addresses) {
+		rand.Shuffle(len(addresses), func(i, j int) {
+			addresses[i], addresses[j] = addresses[j], addresses[i]
+		})
+		addresses = addresses[:a.sampleSize]
+	}
+
+	report := a.audit(head, addresses)
+	report.Indexed = indexed
+	return report, nil
+}
+
+// AuditContracts - audits contracts with `addresses` of `network` at the last indexed level
+func (a *Auditor) AuditContracts(network string, addresses []string) (Report, error) {
+	head, err := a.blocks.Last(network)
+	if err != nil {
+		return Report{}, err
+	}
+	return a.audit(head, addresses), nil
+}
+
+func (a *Auditor) audit(head block.Block, addresses []string) Report {
+	report := Report{
+		Network:    head.Network,
+		Level:      head.Level,
+		Checks:     a.checks,
+		Repair:     a.repair,
+		StartedAt:  time.Now().UTC(),
+		Mismatches: make([]Mismatch, 0),
+		Failures:   make([]Failure, 0),
+	}
+
+	sort.Strings(addresses)
+	for i := range addresses {
+		a.auditContract(&report, head, addresses[i])
+		report.Audited++
+	}
+
+	report.FinishedAt = time.Now().UTC()
+	return report
+}
+
+func (a *Auditor) auditContract(report *Report, head block.Block, address string) {
+	nodeStorage, err := a.rpc.GetScriptStorageRaw(address, head.Level)
+	if err != nil {
+		if errors.Is(err, noderpc.ErrInvalidStatusCode) {
+			if a.enabled(CheckContract) {
+				report.Mismatches = append(report.Mismatches, Mismatch{
+					Check:   CheckContract,
+					Address: address,
+					Indexed: "exists",
+					Node:    "not found",
+				})
+			}
+			return
+		}
+		report.addFailure(address, CheckContract, err)
+		return
+	}
+
+	if !a.enabled(CheckStorage) && !a.enabled(CheckBigMap) && !a.enabled(CheckLedger) {
+		return
+	}
+
+	tree, op, err := a.historical.Storage(head.Network, address, head.Level)
+	if err != nil {
+		report.addFailure(address, CheckStorage, err)
+		return
+	}
+
+	if a.enabled(CheckStorage) {
+		if equal, err := equalJSON([]byte(op.DeffatedStorage), nodeStorage); err != nil {
+			report.addFailure(address, CheckStorage, err)
+		} else if !equal {
+			report.Mismatches = append(report.Mismatches, Mismatch{
+				Check:   CheckStorage,
+				Address: address,
+				Indexed: op.DeffatedStorage,
+				Node:    string(nodeStorage),
+			})
+		}
+	}
+
+	if !a.enabled(CheckBigMap) && !a.enabled(CheckLedger) {
+		return
+	}
+
+	ledger := findLedger(tree)
+
+	bigMaps := tree.FindBigMapByPtr()
+	ptrs := make([]int64, 0, len(bigMaps))
+	for ptr := range bigMaps {
+		ptrs = append(ptrs, ptr)
+	}
+	sort.Slice(ptrs, func(i, j int) bool { return ptrs[i] < ptrs[j] })
+
+	for _, ptr := range ptrs {
+		isLedger := ledger != nil && ledger.ptr == ptr && a.enabled(CheckLedger)
+		if !isLedger && !a.enabled(CheckBigMap) {
+			continue
+		}
+		if err := a.auditBigMap(report, head, address, ptr, ledger, isLedger); err != nil {
+			report.addFailure(address, CheckBigMap, err)
+		}
+	}
+}
+
+func (a *Auditor) auditBigMap(report *Report, head block.Block, address string, ptr int64, ledger *ledgerBigMap, isLedger bool) error {
+	keys, complete, err := a.bigMapKeys(head.Network, ptr)
+	if err != nil {
+		return err
+	}
+
+	balances := make([]tokenbalance.TokenBalance, 0)
+	for i := range keys {
+		nodeValue, err := a.rpc.GetBigMapValue(ptr, keys[i].KeyHash, head.Level)
+		if err != nil {
+			report.addFailure(address, CheckBigMap, errors.Wrap(err, keys[i].KeyHash))
+			complete = false
+			continue
+		}
+
+		if a.enabled(CheckBigMap) {
+			if err := a.checkBigMapKey(report, head, address, ptr, keys[i], nodeValue); err != nil {
+				report.addFailure(address, CheckBigMap, errors.Wrap(err, keys[i].KeyHash))
+			}
+		}
+
+		if isLedger {
+			items, err := ledger.parse(keys[i].Key, nodeValue)
+			if err != nil {
+				report.addFailure(address, CheckLedger, errors.Wrap(err, keys[i].KeyHash))
+				complete = false
+				continue
+			}
+			balances = append(balances, items...)
+		}
+	}
+
+	if isLedger {
+		if err := a.checkLedger(report, head.Network, address, balances, complete); err != nil {
+			report.addFailure(address, CheckLedger, err)
+		}
+	}
+	return nil
+}
+
+func (a *Auditor) checkBigMapKey(report *Report, head block.Block, address string, ptr int64, key bigmapdiff.BigMapDiff, nodeValue []byte) error {
+	current, err := a.bigMapDiffs.CurrentByKey(head.Network, key.KeyHash, ptr)
+	if err != nil {
+		if !a.storage.IsRecordNotFound(err) {
+			return err
+		}
+		current = key
+	}
+
+	equal, err := equalJSON(current.ValueBytes(), nodeValue)
+	if err != nil || equal {
+		return err
+	}
+
+	mismatch := Mismatch{
+		Check:   CheckBigMap,
+		Address: address,
+		Ptr:     &ptr,
+		KeyHash: key.KeyHash,
+		Indexed: string(current.ValueBytes()),
+		Node:    string(nodeValue),
+	}
+
+	if a.repair {
+		fixed := &bigmapdiff.BigMapDiff{
+			ID:           helpers.GenerateID(),
+			Ptr:          ptr,
+			Key:          current.Key,
+			KeyHash:      current.KeyHash,
+			KeyStrings:   current.KeyStrings,
+			Value:        nodeValue,
+			ValueStrings: []string{},
+			Level:        head.Level,
+			Address:      address,
+			Network:      head.Network,
+			IndexedTime:  time.Now().UnixNano() / 1000,
+			Timestamp:    head.Timestamp,
+			Protocol:     head.Protocol,
+		}
+		if err := a.storage.BulkInsert([]models.Model{fixed}); err != nil {
+			return err
+		}
+		mismatch.Repaired = true
+	}
+
+	report.Mismatches = append(report.Mismatches, mismatch)
+	return nil
+}
+
+// bigMapKeys - returns current states of big map keys. The second result is false if not all keys are returned because of `maxKeys`.
+func (a *Auditor) bigMapKeys(network string, ptr int64) ([]bigmapdiff.BigMapDiff, bool, error) {
+	ctx := bigmapdiff.GetContext{
+		Network: network,
+		Ptr:     &ptr,
+		Size:    bigMapPageSize,
+	}
+
+	keys := make([]bigmapdiff.BigMapDiff, 0)
+	for {
+		buckets, err := a.bigMapDiffs.Get(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		for i := range buckets {
+			if a.maxKeys > 0 && int64(len(keys)) == a.maxKeys {
+				return keys, false, nil
+			}
+			keys = append(keys, buckets[i].BigMapDiff)
+		}
+		if len(buckets) < bigMapPageSize {
+			return keys, true, nil
+		}
+		ctx.Offset += bigMapPageSize
+	}
+}
+
+func (a *Auditor) enabled(check string) bool {
+	return helpers.StringInArray(check, a.checks)
+}
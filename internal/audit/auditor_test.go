@@ -0,0 +1,233 @@
+package audit
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	tbModel "github.com/baking-bad/bcdhub/internal/models/tokenbalance"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	mock_general "github.com/baking-bad/bcdhub/internal/models/mock"
+	mock_bmd "github.com/baking-bad/bcdhub/internal/models/mock/bigmapdiff"
+	mock_block "github.com/baking-bad/bcdhub/internal/models/mock/block"
+	mock_contract "github.com/baking-bad/bcdhub/internal/models/mock/contract"
+	mock_migration "github.com/baking-bad/bcdhub/internal/models/mock/migration"
+	mock_operation "github.com/baking-bad/bcdhub/internal/models/mock/operation"
+	mock_token_balance "github.com/baking-bad/bcdhub/internal/models/mock/tokenbalance"
+)
+
+const (
+	testNetwork  = "edo2net"
+	testAddress  = "KT1C2MfcjWb5R1ZDDxVULCsGuxrf5fEn5264"
+	testMissing  = "KT1FgscaMyhxoVLbVirJVVKpRXgiSGtDG9Z4"
+	testProtocol = "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA"
+	testLevel    = int64(100)
+	testPtr      = int64(5)
+
+	testHolder1 = "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"
+	testHolder2 = "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx"
+	testHolder3 = "tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb"
+
+	testScript = `[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"pair","args":[{"prim":"big_map","args":[{"prim":"address"},{"prim":"nat"}],"annots":["%ledger"]},{"prim":"nat","annots":["%total"]}]}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`
+)
+
+type testAuditor struct {
+	auditor       *Auditor
+	rpc           *noderpc.MockINode
+	storage       *mock_general.MockGeneralRepository
+	bigMapDiffs   *mock_bmd.MockRepository
+	tokenBalances *mock_token_balance.MockRepository
+}
+
+func newTestAuditor(t *testing.T, ctrl *gomock.Controller, addresses []string, opts ...AuditorOption) testAuditor {
+	sharePath, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(sharePath) })
+
+	contractDir := filepath.Join(sharePath, "contracts", testNetwork)
+	if err := os.MkdirAll(contractDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(contractDir, testAddress+"_babylon.json"), []byte(testScript), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	blocks := mock_block.NewMockRepository(ctrl)
+	blocks.EXPECT().Last(testNetwork).Return(block.Block{
+		Network:  testNetwork,
+		Level:    testLevel,
+		Protocol: testProtocol,
+	}, nil).AnyTimes()
+
+	contracts := mock_contract.NewMockRepository(ctrl)
+	contracts.EXPECT().GetAddressesByNetworkAndLevel(testNetwork, testLevel).Return(addresses, nil).AnyTimes()
+
+	operations := mock_operation.NewMockRepository(ctrl)
+	operations.EXPECT().LastAtLevel(testNetwork, testAddress, testLevel).Return(operation.Operation{
+		Network:         testNetwork,
+		Destination:     testAddress,
+		Level:           95,
+		Protocol:        testProtocol,
+		DeffatedStorage: `{"prim":"Pair","args":[{"int":"5"},{"int":"99"}]}`,
+	}, nil).AnyTimes()
+
+	migrations := mock_migration.NewMockRepository(ctrl)
+	migrations.EXPECT().Get(testNetwork, gomock.Any()).Return(nil, nil).AnyTimes()
+
+	ta := testAuditor{
+		rpc:           noderpc.NewMockINode(ctrl),
+		storage:       mock_general.NewMockGeneralRepository(ctrl),
+		bigMapDiffs:   mock_bmd.NewMockRepository(ctrl),
+		tokenBalances: mock_token_balance.NewMockRepository(ctrl),
+	}
+	ta.auditor = NewAuditor(ta.rpc, ta.storage, blocks, contracts, operations, ta.bigMapDiffs, migrations, ta.tokenBalances, sharePath, opts...)
+	return ta
+}
+
+func (ta testAuditor) expectContract() {
+	ta.rpc.EXPECT().GetScriptStorageRaw(testAddress, testLevel).Return([]byte(`{ "prim": "Pair", "args": [ { "int": "5" }, { "int": "100" } ] }`), nil)
+
+	keys := []bigmapdiff.Bucket{
+		{BigMapDiff: bigmapdiff.BigMapDiff{Ptr: testPtr, Key: []byte(`{"string":"` + testHolder1 + `"}`), KeyHash: "expr1", Value: []byte(`{"int":"10"}`)}},
+		{BigMapDiff: bigmapdiff.BigMapDiff{Ptr: testPtr, Key: []byte(`{"string":"` + testHolder2 + `"}`), KeyHash: "expr2", Value: []byte(`{"int":"5"}`)}},
+	}
+	ptr := testPtr
+	ta.bigMapDiffs.EXPECT().Get(bigmapdiff.GetContext{
+		Network: testNetwork,
+		Ptr:     &ptr,
+		Size:    bigMapPageSize,
+	}).Return(keys, nil)
+	for i := range keys {
+		ta.bigMapDiffs.EXPECT().CurrentByKey(testNetwork, keys[i].KeyHash, testPtr).Return(keys[i].BigMapDiff, nil)
+	}
+
+	ta.rpc.EXPECT().GetBigMapValue(testPtr, "expr1", testLevel).Return([]byte(`{"int":"10"}`), nil)
+	ta.rpc.EXPECT().GetBigMapValue(testPtr, "expr2", testLevel).Return([]byte(`{"int":"7"}`), nil)
+
+	ta.tokenBalances.EXPECT().GetHolders(testNetwork, testAddress, types.NewBigInt(0)).Return([]tbModel.TokenBalance{
+		{Network: testNetwork, Contract: testAddress, Address: testHolder1, Value: big.NewInt(10)},
+		{Network: testNetwork, Contract: testAddress, Address: testHolder2, Value: big.NewInt(5)},
+		{Network: testNetwork, Contract: testAddress, Address: testHolder3, Value: big.NewInt(4)},
+	}, nil)
+}
+
+func TestAuditor_Audit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ta := newTestAuditor(t, ctrl, []string{testMissing, testAddress})
+	ta.expectContract()
+	ta.rpc.EXPECT().GetScriptStorageRaw(testMissing, testLevel).Return(nil, errors.Wrap(noderpc.ErrInvalidStatusCode, "404"))
+
+	report, err := ta.auditor.Audit(testNetwork)
+	if err != nil {
+		t.Fatalf("Audit() error = %v", err)
+	}
+
+	assert.Equal(t, int64(2), report.Indexed)
+	assert.Equal(t, int64(2), report.Audited)
+	assert.Empty(t, report.Failures)
+	assert.Equal(t, 1, report.CountByCheck(CheckContract))
+	assert.Equal(t, 1, report.CountByCheck(CheckStorage))
+	assert.Equal(t, 1, report.CountByCheck(CheckBigMap))
+	assert.Equal(t, 2, report.CountByCheck(CheckLedger))
+
+	for _, mismatch := range report.Mismatches {
+		assert.False(t, mismatch.Repaired)
+		switch mismatch.Check {
+		case CheckContract:
+			assert.Equal(t, testMissing, mismatch.Address)
+		case CheckBigMap:
+			assert.Equal(t, "expr2", mismatch.KeyHash)
+			assert.Equal(t, `{"int":"7"}`, mismatch.Node)
+		case CheckLedger:
+			switch mismatch.Holder {
+			case testHolder2:
+				assert.Equal(t, "5", mismatch.Indexed)
+				assert.Equal(t, "7", mismatch.Node)
+			case testHolder3:
+				assert.Equal(t, "4", mismatch.Indexed)
+				assert.Equal(t, "0", mismatch.Node)
+			default:
+				t.Errorf("unexpected ledger mismatch of %s", mismatch.Holder)
+			}
+		}
+	}
+}
+
+func TestAuditor_Repair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ta := newTestAuditor(t, ctrl, []string{testAddress}, WithRepair(true), WithChecks(CheckBigMap, CheckLedger))
+	ta.expectContract()
+
+	ta.storage.EXPECT().BulkInsert(gomock.Any()).DoAndReturn(func(items []models.Model) error {
+		if assert.Len(t, items, 1) {
+			bmd, ok := items[0].(*bigmapdiff.BigMapDiff)
+			if assert.True(t, ok) {
+				assert.Equal(t, "expr2", bmd.KeyHash)
+				assert.Equal(t, testLevel, bmd.Level)
+				assert.Equal(t, `{"int":"7"}`, string(bmd.Value))
+			}
+		}
+		return nil
+	})
+	ta.tokenBalances.EXPECT().Update(gomock.Any()).DoAndReturn(func(updates []*tbModel.TokenBalance) error {
+		deltas := make(map[string]int64)
+		for i := range updates {
+			deltas[updates[i].Address] = updates[i].Value.Int64()
+		}
+		assert.Len(t, deltas, 2)
+		assert.Equal(t, int64(2), deltas[testHolder2])
+		assert.Equal(t, int64(-4), deltas[testHolder3])
+		return nil
+	})
+
+	report, err := ta.auditor.AuditContracts(testNetwork, []string{testAddress})
+	if err != nil {
+		t.Fatalf("AuditContracts() error = %v", err)
+	}
+	assert.Empty(t, report.Failures)
+	assert.Equal(t, 0, report.CountByCheck(CheckStorage))
+	assert.Len(t, report.Mismatches, 3)
+	for _, mismatch := range report.Mismatches {
+		assert.True(t, mismatch.Repaired)
+	}
+}
+
+func Test_equalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{name: "formatting", a: `{"prim":"Pair","args":[{"int":"1"},{"string":"a"}]}`, b: `{ "args": [ { "int": "1" }, { "string": "a" } ], "prim": "Pair" }`, want: true},
+		{name: "different", a: `{"int":"1"}`, b: `{"int":"2"}`, want: false},
+		{name: "removed", a: ``, b: ``, want: true},
+		{name: "removed in index", a: ``, b: `{"int":"2"}`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := equalJSON([]byte(tt.a), []byte(tt.b))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package audit
+
+import (
+	"bytes"
+	stdJSON "encoding/json"
+	"math/big"
+	"reflect"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
+	tbModel "github.com/baking-bad/bcdhub/internal/models/tokenbalance"
+	"github.com/baking-bad/bcdhub/internal/parsers/tokenbalance"
+)
+
+const ledgerStorageKey = "ledger"
+
+type ledgerBigMap struct {
+	ptr    int64
+	parser tokenbalance.Parser
+}
+
+// findLedger - returns ledger big map of storage if its type is known by token balance parsers
+func findLedger(tree *ast.TypedAst) *ledgerBigMap {
+	node := tree.FindByName(ledgerStorageKey, false)
+	if node == nil {
+		return nil
+	}
+	bigMap, ok := node.(*ast.BigMap)
+	if !ok || bigMap.Ptr == nil {
+		return nil
+	}
+	parser, err := tokenbalance.GetParserForBigMap(bigMap)
+	if err != nil || parser == nil {
+		return nil
+	}
+	return &ledgerBigMap{
+		ptr:    *bigMap.Ptr,
+		parser: parser,
+	}
+}
+
+// parse - returns balances stored in ledger by `key`. Removed key means zero balance.
+func (l *ledgerBigMap) parse(key, value []byte) ([]tokenbalance.TokenBalance, error) {
+	var s bytes.Buffer
+	s.WriteString(`[{"prim":"Elt","args":[`)
+	s.Write(key)
+	s.WriteByte(',')
+	if len(value) > 0 {
+		s.Write(value)
+	} else {
+		s.WriteString(`{"int":"0"}`)
+	}
+	s.WriteString(`]}]`)
+	return l.parser.Parse(s.Bytes())
+}
+
+// checkLedger - compares balances received from node with indexed ones. If `complete` is true, all keys of ledger were received, so indexed holders which are absent in node ledger are mismatches too.
+func (a *Auditor) checkLedger(report *Report, network, address string, balances []tokenbalance.TokenBalance, complete bool) error {
+	holders := make(map[string]map[string]*big.Int)
+	tokens := make(map[string]*types.BigInt)
+	for _, balance := range balances {
+		tokenID := balance.TokenID.String()
+		if _, ok := holders[tokenID]; ok {
+			continue
+		}
+		indexed, err := a.tokenBalances.GetHolders(network, address, balance.TokenID)
+		if err != nil && !a.storage.IsRecordNotFound(err) {
+			return err
+		}
+		values := make(map[string]*big.Int)
+		for i := range indexed {
+			if indexed[i].Value != nil {
+				values[indexed[i].Address] = indexed[i].Value
+			}
+		}
+		holders[tokenID] = values
+		tokens[tokenID] = balance.TokenID
+	}
+
+	updates := make([]*tbModel.TokenBalance, 0)
+	mismatches := make([]Mismatch, 0)
+	compare := func(holder string, tokenID *types.BigInt, indexed, node *big.Int) {
+		if indexed.Cmp(node) == 0 {
+			return
+		}
+		mismatches = append(mismatches, Mismatch{
+			Check:   CheckLedger,
+			Address: address,
+			Holder:  holder,
+			TokenID: tokenID.String(),
+			Indexed: indexed.String(),
+			Node:    node.String(),
+		})
+		updates = append(updates, &tbModel.TokenBalance{
+			Network:  network,
+			Address:  holder,
+			Contract: address,
+			TokenID:  tokenID,
+			Value:    new(big.Int).Sub(node, indexed),
+		})
+	}
+
+	for _, balance := range balances {
+		values := holders[balance.TokenID.String()]
+		indexed, ok := values[balance.Address]
+		if !ok {
+			indexed = big.NewInt(0)
+		}
+		delete(values, balance.Address)
+		compare(balance.Address, balance.TokenID, indexed, balance.Value)
+	}
+
+	if complete {
+		for tokenID, values := range holders {
+			for holder, indexed := range values {
+				compare(holder, tokens[tokenID], indexed, big.NewInt(0))
+			}
+		}
+	}
+
+	if a.repair && len(updates) > 0 {
+		if err := a.tokenBalances.Update(updates); err != nil {
+			return err
+		}
+		for i := range mismatches {
+			mismatches[i].Repaired = true
+		}
+	}
+
+	report.Mismatches = append(report.Mismatches, mismatches...)
+	return nil
+}
+
+// equalJSON - compares JSON values ignoring formatting. Empty values are equal only to each other.
+func equalJSON(a, b []byte) (bool, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b), nil
+	}
+	var left, right interface{}
+	if err := stdJSON.Unmarshal(a, &left); err != nil {
+		return false, err
+	}
+	if err := stdJSON.Unmarshal(b, &right); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(left, right), nil
+}
@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"time"
+)
+
+// Checks
+const (
+	CheckContract = "contract"
+	CheckStorage  = "storage"
+	CheckBigMap   = "big_map"
+	CheckLedger   = "ledger"
+)
+
+// AllChecks - returns all supported checks
+func AllChecks() []string {
+	return []string{CheckContract, CheckStorage, CheckBigMap, CheckLedger}
+}
+
+// Report - result of audit of one network
+type Report struct {
+	Network    string     `json:"network"`
+	Level      int64      `json:"level"`
+	Checks     []string   `json:"checks"`
+	Indexed    int64      `json:"indexed_contracts,omitempty"`
+	Audited    int64      `json:"audited_contracts"`
+	Repair     bool       `json:"repair"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt time.Time  `json:"finished_at"`
+	Mismatches []Mismatch `json:"mismatches"`
+	Failures   []Failure  `json:"failures"`
+}
+
+// Mismatch - difference between indexed data and node. `Indexed` and `Node` are JSON values for storage and big maps and decimal numbers for ledger balances.
+type Mismatch struct {
+	Check    string `json:"check"`
+	Address  string `json:"address"`
+	Ptr      *int64 `json:"ptr,omitempty"`
+	KeyHash  string `json:"key_hash,omitempty"`
+	Holder   string `json:"holder,omitempty"`
+	TokenID  string `json:"token_id,omitempty"`
+	Indexed  string `json:"indexed"`
+	Node     string `json:"node"`
+	Repaired bool   `json:"repaired"`
+}
+
+// Failure - error which prevented to audit contract
+type Failure struct {
+	Address string `json:"address"`
+	Check   string `json:"check"`
+	Error   string `json:"error"`
+}
+
+// HasMismatches -
+func (r Report) HasMismatches() bool {
+	return len(r.Mismatches) > 0
+}
+
+// CountByCheck - returns count of mismatches of `check`
+func (r Report) CountByCheck(check string) (count int) {
+	for i := range r.Mismatches {
+		if r.Mismatches[i].Check == check {
+			count++
+		}
+	}
+	return
+}
+
+func (r *Report) addFailure(address, check string, err error) {
+	r.Failures = append(r.Failures, Failure{
+		Address: address,
+		Check:   check,
+		Error:   err.Error(),
+	})
+}
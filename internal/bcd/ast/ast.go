@@ -355,6 +355,15 @@ func (a *TypedAst) FindBigMapByPtr() map[int64]*BigMap {
 	return res
 }
 
+// FindTickets - returns all tickets in settled tree except ones stored in big maps
+func (a *TypedAst) FindTickets() []*Ticket {
+	res := make([]*Ticket, 0)
+	for i := range a.Nodes {
+		res = append(res, a.Nodes[i].FindTickets()...)
+	}
+	return res
+}
+
 // GetJSONModel -
 func (a *TypedAst) GetJSONModel(model JSONModel) {
 	if model == nil {
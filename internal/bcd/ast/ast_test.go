@@ -863,6 +863,11 @@ func TestTypedAst_ToMiguel(t *testing.T) {
 			tree: `{"prim":"list","args":[{"prim":"pair","args":[{"prim":"sapling_transaction","args":[{"int":"8"}]},{"prim":"option","args":[{"prim":"key_hash"}]}]}]}`,
 			data: `[{"prim":"Pair","args":[{"bytes":"000002c0db3146a94b750c32d29554da38676439454600485d341403d1e1360732b9dd5baa2ff48826cd9f8d090b01a94c9cef44c0a43fd40a599bc32f6c52d7e6925dd1eb059a1bb5a190242986f9d29962c90fd6b885eac540e49b21cd8b2ef165522d98f55950e232608fda87cbacc6768bb3497985df91412ddc45fd9264d5580e859ecf5e2238761754dd4963bdad0c068cb3f44e10fdfbccf27075024a1c36bf449ae03d2b3e1e292d1a3a07b5ad38633d84c668e6b16fc587f0d91ececad71c0b08986ef29f21fdb14075305540236225b474561d216ae56c065247bcf6c436c22b847e3b0a15ea2f3015c97f252373218fad40b7c3dc46a53ece93f1f699e58674e565d7a1e49fd9edb558382f7dc224d033b71df3011704432673d0144a9da2fa39f585df620016bf745636108147899e1e420e33d742a64727dc7790d205cd431b72d05be6f47b99f9ca985a61b88e1ea09691154e85c42372147e3dc08d0262a154e2e440eb2f337f57f1f0cc5a0dc4d56c16cb78057680b34cc286b1079475d024019313bbff3bdd9a1587fe80f724e656e10e5b20c2ae4364699f8405414ccdbf61fb1f712877d79938ee87f2d77fdd8431a182481cccbc2f89f3c2736aa956745389d03c28676fcbf1f62a723f9c56d751b7b9116dc3a6bf2c324fa58311a2310328ee0c2d12212f626aff96289048f2403e61e9808b3bf6e71be1d91115b473f056abdcebaa7e8518a75b49629e2960269921e7347bd3278410632a8b0946f45799515d1afef676ed8d274fdc2960ffd6ea606274c4602f9b8056180d347a454893605db1a509dec4a98007c19499f5ff8565aaaa19aff03a44ab20674d91113434e3f7eb50d50385ce3ffe1a3e635e74fd1dc36d27a39517e36a83303bcf8add2ff896f27e32479fe94a25f1e16c1ab2ca2d0666f9ece9423699fa4444c3b7a2d861ac9b357b1ceb3a16977d8c89ccebb6a75ce5e39fbfb38895c007000001f322b175583f68f44b97079b9a5eb82d8d79797b911dbda323c6be8456c5a4f23ca38c3e4f488a12980b93dbe4a12f8e54d426103170796d53ec257816e5ff4a25277763fdf0fc14091fd444ba4142f541b255425add66a61aa3b4445e09a9f3f1c8b85fe65c300f5f7b706effbd70cf2295f6d18f28ea982588c3d42863946d3a11772864770b1dce2725ab9316dd776a0a89c2f95027aa0208a6f4624421a6fd211d6cf8848ff191cd161418d1427a818b0f538c8a467732fcb47a67ec621577c31f8360c939776271d4ece94fb600d283c5696d0cc7b969fdf8cdd8685486f67fa52b989223e3a2be4d4c73932e74dcb52b2e581d20a1d6b2d2b600c2905b494a51ad6e29aacbd8d9ce7bca324951c5aefafeafa88627e2aff917d2b37d6f960000004fe3d6d3399ec4adb31f8cc93dec11897f1fe0e2724767edc3d503e1a2856205cef3abb8f12e0b1838834c0c5ae745d0f6f0180c4f1500b110944a2f52eb691c6439cf70626448f300792b7faa651efc455af64027c51a4e70e479001a1be194b8e857634b2c092f094cfa011cddd527eaaaecb2a5b11dd77c9e2937020ab5c7870930f5f9092b207aa2d5955d906ee33689f60957211dd81df3d5fd4b2992657ddf262fe8a44ab0e627fa2d0acb4198662e44ca82296f550120ebd31ed34fc574cddf38aa381ef1a75455e55d74e790cda0b9b2d6b868ff1431bddc11128ef26a1269c68a38b042853ec3406b5479b5c181d28941111a895ff0fc5d53f59fb00d39beb449b516b6b91cfe8c3a0828060000000000c65d40e9f836128ca1d7d717961ba86286807deeda12894a0dc92b74d9f7e16c08592b"},{"prim":"Some","args":[{"bytes":"00f1c4ee52908e89b832d47ef72be0d29bf326d245"}]}]}]`,
 			want: `[{"prim":"list","type":"list","name":"@list_1","children":[{"prim":"pair","type":"namedtuple","name":"@pair_2","children":[{"prim":"sapling_transaction","type":"sapling_transaction","name":"@sapling_transaction_3","value":"000002c0db3146a94b750c32d29554da38676439454600485d341403d1e1360732b9dd5baa2ff48826cd9f8d090b01a94c9cef44c0a43fd40a599bc32f6c52d7e6925dd1eb059a1bb5a190242986f9d29962c90fd6b885eac540e49b21cd8b2ef165522d98f55950e232608fda87cbacc6768bb3497985df91412ddc45fd9264d5580e859ecf5e2238761754dd4963bdad0c068cb3f44e10fdfbccf27075024a1c36bf449ae03d2b3e1e292d1a3a07b5ad38633d84c668e6b16fc587f0d91ececad71c0b08986ef29f21fdb14075305540236225b474561d216ae56c065247bcf6c436c22b847e3b0a15ea2f3015c97f252373218fad40b7c3dc46a53ece93f1f699e58674e565d7a1e49fd9edb558382f7dc224d033b71df3011704432673d0144a9da2fa39f585df620016bf745636108147899e1e420e33d742a64727dc7790d205cd431b72d05be6f47b99f9ca985a61b88e1ea09691154e85c42372147e3dc08d0262a154e2e440eb2f337f57f1f0cc5a0dc4d56c16cb78057680b34cc286b1079475d024019313bbff3bdd9a1587fe80f724e656e10e5b20c2ae4364699f8405414ccdbf61fb1f712877d79938ee87f2d77fdd8431a182481cccbc2f89f3c2736aa956745389d03c28676fcbf1f62a723f9c56d751b7b9116dc3a6bf2c324fa58311a2310328ee0c2d12212f626aff96289048f2403e61e9808b3bf6e71be1d91115b473f056abdcebaa7e8518a75b49629e2960269921e7347bd3278410632a8b0946f45799515d1afef676ed8d274fdc2960ffd6ea606274c4602f9b8056180d347a454893605db1a509dec4a98007c19499f5ff8565aaaa19aff03a44ab20674d91113434e3f7eb50d50385ce3ffe1a3e635e74fd1dc36d27a39517e36a83303bcf8add2ff896f27e32479fe94a25f1e16c1ab2ca2d0666f9ece9423699fa4444c3b7a2d861ac9b357b1ceb3a16977d8c89ccebb6a75ce5e39fbfb38895c007000001f322b175583f68f44b97079b9a5eb82d8d79797b911dbda323c6be8456c5a4f23ca38c3e4f488a12980b93dbe4a12f8e54d426103170796d53ec257816e5ff4a25277763fdf0fc14091fd444ba4142f541b255425add66a61aa3b4445e09a9f3f1c8b85fe65c300f5f7b706effbd70cf2295f6d18f28ea982588c3d42863946d3a11772864770b1dce2725ab9316dd776a0a89c2f95027aa0208a6f4624421a6fd211d6cf8848ff191cd161418d1427a818b0f538c8a467732fcb47a67ec621577c31f8360c939776271d4ece94fb600d283c5696d0cc7b969fdf8cdd8685486f67fa52b989223e3a2be4d4c73932e74dcb52b2e581d20a1d6b2d2b600c2905b494a51ad6e29aacbd8d9ce7bca324951c5aefafeafa88627e2aff917d2b37d6f960000004fe3d6d3399ec4adb31f8cc93dec11897f1fe0e2724767edc3d503e1a2856205cef3abb8f12e0b1838834c0c5ae745d0f6f0180c4f1500b110944a2f52eb691c6439cf70626448f300792b7faa651efc455af64027c51a4e70e479001a1be194b8e857634b2c092f094cfa011cddd527eaaaecb2a5b11dd77c9e2937020ab5c7870930f5f9092b207aa2d5955d906ee33689f60957211dd81df3d5fd4b2992657ddf262fe8a44ab0e627fa2d0acb4198662e44ca82296f550120ebd31ed34fc574cddf38aa381ef1a75455e55d74e790cda0b9b2d6b868ff1431bddc11128ef26a1269c68a38b042853ec3406b5479b5c181d28941111a895ff0fc5d53f59fb00d39beb449b516b6b91cfe8c3a0828060000000000c65d40e9f836128ca1d7d717961ba86286807deeda12894a0dc92b74d9f7e16c08592b"},{"prim":"key_hash","type":"key_hash","name":"@key_hash_5","value":"tz1hgPTMPor2cmqDpGzgwiWJKMPi84HuntYp"}]}]}]`,
+		}, {
+			name: "multisig lambda",
+			tree: `{"prim":"lambda","args":[{"prim":"unit"},{"prim":"list","args":[{"prim":"operation"}]}],"annots":["%operation"]}`,
+			data: `[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PUSH","args":[{"prim":"address"},{"string":"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"}]},{"prim":"CONTRACT","args":[{"prim":"pair","args":[{"prim":"address","annots":["%to"]},{"prim":"nat","annots":["%value"]}]}],"annots":["%transfer"]},[{"prim":"IF_NONE","args":[[[{"prim":"UNIT"},{"prim":"FAILWITH"}]],[]]}],{"prim":"PUSH","args":[{"prim":"mutez"},{"int":"0"}]},{"prim":"PUSH","args":[{"prim":"pair","args":[{"prim":"address"},{"prim":"nat"}]},{"prim":"Pair","args":[{"string":"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"},{"int":"100"}]}]},{"prim":"TRANSFER_TOKENS"},{"prim":"CONS"},{"prim":"NONE","args":[{"prim":"key_hash"}]},{"prim":"SET_DELEGATE"},{"prim":"CONS"}]`,
+			want: `[{"prim":"lambda","type":"lambda","name":"operation","value":"{ DROP ;\n  NIL operation ;\n  PUSH address \"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn\" ;\n  CONTRACT %transfer (pair (address %to) (nat %value)) ;\n  { IF_NONE { { UNIT ; FAILWITH } } {} } ;\n  PUSH mutez 0 ;\n  PUSH (pair address nat) (Pair \"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6\" 100) ;\n  TRANSFER_TOKENS ;\n  CONS ;\n  NONE key_hash ;\n  SET_DELEGATE ;\n  CONS }","lambda":{"operations":[{"kind":"delegation"},{"kind":"transaction","destination":"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn","entrypoint":"transfer","amount":0,"parameters":{"prim":"pair","type":"namedtuple","name":"@pair_1","children":[{"prim":"address","type":"address","name":"to","value":"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"},{"prim":"nat","type":"nat","name":"value","value":"100"}]}}],"complete":true,"failed":false}}]`,
 		}, {
 			name: "simple big map",
 			tree: `{"prim": "big_map","args":[{"prim":"int"},{"prim":"string"}]}`,
@@ -1584,3 +1589,79 @@ func TestTypedAst_EqualType(t *testing.T) {
 		})
 	}
 }
+
+func TestTypedAst_FindTickets(t *testing.T) {
+	type ticket struct {
+		ticketer string
+		content  string
+		amount   int64
+	}
+	tests := []struct {
+		name string
+		tree string
+		data string
+		want []ticket
+	}{
+		{
+			name: "without tickets",
+			tree: `{"prim":"pair","args":[{"prim":"nat"},{"prim":"string"}]}`,
+			data: `{"prim":"Pair","args":[{"int":"1"},{"string":"test"}]}`,
+			want: []ticket{},
+		}, {
+			name: "single ticket",
+			tree: `{"prim":"pair","args":[{"prim":"ticket","args":[{"prim":"string"}]},{"prim":"nat"}]}`,
+			data: `{"prim":"Pair","args":[{"prim":"Pair","args":[{"string":"KT1XtQeSap9wvJGY1Lmek84NU6PK6cjzC9Qd"},{"prim":"Pair","args":[{"string":"test"},{"int":"10"}]}]},{"int":"1"}]}`,
+			want: []ticket{
+				{"KT1XtQeSap9wvJGY1Lmek84NU6PK6cjzC9Qd", `{"string":"test"}`, 10},
+			},
+		}, {
+			name: "tickets in map and option",
+			tree: `{"prim":"pair","args":[{"prim":"map","args":[{"prim":"nat"},{"prim":"ticket","args":[{"prim":"unit"}]}]},{"prim":"option","args":[{"prim":"ticket","args":[{"prim":"nat"}]}]}]}`,
+			data: `{"prim":"Pair","args":[[{"prim":"Elt","args":[{"int":"1"},{"prim":"Pair","args":[{"bytes":"01fbd3e2ef6a7ac1bf8d9f6e5a5ba2cfd1d0e16b3e00"},{"prim":"Pair","args":[{"prim":"Unit"},{"int":"5"}]}]}]}],{"prim":"Some","args":[{"prim":"Pair","args":[{"string":"KT1XtQeSap9wvJGY1Lmek84NU6PK6cjzC9Qd"},{"prim":"Pair","args":[{"int":"7"},{"int":"3"}]}]}]}]}`,
+			want: []ticket{
+				{"KT1XYK13YCaTKSJPhvSXhV8Ejx97yZ5oELsQ", `{"prim":"Unit"}`, 5},
+				{"KT1XtQeSap9wvJGY1Lmek84NU6PK6cjzC9Qd", `{"int":"7"}`, 3},
+			},
+		}, {
+			name: "tickets in big map are skipped",
+			tree: `{"prim":"big_map","args":[{"prim":"nat"},{"prim":"ticket","args":[{"prim":"unit"}]}]}`,
+			data: `{"int":"12"}`,
+			want: []ticket{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ, err := NewSettledTypedAst(tt.tree, tt.data)
+			if err != nil {
+				t.Errorf("NewSettledTypedAst() error = %v", err)
+				return
+			}
+			tickets := typ.FindTickets()
+			got := make([]ticket, 0)
+			for i := range tickets {
+				ticketer, err := tickets[i].Ticketer()
+				if err != nil {
+					t.Errorf("Ticketer() error = %v", err)
+					return
+				}
+				content, err := tickets[i].Content().ToBaseNode(false)
+				if err != nil {
+					t.Errorf("Content() error = %v", err)
+					return
+				}
+				b, err := json.Marshal(content)
+				if err != nil {
+					t.Errorf("Marshal() error = %v", err)
+					return
+				}
+				amount, err := tickets[i].Amount()
+				if err != nil {
+					t.Errorf("Amount() error = %v", err)
+					return
+				}
+				got = append(got, ticket{ticketer, string(b), amount.Int64()})
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
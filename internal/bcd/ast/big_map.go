@@ -2,6 +2,7 @@ package ast
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/base"
@@ -446,6 +447,59 @@ func (m *BigMap) SetValueType(data []byte) error {
 	return nil
 }
 
+// KeyFromJSONSchema - builds big map key from JSON schema form data. Returned key is parsed from its Micheline, so it can be hashed and compared with keys received from big map diffs.
+func (m *BigMap) KeyFromJSONSchema(data map[string]interface{}) (Node, error) {
+	key := Copy(m.KeyType)
+	if err := key.FromJSONSchema(data); err != nil {
+		return nil, err
+	}
+	raw, err := key.ToParameters()
+	if err != nil {
+		return nil, err
+	}
+	return m.KeyFromBytes(raw)
+}
+
+// KeyFromBytes - parses big map key from Micheline
+func (m *BigMap) KeyFromBytes(data []byte) (Node, error) {
+	return m.makeNodeFromBytes(m.KeyType, data)
+}
+
+// HasOrderedKeys - returns true if big map key is simple comparable type which can be received from string: nat, int, mutez, timestamp, address, key_hash, string or bytes
+func (m *BigMap) HasOrderedKeys() bool {
+	switch m.KeyType.GetPrim() {
+	case consts.NAT, consts.INT, consts.MUTEZ, consts.TIMESTAMP, consts.ADDRESS, consts.KEYHASH, consts.STRING, consts.BYTES:
+		return true
+	default:
+		return false
+	}
+}
+
+// KeyFromString - parses big map key of ordered type from string. Timestamp can be passed in RFC3339 format or as unix time.
+func (m *BigMap) KeyFromString(value string) (Node, error) {
+	var node base.Node
+	switch m.KeyType.GetPrim() {
+	case consts.NAT, consts.INT, consts.MUTEZ:
+		i, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return nil, errors.Wrapf(consts.ErrInvalidType, "invalid %s key: %s", m.KeyType.GetPrim(), value)
+		}
+		node.IntValue = &types.BigInt{Int: i}
+	case consts.BYTES:
+		node.BytesValue = &value
+	case consts.TIMESTAMP, consts.ADDRESS, consts.KEYHASH, consts.STRING:
+		node.StringValue = &value
+	default:
+		return nil, errors.Wrapf(consts.ErrTypeIsNotComparable, "big map key %s", m.KeyType.GetPrim())
+	}
+
+	key := Copy(m.KeyType)
+	if err := key.ParseValue(&node); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
 // AddDiffs -
 func (m *BigMap) AddDiffs(diffs ...*types.BigMapDiff) {
 	m.diffs = append(m.diffs, diffs...)
@@ -480,6 +534,11 @@ func (m *BigMap) FindPointers() map[int64]*BigMap {
 	return res
 }
 
+// FindTickets - tickets inside big map are not collected. They are tracked by big map diffs.
+func (m *BigMap) FindTickets() []*Ticket {
+	return nil
+}
+
 // Range -
 func (m *BigMap) Range(handler func(node Node) error) error {
 	if err := handler(m); err != nil {
@@ -0,0 +1,139 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getBigMapType(t *testing.T, tree string) *BigMap {
+	typ, err := NewTypedAstFromString(tree)
+	if err != nil {
+		t.Fatalf("NewTypedAstFromString() error = %v", err)
+	}
+	bigMap, ok := typ.Nodes[0].(*BigMap)
+	if !ok {
+		t.Fatalf("invalid big map type: %T", typ.Nodes[0])
+	}
+	return bigMap
+}
+
+func TestBigMap_KeyFromJSONSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		tree    string
+		data    map[string]interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "nat",
+			tree: `[{"prim":"big_map","args":[{"prim":"nat","annots":["%token_id"]},{"prim":"nat"}]}]`,
+			data: map[string]interface{}{"token_id": float64(505506)},
+			want: "exprufzwVGdAX7zG91UpiAkR2yVxEDE75tHD5YgSBmYMUx22teZTCM",
+		}, {
+			name: "string",
+			tree: `[{"prim":"big_map","args":[{"prim":"string","annots":["%key"]},{"prim":"bytes"}]}]`,
+			data: map[string]interface{}{"key": "metadata"},
+			want: "exprtuf4ctHCKfnRvAxgU8rMeqPzfb8D8e51GWR3iHkoWsFBxD8u9h",
+		}, {
+			name: "address",
+			tree: `[{"prim":"big_map","args":[{"prim":"address","annots":["%owner"]},{"prim":"nat"}]}]`,
+			data: map[string]interface{}{"owner": "tz1MsmYzmqxHs9trE1qQugZxxcLPqAXdQaX9"},
+			want: "expru2YV8AanTTUSV4K21P7X4DzbuWQFVk7NewDuP1A5uamffiiFA3",
+		}, {
+			name:    "invalid address",
+			tree:    `[{"prim":"big_map","args":[{"prim":"address","annots":["%owner"]},{"prim":"nat"}]}]`,
+			data:    map[string]interface{}{"owner": "invalid"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bigMap := getBigMapType(t, tt.tree)
+			key, err := bigMap.KeyFromJSONSchema(tt.data)
+			if err == nil {
+				var hash string
+				hash, err = BigMapKeyHashFromNode(key)
+				if err == nil {
+					assert.Equal(t, tt.want, hash)
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("KeyFromJSONSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBigMap_KeyFromString(t *testing.T) {
+	tests := []struct {
+		name    string
+		tree    string
+		x       string
+		y       string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "nat",
+			tree: `[{"prim":"big_map","args":[{"prim":"nat"},{"prim":"nat"}]}]`,
+			x:    "10",
+			y:    "9",
+			want: 1,
+		}, {
+			name: "timestamp",
+			tree: `[{"prim":"big_map","args":[{"prim":"timestamp"},{"prim":"nat"}]}]`,
+			x:    "2021-01-01T00:00:00Z",
+			y:    "1609459200",
+			want: 0,
+		}, {
+			name: "address",
+			tree: `[{"prim":"big_map","args":[{"prim":"address"},{"prim":"nat"}]}]`,
+			x:    "tz1MsmYzmqxHs9trE1qQugZxxcLPqAXdQaX9",
+			y:    "tz1eLWfccL46VAUjtyz9kEKgzuKnwyZH4rTA",
+			want: -1,
+		}, {
+			name:    "invalid nat",
+			tree:    `[{"prim":"big_map","args":[{"prim":"nat"},{"prim":"nat"}]}]`,
+			x:       "abc",
+			y:       "1",
+			wantErr: true,
+		}, {
+			name:    "pair",
+			tree:    `[{"prim":"big_map","args":[{"prim":"pair","args":[{"prim":"address"},{"prim":"nat"}]},{"prim":"nat"}]}]`,
+			x:       "1",
+			y:       "1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bigMap := getBigMapType(t, tt.tree)
+			x, err := bigMap.KeyFromString(tt.x)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("KeyFromString() error = %v", err)
+				}
+				return
+			}
+			y, err := bigMap.KeyFromString(tt.y)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("KeyFromString() error = %v", err)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Errorf("KeyFromString() error is expected")
+				return
+			}
+			got, err := x.Compare(y)
+			if err != nil {
+				t.Errorf("Compare() error = %v", err)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,8 +1,13 @@
 package ast
 
 import (
+	stdJSON "encoding/json"
+	"sort"
+	"sync"
+
 	"github.com/baking-bad/bcdhub/internal/bcd/ast/interfaces"
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/pkg/errors"
 )
 
 // contract tags
@@ -17,29 +22,58 @@ const (
 
 type contractInterface struct {
 	Entrypoints map[string]Node
+	Optional    map[string]Node
+	Storage     map[string]Node
 	IsRoot      bool
+	MinScore    float64
 }
 
-var interfaceTrees = map[string]contractInterface{}
+var interfaceTrees = struct {
+	trees   map[string]contractInterface
+	version int
+	mx      sync.RWMutex
+}{
+	version: -1,
+}
 
-// FindContractInterfaces -
-func FindContractInterfaces(tree *TypedAst) []string {
-	if initInterfaceTrees() != nil {
+// InterfaceMatch - contract interface implemented by contract. `Score` is share of interface entrypoints and storage fields which are found in contract.
+type InterfaceMatch struct {
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+// MatchContractInterfaces - returns interfaces implemented by contract with `parameter` and `storage` types sorted by name. `storage` may be nil, then interfaces with storage fields are not matched.
+func MatchContractInterfaces(parameter, storage *TypedAst) []InterfaceMatch {
+	trees, err := getInterfaceTrees()
+	if err != nil {
 		return nil
 	}
-	tags := make([]string, 0)
-	for tag := range interfaceTrees {
-		if FindContractInterface(tree, tag) {
-			tags = append(tags, tag)
+	matches := make([]InterfaceMatch, 0)
+	for name, ci := range trees {
+		if score, ok := matchInterface(parameter, storage, ci); ok {
+			matches = append(matches, InterfaceMatch{
+				Name:  name,
+				Score: score,
+			})
 		}
 	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Name < matches[j].Name
+	})
+	return matches
+}
+
+// FindContractInterfaces -
+func FindContractInterfaces(tree *TypedAst) []string {
+	matches := MatchContractInterfaces(tree, nil)
+	tags := make([]string, len(matches))
+	for i := range matches {
+		tags[i] = matches[i].Name
+	}
 	return tags
 }
 
 func findViewContractInterfaces(tree *TypedAst) []string {
-	if initInterfaceTrees() != nil {
-		return nil
-	}
 	tags := make([]string, 0)
 	for _, tag := range []string{ContractTagViewNat, ContractTagViewAddress, ContractTagViewBalanceOf} {
 		if FindContractInterface(tree, tag) {
@@ -52,46 +86,78 @@ func findViewContractInterfaces(tree *TypedAst) []string {
 
 // FindContractInterface -
 func FindContractInterface(tree *TypedAst, name string) bool {
-	if initInterfaceTrees() != nil {
+	trees, err := getInterfaceTrees()
+	if err != nil {
 		return false
 	}
-	if contract, ok := interfaceTrees[name]; ok {
-		return findEntrypoints(tree, contract, nil)
+	if contract, ok := trees[name]; ok {
+		_, ok := matchInterface(tree, nil, contract)
+		return ok
 	}
 	return false
 }
 
-func findEntrypoints(tree *TypedAst, ci contractInterface, exists map[string]struct{}) bool {
+func matchInterface(parameter, storage *TypedAst, ci contractInterface) (float64, bool) {
 	if ci.IsRoot {
-		if len(tree.Nodes) != 1 || len(ci.Entrypoints) != 1 {
-			return false
+		if len(parameter.Nodes) != 1 || len(ci.Entrypoints) != 1 {
+			return 0, false
 		}
-		return tree.Nodes[0].EqualType(ci.Entrypoints[consts.DefaultEntrypoint])
+		return 1, parameter.Nodes[0].EqualType(ci.Entrypoints[consts.DefaultEntrypoint])
 	}
 
-	if exists == nil {
-		exists = make(map[string]struct{})
+	total := len(ci.Entrypoints) + len(ci.Optional) + len(ci.Storage)
+	if total == 0 {
+		return 0, false
+	}
+
+	required := make(map[string]struct{})
+	findEntrypoints(parameter, ci.Entrypoints, required)
+	complete := len(required) == len(ci.Entrypoints)
+	matched := len(required)
+
+	if len(ci.Optional) > 0 {
+		optional := make(map[string]struct{})
+		findEntrypoints(parameter, ci.Optional, optional)
+		matched += len(optional)
 	}
 
+	for name, typ := range ci.Storage {
+		if storage != nil {
+			if node := storage.FindByName(name, false); node != nil && node.EqualType(typ) {
+				matched++
+				continue
+			}
+		}
+		complete = false
+	}
+
+	score := float64(matched) / float64(total)
+	if ci.MinScore > 0 {
+		return score, score >= ci.MinScore
+	}
+	return score, complete
+}
+
+func findEntrypoints(tree *TypedAst, entrypoints map[string]Node, exists map[string]struct{}) bool {
 	for i := range tree.Nodes {
 		if tree.Nodes[i].IsPrim(consts.OR) {
 			or := tree.Nodes[i].(*Or)
 			orTree := &TypedAst{
 				Nodes: []Node{or.LeftType, or.RightType},
 			}
-			if findEntrypoints(orTree, ci, exists) {
+			if findEntrypoints(orTree, entrypoints, exists) {
 				return true
 			}
 			continue
 		}
 
-		for name, subTree := range ci.Entrypoints {
+		for name, subTree := range entrypoints {
 			if _, ok := exists[name]; !ok && tree.Nodes[i].EqualType(subTree) {
 				exists[name] = struct{}{}
 			}
 		}
 
-		if len(exists) == len(ci.Entrypoints) {
+		if len(exists) == len(entrypoints) {
 			return true
 		}
 	}
@@ -99,33 +165,86 @@ func findEntrypoints(tree *TypedAst, ci contractInterface, exists map[string]str
 	return false
 }
 
-func initInterfaceTrees() error {
-	if len(interfaceTrees) > 0 {
-		return nil
+// getInterfaceTrees - returns typed trees of all contract interfaces. Trees are rebuilt if interfaces registry was changed.
+func getInterfaceTrees() (map[string]contractInterface, error) {
+	version := interfaces.Version()
+
+	interfaceTrees.mx.RLock()
+	if interfaceTrees.version == version {
+		trees := interfaceTrees.trees
+		interfaceTrees.mx.RUnlock()
+		return trees, nil
 	}
+	interfaceTrees.mx.RUnlock()
 
 	all, err := interfaces.GetAll()
 	if err != nil {
-		return err
+		return nil, err
 	}
+
+	trees := make(map[string]contractInterface)
 	for name, data := range all {
-		ci := contractInterface{
-			Entrypoints: make(map[string]Node),
-			IsRoot:      data.IsRoot,
+		ci, err := buildContractInterface(data)
+		if err != nil {
+			// interfaces with invalid types are rejected by LoadContractInterfaces, so they are skipped here
+			continue
 		}
+		trees[name] = ci
+	}
 
-		for key, str := range data.Entrypoints {
-			var tree UntypedAST
-			if err := json.Unmarshal(str, &tree); err != nil {
-				return err
-			}
-			t, err := tree.ToTypedAST()
-			if err != nil {
-				return err
-			}
-			ci.Entrypoints[key] = t.Nodes[0]
+	interfaceTrees.mx.Lock()
+	interfaceTrees.trees = trees
+	interfaceTrees.version = version
+	interfaceTrees.mx.Unlock()
+	return trees, nil
+}
+
+// LoadContractInterfaces - registers contract interfaces from JSON files of `dir` and checks that their types are valid Michelson types
+func LoadContractInterfaces(dir string) (int, error) {
+	count, err := interfaces.Load(dir)
+	if err != nil {
+		return count, err
+	}
+	all, err := interfaces.GetAll()
+	if err != nil {
+		return count, err
+	}
+	for name, data := range all {
+		if _, err := buildContractInterface(data); err != nil {
+			return count, errors.Wrapf(err, "invalid contract interface '%s'", name)
+		}
+	}
+	return count, nil
+}
+
+func buildContractInterface(data interfaces.ContractInterface) (ci contractInterface, err error) {
+	ci.IsRoot = data.IsRoot
+	ci.MinScore = data.MinScore
+	if ci.Entrypoints, err = buildInterfaceTrees(data.Required()); err != nil {
+		return
+	}
+	if ci.Optional, err = buildInterfaceTrees(data.Optional); err != nil {
+		return
+	}
+	ci.Storage, err = buildInterfaceTrees(data.Storage)
+	return
+}
+
+func buildInterfaceTrees(types map[string]stdJSON.RawMessage) (map[string]Node, error) {
+	result := make(map[string]Node, len(types))
+	for key, str := range types {
+		var tree UntypedAST
+		if err := json.Unmarshal(str, &tree); err != nil {
+			return nil, err
+		}
+		t, err := tree.ToTypedAST()
+		if err != nil {
+			return nil, err
+		}
+		if len(t.Nodes) != 1 {
+			return nil, errors.Errorf("invalid type of '%s'", key)
 		}
-		interfaceTrees[name] = ci
+		result[key] = t.Nodes[0]
 	}
-	return nil
+	return result, nil
 }
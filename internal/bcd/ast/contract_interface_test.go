@@ -1,8 +1,10 @@
 package ast
 
 import (
+	stdJSON "encoding/json"
 	"testing"
 
+	"github.com/baking-bad/bcdhub/internal/bcd/ast/interfaces"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -126,3 +128,94 @@ func TestFindContractInterfaces(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchContractInterfaces(t *testing.T) {
+	for _, ci := range []interfaces.ContractInterface{
+		{
+			Name: "test_dex",
+			Entrypoints: map[string]stdJSON.RawMessage{
+				"swap": []byte(`{"prim":"pair","args":[{"prim":"nat"},{"prim":"address"}]}`),
+			},
+			Optional: map[string]stdJSON.RawMessage{
+				"addLiquidity":    []byte(`{"prim":"nat"}`),
+				"removeLiquidity": []byte(`{"prim":"pair","args":[{"prim":"nat"},{"prim":"nat"}]}`),
+			},
+			Views: map[string]interfaces.View{
+				"getPrice": {
+					Parameter: []byte(`{"prim":"unit"}`),
+					Return:    []byte(`{"prim":"nat"}`),
+				},
+			},
+			Storage: map[string]stdJSON.RawMessage{
+				"pool": []byte(`{"prim":"big_map","args":[{"prim":"address"},{"prim":"nat"}]}`),
+			},
+		}, {
+			Name: "test_partial",
+			Entrypoints: map[string]stdJSON.RawMessage{
+				"a": []byte(`{"prim":"string"}`),
+				"b": []byte(`{"prim":"bytes"}`),
+				"c": []byte(`{"prim":"mutez"}`),
+				"d": []byte(`{"prim":"int"}`),
+			},
+			MinScore: 0.5,
+		},
+	} {
+		if err := interfaces.Register(ci); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		parameter string
+		storage   string
+		want      []InterfaceMatch
+	}{
+		{
+			name:      "dex with optional entrypoint",
+			parameter: `{"prim":"or","args":[{"prim":"pair","args":[{"prim":"nat"},{"prim":"address"}],"annots":["%swap"]},{"prim":"or","args":[{"prim":"pair","args":[{"prim":"unit"},{"prim":"contract","args":[{"prim":"nat"}]}],"annots":["%getPrice"]},{"prim":"nat","annots":["%addLiquidity"]}]}]}`,
+			storage:   `{"prim":"pair","args":[{"prim":"big_map","args":[{"prim":"address"},{"prim":"nat"}],"annots":["%pool"]},{"prim":"nat","annots":["%fee"]}]}`,
+			want:      []InterfaceMatch{{Name: "test_dex", Score: 0.8}},
+		}, {
+			name:      "dex without storage field",
+			parameter: `{"prim":"or","args":[{"prim":"pair","args":[{"prim":"nat"},{"prim":"address"}],"annots":["%swap"]},{"prim":"pair","args":[{"prim":"unit"},{"prim":"contract","args":[{"prim":"nat"}]}],"annots":["%getPrice"]}]}`,
+			storage:   `{"prim":"big_map","args":[{"prim":"address"},{"prim":"nat"}],"annots":["%ledger"]}`,
+			want:      []InterfaceMatch{},
+		}, {
+			name:      "dex without view",
+			parameter: `{"prim":"or","args":[{"prim":"pair","args":[{"prim":"nat"},{"prim":"address"}],"annots":["%swap"]},{"prim":"nat","annots":["%addLiquidity"]}]}`,
+			storage:   `{"prim":"big_map","args":[{"prim":"address"},{"prim":"nat"}],"annots":["%pool"]}`,
+			want:      []InterfaceMatch{},
+		}, {
+			name:      "partial match",
+			parameter: `{"prim":"or","args":[{"prim":"string","annots":["%a"]},{"prim":"or","args":[{"prim":"bytes","annots":["%b"]},{"prim":"unit","annots":["%e"]}]}]}`,
+			storage:   `{"prim":"unit"}`,
+			want:      []InterfaceMatch{{Name: "test_partial", Score: 0.5}},
+		}, {
+			name:      "partial match below min score",
+			parameter: `{"prim":"or","args":[{"prim":"string","annots":["%a"]},{"prim":"unit","annots":["%e"]}]}`,
+			storage:   `{"prim":"unit"}`,
+			want:      []InterfaceMatch{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parameter := getTypedTree(t, tt.parameter)
+			storage := getTypedTree(t, tt.storage)
+			got := MatchContractInterfaces(parameter, storage)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func getTypedTree(t *testing.T, data string) *TypedAst {
+	var tree UntypedAST
+	if err := json.UnmarshalFromString(data, &tree); err != nil {
+		t.Fatalf("UnmarshalFromString() error = %v", err)
+	}
+	typedTree, err := tree.ToTypedAST()
+	if err != nil {
+		t.Fatalf("ToTypedAST() error = %v", err)
+	}
+	return typedTree
+}
@@ -384,6 +384,11 @@ func (d *Default) FindPointers() map[int64]*BigMap {
 	return nil
 }
 
+// FindTickets -
+func (d *Default) FindTickets() []*Ticket {
+	return nil
+}
+
 // Range -
 func (d *Default) Range(handler func(node Node) error) error {
 	return handler(d)
@@ -40,6 +40,7 @@ type Value interface {
 	EnrichBigMap(bmd []*types.BigMapDiff) error
 	Equal(second Node) bool
 	FindPointers() map[int64]*BigMap
+	FindTickets() []*Ticket
 	FromJSONSchema(data map[string]interface{}) error
 	GetValue() interface{}
 	ParseValue(node *base.Node) error
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	stdJSON "encoding/json"
+	"sort"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
 	jsoniter "github.com/json-iterator/go"
@@ -25,40 +26,132 @@ type Contract interface {
 	GetContractInterface() string
 }
 
-// ContractInterface -
+// ContractInterface - description of contract interface. Contract implements interface if its parameter has all `Entrypoints` and `Views` and its storage has all `Storage` fields.
+// If `MinScore` is set, contract implements interface if share of matched entrypoints, views, optional entrypoints and storage fields is not less than `MinScore`.
 type ContractInterface struct {
-	IsRoot      bool `json:"is_root,omitempty"`
-	Entrypoints map[string]stdJSON.RawMessage
+	Name        string                        `json:"name,omitempty"`
+	Description string                        `json:"description,omitempty"`
+	IsRoot      bool                          `json:"is_root,omitempty"`
+	Entrypoints map[string]stdJSON.RawMessage `json:"entrypoints,omitempty"`
+	Optional    map[string]stdJSON.RawMessage `json:"optional_entrypoints,omitempty"`
+	Views       map[string]View               `json:"views,omitempty"`
+	Storage     map[string]stdJSON.RawMessage `json:"storage,omitempty"`
+	MinScore    float64                       `json:"min_score,omitempty"`
 }
 
-// GetAll - receives all contract interfaces
+// View - callback view entrypoint which receives `Parameter` and sends `Return` to contract
+type View struct {
+	Parameter stdJSON.RawMessage `json:"parameter"`
+	Return    stdJSON.RawMessage `json:"return"`
+}
+
+// Type - returns type of view entrypoint: `pair parameter (contract return)`
+func (v View) Type() stdJSON.RawMessage {
+	return stdJSON.RawMessage(`{"prim":"pair","args":[` + string(v.Parameter) + `,{"prim":"contract","args":[` + string(v.Return) + `]}]}`)
+}
+
+// Required - returns types of entrypoints and views which contract has to implement
+func (ci ContractInterface) Required() map[string]stdJSON.RawMessage {
+	required := make(map[string]stdJSON.RawMessage, len(ci.Entrypoints)+len(ci.Views))
+	for name, typ := range ci.Entrypoints {
+		required[name] = typ
+	}
+	for name, view := range ci.Views {
+		required[name] = view.Type()
+	}
+	return required
+}
+
+// Methods - returns sorted names of all entrypoints of interface
+func (ci ContractInterface) Methods() []string {
+	methods := make([]string, 0, len(ci.Entrypoints)+len(ci.Optional)+len(ci.Views))
+	for name := range ci.Entrypoints {
+		methods = append(methods, name)
+	}
+	for name := range ci.Optional {
+		methods = append(methods, name)
+	}
+	for name := range ci.Views {
+		methods = append(methods, name)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// GetAll - receives all contract interfaces: built-in and registered ones
 func GetAll() (map[string]ContractInterface, error) {
 	res := make(map[string]ContractInterface)
 	for _, i := range all {
-		name := i.GetName()
-		str := i.GetContractInterface()
-		var ci ContractInterface
-		if err := json.UnmarshalFromString(str, &ci); err != nil {
+		ci, err := parseBuiltIn(i)
+		if err != nil {
 			return nil, err
 		}
+		res[ci.Name] = ci
+	}
+
+	registry.mx.RLock()
+	for name, ci := range registry.interfaces {
 		res[name] = ci
 	}
+	registry.mx.RUnlock()
 	return res, nil
 }
 
+// Get - returns contract interface by name
+func Get(name string) (ContractInterface, error) {
+	if i, ok := all[name]; ok {
+		return parseBuiltIn(i)
+	}
+
+	registry.mx.RLock()
+	defer registry.mx.RUnlock()
+	if ci, ok := registry.interfaces[name]; ok {
+		return ci, nil
+	}
+	return ContractInterface{}, errors.Errorf("Unknwon interface name: %s", name)
+}
+
 // GetMethods - returns list of interface methods
 func GetMethods(name string) ([]string, error) {
-	i, ok := all[name]
-	if !ok {
-		return nil, errors.Errorf("Unknwon interface name: %s", name)
+	ci, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return ci.Methods(), nil
+}
+
+// Names - returns sorted names of all contract interfaces
+func Names() []string {
+	registry.mx.RLock()
+	names := make([]string, 0, len(all)+len(registry.interfaces))
+	for name := range registry.interfaces {
+		names = append(names, name)
+	}
+	registry.mx.RUnlock()
+
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Has - returns true if interface with `name` is built-in or registered
+func Has(name string) bool {
+	if _, ok := all[name]; ok {
+		return true
 	}
+	registry.mx.RLock()
+	defer registry.mx.RUnlock()
+	_, ok := registry.interfaces[name]
+	return ok
+}
+
+func parseBuiltIn(i Contract) (ContractInterface, error) {
 	var ci ContractInterface
 	if err := json.UnmarshalFromString(i.GetContractInterface(), &ci); err != nil {
-		return nil, err
-	}
-	methods := make([]string, len(ci.Entrypoints))
-	for entrypoint := range ci.Entrypoints {
-		methods = append(methods, entrypoint)
+		return ci, err
 	}
-	return methods, nil
+	ci.Name = i.GetName()
+	return ci, nil
 }
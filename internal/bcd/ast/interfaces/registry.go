@@ -0,0 +1,128 @@
+package interfaces
+
+import (
+	stdJSON "encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/pkg/errors"
+)
+
+// Directory - name of directory in share path with contract interface files
+const Directory = "interfaces"
+
+var registry = struct {
+	interfaces map[string]ContractInterface
+	version    int
+	mx         sync.RWMutex
+}{
+	interfaces: make(map[string]ContractInterface),
+}
+
+var nameRegexp = regexp.MustCompile(`^[a-z0-9_\-]+$`)
+
+// reservedNames - contract tags which are set without interfaces
+var reservedNames = []string{
+	consts.DelegatorTag, consts.SaplingTag, consts.UpgradableTag, consts.MultisigTag, consts.NFTLedgerTag,
+}
+
+// Register - adds contract interface to registry. Interface with the same name is replaced. Built-in interfaces can not be replaced.
+func Register(ci ContractInterface) error {
+	if err := validate(ci); err != nil {
+		return err
+	}
+
+	registry.mx.Lock()
+	registry.interfaces[ci.Name] = ci
+	registry.version++
+	registry.mx.Unlock()
+	return nil
+}
+
+// Load - registers contract interfaces from all JSON files of `dir`. Every file contains one interface. Missing directory is not an error.
+func Load(dir string) (int, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+	sort.Strings(files)
+
+	for i := range files {
+		data, err := ioutil.ReadFile(files[i])
+		if err != nil {
+			return i, err
+		}
+		var ci ContractInterface
+		if err := json.Unmarshal(data, &ci); err != nil {
+			return i, errors.Wrap(err, files[i])
+		}
+		if err := Register(ci); err != nil {
+			return i, errors.Wrap(err, files[i])
+		}
+	}
+	return len(files), nil
+}
+
+// Version - returns counter of registry changes. It's used to rebuild cached interface trees.
+func Version() int {
+	registry.mx.RLock()
+	defer registry.mx.RUnlock()
+	return registry.version
+}
+
+func validate(ci ContractInterface) error {
+	if !nameRegexp.MatchString(ci.Name) {
+		return errors.Errorf("invalid interface name '%s': only lower case letters, digits, '_' and '-' are allowed", ci.Name)
+	}
+	if _, ok := all[ci.Name]; ok {
+		return errors.Errorf("built-in interface '%s' can not be replaced", ci.Name)
+	}
+	for i := range reservedNames {
+		if reservedNames[i] == ci.Name {
+			return errors.Errorf("interface name '%s' is reserved for contract tag", ci.Name)
+		}
+	}
+	if ci.MinScore < 0 || ci.MinScore > 1 {
+		return errors.Errorf("min_score of '%s' should be in range [0, 1]", ci.Name)
+	}
+	if ci.IsRoot {
+		if len(ci.Entrypoints) != 1 || len(ci.Optional) > 0 || len(ci.Views) > 0 || len(ci.Storage) > 0 {
+			return errors.Errorf("root interface '%s' should have only '%s' entrypoint", ci.Name, consts.DefaultEntrypoint)
+		}
+		if _, ok := ci.Entrypoints[consts.DefaultEntrypoint]; !ok {
+			return errors.Errorf("root interface '%s' should have only '%s' entrypoint", ci.Name, consts.DefaultEntrypoint)
+		}
+	}
+	if len(ci.Entrypoints)+len(ci.Optional)+len(ci.Views)+len(ci.Storage) == 0 {
+		return errors.Errorf("interface '%s' is empty", ci.Name)
+	}
+	for name, view := range ci.Views {
+		if len(view.Parameter) == 0 || len(view.Return) == 0 {
+			return errors.Errorf("view '%s' of '%s' should have parameter and return types", name, ci.Name)
+		}
+	}
+	for _, types := range []map[string]stdJSON.RawMessage{ci.Required(), ci.Optional, ci.Storage} {
+		for name, typ := range types {
+			if err := validateType(typ); err != nil {
+				return errors.Wrapf(err, "invalid type of '%s' in '%s'", name, ci.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func validateType(data []byte) error {
+	var node base.Node
+	if err := json.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	if node.Prim == "" || node.Prim == consts.PrimArray {
+		return errors.New("type should be Michelson primitive")
+	}
+	return nil
+}
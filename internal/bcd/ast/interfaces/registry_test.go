@@ -0,0 +1,106 @@
+package interfaces
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "interfaces")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"oracle.json": `{
+			"name": "test_oracle",
+			"description": "Price oracle",
+			"entrypoints": {
+				"update": {"prim": "map", "args": [{"prim": "string"}, {"prim": "nat"}]}
+			},
+			"views": {
+				"get": {"parameter": {"prim": "string"}, "return": {"prim": "nat"}}
+			}
+		}`,
+		"readme.md": `not an interface`,
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	count, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	assert.Equal(t, 1, count)
+	assert.True(t, Has("test_oracle"))
+	assert.Contains(t, Names(), "test_oracle")
+
+	methods, err := GetMethods("test_oracle")
+	if err != nil {
+		t.Fatalf("GetMethods() error = %v", err)
+	}
+	assert.Equal(t, []string{"get", "update"}, methods)
+
+	ci, err := Get("test_oracle")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	assert.JSONEq(t, `{"prim":"pair","args":[{"prim":"string"},{"prim":"contract","args":[{"prim":"nat"}]}]}`, string(ci.Required()["get"]))
+
+	count, err = Load(filepath.Join(dir, "missing"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestRegister_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		ci   string
+	}{
+		{
+			name: "invalid name",
+			ci:   `{"name": "My DEX", "entrypoints": {"swap": {"prim": "nat"}}}`,
+		}, {
+			name: "built-in",
+			ci:   `{"name": "fa2", "entrypoints": {"transfer": {"prim": "nat"}}}`,
+		}, {
+			name: "reserved",
+			ci:   `{"name": "multisig", "entrypoints": {"main": {"prim": "nat"}}}`,
+		}, {
+			name: "empty",
+			ci:   `{"name": "empty"}`,
+		}, {
+			name: "min score",
+			ci:   `{"name": "score", "entrypoints": {"swap": {"prim": "nat"}}, "min_score": 2}`,
+		}, {
+			name: "root with several entrypoints",
+			ci:   `{"name": "root", "is_root": true, "entrypoints": {"default": {"prim": "nat"}, "swap": {"prim": "nat"}}}`,
+		}, {
+			name: "view without return",
+			ci:   `{"name": "view", "views": {"get": {"parameter": {"prim": "nat"}}}}`,
+		}, {
+			name: "invalid type",
+			ci:   `{"name": "invalid", "storage": {"ledger": [{"prim": "nat"}]}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ci ContractInterface
+			if err := json.UnmarshalFromString(tt.ci, &ci); err != nil {
+				t.Fatal(err)
+			}
+			assert.Error(t, Register(ci))
+			if ci.Name != "fa2" {
+				assert.False(t, Has(ci.Name))
+			}
+		})
+	}
+}
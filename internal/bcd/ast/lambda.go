@@ -7,6 +7,7 @@ import (
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
 	"github.com/baking-bad/bcdhub/internal/bcd/forge"
 	"github.com/baking-bad/bcdhub/internal/bcd/formatter"
+	"github.com/baking-bad/bcdhub/internal/bcd/lambda"
 	"github.com/baking-bad/bcdhub/internal/bcd/translator"
 )
 
@@ -17,6 +18,24 @@ type Lambda struct {
 	ReturnType Node
 }
 
+// LambdaOperation - operation which is emitted by lambda. Delegation without `Delegate` removes delegate.
+type LambdaOperation struct {
+	Kind        string      `json:"kind"`
+	Destination string      `json:"destination,omitempty"`
+	Entrypoint  string      `json:"entrypoint,omitempty"`
+	Amount      *int64      `json:"amount,omitempty"`
+	Parameters  *MiguelNode `json:"parameters,omitempty"`
+	Delegate    *string     `json:"delegate,omitempty"`
+}
+
+// LambdaOperations - operations which are returned by lambda. If `Complete` is false lambda can't be evaluated statically:
+// operations are listed in order of creation and some of their fields are unknown. `Failed` is true if lambda always fails.
+type LambdaOperations struct {
+	Operations []LambdaOperation `json:"operations"`
+	Complete   bool              `json:"complete"`
+	Failed     bool              `json:"failed"`
+}
+
 // NewLambda -
 func NewLambda(depth int) *Lambda {
 	return &Lambda{
@@ -88,13 +107,82 @@ func (l *Lambda) ToMiguel() (*MiguelNode, error) {
 	}
 	name := l.GetTypeName()
 	return &MiguelNode{
-		Value: formatted,
-		Type:  l.Prim,
-		Prim:  l.Prim,
-		Name:  &name,
+		Value:  formatted,
+		Type:   l.Prim,
+		Prim:   l.Prim,
+		Name:   &name,
+		Lambda: l.decodeOperations(),
 	}, nil
 }
 
+// decodeOperations - extracts operations from lambda returning `operation` or `list operation`. Returns nil if lambda returns something else or can't be decoded.
+func (l *Lambda) decodeOperations() *LambdaOperations {
+	if !l.returnsOperations() {
+		return nil
+	}
+	s, ok := l.Value.(string)
+	if !ok || s == "" {
+		return nil
+	}
+	var code base.Node
+	if err := json.UnmarshalFromString(s, &code); err != nil {
+		return nil
+	}
+	var input *base.Node
+	if l.InputType.GetPrim() == consts.UNIT {
+		input = &base.Node{Prim: consts.Unit}
+	}
+	result, err := lambda.Decode(&code, input)
+	if err != nil {
+		return nil
+	}
+
+	decoded := &LambdaOperations{
+		Operations: make([]LambdaOperation, len(result.Operations)),
+		Complete:   result.Complete,
+		Failed:     result.Failed,
+	}
+	for i, op := range result.Operations {
+		decoded.Operations[i] = LambdaOperation{
+			Kind:        op.Kind,
+			Destination: op.Destination,
+			Entrypoint:  op.Entrypoint,
+			Amount:      op.Amount,
+			Delegate:    op.Delegate,
+		}
+		if op.ParameterType != nil && op.Parameter != nil {
+			decoded.Operations[i].Parameters = newLambdaParameters(op.ParameterType, op.Parameter)
+		}
+	}
+	return decoded
+}
+
+func (l *Lambda) returnsOperations() bool {
+	switch typ := l.ReturnType.(type) {
+	case *Operation:
+		return true
+	case *List:
+		return typ.Type.GetPrim() == consts.OPERATION
+	}
+	return false
+}
+
+// newLambdaParameters - returns typed parameters of operation emitted by lambda or nil if they don't match type
+func newLambdaParameters(typ, value *base.Node) *MiguelNode {
+	tree, err := UntypedAST{typ}.ToTypedAST()
+	if err != nil {
+		return nil
+	}
+	if err := tree.Settle(UntypedAST{value}); err != nil {
+		return nil
+	}
+	nodes, err := tree.ToMiguel()
+	if err != nil || len(nodes) != 1 {
+		return nil
+	}
+	return nodes[0]
+}
+
 // FromJSONSchema -
 func (l *Lambda) FromJSONSchema(data map[string]interface{}) error {
 	for key := range data {
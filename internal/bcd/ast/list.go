@@ -268,6 +268,15 @@ func (list *List) FindPointers() map[int64]*BigMap {
 	return res
 }
 
+// FindTickets -
+func (list *List) FindTickets() []*Ticket {
+	res := make([]*Ticket, 0)
+	for i := range list.Data {
+		res = append(res, list.Data[i].FindTickets()...)
+	}
+	return res
+}
+
 // Range -
 func (list *List) Range(handler func(node Node) error) error {
 	if err := handler(list); err != nil {
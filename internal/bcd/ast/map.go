@@ -440,6 +440,18 @@ func (m *Map) FindPointers() map[int64]*BigMap {
 	return res
 }
 
+// FindTickets -
+func (m *Map) FindTickets() []*Ticket {
+	res := make([]*Ticket, 0)
+	if err := m.Data.Range(func(_, value Comparable) (bool, error) {
+		res = append(res, value.(Node).FindTickets()...)
+		return false, nil
+	}); err != nil {
+		return nil
+	}
+	return res
+}
+
 // Range -
 func (m *Map) Range(handler func(node Node) error) error {
 	if err := handler(m); err != nil {
@@ -17,7 +17,8 @@ type MiguelNode struct {
 	DiffType string      `json:"diff_type,omitempty"`
 	Value    interface{} `json:"value,omitempty"`
 
-	Children []*MiguelNode `json:"children,omitempty"`
+	Children []*MiguelNode     `json:"children,omitempty"`
+	Lambda   *LambdaOperations `json:"lambda,omitempty"`
 }
 
 // String -
@@ -358,6 +358,14 @@ func (opt *Option) FindPointers() map[int64]*BigMap {
 	return nil
 }
 
+// FindTickets -
+func (opt *Option) FindTickets() []*Ticket {
+	if opt.Value == consts.Some {
+		return opt.Type.FindTickets()
+	}
+	return nil
+}
+
 // Range -
 func (opt *Option) Range(handler func(node Node) error) error {
 	if opt.Value == consts.SOME {
@@ -470,6 +470,17 @@ func (or *Or) FindPointers() map[int64]*BigMap {
 	return nil
 }
 
+// FindTickets -
+func (or *Or) FindTickets() []*Ticket {
+	switch or.key {
+	case leftKey:
+		return or.LeftType.FindTickets()
+	case rightKey:
+		return or.RightType.FindTickets()
+	}
+	return nil
+}
+
 // Range -
 func (or *Or) Range(handler func(node Node) error) error {
 	if err := or.LeftType.Range(handler); err != nil {
@@ -367,6 +367,15 @@ func (p *Pair) FindPointers() map[int64]*BigMap {
 	return res
 }
 
+// FindTickets -
+func (p *Pair) FindTickets() []*Ticket {
+	res := make([]*Ticket, 0)
+	for i := range p.Args {
+		res = append(res, p.Args[i].FindTickets()...)
+	}
+	return res
+}
+
 // Range -
 func (p *Pair) Range(handler func(node Node) error) error {
 	if err := handler(p); err != nil {
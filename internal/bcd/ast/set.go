@@ -271,6 +271,15 @@ func (set *Set) FindPointers() map[int64]*BigMap {
 	return res
 }
 
+// FindTickets -
+func (set *Set) FindTickets() []*Ticket {
+	res := make([]*Ticket, 0)
+	for i := range set.Data {
+		res = append(res, set.Data[i].FindTickets()...)
+	}
+	return res
+}
+
 // Range -
 func (set *Set) Range(handler func(node Node) error) error {
 	if err := handler(set); err != nil {
@@ -2,10 +2,13 @@ package ast
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/base"
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/forge"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 )
 
 // Ticket -
@@ -164,3 +167,36 @@ func (t *Ticket) FindByName(name string, isEntrypoint bool) Node {
 	}
 	return nil
 }
+
+// FindTickets -
+func (t *Ticket) FindTickets() []*Ticket {
+	return []*Ticket{t}
+}
+
+// Ticketer - returns address of contract which created the ticket. Value must be parsed.
+func (t *Ticket) Ticketer() (string, error) {
+	address := t.PairedType.(*Pair).Args[0].(*Address)
+	value, ok := address.Value.(string)
+	if !ok {
+		return "", consts.ErrInvalidType
+	}
+	if address.ValueKind == valueKindBytes {
+		return forge.UnforgeContract(value)
+	}
+	return value, nil
+}
+
+// Content - returns node with ticket's content. Value must be parsed.
+func (t *Ticket) Content() Node {
+	return t.PairedType.(*Pair).Args[1].(*Pair).Args[0]
+}
+
+// Amount - returns amount of ticket. Value must be parsed.
+func (t *Ticket) Amount() (*big.Int, error) {
+	nat := t.PairedType.(*Pair).Args[1].(*Pair).Args[1].(*Nat)
+	value, ok := nat.Value.(*types.BigInt)
+	if !ok {
+		return nil, consts.ErrInvalidType
+	}
+	return value.Int, nil
+}
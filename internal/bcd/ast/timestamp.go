@@ -126,8 +126,14 @@ func (t *Timestamp) Compare(second Comparable) (int, error) {
 	if !ok {
 		return 0, consts.ErrTypeIsNotComparable
 	}
-	ts := t.Value.(time.Time)
-	ts2 := secondItem.Value.(time.Time)
+	ts, ok := t.Value.(time.Time)
+	if !ok {
+		return 0, errors.Wrapf(consts.ErrTypeIsNotComparable, "Timestamp.Compare: %v", t.Value)
+	}
+	ts2, ok := secondItem.Value.(time.Time)
+	if !ok {
+		return 0, errors.Wrapf(consts.ErrTypeIsNotComparable, "Timestamp.Compare: %v", secondItem.Value)
+	}
 	switch {
 	case ts.Equal(ts2):
 		return 0, nil
@@ -6,6 +6,7 @@ const (
 	Origination    = "origination"
 	OriginationNew = "origination_new"
 	Delegation     = "delegation"
+	Reveal         = "reveal"
 	Migration      = "migration"
 )
 
@@ -176,8 +176,13 @@ func (p *Parser) parseParameter() error {
 	if err != nil {
 		return err
 	}
-	tags := ast.FindContractInterfaces(typedParamTree)
-	p.Tags.Append(tags...)
+	typedStorageTree, err := p.Code.StorageType()
+	if err != nil {
+		return err
+	}
+	for _, match := range ast.MatchContractInterfaces(typedParamTree, typedStorageTree) {
+		p.Tags.Add(match.Name)
+	}
 
 	return p.parse(p.Code.Parameter, p.handleParameterNode)
 }
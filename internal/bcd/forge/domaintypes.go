@@ -94,8 +94,11 @@ func UnforgeContract(str string) (string, error) {
 
 // PublicKey -
 func PublicKey(val string) ([]byte, error) {
-	prefix := val[4:]
-	decoded, err := encoding.DecodeBase58String(val)
+	if len(val) < 4 {
+		return nil, errors.Errorf("Invalid public key: %s", val)
+	}
+	prefix := val[:4]
+	decoded, err := encoding.DecodeBase58(val)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package forge
 
 import (
+	"encoding/hex"
 	"testing"
 )
 
@@ -189,3 +190,38 @@ func TestUnforgePublicKey(t *testing.T) {
 		})
 	}
 }
+
+func TestPublicKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		val     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "secp256k1",
+			val:  "sppk7c3Fz7QqhZqY2FZUWWAnDuqTwx4KwDjgFA4VeLPiV8n4tnbsVzG",
+			want: "0103682c3aaa998fd9adfe8111cd42cc0daedb5d97647e6020eb629fbc91b613f721",
+		}, {
+			name: "ed25519",
+			val:  "edpktxGsKjnk43ZZ7v6gJe6PFV85peHvoWqVUzDQjTfN8idYwVkBwN",
+			want: "0028fc6875ca69a6f5bde4f377bfcde72fd618bcfa52e7272c7b788d1165449eb4",
+		}, {
+			name:    "invalid prefix",
+			val:     "tz1LFEVYR7YRCxT6Nm3Zfjdnfj77xZqhbR5U",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PublicKey(tt.val)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PublicKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if hex.EncodeToString(got) != tt.want {
+				t.Errorf("PublicKey() = %x, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package forge
+
+import (
+	"fmt"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
+	"github.com/pkg/errors"
+)
+
+// Nat - unsigned zarith number. It's used in operation headers (fee, counter, limits and amounts).
+type Nat base.Node
+
+// NewNat -
+func NewNat() *Nat {
+	return &Nat{
+		IntValue: types.NewBigInt(0),
+	}
+}
+
+// NewNatFromInt64 -
+func NewNatFromInt64(val int64) *Nat {
+	return &Nat{
+		IntValue: types.NewBigInt(val),
+	}
+}
+
+// Unforge -
+func (val *Nat) Unforge(data []byte) (int, error) {
+	if val.IntValue == nil {
+		val.IntValue = types.NewBigInt(0)
+	}
+	val.IntValue.SetInt64(0)
+
+	shift := uint(0)
+	for i := range data {
+		part := types.NewBigInt(int64(data[i] & 0x7f))
+		part.Lsh(part.Int, shift)
+		val.IntValue.Or(val.IntValue.Int, part.Int)
+		if data[i] < 0x80 {
+			return i + 1, nil
+		}
+		shift += 7
+	}
+	return len(data), errors.Wrap(ErrTooFewBytes, fmt.Sprintf("Nat.Unforge: %x", data))
+}
+
+// Forge -
+func (val *Nat) Forge() ([]byte, error) {
+	if val.IntValue == nil || val.IntValue.Sign() < 0 {
+		return nil, errors.New("Invalid nat value")
+	}
+
+	value := types.NewBigInt(0)
+	value.Set(val.IntValue.Int)
+
+	data := make([]byte, 0)
+	for {
+		b := byte(value.Uint64() & 0x7f)
+		value.Rsh(value.Int, 7)
+		if value.Sign() == 0 {
+			return append(data, b), nil
+		}
+		data = append(data, b|0x80)
+	}
+}
@@ -0,0 +1,104 @@
+package forge
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestNat_Unforge(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    int
+		val     *big.Int
+		wantErr bool
+	}{
+		{
+			name: "zero",
+			data: []byte{0x00},
+			want: 1,
+			val:  big.NewInt(0),
+		}, {
+			name: "small nat",
+			data: []byte{0x7f, 0x01},
+			want: 1,
+			val:  big.NewInt(127),
+		}, {
+			name: "medium nat",
+			data: []byte{0x80, 0x01},
+			want: 2,
+			val:  big.NewInt(128),
+		}, {
+			name: "large nat",
+			data: []byte{0xc0, 0x84, 0x3d},
+			want: 3,
+			val:  big.NewInt(1000000),
+		}, {
+			name:    "too few bytes",
+			data:    []byte{0x80, 0x80},
+			want:    2,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val := NewNat()
+			got, err := val.Unforge(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Nat.Unforge() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Nat.Unforge() = %v, want %v", got, tt.want)
+				return
+			}
+			if !tt.wantErr && val.IntValue.Cmp(tt.val) != 0 {
+				t.Errorf("Nat.Unforge() value = %v, want %v", val.IntValue, tt.val)
+			}
+		})
+	}
+}
+
+func TestNat_Forge(t *testing.T) {
+	tests := []struct {
+		name    string
+		val     int64
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "zero",
+			val:  0,
+			want: []byte{0x00},
+		}, {
+			name: "small nat",
+			val:  127,
+			want: []byte{0x7f},
+		}, {
+			name: "medium nat",
+			val:  128,
+			want: []byte{0x80, 0x01},
+		}, {
+			name: "large nat",
+			val:  1000000,
+			want: []byte{0xc0, 0x84, 0x3d},
+		}, {
+			name:    "negative",
+			val:     -1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewNatFromInt64(tt.val).Forge()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Nat.Forge() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Nat.Forge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
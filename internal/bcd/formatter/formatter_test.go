@@ -134,3 +134,58 @@ func TestMichelineToMichelson(t *testing.T) {
 		})
 	}
 }
+
+func TestLocateNodes(t *testing.T) {
+	script := `[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"string"}]},{"prim":"code","args":[[{"prim":"CDR","annots":["@CDR"]},{"prim":"PUSH","args":[{"prim":"string"},{"string":"CDR"}]},{"prim":"CONCAT"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`
+	text, locations, err := LocateNodes(gjson.Parse(script), DefLineSize)
+	if err != nil {
+		t.Fatalf("LocateNodes error: %v", err)
+	}
+
+	want := map[int]NodeLocation{
+		6:  {Parent: 5, Sequence: true, Row: 3, StartColumn: 5, EndColumn: 5},
+		7:  {Prim: "CDR", Parent: 6, Row: 3, StartColumn: 7, EndColumn: 9},
+		8:  {Prim: "PUSH", Parent: 6, Row: 3, StartColumn: 18, EndColumn: 21},
+		10: {Parent: 8, Row: 3, StartColumn: 30, EndColumn: 34},
+		11: {Prim: "CONCAT", Parent: 6, Row: 3, StartColumn: 38, EndColumn: 43},
+	}
+	for id, location := range want {
+		if locations[id] != location {
+			t.Errorf("location of %d: got %v, want %v in\n%s", id, locations[id], location, text)
+		}
+	}
+	if len(locations) != 14 {
+		t.Errorf("located %d nodes, want 14", len(locations))
+	}
+}
+
+func TestLocateNodes_Contracts(t *testing.T) {
+	dirs, err := ioutil.ReadDir("./formatter_tests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range dirs {
+		tt := dir.Name()
+		t.Run(tt, func(t *testing.T) {
+			data, err := ioutil.ReadFile(fmt.Sprintf("./formatter_tests/%v/code_%v.json", tt, tt[:6]))
+			if err != nil {
+				t.Fatal(err)
+			}
+			text, locations, err := LocateNodes(gjson.ParseBytes(data), DefLineSize)
+			if err != nil {
+				t.Fatalf("LocateNodes error: %v", err)
+			}
+			rows := strings.Split(text, "\n")
+			for id, location := range locations {
+				if location.Prim == "" {
+					continue
+				}
+				row := []rune(rows[location.Row-1])
+				if got := string(row[location.StartColumn : location.EndColumn+1]); got != location.Prim {
+					t.Errorf("node %d: got %s, want %s", id, got, location.Prim)
+					return
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package formatter
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// NodeLocation - position of Micheline node in Michelson text. `Row` starts from 1, columns start from 0, `EndColumn` is inclusive.
+type NodeLocation struct {
+	Prim        string `json:"prim,omitempty"`
+	Parent      int    `json:"-"`
+	Sequence    bool   `json:"-"`
+	Row         int    `json:"row"`
+	StartColumn int    `json:"start_col"`
+	EndColumn   int    `json:"end_col"`
+}
+
+// LocateNodes - returns Michelson text of `n` formatted by `MichelineToMichelson` and positions of its nodes in the text.
+// Nodes are numbered as Micheline canonical locations: in prefix order starting with 0 for root. Root of script is not located.
+func LocateNodes(n gjson.Result, lineSize int) (string, map[int]NodeLocation, error) {
+	text, err := MichelineToMichelson(n, false, lineSize)
+	if err != nil {
+		return "", nil, err
+	}
+
+	l := &locator{
+		text:      text,
+		locations: make(map[int]NodeLocation),
+		current:   -1,
+	}
+	if err := l.locate(n, -1, true); err != nil {
+		return "", nil, err
+	}
+	return text, l.locations, nil
+}
+
+type locator struct {
+	text      string
+	cursor    int
+	current   int
+	locations map[int]NodeLocation
+}
+
+func (l *locator) locate(node gjson.Result, parent int, isRoot bool) error {
+	l.current++
+	id := l.current
+
+	var token, prim string
+	switch {
+	case node.IsArray():
+		if !isRoot || !IsScript(node) {
+			token = "{"
+		}
+	case node.IsObject() && node.Get("prim").Exists():
+		prim = node.Get("prim").String()
+		token = prim
+	case node.IsObject():
+		str, err := formatNonPrimObject(node)
+		if err != nil {
+			return err
+		}
+		token = str
+	default:
+		return errors.Errorf("data is not array or object %v", node)
+	}
+
+	if token != "" {
+		start := l.find(token, prim != "" || node.Get("int").Exists())
+		if start < 0 {
+			return errors.Errorf("can't locate node %d: %s", id, token)
+		}
+		l.cursor = start + len(token)
+		location := l.newLocation(prim, parent, start, l.cursor)
+		location.Sequence = node.IsArray()
+		l.locations[id] = location
+	}
+
+	var args []gjson.Result
+	if node.IsArray() {
+		args = node.Array()
+	} else if rawArgs := node.Get("args"); rawArgs.Exists() {
+		args = rawArgs.Array()
+	}
+	for i := range args {
+		if err := l.locate(args[i], id, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// find - returns index of `token` in text after cursor. If `isWord` is true `token` should be separated from other words.
+func (l *locator) find(token string, isWord bool) int {
+	from := l.cursor
+	for {
+		idx := strings.Index(l.text[from:], token)
+		if idx < 0 {
+			return -1
+		}
+		start := from + idx
+		end := start + len(token)
+		if !isWord || (isWordStart(l.text, start) && isWordEnd(l.text, end)) {
+			return start
+		}
+		from = end
+	}
+}
+
+func (l *locator) newLocation(prim string, parent, start, end int) NodeLocation {
+	lineStart := strings.LastIndex(l.text[:start], "\n") + 1
+	startColumn := utf8.RuneCountInString(l.text[lineStart:start])
+	return NodeLocation{
+		Prim:        prim,
+		Parent:      parent,
+		Row:         strings.Count(l.text[:start], "\n") + 1,
+		StartColumn: startColumn,
+		EndColumn:   startColumn + utf8.RuneCountInString(l.text[start:end]) - 1,
+	}
+}
+
+func isWordStart(text string, idx int) bool {
+	return idx == 0 || strings.ContainsRune(" \n{(", rune(text[idx-1]))
+}
+
+func isWordEnd(text string, idx int) bool {
+	return idx == len(text) || strings.ContainsRune(" \n;})", rune(text[idx]))
+}
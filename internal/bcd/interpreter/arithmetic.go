@@ -0,0 +1,234 @@
+package interpreter
+
+import (
+	"math/big"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/pkg/errors"
+)
+
+// maxShift - maximal number of bits in `LSL` and `LSR`
+const maxShift = 256
+
+func isNumber(typ *base.Node) bool {
+	return typ.Prim == consts.INT || typ.Prim == consts.NAT
+}
+
+func checkMutez(value *big.Int) (*base.Node, error) {
+	if value.Sign() < 0 || !value.IsInt64() {
+		return nil, errors.Wrapf(ErrOverflow, "mutez %s", value.String())
+	}
+	return intNode(value), nil
+}
+
+func (i *Interpreter) arithmetic(instr *base.Node, s *stack) error {
+	switch instr.Prim {
+	case ADD, SUB, MUL, EDIV:
+		items, err := s.popN(2)
+		if err != nil {
+			return err
+		}
+		typ, value, err := binaryOperation(instr.Prim, items[0], items[1])
+		if err != nil {
+			return err
+		}
+		s.push(typ, value)
+	case LSL, LSR:
+		items, err := s.popN(2)
+		if err != nil {
+			return err
+		}
+		if err := checkType(items[0], consts.NAT); err != nil {
+			return err
+		}
+		if err := checkType(items[1], consts.NAT); err != nil {
+			return err
+		}
+		shift := items[1].val.IntValue.Int
+		if shift.Cmp(big.NewInt(maxShift)) > 0 {
+			return errors.Wrapf(ErrOverflow, "%s by %s", instr.Prim, shift.String())
+		}
+		result := big.NewInt(0)
+		if instr.Prim == LSL {
+			result.Lsh(items[0].val.IntValue.Int, uint(shift.Uint64()))
+		} else {
+			result.Rsh(items[0].val.IntValue.Int, uint(shift.Uint64()))
+		}
+		s.push(prim(consts.NAT), intNode(result))
+	case OR, AND, XOR:
+		items, err := s.popN(2)
+		if err != nil {
+			return err
+		}
+		typ, value, err := logicalOperation(instr.Prim, items[0], items[1])
+		if err != nil {
+			return err
+		}
+		s.push(typ, value)
+	default:
+		it, err := s.pop()
+		if err != nil {
+			return err
+		}
+		typ, value, err := unaryOperation(instr.Prim, it)
+		if err != nil {
+			return err
+		}
+		s.push(typ, value)
+	}
+	return nil
+}
+
+func binaryOperation(name string, a, b item) (*base.Node, *base.Node, error) {
+	x, err := getInt(a.val)
+	if err != nil {
+		return nil, nil, err
+	}
+	y, err := getInt(b.val)
+	if err != nil {
+		return nil, nil, err
+	}
+	pa, pb := a.typ.Prim, b.typ.Prim
+
+	switch name {
+	case ADD:
+		result := big.NewInt(0).Add(x, y)
+		switch {
+		case pa == consts.NAT && pb == consts.NAT:
+			return prim(consts.NAT), intNode(result), nil
+		case isNumber(a.typ) && isNumber(b.typ):
+			return prim(consts.INT), intNode(result), nil
+		case pa == consts.TIMESTAMP && pb == consts.INT, pa == consts.INT && pb == consts.TIMESTAMP:
+			return prim(consts.TIMESTAMP), intNode(result), nil
+		case pa == consts.MUTEZ && pb == consts.MUTEZ:
+			value, err := checkMutez(result)
+			return prim(consts.MUTEZ), value, err
+		}
+	case SUB:
+		result := big.NewInt(0).Sub(x, y)
+		switch {
+		case isNumber(a.typ) && isNumber(b.typ), pa == consts.TIMESTAMP && pb == consts.TIMESTAMP:
+			return prim(consts.INT), intNode(result), nil
+		case pa == consts.TIMESTAMP && pb == consts.INT:
+			return prim(consts.TIMESTAMP), intNode(result), nil
+		case pa == consts.MUTEZ && pb == consts.MUTEZ:
+			value, err := checkMutez(result)
+			return prim(consts.MUTEZ), value, err
+		}
+	case MUL:
+		result := big.NewInt(0).Mul(x, y)
+		switch {
+		case pa == consts.NAT && pb == consts.NAT:
+			return prim(consts.NAT), intNode(result), nil
+		case isNumber(a.typ) && isNumber(b.typ):
+			return prim(consts.INT), intNode(result), nil
+		case pa == consts.MUTEZ && pb == consts.NAT, pa == consts.NAT && pb == consts.MUTEZ:
+			value, err := checkMutez(result)
+			return prim(consts.MUTEZ), value, err
+		}
+	case EDIV:
+		var quotientType, remainderType string
+		switch {
+		case pa == consts.NAT && pb == consts.NAT:
+			quotientType, remainderType = consts.NAT, consts.NAT
+		case isNumber(a.typ) && isNumber(b.typ):
+			quotientType, remainderType = consts.INT, consts.NAT
+		case pa == consts.MUTEZ && pb == consts.NAT:
+			quotientType, remainderType = consts.MUTEZ, consts.MUTEZ
+		case pa == consts.MUTEZ && pb == consts.MUTEZ:
+			quotientType, remainderType = consts.NAT, consts.MUTEZ
+		default:
+			return nil, nil, errors.Wrapf(ErrTypeMismatch, "%s: %s and %s", name, pa, pb)
+		}
+		typ := prim(consts.OPTION, prim(consts.PAIR, prim(quotientType), prim(remainderType)))
+		if y.Sign() == 0 {
+			return typ, prim(consts.None), nil
+		}
+		quotient, remainder := big.NewInt(0).DivMod(x, y, big.NewInt(0))
+		return typ, prim(consts.Some, prim(consts.Pair, intNode(quotient), intNode(remainder))), nil
+	}
+	return nil, nil, errors.Wrapf(ErrTypeMismatch, "%s: %s and %s", name, pa, pb)
+}
+
+func logicalOperation(name string, a, b item) (*base.Node, *base.Node, error) {
+	if a.typ.Prim == consts.BOOL && b.typ.Prim == consts.BOOL {
+		x, err := getBool(a.val)
+		if err != nil {
+			return nil, nil, err
+		}
+		y, err := getBool(b.val)
+		if err != nil {
+			return nil, nil, err
+		}
+		var result bool
+		switch name {
+		case OR:
+			result = x || y
+		case AND:
+			result = x && y
+		case XOR:
+			result = x != y
+		}
+		return prim(consts.BOOL), boolNode(result), nil
+	}
+
+	x, err := getInt(a.val)
+	if err != nil {
+		return nil, nil, err
+	}
+	y, err := getInt(b.val)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := big.NewInt(0)
+	switch {
+	case a.typ.Prim == consts.NAT && b.typ.Prim == consts.NAT:
+		switch name {
+		case OR:
+			result.Or(x, y)
+		case AND:
+			result.And(x, y)
+		case XOR:
+			result.Xor(x, y)
+		}
+	case name == AND && a.typ.Prim == consts.INT && b.typ.Prim == consts.NAT:
+		result.And(x, y)
+	default:
+		return nil, nil, errors.Wrapf(ErrTypeMismatch, "%s: %s and %s", name, a.typ.Prim, b.typ.Prim)
+	}
+	return prim(consts.NAT), intNode(result), nil
+}
+
+func unaryOperation(name string, it item) (*base.Node, *base.Node, error) {
+	if name == NOT && it.typ.Prim == consts.BOOL {
+		x, err := getBool(it.val)
+		if err != nil {
+			return nil, nil, err
+		}
+		return prim(consts.BOOL), boolNode(!x), nil
+	}
+
+	x, err := getInt(it.val)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch {
+	case name == ABS && it.typ.Prim == consts.INT:
+		return prim(consts.NAT), intNode(big.NewInt(0).Abs(x)), nil
+	case name == ISNAT && it.typ.Prim == consts.INT:
+		typ := prim(consts.OPTION, prim(consts.NAT))
+		if x.Sign() < 0 {
+			return typ, prim(consts.None), nil
+		}
+		return typ, prim(consts.Some, intNode(x)), nil
+	case name == INT && it.typ.Prim == consts.NAT:
+		return prim(consts.INT), it.val, nil
+	case name == NEG && isNumber(it.typ):
+		return prim(consts.INT), intNode(big.NewInt(0).Neg(x)), nil
+	case name == NOT && isNumber(it.typ):
+		return prim(consts.INT), intNode(big.NewInt(0).Not(x)), nil
+	default:
+		return nil, nil, errors.Wrapf(ErrTypeMismatch, "%s: %s", name, it.typ.Prim)
+	}
+}
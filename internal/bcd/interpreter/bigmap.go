@@ -0,0 +1,109 @@
+package interpreter
+
+import (
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/pkg/errors"
+)
+
+// bigMapState - lazy copy of on-chain big map. Only updated keys are stored, other keys are requested by `BigMapGetter`.
+type bigMapState struct {
+	parent int64
+	values map[string]bigMapEntry
+}
+
+type bigMapEntry struct {
+	key   *base.Node
+	value *base.Node
+}
+
+func (i *Interpreter) getBigMapState(ptr int64) *bigMapState {
+	if state, ok := i.bigMaps[ptr]; ok {
+		return state
+	}
+	return &bigMapState{
+		parent: ptr,
+		values: make(map[string]bigMapEntry),
+	}
+}
+
+func (i *Interpreter) bigMapGet(typ, ptr, key *base.Node) (*base.Node, error) {
+	hash, err := keyHash(typ.Args[0], key)
+	if err != nil {
+		return nil, err
+	}
+
+	state := i.getBigMapState(ptr.IntValue.Int64())
+	if entry, ok := state.values[hash]; ok {
+		return entry.value, nil
+	}
+
+	if i.getter == nil {
+		return nil, errors.Wrapf(ErrUnknownBigMap, "%d", state.parent)
+	}
+	value, err := i.getter.GetBigMapValue(state.parent, hash)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, nil
+	}
+	if err := i.consume(parsingCost(value)); err != nil {
+		return nil, err
+	}
+	return normalizeValue(typ.Args[1], value)
+}
+
+func (i *Interpreter) bigMapUpdate(typ, ptr, key, value *base.Node) (*base.Node, error) {
+	hash, err := keyHash(typ.Args[0], key)
+	if err != nil {
+		return nil, err
+	}
+
+	state := i.getBigMapState(ptr.IntValue.Int64())
+	updated := &bigMapState{
+		parent: state.parent,
+		values: make(map[string]bigMapEntry, len(state.values)+1),
+	}
+	for k, v := range state.values {
+		updated.values[k] = v
+	}
+	updated.values[hash] = bigMapEntry{key, value}
+
+	i.tempID--
+	i.bigMaps[i.tempID] = updated
+	return int64Node(i.tempID), nil
+}
+
+// mapGet - `GET` for maps and big maps
+func (i *Interpreter) mapGet(typ, collection, key *base.Node) (*base.Node, error) {
+	if typ.Prim == consts.BIGMAP && collection.IntValue != nil {
+		return i.bigMapGet(typ, collection, key)
+	}
+	idx, ok, err := findInMap(typ.Args[0], collection, key)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return collection.Args[idx].Args[1], nil
+}
+
+// mapUpdate - `UPDATE` for maps and big maps. Removes key if `value` is nil.
+func (i *Interpreter) mapUpdate(typ, collection, key, value *base.Node) (*base.Node, error) {
+	if typ.Prim == consts.BIGMAP && collection.IntValue != nil {
+		return i.bigMapUpdate(typ, collection, key, value)
+	}
+	idx, ok, err := findInMap(typ.Args[0], collection, key)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case ok && value == nil:
+		return seq(removeAt(collection.Args, idx)...), nil
+	case ok:
+		return seq(replaceAt(collection.Args, idx, prim(consts.Elt, key, value))...), nil
+	case value == nil:
+		return collection, nil
+	default:
+		return seq(insertAt(collection.Args, idx, prim(consts.Elt, key, value))...), nil
+	}
+}
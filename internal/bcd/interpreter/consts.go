@@ -0,0 +1,110 @@
+package interpreter
+
+// Instructions
+const (
+	ABS              = "ABS"
+	ADD              = "ADD"
+	ADDRESS          = "ADDRESS"
+	AMOUNT           = "AMOUNT"
+	AND              = "AND"
+	APPLY            = "APPLY"
+	BALANCE          = "BALANCE"
+	BLAKE2B          = "BLAKE2B"
+	CAR              = "CAR"
+	CAST             = "CAST"
+	CDR              = "CDR"
+	CHAINID          = "CHAIN_ID"
+	CHECKSIGNATURE   = "CHECK_SIGNATURE"
+	COMPARE          = "COMPARE"
+	CONCAT           = "CONCAT"
+	CONS             = "CONS"
+	CONTRACT         = "CONTRACT"
+	CREATECONTRACT   = "CREATE_CONTRACT"
+	DIG              = "DIG"
+	DIP              = "DIP"
+	DROP             = "DROP"
+	DUG              = "DUG"
+	DUP              = "DUP"
+	EDIV             = "EDIV"
+	EMPTYBIGMAP      = "EMPTY_BIG_MAP"
+	EMPTYMAP         = "EMPTY_MAP"
+	EMPTYSET         = "EMPTY_SET"
+	EQ               = "EQ"
+	EXEC             = "EXEC"
+	FAILWITH         = "FAILWITH"
+	GE               = "GE"
+	GET              = "GET"
+	GETANDUPDATE     = "GET_AND_UPDATE"
+	GT               = "GT"
+	HASHKEY          = "HASH_KEY"
+	IF               = "IF"
+	IFCONS           = "IF_CONS"
+	IFLEFT           = "IF_LEFT"
+	IFNONE           = "IF_NONE"
+	IMPLICITACCOUNT  = "IMPLICIT_ACCOUNT"
+	INT              = "INT"
+	ISNAT            = "ISNAT"
+	ITER             = "ITER"
+	KECCAK           = "KECCAK"
+	LAMBDA           = "LAMBDA"
+	LE               = "LE"
+	LEFT             = "LEFT"
+	LEVEL            = "LEVEL"
+	LOOP             = "LOOP"
+	LOOPLEFT         = "LOOP_LEFT"
+	LSL              = "LSL"
+	LSR              = "LSR"
+	LT               = "LT"
+	MAP              = "MAP"
+	MEM              = "MEM"
+	MUL              = "MUL"
+	NEG              = "NEG"
+	NEQ              = "NEQ"
+	NEVER            = "NEVER"
+	NIL              = "NIL"
+	NONE             = "NONE"
+	NOT              = "NOT"
+	NOW              = "NOW"
+	OR               = "OR"
+	PACK             = "PACK"
+	PAIR             = "PAIR"
+	PUSH             = "PUSH"
+	RENAME           = "RENAME"
+	RIGHT            = "RIGHT"
+	SELF             = "SELF"
+	SELFADDRESS      = "SELF_ADDRESS"
+	SENDER           = "SENDER"
+	SETDELEGATE      = "SET_DELEGATE"
+	SHA256           = "SHA256"
+	SHA3             = "SHA3"
+	SHA512           = "SHA512"
+	SIZE             = "SIZE"
+	SLICE            = "SLICE"
+	SOME             = "SOME"
+	SOURCE           = "SOURCE"
+	STEPSTOQUOTA     = "STEPS_TO_QUOTA"
+	SUB              = "SUB"
+	SWAP             = "SWAP"
+	TOTALVOTINGPOWER = "TOTAL_VOTING_POWER"
+	TRANSFERTOKENS   = "TRANSFER_TOKENS"
+	UNIT             = "UNIT"
+	UNPACK           = "UNPACK"
+	UNPAIR           = "UNPAIR"
+	UPDATE           = "UPDATE"
+	VOTINGPOWER      = "VOTING_POWER"
+	XOR              = "XOR"
+)
+
+// Default values of execution context. They are the same as the node uses in `run_code` RPC.
+const (
+	DefaultSelf         = "KT1BEqzn5Wx8uJrZNvuS9DVHmLvG9td3fDLi"
+	DefaultSource       = "tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU"
+	DefaultHardGasLimit = 1040000
+	DefaultChainID      = "NetXdQprcVkpaWU"
+)
+
+// internal primitives of operation values
+const (
+	opTransaction = "transaction"
+	opDelegation  = "delegation"
+)
@@ -0,0 +1,131 @@
+package interpreter
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/sha512"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/encoding"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+)
+
+// key hash is 20 bytes blake2b hash of public key
+const keyHashSize = 20
+
+var keyHashPrefixes = map[string]string{
+	encoding.PrefixED25519PublicKey:   encoding.PrefixPublicKeyTZ1,
+	encoding.PrefixSecp256k1PublicKey: encoding.PrefixPublicKeyTZ2,
+	encoding.PrefixP256PublicKey:      encoding.PrefixPublicKeyTZ3,
+}
+
+func (i *Interpreter) crypto(instr *base.Node, s *stack) error {
+	switch instr.Prim {
+	case HASHKEY:
+		it, err := s.popType(consts.KEY)
+		if err != nil {
+			return err
+		}
+		hash, err := hashKey(*it.val.StringValue)
+		if err != nil {
+			return err
+		}
+		s.push(prim(consts.KEYHASH), stringNode(hash))
+	case CHECKSIGNATURE:
+		items, err := s.popN(3)
+		if err != nil {
+			return err
+		}
+		if err := checkType(items[0], consts.KEY); err != nil {
+			return err
+		}
+		if err := checkType(items[1], consts.SIGNATURE); err != nil {
+			return err
+		}
+		if err := checkType(items[2], consts.BYTES); err != nil {
+			return err
+		}
+		message, err := getBytes(items[2].val)
+		if err != nil {
+			return err
+		}
+		ok, err := checkSignature(*items[0].val.StringValue, *items[1].val.StringValue, message)
+		if err != nil {
+			return err
+		}
+		s.push(prim(consts.BOOL), boolNode(ok))
+	default:
+		it, err := s.popType(consts.BYTES)
+		if err != nil {
+			return err
+		}
+		data, err := getBytes(it.val)
+		if err != nil {
+			return err
+		}
+		var hash []byte
+		switch instr.Prim {
+		case BLAKE2B:
+			sum := blake2b.Sum256(data)
+			hash = sum[:]
+		case SHA256:
+			sum := sha256.Sum256(data)
+			hash = sum[:]
+		case SHA512:
+			sum := sha512.Sum512(data)
+			hash = sum[:]
+		case SHA3:
+			sum := sha3.Sum256(data)
+			hash = sum[:]
+		case KECCAK:
+			h := sha3.NewLegacyKeccak256()
+			h.Write(data)
+			hash = h.Sum(nil)
+		}
+		s.push(prim(consts.BYTES), bytesNode(hash))
+	}
+	return nil
+}
+
+func hashKey(key string) (string, error) {
+	if len(key) < 4 {
+		return "", errors.Wrapf(ErrInvalidValue, "invalid key: %s", key)
+	}
+	prefix, ok := keyHashPrefixes[key[:4]]
+	if !ok {
+		return "", errors.Wrapf(ErrInvalidValue, "invalid key: %s", key)
+	}
+	data, err := encoding.DecodeBase58(key)
+	if err != nil {
+		return "", err
+	}
+	hash, err := blake2b.New(keyHashSize, nil)
+	if err != nil {
+		return "", err
+	}
+	hash.Write(data)
+	return encoding.EncodeBase58(hash.Sum(nil), []byte(prefix))
+}
+
+// checkSignature - only ed25519 signatures are supported now
+func checkSignature(key, signature string, message []byte) (bool, error) {
+	if len(key) < 4 || key[:4] != encoding.PrefixED25519PublicKey {
+		return false, errors.Wrap(ErrUnsupported, "CHECK_SIGNATURE for non-ed25519 keys")
+	}
+	publicKey, err := encoding.DecodeBase58(key)
+	if err != nil {
+		return false, err
+	}
+	sig, err := encoding.DecodeBase58(signature)
+	if err != nil {
+		return false, err
+	}
+	if len(publicKey) != ed25519.PublicKeySize || len(sig) != ed25519.SignatureSize {
+		return false, nil
+	}
+	digest := blake2b.Sum256(message)
+	return ed25519.Verify(publicKey, digest[:], sig), nil
+}
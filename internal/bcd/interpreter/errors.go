@@ -0,0 +1,41 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/pkg/errors"
+)
+
+// Errors
+var (
+	ErrGasExhausted    = errors.New("gas_exhausted.operation")
+	ErrUnsupported     = errors.New("Unsupported instruction")
+	ErrStackUnderflow  = errors.New("Stack underflow")
+	ErrTypeMismatch    = errors.New("Type mismatch")
+	ErrInvalidValue    = errors.New("Invalid value")
+	ErrInvalidScript   = errors.New("Invalid script")
+	ErrOverflow        = errors.New("Arithmetic overflow")
+	ErrUnknownBigMap   = errors.New("Unknown big map")
+	ErrUnknownContract = errors.New("Unknown contract")
+)
+
+// ScriptRejectedError - is returned when script reaches `FAILWITH` instruction
+type ScriptRejectedError struct {
+	Location int64
+	With     *base.Node
+}
+
+// Error -
+func (e ScriptRejectedError) Error() string {
+	with, err := json.MarshalToString(e.With)
+	if err != nil {
+		with = err.Error()
+	}
+	return fmt.Sprintf("michelson_v1.script_rejected at %d with %s", e.Location, with)
+}
+
+// IsUnsupported - returns true if script can not be executed without node
+func IsUnsupported(err error) bool {
+	return errors.Is(err, ErrUnsupported) || errors.Is(err, ErrUnknownBigMap) || errors.Is(err, ErrUnknownContract)
+}
@@ -0,0 +1,86 @@
+package interpreter
+
+import (
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+)
+
+// Gas is counted in milligas. Costs are an approximation of the protocol gas model:
+// every instruction has constant cost and data-dependent instructions have additional cost linear to the size of their arguments.
+const (
+	milligasInGas   int64 = 1000
+	costNodeParsing int64 = 10
+	costStep        int64 = 10
+	costSizeUnit    int64 = 2
+)
+
+var instructionCosts = map[string]int64{
+	ADD:            35,
+	SUB:            35,
+	MUL:            50,
+	EDIV:           80,
+	LSL:            50,
+	LSR:            50,
+	COMPARE:        35,
+	CONCAT:         30,
+	SLICE:          30,
+	PACK:           300,
+	UNPACK:         300,
+	BLAKE2B:        400,
+	SHA256:         400,
+	SHA512:         400,
+	SHA3:           800,
+	KECCAK:         800,
+	HASHKEY:        650,
+	CHECKSIGNATURE: 1600,
+	MEM:            80,
+	GET:            80,
+	UPDATE:         100,
+	GETANDUPDATE:   120,
+	EXEC:           20,
+	APPLY:          40,
+	LAMBDA:         20,
+	TRANSFERTOKENS: 60,
+	CONTRACT:       120,
+}
+
+// sizeDependent - instructions which cost depends on the size of arguments on the top of the stack
+var sizeDependent = map[string]int{
+	ADD:     2,
+	SUB:     2,
+	MUL:     2,
+	EDIV:    2,
+	COMPARE: 2,
+	CONCAT:  2,
+	PACK:    1,
+	UNPACK:  1,
+	BLAKE2B: 1,
+	SHA256:  1,
+	SHA512:  1,
+	SHA3:    1,
+	KECCAK:  1,
+	MEM:     2,
+	GET:     2,
+	UPDATE:  3,
+}
+
+func instructionCost(name string, s *stack) int64 {
+	cost := costStep + instructionCosts[name]
+	if count, ok := sizeDependent[name]; ok {
+		for i := 0; i < count && i < s.len(); i++ {
+			cost += costSizeUnit * nodeSize(s.items[len(s.items)-1-i].val)
+		}
+	}
+	return cost
+}
+
+func parsingCost(node *base.Node) int64 {
+	return costNodeParsing * nodeSize(node)
+}
+
+func (i *Interpreter) consume(milligas int64) error {
+	i.gas -= milligas
+	if i.gas < 0 {
+		return ErrGasExhausted
+	}
+	return nil
+}
@@ -0,0 +1,1097 @@
+package interpreter
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/forge"
+	"github.com/pkg/errors"
+)
+
+// maxIntArg - limit of numeric arguments of instructions like `DIG n`
+const maxIntArg = 1023
+
+func getIntArg(instr *base.Node, defaultValue int) (int, error) {
+	if len(instr.Args) == 0 || instr.Args[0].IntValue == nil {
+		return defaultValue, nil
+	}
+	value, err := getInt(instr.Args[0])
+	if err != nil {
+		return 0, err
+	}
+	if value.Sign() < 0 || value.Cmp(big.NewInt(maxIntArg)) > 0 {
+		return 0, errors.Wrapf(ErrInvalidScript, "%s: invalid argument %s", instr.Prim, value.String())
+	}
+	return int(value.Int64()), nil
+}
+
+func getEntrypointAnnot(instr *base.Node) string {
+	for _, annot := range instr.Annots {
+		if strings.HasPrefix(annot, "%") {
+			return annot[1:]
+		}
+	}
+	return ""
+}
+
+func checkType(it item, prims ...string) error {
+	for i := range prims {
+		if it.typ.Prim == prims[i] {
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrTypeMismatch, "expected %v, got %s", prims, it.typ.Prim)
+}
+
+func (i *Interpreter) step(instr *base.Node, s *stack) error {
+	switch instr.Prim {
+	case DROP, DUP, SWAP, DIG, DUG, PUSH, DIP, RENAME, CAST:
+		return i.stackInstruction(instr, s)
+	case SOME, NONE, UNIT, PAIR, UNPAIR, CAR, CDR, LEFT, RIGHT, NIL, CONS, EMPTYSET, EMPTYMAP, EMPTYBIGMAP, LAMBDA:
+		return i.constructor(instr, s)
+	case IF, IFNONE, IFLEFT, IFCONS, LOOP, LOOPLEFT, MAP, ITER, EXEC, APPLY, FAILWITH, NEVER:
+		return i.control(instr, s)
+	case SIZE, MEM, GET, UPDATE, GETANDUPDATE, CONCAT, SLICE, PACK, UNPACK:
+		return i.collection(instr, s)
+	case ADD, SUB, MUL, EDIV, ABS, ISNAT, INT, NEG, LSL, LSR, OR, AND, XOR, NOT:
+		return i.arithmetic(instr, s)
+	case COMPARE, EQ, NEQ, LT, GT, LE, GE:
+		return i.comparison(instr, s)
+	case SELF, SELFADDRESS, AMOUNT, BALANCE, NOW, LEVEL, CHAINID, SOURCE, SENDER, ADDRESS, CONTRACT, IMPLICITACCOUNT, TRANSFERTOKENS, SETDELEGATE:
+		return i.blockchain(instr, s)
+	case HASHKEY, CHECKSIGNATURE, BLAKE2B, SHA256, SHA512, SHA3, KECCAK:
+		return i.crypto(instr, s)
+	default:
+		return errors.Wrap(ErrUnsupported, instr.Prim)
+	}
+}
+
+func (i *Interpreter) stackInstruction(instr *base.Node, s *stack) error {
+	switch instr.Prim {
+	case DROP:
+		n, err := getIntArg(instr, 1)
+		if err != nil {
+			return err
+		}
+		_, err = s.popN(n)
+		return err
+	case DUP:
+		n, err := getIntArg(instr, 1)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return errors.Wrap(ErrInvalidScript, "DUP 0")
+		}
+		it, err := s.peek(n - 1)
+		if err != nil {
+			return err
+		}
+		s.pushItem(it)
+	case SWAP:
+		items, err := s.popN(2)
+		if err != nil {
+			return err
+		}
+		s.pushItem(items[0])
+		s.pushItem(items[1])
+	case DIG:
+		n, err := getIntArg(instr, 0)
+		if err != nil {
+			return err
+		}
+		items, err := s.popN(n + 1)
+		if err != nil {
+			return err
+		}
+		for j := n - 1; j >= 0; j-- {
+			s.pushItem(items[j])
+		}
+		s.pushItem(items[n])
+	case DUG:
+		n, err := getIntArg(instr, 0)
+		if err != nil {
+			return err
+		}
+		items, err := s.popN(n + 1)
+		if err != nil {
+			return err
+		}
+		s.pushItem(items[0])
+		for j := n; j > 0; j-- {
+			s.pushItem(items[j])
+		}
+	case PUSH:
+		args, err := getArgs(instr, 2)
+		if err != nil {
+			return err
+		}
+		typ, err := normalizeType(args[0])
+		if err != nil {
+			return err
+		}
+		value, err := normalizeValue(typ, args[1])
+		if err != nil {
+			return err
+		}
+		s.push(typ, value)
+	case DIP:
+		n := 1
+		var code *base.Node
+		switch len(instr.Args) {
+		case 1:
+			code = instr.Args[0]
+		case 2:
+			arg, err := getIntArg(instr, 1)
+			if err != nil {
+				return err
+			}
+			n = arg
+			code = instr.Args[1]
+		default:
+			return errors.Wrap(consts.ErrInvalidArgsCount, DIP)
+		}
+		items, err := s.popN(n)
+		if err != nil {
+			return err
+		}
+		if err := i.execute(code, s); err != nil {
+			return err
+		}
+		for j := n - 1; j >= 0; j-- {
+			s.pushItem(items[j])
+		}
+	case CAST:
+		args, err := getArgs(instr, 1)
+		if err != nil {
+			return err
+		}
+		typ, err := normalizeType(args[0])
+		if err != nil {
+			return err
+		}
+		it, err := s.pop()
+		if err != nil {
+			return err
+		}
+		if !equalTypes(it.typ, typ) {
+			return errors.Wrap(ErrTypeMismatch, CAST)
+		}
+		s.push(typ, it.val)
+	case RENAME:
+		if s.len() == 0 {
+			return ErrStackUnderflow
+		}
+	}
+	return nil
+}
+
+func (i *Interpreter) constructor(instr *base.Node, s *stack) error {
+	switch instr.Prim {
+	case SOME:
+		it, err := s.pop()
+		if err != nil {
+			return err
+		}
+		s.push(prim(consts.OPTION, it.typ), prim(consts.Some, it.val))
+	case NONE:
+		typ, err := i.getTypeArg(instr, 0, 1)
+		if err != nil {
+			return err
+		}
+		s.push(prim(consts.OPTION, typ), prim(consts.None))
+	case UNIT:
+		s.push(prim(consts.UNIT), prim(consts.Unit))
+	case PAIR:
+		n, err := getIntArg(instr, 2)
+		if err != nil {
+			return err
+		}
+		if n < 2 {
+			return errors.Wrapf(ErrInvalidScript, "PAIR %d", n)
+		}
+		items, err := s.popN(n)
+		if err != nil {
+			return err
+		}
+		typ, value := items[n-1].typ, items[n-1].val
+		for j := n - 2; j >= 0; j-- {
+			typ = prim(consts.PAIR, items[j].typ, typ)
+			value = prim(consts.Pair, items[j].val, value)
+		}
+		s.push(typ, value)
+	case UNPAIR:
+		n, err := getIntArg(instr, 2)
+		if err != nil {
+			return err
+		}
+		if n < 2 {
+			return errors.Wrapf(ErrInvalidScript, "UNPAIR %d", n)
+		}
+		it, err := s.pop()
+		if err != nil {
+			return err
+		}
+		items := make([]item, 0, n)
+		for j := 0; j < n-1; j++ {
+			if err := checkType(it, consts.PAIR); err != nil {
+				return err
+			}
+			items = append(items, item{it.typ.Args[0], it.val.Args[0]})
+			it = item{it.typ.Args[1], it.val.Args[1]}
+		}
+		items = append(items, it)
+		for j := len(items) - 1; j >= 0; j-- {
+			s.pushItem(items[j])
+		}
+	case CAR, CDR:
+		it, err := s.popType(consts.PAIR)
+		if err != nil {
+			return err
+		}
+		idx := 0
+		if instr.Prim == CDR {
+			idx = 1
+		}
+		s.push(it.typ.Args[idx], it.val.Args[idx])
+	case LEFT, RIGHT:
+		typ, err := i.getTypeArg(instr, 0, 1)
+		if err != nil {
+			return err
+		}
+		it, err := s.pop()
+		if err != nil {
+			return err
+		}
+		if instr.Prim == LEFT {
+			s.push(prim(consts.OR, it.typ, typ), prim(consts.Left, it.val))
+		} else {
+			s.push(prim(consts.OR, typ, it.typ), prim(consts.Right, it.val))
+		}
+	case NIL:
+		typ, err := i.getTypeArg(instr, 0, 1)
+		if err != nil {
+			return err
+		}
+		s.push(prim(consts.LIST, typ), seq())
+	case CONS:
+		items, err := s.popN(2)
+		if err != nil {
+			return err
+		}
+		if err := checkType(items[1], consts.LIST); err != nil {
+			return err
+		}
+		if !equalTypes(items[1].typ.Args[0], items[0].typ) {
+			return errors.Wrap(ErrTypeMismatch, CONS)
+		}
+		list := make([]*base.Node, 0, len(items[1].val.Args)+1)
+		list = append(list, items[0].val)
+		list = append(list, items[1].val.Args...)
+		s.push(prim(consts.LIST, mergeTypes(items[1].typ.Args[0], items[0].typ)), seq(list...))
+	case EMPTYSET:
+		typ, err := i.getTypeArg(instr, 0, 1)
+		if err != nil {
+			return err
+		}
+		s.push(prim(consts.SET, typ), seq())
+	case EMPTYMAP, EMPTYBIGMAP:
+		keyType, err := i.getTypeArg(instr, 0, 2)
+		if err != nil {
+			return err
+		}
+		valueType, err := i.getTypeArg(instr, 1, 2)
+		if err != nil {
+			return err
+		}
+		typ := consts.MAP
+		if instr.Prim == EMPTYBIGMAP {
+			typ = consts.BIGMAP
+		}
+		s.push(prim(typ, keyType, valueType), seq())
+	case LAMBDA:
+		args, err := getArgs(instr, 3)
+		if err != nil {
+			return err
+		}
+		typ, err := normalizeType(prim(consts.LAMBDA, args[0], args[1]))
+		if err != nil {
+			return err
+		}
+		code, err := normalizeValue(typ, args[2])
+		if err != nil {
+			return err
+		}
+		s.push(typ, code)
+	}
+	return nil
+}
+
+func (i *Interpreter) getTypeArg(instr *base.Node, idx, count int) (*base.Node, error) {
+	args, err := getArgs(instr, count)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeType(args[idx])
+}
+
+func (i *Interpreter) control(instr *base.Node, s *stack) error {
+	switch instr.Prim {
+	case IF:
+		args, err := getArgs(instr, 2)
+		if err != nil {
+			return err
+		}
+		it, err := s.popType(consts.BOOL)
+		if err != nil {
+			return err
+		}
+		if it.val.Prim == consts.True {
+			return i.execute(args[0], s)
+		}
+		return i.execute(args[1], s)
+	case IFNONE:
+		args, err := getArgs(instr, 2)
+		if err != nil {
+			return err
+		}
+		it, err := s.popType(consts.OPTION)
+		if err != nil {
+			return err
+		}
+		if it.val.Prim == consts.None {
+			return i.execute(args[0], s)
+		}
+		s.push(it.typ.Args[0], it.val.Args[0])
+		return i.execute(args[1], s)
+	case IFLEFT:
+		args, err := getArgs(instr, 2)
+		if err != nil {
+			return err
+		}
+		it, err := s.popType(consts.OR)
+		if err != nil {
+			return err
+		}
+		if it.val.Prim == consts.Left {
+			s.push(it.typ.Args[0], it.val.Args[0])
+			return i.execute(args[0], s)
+		}
+		s.push(it.typ.Args[1], it.val.Args[0])
+		return i.execute(args[1], s)
+	case IFCONS:
+		args, err := getArgs(instr, 2)
+		if err != nil {
+			return err
+		}
+		it, err := s.popType(consts.LIST)
+		if err != nil {
+			return err
+		}
+		if len(it.val.Args) == 0 {
+			return i.execute(args[1], s)
+		}
+		s.push(it.typ, seq(it.val.Args[1:]...))
+		s.push(it.typ.Args[0], it.val.Args[0])
+		return i.execute(args[0], s)
+	case LOOP:
+		args, err := getArgs(instr, 1)
+		if err != nil {
+			return err
+		}
+		for {
+			it, err := s.popType(consts.BOOL)
+			if err != nil {
+				return err
+			}
+			if it.val.Prim != consts.True {
+				return nil
+			}
+			if err := i.execute(args[0], s); err != nil {
+				return err
+			}
+		}
+	case LOOPLEFT:
+		args, err := getArgs(instr, 1)
+		if err != nil {
+			return err
+		}
+		for {
+			it, err := s.popType(consts.OR)
+			if err != nil {
+				return err
+			}
+			if it.val.Prim != consts.Left {
+				s.push(it.typ.Args[1], it.val.Args[0])
+				return nil
+			}
+			s.push(it.typ.Args[0], it.val.Args[0])
+			if err := i.execute(args[0], s); err != nil {
+				return err
+			}
+		}
+	case MAP:
+		return i.mapInstruction(instr, s)
+	case ITER:
+		return i.iterInstruction(instr, s)
+	case EXEC:
+		items, err := s.popN(2)
+		if err != nil {
+			return err
+		}
+		lambda := items[1]
+		if err := checkType(lambda, consts.LAMBDA); err != nil {
+			return err
+		}
+		if !equalTypes(lambda.typ.Args[0], items[0].typ) {
+			return errors.Wrap(ErrTypeMismatch, EXEC)
+		}
+		result, err := i.exec(lambda, items[0].val)
+		if err != nil {
+			return err
+		}
+		s.pushItem(result)
+	case APPLY:
+		items, err := s.popN(2)
+		if err != nil {
+			return err
+		}
+		lambda := items[1]
+		if err := checkType(lambda, consts.LAMBDA); err != nil {
+			return err
+		}
+		if lambda.typ.Args[0].Prim != consts.PAIR || !equalTypes(lambda.typ.Args[0].Args[0], items[0].typ) {
+			return errors.Wrap(ErrTypeMismatch, APPLY)
+		}
+		code := seq(prim(PUSH, lambda.typ.Args[0].Args[0], items[0].val), prim(PAIR), lambda.val)
+		s.push(prim(consts.LAMBDA, lambda.typ.Args[0].Args[1], lambda.typ.Args[1]), code)
+	case FAILWITH:
+		it, err := s.pop()
+		if err != nil {
+			return err
+		}
+		return ScriptRejectedError{
+			Location: i.location,
+			With:     it.val,
+		}
+	case NEVER:
+		if _, err := s.popType(consts.NEVER); err != nil {
+			return err
+		}
+		return errors.Wrap(ErrInvalidValue, "value of type never")
+	}
+	return nil
+}
+
+func (i *Interpreter) exec(lambda item, arg *base.Node) (item, error) {
+	sub := newStack(item{lambda.typ.Args[0], arg})
+	if err := i.execute(lambda.val, sub); err != nil {
+		return item{}, err
+	}
+	if sub.len() != 1 {
+		return item{}, errors.Wrapf(ErrTypeMismatch, "lambda must return 1 item, got %d", sub.len())
+	}
+	result, err := sub.pop()
+	if err != nil {
+		return item{}, err
+	}
+	if !equalTypes(result.typ, lambda.typ.Args[1]) {
+		return item{}, errors.Wrap(ErrTypeMismatch, "lambda result")
+	}
+	return item{lambda.typ.Args[1], result.val}, nil
+}
+
+func (i *Interpreter) mapInstruction(instr *base.Node, s *stack) error {
+	args, err := getArgs(instr, 1)
+	if err != nil {
+		return err
+	}
+	it, err := s.popType(consts.LIST, consts.MAP)
+	if err != nil {
+		return err
+	}
+
+	resultType := unknownType
+	items := make([]*base.Node, len(it.val.Args))
+	for j, elem := range it.val.Args {
+		if it.typ.Prim == consts.LIST {
+			s.push(it.typ.Args[0], elem)
+		} else {
+			s.push(prim(consts.PAIR, it.typ.Args[0], it.typ.Args[1]), prim(consts.Pair, elem.Args[0], elem.Args[1]))
+		}
+		if err := i.execute(args[0], s); err != nil {
+			return err
+		}
+		result, err := s.pop()
+		if err != nil {
+			return err
+		}
+		resultType = mergeTypes(resultType, result.typ)
+		if it.typ.Prim == consts.LIST {
+			items[j] = result.val
+		} else {
+			items[j] = prim(consts.Elt, elem.Args[0], result.val)
+		}
+	}
+
+	if it.typ.Prim == consts.LIST {
+		s.push(prim(consts.LIST, resultType), seq(items...))
+	} else {
+		s.push(prim(consts.MAP, it.typ.Args[0], resultType), seq(items...))
+	}
+	return nil
+}
+
+func (i *Interpreter) iterInstruction(instr *base.Node, s *stack) error {
+	args, err := getArgs(instr, 1)
+	if err != nil {
+		return err
+	}
+	it, err := s.popType(consts.LIST, consts.SET, consts.MAP)
+	if err != nil {
+		return err
+	}
+	for _, elem := range it.val.Args {
+		if it.typ.Prim == consts.MAP {
+			s.push(prim(consts.PAIR, it.typ.Args[0], it.typ.Args[1]), prim(consts.Pair, elem.Args[0], elem.Args[1]))
+		} else {
+			s.push(it.typ.Args[0], elem)
+		}
+		if err := i.execute(args[0], s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Interpreter) collection(instr *base.Node, s *stack) error {
+	switch instr.Prim {
+	case SIZE:
+		it, err := s.popType(consts.STRING, consts.BYTES, consts.LIST, consts.SET, consts.MAP)
+		if err != nil {
+			return err
+		}
+		var size int
+		switch it.typ.Prim {
+		case consts.STRING:
+			size = len(*it.val.StringValue)
+		case consts.BYTES:
+			data, err := getBytes(it.val)
+			if err != nil {
+				return err
+			}
+			size = len(data)
+		default:
+			size = len(it.val.Args)
+		}
+		s.push(prim(consts.NAT), int64Node(int64(size)))
+	case MEM:
+		items, err := s.popN(2)
+		if err != nil {
+			return err
+		}
+		key, coll := items[0], items[1]
+		if err := checkType(coll, consts.SET, consts.MAP, consts.BIGMAP); err != nil {
+			return err
+		}
+		if !equalTypes(coll.typ.Args[0], key.typ) {
+			return errors.Wrap(ErrTypeMismatch, MEM)
+		}
+		var found bool
+		if coll.typ.Prim == consts.SET {
+			_, found, err = findInSet(coll.typ.Args[0], coll.val, key.val)
+		} else {
+			var value *base.Node
+			value, err = i.mapGet(coll.typ, coll.val, key.val)
+			found = value != nil
+		}
+		if err != nil {
+			return err
+		}
+		s.push(prim(consts.BOOL), boolNode(found))
+	case GET:
+		if len(instr.Args) == 1 {
+			n, err := getIntArg(instr, 0)
+			if err != nil {
+				return err
+			}
+			it, err := s.pop()
+			if err != nil {
+				return err
+			}
+			typ, value, err := combGet(it.typ, it.val, n)
+			if err != nil {
+				return err
+			}
+			s.push(typ, value)
+			return nil
+		}
+		items, err := s.popN(2)
+		if err != nil {
+			return err
+		}
+		key, coll := items[0], items[1]
+		if err := checkType(coll, consts.MAP, consts.BIGMAP); err != nil {
+			return err
+		}
+		if !equalTypes(coll.typ.Args[0], key.typ) {
+			return errors.Wrap(ErrTypeMismatch, GET)
+		}
+		value, err := i.mapGet(coll.typ, coll.val, key.val)
+		if err != nil {
+			return err
+		}
+		s.push(prim(consts.OPTION, coll.typ.Args[1]), optionNode(value))
+	case UPDATE:
+		if len(instr.Args) == 1 {
+			n, err := getIntArg(instr, 0)
+			if err != nil {
+				return err
+			}
+			items, err := s.popN(2)
+			if err != nil {
+				return err
+			}
+			typ, value, err := combUpdate(items[1].typ, items[1].val, n, items[0])
+			if err != nil {
+				return err
+			}
+			s.push(typ, value)
+			return nil
+		}
+		items, err := s.popN(3)
+		if err != nil {
+			return err
+		}
+		key, value, coll := items[0], items[1], items[2]
+		updated, typ, err := i.update(coll, key, value)
+		if err != nil {
+			return err
+		}
+		s.push(typ, updated)
+	case GETANDUPDATE:
+		items, err := s.popN(3)
+		if err != nil {
+			return err
+		}
+		key, value, coll := items[0], items[1], items[2]
+		if err := checkType(coll, consts.MAP, consts.BIGMAP); err != nil {
+			return err
+		}
+		old, err := i.mapGet(coll.typ, coll.val, key.val)
+		if err != nil {
+			return err
+		}
+		updated, typ, err := i.update(coll, key, value)
+		if err != nil {
+			return err
+		}
+		s.push(typ, updated)
+		s.push(prim(consts.OPTION, typ.Args[1]), optionNode(old))
+	case CONCAT:
+		return i.concat(s)
+	case SLICE:
+		items, err := s.popN(3)
+		if err != nil {
+			return err
+		}
+		if err := checkType(items[0], consts.NAT); err != nil {
+			return err
+		}
+		if err := checkType(items[1], consts.NAT); err != nil {
+			return err
+		}
+		if err := checkType(items[2], consts.STRING, consts.BYTES); err != nil {
+			return err
+		}
+		offset, length := items[0].val.IntValue.Int, items[1].val.IntValue.Int
+		var size int
+		var data []byte
+		if items[2].typ.Prim == consts.STRING {
+			size = len(*items[2].val.StringValue)
+		} else {
+			if data, err = getBytes(items[2].val); err != nil {
+				return err
+			}
+			size = len(data)
+		}
+		typ := prim(consts.OPTION, items[2].typ)
+		end := big.NewInt(0).Add(offset, length)
+		if end.Cmp(big.NewInt(int64(size))) > 0 {
+			s.push(typ, prim(consts.None))
+			return nil
+		}
+		from, to := int(offset.Int64()), int(end.Int64())
+		if items[2].typ.Prim == consts.STRING {
+			s.push(typ, prim(consts.Some, stringNode((*items[2].val.StringValue)[from:to])))
+		} else {
+			s.push(typ, prim(consts.Some, bytesNode(data[from:to])))
+		}
+	case PACK:
+		it, err := s.pop()
+		if err != nil {
+			return err
+		}
+		data, err := pack(it.typ, it.val)
+		if err != nil {
+			return err
+		}
+		s.push(prim(consts.BYTES), bytesNode(data))
+	case UNPACK:
+		typ, err := i.getTypeArg(instr, 0, 1)
+		if err != nil {
+			return err
+		}
+		it, err := s.popType(consts.BYTES)
+		if err != nil {
+			return err
+		}
+		data, err := getBytes(it.val)
+		if err != nil {
+			return err
+		}
+		s.push(prim(consts.OPTION, typ), optionNode(unpack(typ, data)))
+	}
+	return nil
+}
+
+func (i *Interpreter) update(coll, key, value item) (*base.Node, *base.Node, error) {
+	if err := checkType(coll, consts.SET, consts.MAP, consts.BIGMAP); err != nil {
+		return nil, nil, err
+	}
+	if !equalTypes(coll.typ.Args[0], key.typ) {
+		return nil, nil, errors.Wrap(ErrTypeMismatch, UPDATE)
+	}
+
+	if coll.typ.Prim == consts.SET {
+		if err := checkType(value, consts.BOOL); err != nil {
+			return nil, nil, err
+		}
+		typ := prim(consts.SET, mergeTypes(coll.typ.Args[0], key.typ))
+		idx, found, err := findInSet(typ.Args[0], coll.val, key.val)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch {
+		case value.val.Prim == consts.True && !found:
+			return seq(insertAt(coll.val.Args, idx, key.val)...), typ, nil
+		case value.val.Prim == consts.False && found:
+			return seq(removeAt(coll.val.Args, idx)...), typ, nil
+		default:
+			return coll.val, typ, nil
+		}
+	}
+
+	if err := checkType(value, consts.OPTION); err != nil {
+		return nil, nil, err
+	}
+	if !equalTypes(coll.typ.Args[1], value.typ.Args[0]) {
+		return nil, nil, errors.Wrap(ErrTypeMismatch, UPDATE)
+	}
+	typ := prim(coll.typ.Prim, mergeTypes(coll.typ.Args[0], key.typ), mergeTypes(coll.typ.Args[1], value.typ.Args[0]))
+	var newValue *base.Node
+	if value.val.Prim == consts.Some {
+		newValue = value.val.Args[0]
+	}
+	updated, err := i.mapUpdate(typ, coll.val, key.val, newValue)
+	return updated, typ, err
+}
+
+func (i *Interpreter) concat(s *stack) error {
+	top, err := s.popType(consts.STRING, consts.BYTES, consts.LIST)
+	if err != nil {
+		return err
+	}
+
+	var parts []*base.Node
+	typ := top.typ
+	if top.typ.Prim == consts.LIST {
+		typ = top.typ.Args[0]
+		if typ != unknownType && typ.Prim != consts.STRING && typ.Prim != consts.BYTES {
+			return errors.Wrap(ErrTypeMismatch, CONCAT)
+		}
+		parts = top.val.Args
+		if typ == unknownType {
+			return errors.Wrap(ErrTypeMismatch, "can't infer type of CONCAT result")
+		}
+	} else {
+		second, err := s.popType(top.typ.Prim)
+		if err != nil {
+			return err
+		}
+		parts = []*base.Node{top.val, second.val}
+	}
+
+	if typ.Prim == consts.STRING {
+		var builder strings.Builder
+		for _, part := range parts {
+			str, err := getString(part)
+			if err != nil {
+				return err
+			}
+			builder.WriteString(str)
+		}
+		s.push(typ, stringNode(builder.String()))
+		return nil
+	}
+
+	result := make([]byte, 0)
+	for _, part := range parts {
+		data, err := getBytes(part)
+		if err != nil {
+			return err
+		}
+		result = append(result, data...)
+	}
+	s.push(typ, bytesNode(result))
+	return nil
+}
+
+func optionNode(value *base.Node) *base.Node {
+	if value == nil {
+		return prim(consts.None)
+	}
+	return prim(consts.Some, value)
+}
+
+func unpack(typ *base.Node, data []byte) *base.Node {
+	if len(data) == 0 || data[0] != 0x05 {
+		return nil
+	}
+	nodes, err := forge.Unpack(data)
+	if err != nil || len(nodes) != 1 {
+		return nil
+	}
+	value, err := normalizeValue(typ, nodes[0])
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+// combGet - `GET n` for right combs: 0 is the whole comb, odd indices are left elements, even indices are right tails
+func combGet(typ, value *base.Node, n int) (*base.Node, *base.Node, error) {
+	for ; n > 1; n -= 2 {
+		if typ.Prim != consts.PAIR {
+			return nil, nil, errors.Wrap(ErrTypeMismatch, GET)
+		}
+		typ, value = typ.Args[1], value.Args[1]
+	}
+	if n == 0 {
+		return typ, value, nil
+	}
+	if typ.Prim != consts.PAIR {
+		return nil, nil, errors.Wrap(ErrTypeMismatch, GET)
+	}
+	return typ.Args[0], value.Args[0], nil
+}
+
+// combUpdate - `UPDATE n` for right combs
+func combUpdate(typ, value *base.Node, n int, newItem item) (*base.Node, *base.Node, error) {
+	if n == 0 {
+		return newItem.typ, newItem.val, nil
+	}
+	if typ.Prim != consts.PAIR {
+		return nil, nil, errors.Wrap(ErrTypeMismatch, UPDATE)
+	}
+	if n == 1 {
+		return prim(consts.PAIR, newItem.typ, typ.Args[1]), prim(consts.Pair, newItem.val, value.Args[1]), nil
+	}
+	tailType, tail, err := combUpdate(typ.Args[1], value.Args[1], n-2, newItem)
+	if err != nil {
+		return nil, nil, err
+	}
+	return prim(consts.PAIR, typ.Args[0], tailType), prim(consts.Pair, value.Args[0], tail), nil
+}
+
+func (i *Interpreter) comparison(instr *base.Node, s *stack) error {
+	if instr.Prim == COMPARE {
+		items, err := s.popN(2)
+		if err != nil {
+			return err
+		}
+		if !equalTypes(items[0].typ, items[1].typ) {
+			return errors.Wrap(ErrTypeMismatch, COMPARE)
+		}
+		res, err := compareValues(items[0].typ, items[0].val, items[1].val)
+		if err != nil {
+			return err
+		}
+		s.push(prim(consts.INT), int64Node(int64(res)))
+		return nil
+	}
+
+	it, err := s.popType(consts.INT)
+	if err != nil {
+		return err
+	}
+	var result bool
+	switch res := it.val.IntValue.Sign(); instr.Prim {
+	case EQ:
+		result = res == 0
+	case NEQ:
+		result = res != 0
+	case LT:
+		result = res < 0
+	case GT:
+		result = res > 0
+	case LE:
+		result = res <= 0
+	case GE:
+		result = res >= 0
+	}
+	s.push(prim(consts.BOOL), boolNode(result))
+	return nil
+}
+
+func (i *Interpreter) blockchain(instr *base.Node, s *stack) error {
+	switch instr.Prim {
+	case SELF:
+		entrypoint := getEntrypointAnnot(instr)
+		if entrypoint == "" {
+			entrypoint = consts.DefaultEntrypoint
+		}
+		typ := i.parameter
+		address := i.ctx.Self
+		if _, subtype, ok := findEntrypoint(i.parameter, entrypoint); ok {
+			typ = subtype
+			if entrypoint != consts.DefaultEntrypoint {
+				address += "%" + entrypoint
+			}
+		} else if entrypoint != consts.DefaultEntrypoint {
+			return errors.Wrapf(ErrInvalidScript, "unknown entrypoint: %s", entrypoint)
+		}
+		normalized, err := normalizeType(typ)
+		if err != nil {
+			return err
+		}
+		s.push(prim(consts.CONTRACT, normalized), stringNode(address))
+	case SELFADDRESS:
+		s.push(prim(consts.ADDRESS), stringNode(i.ctx.Self))
+	case AMOUNT:
+		s.push(prim(consts.MUTEZ), int64Node(i.ctx.Amount))
+	case BALANCE:
+		if i.strict && i.ctx.Balance == 0 {
+			return errors.Wrap(ErrUnsupported, "BALANCE without balance in context")
+		}
+		s.push(prim(consts.MUTEZ), int64Node(i.ctx.Balance))
+	case NOW:
+		if i.strict && i.ctx.Now == 0 {
+			return errors.Wrap(ErrUnsupported, "NOW without timestamp in context")
+		}
+		s.push(prim(consts.TIMESTAMP), int64Node(i.ctx.Now))
+	case LEVEL:
+		if i.strict && i.ctx.Level == 0 {
+			return errors.Wrap(ErrUnsupported, "LEVEL without level in context")
+		}
+		s.push(prim(consts.NAT), int64Node(i.ctx.Level))
+	case CHAINID:
+		s.push(prim(consts.CHAINID), stringNode(i.ctx.ChainID))
+	case SOURCE:
+		s.push(prim(consts.ADDRESS), stringNode(i.ctx.Source))
+	case SENDER:
+		s.push(prim(consts.ADDRESS), stringNode(i.ctx.Sender))
+	case ADDRESS:
+		it, err := s.popType(consts.CONTRACT)
+		if err != nil {
+			return err
+		}
+		s.push(prim(consts.ADDRESS), it.val)
+	case CONTRACT:
+		return i.contract(instr, s)
+	case IMPLICITACCOUNT:
+		it, err := s.popType(consts.KEYHASH)
+		if err != nil {
+			return err
+		}
+		s.push(prim(consts.CONTRACT, prim(consts.UNIT)), it.val)
+	case TRANSFERTOKENS:
+		items, err := s.popN(3)
+		if err != nil {
+			return err
+		}
+		param, amount, contract := items[0], items[1], items[2]
+		if err := checkType(amount, consts.MUTEZ); err != nil {
+			return err
+		}
+		if err := checkType(contract, consts.CONTRACT); err != nil {
+			return err
+		}
+		if !equalTypes(contract.typ.Args[0], param.typ) {
+			return errors.Wrap(ErrTypeMismatch, TRANSFERTOKENS)
+		}
+		s.push(prim(consts.OPERATION), prim(opTransaction, param.val, amount.val, contract.val))
+	case SETDELEGATE:
+		it, err := s.popType(consts.OPTION)
+		if err != nil {
+			return err
+		}
+		if it.typ.Args[0].Prim != consts.KEYHASH {
+			return errors.Wrap(ErrTypeMismatch, SETDELEGATE)
+		}
+		s.push(prim(consts.OPERATION), prim(opDelegation, it.val))
+	}
+	return nil
+}
+
+func (i *Interpreter) contract(instr *base.Node, s *stack) error {
+	typ, err := i.getTypeArg(instr, 0, 1)
+	if err != nil {
+		return err
+	}
+	it, err := s.popType(consts.ADDRESS)
+	if err != nil {
+		return err
+	}
+	resultType := prim(consts.OPTION, prim(consts.CONTRACT, typ))
+
+	str, err := getString(it.val)
+	if err != nil {
+		return err
+	}
+	address, entrypoint := splitAddress(str)
+	if annot := getEntrypointAnnot(instr); annot != "" {
+		if entrypoint != "" {
+			s.push(resultType, prim(consts.None))
+			return nil
+		}
+		entrypoint = annot
+	}
+	if entrypoint == "" {
+		entrypoint = consts.DefaultEntrypoint
+	}
+	value := address
+	if entrypoint != consts.DefaultEntrypoint {
+		value += "%" + entrypoint
+	}
+
+	switch {
+	case address == i.ctx.Self:
+		_, subtype, ok := findEntrypoint(i.parameter, entrypoint)
+		if !ok && entrypoint == consts.DefaultEntrypoint {
+			subtype, ok = i.parameter, true
+		}
+		if ok {
+			normalized, err := normalizeType(subtype)
+			if err != nil {
+				return err
+			}
+			if equalTypes(normalized, typ) {
+				s.push(resultType, prim(consts.Some, stringNode(value)))
+				return nil
+			}
+		}
+		s.push(resultType, prim(consts.None))
+	case strings.HasPrefix(address, "tz"):
+		if typ.Prim == consts.UNIT && entrypoint == consts.DefaultEntrypoint {
+			s.push(resultType, prim(consts.Some, stringNode(value)))
+		} else {
+			s.push(resultType, prim(consts.None))
+		}
+	default:
+		return errors.Wrap(ErrUnknownContract, address)
+	}
+	return nil
+}
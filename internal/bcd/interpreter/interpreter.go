@@ -0,0 +1,472 @@
+package interpreter
+
+import (
+	"sort"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// Context - execution context of the script
+type Context struct {
+	Source   string
+	Sender   string
+	Self     string
+	Amount   int64
+	Balance  int64
+	ChainID  string
+	Now      int64
+	Level    int64
+	GasLimit int64
+}
+
+// BigMapGetter - receives values of big maps which are stored on chain. Returns `nil` if key is absent.
+type BigMapGetter interface {
+	GetBigMapValue(ptr int64, keyHash string) (*base.Node, error)
+}
+
+// Option -
+type Option func(*Interpreter)
+
+// WithTrace - collects stack after every executed instruction
+func WithTrace() Option {
+	return func(i *Interpreter) {
+		i.trace = true
+	}
+}
+
+// WithStrictContext - instructions reading block context (`BALANCE`, `NOW`, `LEVEL`) return `ErrUnsupported` if their values are not set in `Context`, so script can be executed by node instead
+func WithStrictContext() Option {
+	return func(i *Interpreter) {
+		i.strict = true
+	}
+}
+
+// WithBigMapGetter - sets source of on-chain big map values
+func WithBigMapGetter(getter BigMapGetter) Option {
+	return func(i *Interpreter) {
+		i.getter = getter
+	}
+}
+
+// Script -
+type Script struct {
+	Parameter *base.Node
+	Storage   *base.Node
+	Code      *base.Node
+
+	root *base.Node
+}
+
+// ParseScript - parses micheline script which consists of `parameter`, `storage` and `code` sections
+func ParseScript(data []byte) (*Script, error) {
+	var root base.Node
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	if !isSeq(&root) {
+		return nil, errors.Wrap(ErrInvalidScript, "script must be an array")
+	}
+	if len(root.Args) == 1 && isSeq(root.Args[0]) {
+		root = *root.Args[0]
+	}
+
+	script := Script{root: &root}
+	for _, section := range root.Args {
+		if len(section.Args) != 1 {
+			return nil, errors.Wrapf(ErrInvalidScript, "invalid section: %s", section.Prim)
+		}
+		switch section.Prim {
+		case consts.PARAMETER:
+			script.Parameter = section.Args[0]
+		case consts.STORAGE:
+			script.Storage = section.Args[0]
+		case consts.CODE:
+			script.Code = section.Args[0]
+		}
+	}
+	if script.Parameter == nil || script.Storage == nil || script.Code == nil {
+		return nil, errors.Wrap(ErrInvalidScript, "script must contain parameter, storage and code sections")
+	}
+	return &script, nil
+}
+
+// Operation - operation emitted by the script
+type Operation struct {
+	Kind        string
+	Destination string
+	Entrypoint  string
+	Amount      int64
+	Parameters  *base.Node
+	Delegate    *string
+}
+
+// BigMapDiff - update of on-chain big map. `Value` is nil if key was removed.
+type BigMapDiff struct {
+	Ptr     int64
+	KeyHash string
+	Key     *base.Node
+	Value   *base.Node
+}
+
+// TraceItem - state of execution after instruction. `Gas` and `Milligas` are remaining gas.
+type TraceItem struct {
+	Location int64       `json:"location"`
+	Gas      int64       `json:"gas"`
+	Milligas int64       `json:"milligas"`
+	Stack    []StackItem `json:"stack"`
+}
+
+// Result - result of script execution
+type Result struct {
+	Storage          *base.Node
+	Operations       []Operation
+	BigMapDiffs      []BigMapDiff
+	ConsumedGas      int64
+	ConsumedMilligas int64
+	Trace            []TraceItem
+}
+
+// Interpreter - executes Michelson scripts without node
+type Interpreter struct {
+	ctx    Context
+	trace  bool
+	strict bool
+	getter BigMapGetter
+
+	gas       int64
+	location  int64
+	locations map[*base.Node]int64
+	parameter *base.Node
+	bigMaps   map[int64]*bigMapState
+	tempID    int64
+	result    *Result
+}
+
+// New -
+func New(ctx Context, opts ...Option) *Interpreter {
+	if ctx.Source == "" {
+		ctx.Source = DefaultSource
+	}
+	if ctx.Sender == "" {
+		ctx.Sender = ctx.Source
+	}
+	if ctx.Self == "" {
+		ctx.Self = DefaultSelf
+	}
+	if ctx.ChainID == "" {
+		ctx.ChainID = DefaultChainID
+	}
+	if ctx.GasLimit <= 0 {
+		ctx.GasLimit = DefaultHardGasLimit
+	}
+
+	i := &Interpreter{ctx: ctx}
+	for _, opt := range opts {
+		opt(i)
+	}
+	if i.ctx.Now == 0 && !i.strict {
+		i.ctx.Now = time.Now().UTC().Unix()
+	}
+	return i
+}
+
+// Run - executes `script` with `parameter` passed to `entrypoint` and initial `storage`
+func (i *Interpreter) Run(script *Script, entrypoint string, parameter, storage *base.Node) (*Result, error) {
+	i.gas = i.ctx.GasLimit * milligasInGas
+	i.location = 0
+	i.locations = getLocations(script.root)
+	i.bigMaps = make(map[int64]*bigMapState)
+	i.tempID = 0
+	i.result = &Result{
+		Operations:  make([]Operation, 0),
+		BigMapDiffs: make([]BigMapDiff, 0),
+	}
+	if i.trace {
+		i.result.Trace = make([]TraceItem, 0)
+	}
+
+	if err := i.consume(parsingCost(script.root) + parsingCost(parameter) + parsingCost(storage)); err != nil {
+		return nil, err
+	}
+
+	parameterType, err := normalizeType(script.Parameter)
+	if err != nil {
+		return nil, err
+	}
+	i.parameter = script.Parameter
+
+	storageType, err := normalizeType(script.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	param, err := i.getParameterValue(entrypoint, parameter)
+	if err != nil {
+		return nil, err
+	}
+	param, err = normalizeValue(parameterType, param)
+	if err != nil {
+		return nil, errors.Wrap(err, "parameter")
+	}
+	initial, err := normalizeValue(storageType, storage)
+	if err != nil {
+		return nil, errors.Wrap(err, "storage")
+	}
+
+	s := newStack()
+	s.push(prim(consts.PAIR, parameterType, storageType), prim(consts.Pair, param, initial))
+	if err := i.execute(script.Code, s); err != nil {
+		return nil, err
+	}
+
+	if s.len() != 1 {
+		return nil, errors.Wrapf(ErrTypeMismatch, "final stack must contain 1 item, got %d", s.len())
+	}
+	final, err := s.pop()
+	if err != nil {
+		return nil, err
+	}
+	resultType := prim(consts.PAIR, prim(consts.LIST, prim(consts.OPERATION)), storageType)
+	if !equalTypes(final.typ, resultType) {
+		return nil, errors.Wrap(ErrTypeMismatch, "final stack must be pair of operations and storage")
+	}
+
+	for _, op := range final.val.Args[0].Args {
+		operation, err := parseOperation(op)
+		if err != nil {
+			return nil, err
+		}
+		i.result.Operations = append(i.result.Operations, operation)
+	}
+
+	if i.result.Storage, err = i.finalize(storageType, final.val.Args[1]); err != nil {
+		return nil, err
+	}
+
+	i.result.ConsumedMilligas = i.ctx.GasLimit*milligasInGas - i.gas
+	i.result.ConsumedGas = (i.result.ConsumedMilligas + milligasInGas - 1) / milligasInGas
+	return i.result, nil
+}
+
+func (i *Interpreter) execute(code *base.Node, s *stack) error {
+	if isSeq(code) {
+		for _, instr := range code.Args {
+			if err := i.execute(instr, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if loc, ok := i.locations[code]; ok {
+		i.location = loc
+	}
+	location := i.location
+
+	expanded, ok, err := expandMacro(code)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return i.execute(expanded, s)
+	}
+
+	if err := i.consume(instructionCost(code.Prim, s)); err != nil {
+		return err
+	}
+	if err := i.step(code, s); err != nil {
+		return err
+	}
+
+	if i.trace {
+		i.result.Trace = append(i.result.Trace, TraceItem{
+			Location: location,
+			Gas:      i.gas / milligasInGas,
+			Milligas: i.gas,
+			Stack:    s.export(),
+		})
+	}
+	return nil
+}
+
+func (i *Interpreter) getParameterValue(entrypoint string, value *base.Node) (*base.Node, error) {
+	if entrypoint == "" {
+		entrypoint = consts.DefaultEntrypoint
+	}
+	path, _, ok := findEntrypoint(i.parameter, entrypoint)
+	if !ok {
+		if entrypoint != consts.DefaultEntrypoint {
+			return nil, errors.Wrapf(ErrInvalidValue, "unknown entrypoint: %s", entrypoint)
+		}
+		path = nil
+	}
+	for j := len(path) - 1; j >= 0; j-- {
+		value = prim(path[j], value)
+	}
+	return value, nil
+}
+
+// findEntrypoint - returns path of `Left` and `Right` to entrypoint and its type
+func findEntrypoint(typ *base.Node, name string) ([]string, *base.Node, bool) {
+	for _, annot := range typ.Annots {
+		if annot == "%"+name {
+			return []string{}, typ, true
+		}
+	}
+	if typ.Prim != consts.OR || len(typ.Args) != 2 {
+		return nil, nil, false
+	}
+	for j, branch := range []string{consts.Left, consts.Right} {
+		if path, subtype, ok := findEntrypoint(typ.Args[j], name); ok {
+			return append([]string{branch}, path...), subtype, true
+		}
+	}
+	return nil, nil, false
+}
+
+// getLocations - numbers nodes of the script in preorder like the node does
+func getLocations(root *base.Node) map[*base.Node]int64 {
+	locations := make(map[*base.Node]int64)
+	var counter int64
+	var walk func(node *base.Node)
+	walk = func(node *base.Node) {
+		locations[node] = counter
+		counter++
+		for _, arg := range node.Args {
+			walk(arg)
+		}
+	}
+	if root != nil {
+		walk(root)
+	}
+	return locations
+}
+
+func parseOperation(op *base.Node) (Operation, error) {
+	switch op.Prim {
+	case opTransaction:
+		amount, err := getInt(op.Args[1])
+		if err != nil {
+			return Operation{}, err
+		}
+		destination, err := getString(op.Args[2])
+		if err != nil {
+			return Operation{}, err
+		}
+		address, entrypoint := splitAddress(destination)
+		if entrypoint == "" {
+			entrypoint = consts.DefaultEntrypoint
+		}
+		return Operation{
+			Kind:        consts.Transaction,
+			Destination: address,
+			Entrypoint:  entrypoint,
+			Amount:      amount.Int64(),
+			Parameters:  op.Args[0],
+		}, nil
+	case opDelegation:
+		operation := Operation{
+			Kind: consts.Delegation,
+		}
+		if op.Args[0].Prim == consts.Some {
+			delegate, err := getString(op.Args[0].Args[0])
+			if err != nil {
+				return Operation{}, err
+			}
+			operation.Delegate = &delegate
+		}
+		return operation, nil
+	default:
+		return Operation{}, errors.Wrapf(ErrInvalidValue, "unknown operation: %s", op.Prim)
+	}
+}
+
+// finalize - replaces temporary big maps in `value` with on-chain pointers and collects big map diffs
+func (i *Interpreter) finalize(typ, value *base.Node) (*base.Node, error) {
+	if typ == unknownType || len(typ.Args) == 0 {
+		return value, nil
+	}
+	switch typ.Prim {
+	case consts.BIGMAP:
+		if value.IntValue == nil {
+			return value, nil
+		}
+		id := value.IntValue.Int64()
+		state, ok := i.bigMaps[id]
+		if !ok {
+			return value, nil
+		}
+		hashes := make([]string, 0, len(state.values))
+		for hash := range state.values {
+			hashes = append(hashes, hash)
+		}
+		sort.Strings(hashes)
+		for _, hash := range hashes {
+			entry := state.values[hash]
+			i.result.BigMapDiffs = append(i.result.BigMapDiffs, BigMapDiff{
+				Ptr:     state.parent,
+				KeyHash: hash,
+				Key:     entry.key,
+				Value:   entry.value,
+			})
+		}
+		return int64Node(state.parent), nil
+	case consts.PAIR:
+		left, err := i.finalize(typ.Args[0], value.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		right, err := i.finalize(typ.Args[1], value.Args[1])
+		if err != nil {
+			return nil, err
+		}
+		return prim(value.Prim, left, right), nil
+	case consts.OPTION:
+		if value.Prim == consts.None {
+			return value, nil
+		}
+		arg, err := i.finalize(typ.Args[0], value.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		return prim(value.Prim, arg), nil
+	case consts.OR:
+		idx := 0
+		if value.Prim == consts.Right {
+			idx = 1
+		}
+		arg, err := i.finalize(typ.Args[idx], value.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		return prim(value.Prim, arg), nil
+	case consts.LIST:
+		items := make([]*base.Node, len(value.Args))
+		for j := range value.Args {
+			item, err := i.finalize(typ.Args[0], value.Args[j])
+			if err != nil {
+				return nil, err
+			}
+			items[j] = item
+		}
+		return seq(items...), nil
+	case consts.MAP:
+		items := make([]*base.Node, len(value.Args))
+		for j := range value.Args {
+			val, err := i.finalize(typ.Args[1], value.Args[j].Args[1])
+			if err != nil {
+				return nil, err
+			}
+			items[j] = prim(consts.Elt, value.Args[j].Args[0], val)
+		}
+		return seq(items...), nil
+	default:
+		return value, nil
+	}
+}
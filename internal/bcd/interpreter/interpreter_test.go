@@ -0,0 +1,345 @@
+package interpreter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildScript(parameter, storage, code string) string {
+	return fmt.Sprintf(`[{"prim":"parameter","args":[%s]},{"prim":"storage","args":[%s]},{"prim":"code","args":[%s]}]`, parameter, storage, code)
+}
+
+func parseNode(t *testing.T, data string) *base.Node {
+	var node base.Node
+	if err := json.UnmarshalFromString(data, &node); err != nil {
+		t.Fatalf("UnmarshalFromString error: %v", err)
+	}
+	return &node
+}
+
+type testBigMapGetter map[string]string
+
+func (g testBigMapGetter) GetBigMapValue(ptr int64, keyHash string) (*base.Node, error) {
+	value, ok := g[fmt.Sprintf("%d:%s", ptr, keyHash)]
+	if !ok {
+		return nil, nil
+	}
+	var node base.Node
+	if err := json.UnmarshalFromString(value, &node); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+func TestInterpreter_Run(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameter  string
+		storage    string
+		code       string
+		entrypoint string
+		input      string
+		initial    string
+		want       string
+		wantErr    error
+	}{
+		{
+			name:      "add",
+			parameter: `{"prim":"nat"}`,
+			storage:   `{"prim":"int"}`,
+			code:      `[{"prim":"UNPAIR"},{"prim":"ADD"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]`,
+			input:     `{"int":"10"}`,
+			initial:   `{"int":"-3"}`,
+			want:      `{"int":"7"}`,
+		}, {
+			name:      "euclidean division",
+			parameter: `{"prim":"int"}`,
+			storage:   `{"prim":"option","args":[{"prim":"pair","args":[{"prim":"int"},{"prim":"nat"}]}]}`,
+			code:      `[{"prim":"CAR"},{"prim":"PUSH","args":[{"prim":"int"},{"int":"2"}]},{"prim":"SWAP"},{"prim":"EDIV"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]`,
+			input:     `{"int":"-7"}`,
+			initial:   `{"prim":"None"}`,
+			want:      `{"prim":"Some","args":[{"prim":"Pair","args":[{"int":"-4"},{"int":"1"}]}]}`,
+		}, {
+			name:      "macros",
+			parameter: `{"prim":"pair","args":[{"prim":"nat"},{"prim":"nat"}]}`,
+			storage:   `{"prim":"pair","args":[{"prim":"nat"},{"prim":"pair","args":[{"prim":"nat"},{"prim":"bool"}]}]}`,
+			code:      `[{"prim":"DUP"},{"prim":"CAAR"},{"prim":"DIP","args":[[{"prim":"CDAR"}]]},{"prim":"DUUP"},{"prim":"DUUP"},{"prim":"CMPGT"},{"prim":"DUG","args":[{"int":"2"}]},{"prim":"PAPAIR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]`,
+			input:     `{"prim":"Pair","args":[{"int":"5"},{"int":"3"}]}`,
+			initial:   `{"prim":"Pair","args":[{"int":"0"},{"int":"0"},{"prim":"False"}]}`,
+			want:      `{"prim":"Pair","args":[{"int":"5"},{"prim":"Pair","args":[{"int":"0"},{"prim":"True"}]}]}`,
+		}, {
+			name:      "map update",
+			parameter: `{"prim":"nat"}`,
+			storage:   `{"prim":"map","args":[{"prim":"string"},{"prim":"nat"}]}`,
+			code:      `[{"prim":"UNPAIR"},{"prim":"SOME"},{"prim":"PUSH","args":[{"prim":"string"},{"string":"b"}]},{"prim":"UPDATE"},{"prim":"NONE","args":[{"prim":"nat"}]},{"prim":"PUSH","args":[{"prim":"string"},{"string":"c"}]},{"prim":"UPDATE"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]`,
+			input:     `{"int":"2"}`,
+			initial:   `[{"prim":"Elt","args":[{"string":"a"},{"int":"1"}]},{"prim":"Elt","args":[{"string":"c"},{"int":"3"}]}]`,
+			want:      `[{"prim":"Elt","args":[{"string":"a"},{"int":"1"}]},{"prim":"Elt","args":[{"string":"b"},{"int":"2"}]}]`,
+		}, {
+			name:       "entrypoint",
+			parameter:  `{"prim":"or","args":[{"prim":"nat","annots":["%increment"]},{"prim":"nat","annots":["%decrement"]}]}`,
+			storage:    `{"prim":"int"}`,
+			code:       `[{"prim":"UNPAIR"},{"prim":"IF_LEFT","args":[[{"prim":"ADD"}],[{"prim":"SWAP"},{"prim":"SUB"}]]},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]`,
+			entrypoint: "decrement",
+			input:      `{"int":"5"}`,
+			initial:    `{"int":"3"}`,
+			want:       `{"int":"-2"}`,
+		}, {
+			name:      "lambda",
+			parameter: `{"prim":"int"}`,
+			storage:   `{"prim":"int"}`,
+			code:      `[{"prim":"CAR"},{"prim":"LAMBDA","args":[{"prim":"pair","args":[{"prim":"int"},{"prim":"int"}]},{"prim":"int"},[{"prim":"UNPAIR"},{"prim":"MUL"}]]},{"prim":"PUSH","args":[{"prim":"int"},{"int":"3"}]},{"prim":"APPLY"},{"prim":"SWAP"},{"prim":"EXEC"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]`,
+			input:     `{"int":"7"}`,
+			initial:   `{"int":"0"}`,
+			want:      `{"int":"21"}`,
+		}, {
+			name:      "map and iter",
+			parameter: `{"prim":"list","args":[{"prim":"nat"}]}`,
+			storage:   `{"prim":"nat"}`,
+			code:      `[{"prim":"CAR"},{"prim":"MAP","args":[[{"prim":"PUSH","args":[{"prim":"nat"},{"int":"2"}]},{"prim":"MUL"}]]},{"prim":"PUSH","args":[{"prim":"nat"},{"int":"0"}]},{"prim":"SWAP"},{"prim":"ITER","args":[[{"prim":"ADD"}]]},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]`,
+			input:     `[{"int":"1"},{"int":"2"},{"int":"3"}]`,
+			initial:   `{"int":"0"}`,
+			want:      `{"int":"12"}`,
+		}, {
+			name:      "pack and unpack",
+			parameter: `{"prim":"pair","args":[{"prim":"string"},{"prim":"address"}]}`,
+			storage:   `{"prim":"option","args":[{"prim":"pair","args":[{"prim":"string"},{"prim":"address"}]}]}`,
+			code:      `[{"prim":"CAR"},{"prim":"PACK"},{"prim":"UNPACK","args":[{"prim":"pair","args":[{"prim":"string"},{"prim":"address"}]}]},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]`,
+			input:     `{"prim":"Pair","args":[{"string":"test"},{"string":"tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU"}]}`,
+			initial:   `{"prim":"None"}`,
+			want:      `{"prim":"Some","args":[{"prim":"Pair","args":[{"string":"test"},{"string":"tz1Ke2h7sDdakHJQh8WX4Z372du1KChsksyU"}]}]}`,
+		}, {
+			name:      "failwith",
+			parameter: `{"prim":"unit"}`,
+			storage:   `{"prim":"unit"}`,
+			code:      `[{"prim":"DROP"},{"prim":"PUSH","args":[{"prim":"string"},{"string":"error"}]},{"prim":"FAILWITH"}]`,
+			input:     `{"prim":"Unit"}`,
+			initial:   `{"prim":"Unit"}`,
+			wantErr:   ScriptRejectedError{Location: 11, With: stringNode("error")},
+		}, {
+			name:      "gas exhausted",
+			parameter: `{"prim":"unit"}`,
+			storage:   `{"prim":"unit"}`,
+			code:      `[{"prim":"PUSH","args":[{"prim":"bool"},{"prim":"True"}]},{"prim":"LOOP","args":[[{"prim":"PUSH","args":[{"prim":"bool"},{"prim":"True"}]}]]},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]`,
+			input:     `{"prim":"Unit"}`,
+			initial:   `{"prim":"Unit"}`,
+			wantErr:   ErrGasExhausted,
+		}, {
+			name:      "unsupported instruction",
+			parameter: `{"prim":"unit"}`,
+			storage:   `{"prim":"nat"}`,
+			code:      `[{"prim":"DROP"},{"prim":"TOTAL_VOTING_POWER"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]`,
+			input:     `{"prim":"Unit"}`,
+			initial:   `{"int":"0"}`,
+			wantErr:   ErrUnsupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script, err := ParseScript([]byte(buildScript(tt.parameter, tt.storage, tt.code)))
+			if err != nil {
+				t.Errorf("ParseScript() error = %v", err)
+				return
+			}
+
+			i := New(Context{GasLimit: 10000})
+			result, err := i.Run(script, tt.entrypoint, parseNode(t, tt.input), parseNode(t, tt.initial))
+			if tt.wantErr != nil {
+				if rejected, ok := tt.wantErr.(ScriptRejectedError); ok {
+					assert.Equal(t, rejected, err)
+				} else {
+					assert.True(t, errors.Is(err, tt.wantErr), "error = %v, wantErr = %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Run() error = %v", err)
+				return
+			}
+			got, err := json.MarshalToString(result.Storage)
+			if err != nil {
+				t.Errorf("MarshalToString error = %v", err)
+				return
+			}
+			assert.JSONEq(t, tt.want, got)
+			assert.Greater(t, result.ConsumedGas, int64(0))
+		})
+	}
+}
+
+func TestInterpreter_Operations(t *testing.T) {
+	code := `[{"prim":"CAR"},{"prim":"IMPLICIT_ACCOUNT"},{"prim":"PUSH","args":[{"prim":"mutez"},{"int":"100"}]},{"prim":"UNIT"},{"prim":"TRANSFER_TOKENS"},{"prim":"DIP","args":[[{"prim":"NIL","args":[{"prim":"operation"}]}]]},{"prim":"CONS"},{"prim":"UNIT"},{"prim":"SWAP"},{"prim":"PAIR"}]`
+	script, err := ParseScript([]byte(buildScript(`{"prim":"key_hash"}`, `{"prim":"unit"}`, code)))
+	if err != nil {
+		t.Errorf("ParseScript() error = %v", err)
+		return
+	}
+
+	result, err := New(Context{}).Run(script, "", stringNode(DefaultSource), prim("Unit"))
+	if err != nil {
+		t.Errorf("Run() error = %v", err)
+		return
+	}
+	if assert.Len(t, result.Operations, 1) {
+		assert.Equal(t, "transaction", result.Operations[0].Kind)
+		assert.Equal(t, DefaultSource, result.Operations[0].Destination)
+		assert.Equal(t, "default", result.Operations[0].Entrypoint)
+		assert.Equal(t, int64(100), result.Operations[0].Amount)
+	}
+}
+
+func TestInterpreter_StrictContext(t *testing.T) {
+	code := `[{"prim":"DROP"},{"prim":"LEVEL"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]`
+	script, err := ParseScript([]byte(buildScript(`{"prim":"unit"}`, `{"prim":"nat"}`, code)))
+	if err != nil {
+		t.Errorf("ParseScript() error = %v", err)
+		return
+	}
+
+	_, err = New(Context{}, WithStrictContext()).Run(script, "", prim("Unit"), int64Node(0))
+	assert.True(t, IsUnsupported(err), "Run() error = %v, want unsupported", err)
+
+	result, err := New(Context{Level: 100}, WithStrictContext()).Run(script, "", prim("Unit"), int64Node(0))
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(100), result.Storage.IntValue.Int64())
+	}
+}
+
+func TestInterpreter_BigMap(t *testing.T) {
+	storage := `{"prim":"big_map","args":[{"prim":"string"},{"prim":"nat"}]}`
+	code := `[{"prim":"UNPAIR"},{"prim":"DUP","args":[{"int":"2"}]},{"prim":"DUP","args":[{"int":"2"}]},{"prim":"GET"},{"prim":"IF_NONE","args":[[{"prim":"PUSH","args":[{"prim":"nat"},{"int":"0"}]}],[]]},{"prim":"PUSH","args":[{"prim":"nat"},{"int":"1"}]},{"prim":"ADD"},{"prim":"SOME"},{"prim":"SWAP"},{"prim":"UPDATE"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]`
+	script, err := ParseScript([]byte(buildScript(`{"prim":"string"}`, storage, code)))
+	if err != nil {
+		t.Errorf("ParseScript() error = %v", err)
+		return
+	}
+
+	hash, err := keyHash(prim("string"), stringNode("Game one!"))
+	if err != nil {
+		t.Errorf("keyHash() error = %v", err)
+		return
+	}
+	assert.Equal(t, "exprtiRSZkLKYRess9GZ3ryb4cVQD36WLo2oysZBFxKTZ2jXqcHWGj", hash)
+
+	t.Run("without getter", func(t *testing.T) {
+		_, err := New(Context{}).Run(script, "", stringNode("Game one!"), int64Node(5))
+		assert.True(t, IsUnsupported(err))
+	})
+
+	t.Run("with getter", func(t *testing.T) {
+		getter := testBigMapGetter{
+			"5:" + hash: `{"int":"41"}`,
+		}
+		result, err := New(Context{}, WithBigMapGetter(getter)).Run(script, "", stringNode("Game one!"), int64Node(5))
+		if err != nil {
+			t.Errorf("Run() error = %v", err)
+			return
+		}
+		assert.Equal(t, int64(5), result.Storage.IntValue.Int64())
+		if assert.Len(t, result.BigMapDiffs, 1) {
+			assert.Equal(t, int64(5), result.BigMapDiffs[0].Ptr)
+			assert.Equal(t, hash, result.BigMapDiffs[0].KeyHash)
+			assert.Equal(t, int64(42), result.BigMapDiffs[0].Value.IntValue.Int64())
+		}
+	})
+
+	t.Run("literal", func(t *testing.T) {
+		result, err := New(Context{}).Run(script, "", stringNode("a"), seq())
+		if err != nil {
+			t.Errorf("Run() error = %v", err)
+			return
+		}
+		got, err := json.MarshalToString(result.Storage)
+		if err != nil {
+			t.Errorf("MarshalToString error = %v", err)
+			return
+		}
+		assert.JSONEq(t, `[{"prim":"Elt","args":[{"string":"a"},{"int":"1"}]}]`, got)
+		assert.Len(t, result.BigMapDiffs, 0)
+	})
+}
+
+func TestInterpreter_Trace(t *testing.T) {
+	code := `[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]`
+	script, err := ParseScript([]byte(buildScript(`{"prim":"unit"}`, `{"prim":"unit"}`, code)))
+	if err != nil {
+		t.Errorf("ParseScript() error = %v", err)
+		return
+	}
+	result, err := New(Context{}, WithTrace()).Run(script, "", prim("Unit"), prim("Unit"))
+	if err != nil {
+		t.Errorf("Run() error = %v", err)
+		return
+	}
+	if assert.Len(t, result.Trace, 3) {
+		assert.Equal(t, int64(7), result.Trace[0].Location)
+		assert.Equal(t, int64(8), result.Trace[1].Location)
+		assert.Equal(t, int64(10), result.Trace[2].Location)
+		assert.Len(t, result.Trace[2].Stack, 1)
+	}
+}
+
+func Test_expandMacro(t *testing.T) {
+	tests := []struct {
+		name    string
+		macro   string
+		want    string
+		isMacro bool
+	}{
+		{
+			name:    "CADR",
+			macro:   `{"prim":"CADR"}`,
+			want:    `[{"prim":"CAR"},{"prim":"CDR"}]`,
+			isMacro: true,
+		}, {
+			name:    "PAPPAIIR",
+			macro:   `{"prim":"PAPPAIIR"}`,
+			want:    `[{"prim":"DIP","args":[[[{"prim":"PAIR"}],{"prim":"PAIR"}]]},{"prim":"PAIR"}]`,
+			isMacro: true,
+		}, {
+			name:    "UNPAPAIR",
+			macro:   `{"prim":"UNPAPAIR"}`,
+			want:    `[{"prim":"UNPAIR"},{"prim":"DIP","args":[[{"prim":"UNPAIR"}]]}]`,
+			isMacro: true,
+		}, {
+			name:    "DUUUP",
+			macro:   `{"prim":"DUUUP"}`,
+			want:    `[{"prim":"DUP","args":[{"int":"3"}]}]`,
+			isMacro: true,
+		}, {
+			name:    "CMPLE",
+			macro:   `{"prim":"CMPLE"}`,
+			want:    `[{"prim":"COMPARE"},{"prim":"LE"}]`,
+			isMacro: true,
+		}, {
+			name:  "CAR",
+			macro: `{"prim":"CAR"}`,
+		}, {
+			name:  "UNPAIR",
+			macro: `{"prim":"UNPAIR"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isMacro, err := expandMacro(parseNode(t, tt.macro))
+			if err != nil {
+				t.Errorf("expandMacro() error = %v", err)
+				return
+			}
+			assert.Equal(t, tt.isMacro, isMacro)
+			if !tt.isMacro {
+				return
+			}
+			s, err := json.MarshalToString(got)
+			if err != nil {
+				t.Errorf("MarshalToString error = %v", err)
+				return
+			}
+			assert.JSONEq(t, tt.want, s)
+		})
+	}
+}
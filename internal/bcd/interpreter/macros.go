@@ -0,0 +1,224 @@
+package interpreter
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/pkg/errors"
+)
+
+var (
+	regCmp     = regexp.MustCompile(`^CMP(EQ|NEQ|LT|GT|LE|GE)$`)
+	regIf      = regexp.MustCompile(`^IF(EQ|NEQ|LT|GT|LE|GE)$`)
+	regIfCmp   = regexp.MustCompile(`^IFCMP(EQ|NEQ|LT|GT|LE|GE)$`)
+	regAssert  = regexp.MustCompile(`^ASSERT_(EQ|NEQ|LT|GT|LE|GE)$`)
+	regAssertC = regexp.MustCompile(`^ASSERT_CMP(EQ|NEQ|LT|GT|LE|GE)$`)
+	regDup     = regexp.MustCompile(`^DU(U+)P$`)
+	regDip     = regexp.MustCompile(`^DI(I+)P$`)
+	regCadr    = regexp.MustCompile(`^C[AD]{2,}R$`)
+	regSetCadr = regexp.MustCompile(`^SET_C[AD]+R$`)
+	regMapCadr = regexp.MustCompile(`^MAP_C[AD]+R$`)
+	regPair    = regexp.MustCompile(`^P[PAI]{3,}R$`)
+	regUnpair  = regexp.MustCompile(`^UNP[PAI]{3,}R$`)
+)
+
+func fail() *base.Node {
+	return seq(prim(UNIT), prim(FAILWITH))
+}
+
+// expandMacro - expands Michelson macro to the sequence of instructions. Returns `false` if `node` is not a macro.
+func expandMacro(node *base.Node) (*base.Node, bool, error) {
+	name := node.Prim
+	switch {
+	case name == "FAIL":
+		return fail(), true, nil
+	case name == "ASSERT":
+		return seq(prim(IF, seq(), fail())), true, nil
+	case name == "ASSERT_NONE":
+		return seq(prim(IFNONE, seq(), fail())), true, nil
+	case name == "ASSERT_SOME":
+		return seq(prim(IFNONE, fail(), seq())), true, nil
+	case name == "ASSERT_LEFT":
+		return seq(prim(IFLEFT, seq(), fail())), true, nil
+	case name == "ASSERT_RIGHT":
+		return seq(prim(IFLEFT, fail(), seq())), true, nil
+	case name == "IF_SOME":
+		args, err := getArgs(node, 2)
+		if err != nil {
+			return nil, true, err
+		}
+		return seq(prim(IFNONE, args[1], args[0])), true, nil
+	case name == "IF_RIGHT":
+		args, err := getArgs(node, 2)
+		if err != nil {
+			return nil, true, err
+		}
+		return seq(prim(IFLEFT, args[1], args[0])), true, nil
+	case regCmp.MatchString(name):
+		return seq(prim(COMPARE), prim(name[3:])), true, nil
+	case regIfCmp.MatchString(name):
+		args, err := getArgs(node, 2)
+		if err != nil {
+			return nil, true, err
+		}
+		return seq(prim(COMPARE), prim(name[5:]), prim(IF, args...)), true, nil
+	case regIf.MatchString(name):
+		args, err := getArgs(node, 2)
+		if err != nil {
+			return nil, true, err
+		}
+		return seq(prim(name[2:]), prim(IF, args...)), true, nil
+	case regAssertC.MatchString(name):
+		return seq(prim(COMPARE), prim(name[10:]), prim(IF, seq(), fail())), true, nil
+	case regAssert.MatchString(name):
+		return seq(prim(name[7:]), prim(IF, seq(), fail())), true, nil
+	case regDup.MatchString(name):
+		return seq(prim(DUP, int64Node(int64(len(name)-2)))), true, nil
+	case regDip.MatchString(name):
+		args, err := getArgs(node, 1)
+		if err != nil {
+			return nil, true, err
+		}
+		return seq(prim(DIP, int64Node(int64(len(name)-2)), args[0])), true, nil
+	case regCadr.MatchString(name):
+		return expandCadr(name[1 : len(name)-1]), true, nil
+	case regSetCadr.MatchString(name):
+		return expandSetCadr(name[5 : len(name)-1]), true, nil
+	case regMapCadr.MatchString(name):
+		args, err := getArgs(node, 1)
+		if err != nil {
+			return nil, true, err
+		}
+		return expandMapCadr(name[5:len(name)-1], args[0]), true, nil
+	case regPair.MatchString(name):
+		tree, err := parsePairTree(name[:len(name)-1])
+		if err != nil {
+			return nil, true, err
+		}
+		return expandPair(tree), true, nil
+	case regUnpair.MatchString(name):
+		tree, err := parsePairTree(name[2 : len(name)-1])
+		if err != nil {
+			return nil, true, err
+		}
+		return expandUnpair(tree), true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+func expandCadr(path string) *base.Node {
+	result := seq()
+	for _, c := range path {
+		if c == 'A' {
+			result.Args = append(result.Args, prim(CAR))
+		} else {
+			result.Args = append(result.Args, prim(CDR))
+		}
+	}
+	return result
+}
+
+func expandSetCadr(path string) *base.Node {
+	switch {
+	case path == "A":
+		return seq(prim(CDR), prim(SWAP), prim(PAIR))
+	case path == "D":
+		return seq(prim(CAR), prim(PAIR))
+	case strings.HasPrefix(path, "A"):
+		return seq(prim(DUP), prim(DIP, seq(prim(CAR), expandSetCadr(path[1:]))), prim(CDR), prim(SWAP), prim(PAIR))
+	default:
+		return seq(prim(DUP), prim(DIP, seq(prim(CDR), expandSetCadr(path[1:]))), prim(CAR), prim(PAIR))
+	}
+}
+
+func expandMapCadr(path string, code *base.Node) *base.Node {
+	switch {
+	case path == "A":
+		return seq(prim(DUP), prim(CDR), prim(DIP, seq(prim(CAR), code)), prim(SWAP), prim(PAIR))
+	case path == "D":
+		return seq(prim(DUP), prim(CDR), code, prim(SWAP), prim(CAR), prim(PAIR))
+	case strings.HasPrefix(path, "A"):
+		return seq(prim(DUP), prim(DIP, seq(prim(CAR), expandMapCadr(path[1:], code))), prim(CDR), prim(SWAP), prim(PAIR))
+	default:
+		return seq(prim(DUP), prim(DIP, seq(prim(CDR), expandMapCadr(path[1:], code))), prim(CAR), prim(PAIR))
+	}
+}
+
+// pairTree - tree of `P[AIP]+R` macro. Nil child means leaf.
+type pairTree struct {
+	left  *pairTree
+	right *pairTree
+}
+
+func parsePairTree(pattern string) (*pairTree, error) {
+	tree, pos, err := parsePairNode(pattern, 0)
+	if err != nil {
+		return nil, err
+	}
+	if pos != len(pattern) {
+		return nil, errors.Wrapf(ErrInvalidScript, "invalid pair macro: %s", pattern)
+	}
+	return tree, nil
+}
+
+func parsePairNode(pattern string, pos int) (*pairTree, int, error) {
+	if pos >= len(pattern) || pattern[pos] != 'P' {
+		return nil, pos, errors.Wrapf(ErrInvalidScript, "invalid pair macro: %s", pattern)
+	}
+	pos++
+
+	tree := new(pairTree)
+	if pos >= len(pattern) {
+		return nil, pos, errors.Wrapf(ErrInvalidScript, "invalid pair macro: %s", pattern)
+	}
+	if pattern[pos] == 'A' {
+		pos++
+	} else {
+		left, next, err := parsePairNode(pattern, pos)
+		if err != nil {
+			return nil, pos, err
+		}
+		tree.left = left
+		pos = next
+	}
+
+	if pos >= len(pattern) {
+		return nil, pos, errors.Wrapf(ErrInvalidScript, "invalid pair macro: %s", pattern)
+	}
+	if pattern[pos] == 'I' {
+		pos++
+	} else {
+		right, next, err := parsePairNode(pattern, pos)
+		if err != nil {
+			return nil, pos, err
+		}
+		tree.right = right
+		pos = next
+	}
+	return tree, pos, nil
+}
+
+func expandPair(tree *pairTree) *base.Node {
+	result := seq()
+	if tree.left != nil {
+		result.Args = append(result.Args, expandPair(tree.left))
+	}
+	if tree.right != nil {
+		result.Args = append(result.Args, prim(DIP, expandPair(tree.right)))
+	}
+	result.Args = append(result.Args, prim(PAIR))
+	return result
+}
+
+func expandUnpair(tree *pairTree) *base.Node {
+	result := seq(prim(UNPAIR))
+	if tree.right != nil {
+		result.Args = append(result.Args, prim(DIP, expandUnpair(tree.right)))
+	}
+	if tree.left != nil {
+		result.Args = append(result.Args, expandUnpair(tree.left))
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package interpreter
+
+import (
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/pkg/errors"
+)
+
+// StackItem - typed value on the stack
+type StackItem struct {
+	Type  *base.Node `json:"type"`
+	Value *base.Node `json:"value"`
+}
+
+type item struct {
+	typ *base.Node
+	val *base.Node
+}
+
+// stack - the top of the stack is the last element of `items`
+type stack struct {
+	items []item
+}
+
+func newStack(items ...item) *stack {
+	return &stack{items: items}
+}
+
+func (s *stack) len() int {
+	return len(s.items)
+}
+
+func (s *stack) push(typ, val *base.Node) {
+	s.items = append(s.items, item{typ, val})
+}
+
+func (s *stack) pushItem(it item) {
+	s.items = append(s.items, it)
+}
+
+func (s *stack) pop() (item, error) {
+	if len(s.items) == 0 {
+		return item{}, ErrStackUnderflow
+	}
+	it := s.items[len(s.items)-1]
+	s.items = s.items[:len(s.items)-1]
+	return it, nil
+}
+
+// popN - pops `n` items. The first item of result is the top of the stack.
+func (s *stack) popN(n int) ([]item, error) {
+	if n < 0 || len(s.items) < n {
+		return nil, ErrStackUnderflow
+	}
+	result := make([]item, n)
+	for i := 0; i < n; i++ {
+		result[i] = s.items[len(s.items)-1-i]
+	}
+	s.items = s.items[:len(s.items)-n]
+	return result, nil
+}
+
+// peek - returns item on `depth` (0 is the top)
+func (s *stack) peek(depth int) (item, error) {
+	if depth < 0 || len(s.items) <= depth {
+		return item{}, ErrStackUnderflow
+	}
+	return s.items[len(s.items)-1-depth], nil
+}
+
+func (s *stack) popType(prims ...string) (item, error) {
+	it, err := s.pop()
+	if err != nil {
+		return it, err
+	}
+	for i := range prims {
+		if it.typ.Prim == prims[i] {
+			return it, nil
+		}
+	}
+	return it, errors.Wrapf(ErrTypeMismatch, "expected %v, got %s", prims, it.typ.Prim)
+}
+
+func (s *stack) export() []StackItem {
+	result := make([]StackItem, len(s.items))
+	for i := range s.items {
+		result[len(s.items)-1-i] = StackItem{
+			Type:  s.items[i].typ,
+			Value: s.items[i].val,
+		}
+	}
+	return result
+}
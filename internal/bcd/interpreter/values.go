@@ -0,0 +1,630 @@
+package interpreter
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/encoding"
+	"github.com/baking-bad/bcdhub/internal/bcd/forge"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
+	"github.com/pkg/errors"
+)
+
+// unknownType - type of elements of empty collections which type can not be inferred without static type checking
+var unknownType = &base.Node{}
+
+func prim(name string, args ...*base.Node) *base.Node {
+	return &base.Node{Prim: name, Args: args}
+}
+
+func seq(args ...*base.Node) *base.Node {
+	if args == nil {
+		args = make([]*base.Node, 0)
+	}
+	return &base.Node{Prim: consts.PrimArray, Args: args}
+}
+
+func intNode(value *big.Int) *base.Node {
+	return &base.Node{IntValue: &types.BigInt{Int: value}}
+}
+
+func int64Node(value int64) *base.Node {
+	return intNode(big.NewInt(value))
+}
+
+func stringNode(value string) *base.Node {
+	return &base.Node{StringValue: &value}
+}
+
+func bytesNode(value []byte) *base.Node {
+	s := hex.EncodeToString(value)
+	return &base.Node{BytesValue: &s}
+}
+
+func boolNode(value bool) *base.Node {
+	if value {
+		return prim(consts.True)
+	}
+	return prim(consts.False)
+}
+
+func isSeq(node *base.Node) bool {
+	return node.Prim == consts.PrimArray
+}
+
+func getInt(node *base.Node) (*big.Int, error) {
+	if node.IntValue == nil || node.IntValue.Int == nil {
+		return nil, errors.Wrap(ErrInvalidValue, "int value is expected")
+	}
+	return node.IntValue.Int, nil
+}
+
+func getString(node *base.Node) (string, error) {
+	if node.StringValue == nil {
+		return "", errors.Wrap(ErrInvalidValue, "string value is expected")
+	}
+	return *node.StringValue, nil
+}
+
+func getBytes(node *base.Node) ([]byte, error) {
+	if node.BytesValue == nil {
+		return nil, errors.Wrap(ErrInvalidValue, "bytes value is expected")
+	}
+	return hex.DecodeString(*node.BytesValue)
+}
+
+func getBool(node *base.Node) (bool, error) {
+	switch node.Prim {
+	case consts.True:
+		return true, nil
+	case consts.False:
+		return false, nil
+	default:
+		return false, errors.Wrap(ErrInvalidValue, "bool value is expected")
+	}
+}
+
+func getArgs(node *base.Node, count int) ([]*base.Node, error) {
+	if len(node.Args) != count {
+		return nil, errors.Wrapf(consts.ErrInvalidArgsCount, "%s: expected %d, got %d", node.Prim, count, len(node.Args))
+	}
+	return node.Args, nil
+}
+
+// normalizeType - removes annotations and converts right combs of pairs to nested binary pairs
+func normalizeType(typ *base.Node) (*base.Node, error) {
+	if typ == nil || typ.Prim == "" || isSeq(typ) {
+		return nil, errors.Wrap(ErrInvalidScript, "invalid type")
+	}
+	result := &base.Node{Prim: typ.Prim}
+	if len(typ.Args) > 0 {
+		result.Args = make([]*base.Node, len(typ.Args))
+		for i := range typ.Args {
+			arg, err := normalizeType(typ.Args[i])
+			if err != nil {
+				return nil, err
+			}
+			result.Args[i] = arg
+		}
+	}
+	if typ.Prim == consts.PAIR && len(result.Args) > 2 {
+		tail, err := normalizeType(prim(consts.PAIR, typ.Args[1:]...))
+		if err != nil {
+			return nil, err
+		}
+		result.Args = []*base.Node{result.Args[0], tail}
+	}
+	return result, nil
+}
+
+func equalTypes(a, b *base.Node) bool {
+	if a == unknownType || b == unknownType {
+		return true
+	}
+	if a.Prim != b.Prim || len(a.Args) != len(b.Args) {
+		return false
+	}
+	for i := range a.Args {
+		if !equalTypes(a.Args[i], b.Args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeTypes - replaces unknown parts of `a` with corresponding parts of `b`
+func mergeTypes(a, b *base.Node) *base.Node {
+	if a == unknownType {
+		return b
+	}
+	if b == unknownType || len(a.Args) != len(b.Args) {
+		return a
+	}
+	result := &base.Node{Prim: a.Prim}
+	if len(a.Args) > 0 {
+		result.Args = make([]*base.Node, len(a.Args))
+		for i := range a.Args {
+			result.Args[i] = mergeTypes(a.Args[i], b.Args[i])
+		}
+	}
+	return result
+}
+
+// normalizeValue - validates `value` against `typ` and converts it to the readable form
+func normalizeValue(typ, value *base.Node) (*base.Node, error) {
+	if typ == unknownType {
+		return value, nil
+	}
+	switch typ.Prim {
+	case consts.INT:
+		if _, err := getInt(value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case consts.NAT, consts.MUTEZ:
+		i, err := getInt(value)
+		if err != nil {
+			return nil, err
+		}
+		if i.Sign() < 0 {
+			return nil, errors.Wrapf(ErrInvalidValue, "negative %s", typ.Prim)
+		}
+		return value, nil
+	case consts.STRING:
+		if _, err := getString(value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case consts.BYTES:
+		if _, err := getBytes(value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case consts.BOOL:
+		if _, err := getBool(value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case consts.UNIT:
+		if value.Prim != consts.Unit {
+			return nil, errors.Wrap(ErrInvalidValue, "Unit is expected")
+		}
+		return value, nil
+	case consts.TIMESTAMP:
+		return normalizeTimestamp(value)
+	case consts.ADDRESS, consts.CONTRACT:
+		return normalizeOptimized(value, forge.UnforgeContract)
+	case consts.KEYHASH:
+		return normalizeOptimized(value, forge.UnforgeAddress)
+	case consts.KEY:
+		return normalizeOptimized(value, forge.UnforgePublicKey)
+	case consts.SIGNATURE:
+		return normalizeOptimized(value, forge.UnforgeSignature)
+	case consts.CHAINID:
+		return normalizeOptimized(value, forge.UnforgeChainID)
+	case consts.OPTION:
+		switch value.Prim {
+		case consts.None:
+			return value, nil
+		case consts.Some:
+			args, err := getArgs(value, 1)
+			if err != nil {
+				return nil, err
+			}
+			arg, err := normalizeValue(typ.Args[0], args[0])
+			if err != nil {
+				return nil, err
+			}
+			return prim(consts.Some, arg), nil
+		default:
+			return nil, errors.Wrap(ErrInvalidValue, "option value is expected")
+		}
+	case consts.OR:
+		var idx int
+		switch value.Prim {
+		case consts.Left:
+		case consts.Right:
+			idx = 1
+		default:
+			return nil, errors.Wrap(ErrInvalidValue, "or value is expected")
+		}
+		args, err := getArgs(value, 1)
+		if err != nil {
+			return nil, err
+		}
+		arg, err := normalizeValue(typ.Args[idx], args[0])
+		if err != nil {
+			return nil, err
+		}
+		return prim(value.Prim, arg), nil
+	case consts.PAIR:
+		if value.Prim != consts.Pair && !isSeq(value) {
+			return nil, errors.Wrap(ErrInvalidValue, "pair value is expected")
+		}
+		switch {
+		case len(value.Args) == 2:
+		case len(value.Args) > 2:
+			value = prim(consts.Pair, value.Args[0], prim(consts.Pair, value.Args[1:]...))
+		default:
+			return nil, errors.Wrap(ErrInvalidValue, "pair value is expected")
+		}
+		left, err := normalizeValue(typ.Args[0], value.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		right, err := normalizeValue(typ.Args[1], value.Args[1])
+		if err != nil {
+			return nil, err
+		}
+		return prim(consts.Pair, left, right), nil
+	case consts.LIST, consts.SET:
+		if !isSeq(value) {
+			return nil, errors.Wrapf(ErrInvalidValue, "%s value is expected", typ.Prim)
+		}
+		items := make([]*base.Node, len(value.Args))
+		for i := range value.Args {
+			item, err := normalizeValue(typ.Args[0], value.Args[i])
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return seq(items...), nil
+	case consts.BIGMAP:
+		if value.IntValue != nil {
+			return value, nil
+		}
+		return normalizeMap(typ, value)
+	case consts.MAP:
+		return normalizeMap(typ, value)
+	case consts.LAMBDA:
+		if isSeq(value) {
+			return value, nil
+		}
+		return seq(value), nil
+	default:
+		return value, nil
+	}
+}
+
+func normalizeMap(typ, value *base.Node) (*base.Node, error) {
+	if !isSeq(value) {
+		return nil, errors.Wrapf(ErrInvalidValue, "%s value is expected", typ.Prim)
+	}
+	items := make([]*base.Node, len(value.Args))
+	for i := range value.Args {
+		if value.Args[i].Prim != consts.Elt || len(value.Args[i].Args) != 2 {
+			return nil, errors.Wrap(ErrInvalidValue, "Elt is expected")
+		}
+		key, err := normalizeValue(typ.Args[0], value.Args[i].Args[0])
+		if err != nil {
+			return nil, err
+		}
+		val, err := normalizeValue(typ.Args[1], value.Args[i].Args[1])
+		if err != nil {
+			return nil, err
+		}
+		items[i] = prim(consts.Elt, key, val)
+	}
+	return seq(items...), nil
+}
+
+func normalizeTimestamp(value *base.Node) (*base.Node, error) {
+	switch {
+	case value.IntValue != nil:
+		return value, nil
+	case value.StringValue != nil:
+		if ts, err := time.Parse(time.RFC3339, *value.StringValue); err == nil {
+			return int64Node(ts.UTC().Unix()), nil
+		}
+		i, ok := big.NewInt(0).SetString(*value.StringValue, 10)
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidValue, "invalid timestamp: %s", *value.StringValue)
+		}
+		return intNode(i), nil
+	default:
+		return nil, errors.Wrap(ErrInvalidValue, "timestamp value is expected")
+	}
+}
+
+func normalizeOptimized(value *base.Node, unforge func(string) (string, error)) (*base.Node, error) {
+	switch {
+	case value.StringValue != nil:
+		return value, nil
+	case value.BytesValue != nil:
+		s, err := unforge(*value.BytesValue)
+		if err != nil {
+			return nil, err
+		}
+		return stringNode(s), nil
+	default:
+		return nil, errors.Wrap(ErrInvalidValue, "string or bytes value is expected")
+	}
+}
+
+func sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareValues - compares values of comparable type `typ`. Returns -1, 0 or 1.
+func compareValues(typ, a, b *base.Node) (int, error) {
+	switch typ.Prim {
+	case consts.INT, consts.NAT, consts.MUTEZ, consts.TIMESTAMP:
+		x, err := getInt(a)
+		if err != nil {
+			return 0, err
+		}
+		y, err := getInt(b)
+		if err != nil {
+			return 0, err
+		}
+		return x.Cmp(y), nil
+	case consts.STRING:
+		x, err := getString(a)
+		if err != nil {
+			return 0, err
+		}
+		y, err := getString(b)
+		if err != nil {
+			return 0, err
+		}
+		return sign(strings.Compare(x, y)), nil
+	case consts.BYTES:
+		x, err := getBytes(a)
+		if err != nil {
+			return 0, err
+		}
+		y, err := getBytes(b)
+		if err != nil {
+			return 0, err
+		}
+		return bytes.Compare(x, y), nil
+	case consts.BOOL:
+		x, err := getBool(a)
+		if err != nil {
+			return 0, err
+		}
+		y, err := getBool(b)
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case x == y:
+			return 0, nil
+		case x:
+			return 1, nil
+		default:
+			return -1, nil
+		}
+	case consts.UNIT:
+		return 0, nil
+	case consts.ADDRESS, consts.KEYHASH:
+		return compareAddresses(a, b, typ.Prim == consts.KEYHASH)
+	case consts.KEY, consts.SIGNATURE, consts.CHAINID:
+		return compareEncoded(a, b)
+	case consts.PAIR:
+		res, err := compareValues(typ.Args[0], a.Args[0], b.Args[0])
+		if err != nil || res != 0 {
+			return res, err
+		}
+		return compareValues(typ.Args[1], a.Args[1], b.Args[1])
+	case consts.OPTION:
+		switch {
+		case a.Prim == consts.None && b.Prim == consts.None:
+			return 0, nil
+		case a.Prim == consts.None:
+			return -1, nil
+		case b.Prim == consts.None:
+			return 1, nil
+		default:
+			return compareValues(typ.Args[0], a.Args[0], b.Args[0])
+		}
+	case consts.OR:
+		switch {
+		case a.Prim == consts.Left && b.Prim == consts.Right:
+			return -1, nil
+		case a.Prim == consts.Right && b.Prim == consts.Left:
+			return 1, nil
+		case a.Prim == consts.Left:
+			return compareValues(typ.Args[0], a.Args[0], b.Args[0])
+		default:
+			return compareValues(typ.Args[1], a.Args[0], b.Args[0])
+		}
+	default:
+		return 0, errors.Wrap(consts.ErrTypeIsNotComparable, typ.Prim)
+	}
+}
+
+func compareAddresses(a, b *base.Node, tzOnly bool) (int, error) {
+	x, err := getString(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := getString(b)
+	if err != nil {
+		return 0, err
+	}
+	xAddress, xEntrypoint := splitAddress(x)
+	yAddress, yEntrypoint := splitAddress(y)
+	if len(xAddress) < 3 || len(yAddress) < 3 {
+		return 0, errors.Wrap(ErrInvalidValue, "invalid address")
+	}
+
+	xBytes, err := forge.Address(xAddress, tzOnly)
+	if err != nil {
+		return 0, err
+	}
+	yBytes, err := forge.Address(yAddress, tzOnly)
+	if err != nil {
+		return 0, err
+	}
+	if res := bytes.Compare(xBytes, yBytes); res != 0 {
+		return res, nil
+	}
+	return sign(strings.Compare(xEntrypoint, yEntrypoint)), nil
+}
+
+func compareEncoded(a, b *base.Node) (int, error) {
+	x, err := getString(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := getString(b)
+	if err != nil {
+		return 0, err
+	}
+	xBytes, err := encoding.DecodeBase58(x)
+	if err != nil {
+		return 0, err
+	}
+	yBytes, err := encoding.DecodeBase58(y)
+	if err != nil {
+		return 0, err
+	}
+	// different curves have different prefixes, so prefix is the first criteria
+	if res := sign(strings.Compare(x[:2], y[:2])); res != 0 {
+		return res, nil
+	}
+	return bytes.Compare(xBytes, yBytes), nil
+}
+
+func splitAddress(address string) (string, string) {
+	parts := strings.SplitN(address, "%", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// findInMap - binary search of `key` in sorted list of `Elt`. Returns index of the element or the insertion point.
+func findInMap(keyType, items *base.Node, key *base.Node) (int, bool, error) {
+	var searchErr error
+	idx := sort.Search(len(items.Args), func(i int) bool {
+		res, err := compareValues(keyType, items.Args[i].Args[0], key)
+		if err != nil {
+			searchErr = err
+		}
+		return res >= 0
+	})
+	if searchErr != nil {
+		return 0, false, searchErr
+	}
+	if idx < len(items.Args) {
+		res, err := compareValues(keyType, items.Args[idx].Args[0], key)
+		if err != nil {
+			return 0, false, err
+		}
+		return idx, res == 0, nil
+	}
+	return idx, false, nil
+}
+
+// findInSet - binary search of `value` in sorted set. Returns index of the element or the insertion point.
+func findInSet(elemType, items *base.Node, value *base.Node) (int, bool, error) {
+	var searchErr error
+	idx := sort.Search(len(items.Args), func(i int) bool {
+		res, err := compareValues(elemType, items.Args[i], value)
+		if err != nil {
+			searchErr = err
+		}
+		return res >= 0
+	})
+	if searchErr != nil {
+		return 0, false, searchErr
+	}
+	if idx < len(items.Args) {
+		res, err := compareValues(elemType, items.Args[idx], value)
+		if err != nil {
+			return 0, false, err
+		}
+		return idx, res == 0, nil
+	}
+	return idx, false, nil
+}
+
+func insertAt(items []*base.Node, idx int, value *base.Node) []*base.Node {
+	result := make([]*base.Node, 0, len(items)+1)
+	result = append(result, items[:idx]...)
+	result = append(result, value)
+	return append(result, items[idx:]...)
+}
+
+func replaceAt(items []*base.Node, idx int, value *base.Node) []*base.Node {
+	result := make([]*base.Node, len(items))
+	copy(result, items)
+	result[idx] = value
+	return result
+}
+
+func removeAt(items []*base.Node, idx int) []*base.Node {
+	result := make([]*base.Node, 0, len(items)-1)
+	result = append(result, items[:idx]...)
+	return append(result, items[idx+1:]...)
+}
+
+func toTyped(typ, value *base.Node) (ast.Node, error) {
+	tree, err := ast.UntypedAST{typ}.ToTypedAST()
+	if err != nil {
+		return nil, err
+	}
+	if err := tree.Settle(ast.UntypedAST{value}); err != nil {
+		return nil, err
+	}
+	if len(tree.Nodes) != 1 {
+		return nil, errors.Wrap(ErrInvalidValue, "can't type value")
+	}
+	return tree.Nodes[0], nil
+}
+
+func pack(typ, value *base.Node) ([]byte, error) {
+	node, err := toTyped(typ, value)
+	if err != nil {
+		return nil, err
+	}
+	packed, err := ast.Pack(node)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(packed)
+}
+
+func keyHash(typ, value *base.Node) (string, error) {
+	node, err := toTyped(typ, value)
+	if err != nil {
+		return "", err
+	}
+	return ast.BigMapKeyHashFromNode(node)
+}
+
+func nodeSize(node *base.Node) int64 {
+	if node == nil {
+		return 0
+	}
+	var size int64 = 1
+	switch {
+	case node.IntValue != nil && node.IntValue.Int != nil:
+		size += int64(len(node.IntValue.Bits()))
+	case node.StringValue != nil:
+		size += int64(len(*node.StringValue)) / 8
+	case node.BytesValue != nil:
+		size += int64(len(*node.BytesValue)) / 16
+	}
+	for i := range node.Args {
+		size += nodeSize(node.Args[i])
+	}
+	return size
+}
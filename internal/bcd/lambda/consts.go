@@ -0,0 +1,41 @@
+package lambda
+
+// Instructions which are evaluated by decoder
+const (
+	ADDRESS         = "ADDRESS"
+	AMOUNT          = "AMOUNT"
+	BALANCE         = "BALANCE"
+	CAR             = "CAR"
+	CAST            = "CAST"
+	CDR             = "CDR"
+	CHAINID         = "CHAIN_ID"
+	CONS            = "CONS"
+	CONTRACT        = "CONTRACT"
+	DIG             = "DIG"
+	DIP             = "DIP"
+	DROP            = "DROP"
+	DUG             = "DUG"
+	DUP             = "DUP"
+	FAILWITH        = "FAILWITH"
+	IF              = "IF"
+	IFCONS          = "IF_CONS"
+	IFLEFT          = "IF_LEFT"
+	IFNONE          = "IF_NONE"
+	IMPLICITACCOUNT = "IMPLICIT_ACCOUNT"
+	LEVEL           = "LEVEL"
+	NIL             = "NIL"
+	NONE            = "NONE"
+	NOW             = "NOW"
+	PAIR            = "PAIR"
+	PUSH            = "PUSH"
+	RENAME          = "RENAME"
+	SELFADDRESS     = "SELF_ADDRESS"
+	SENDER          = "SENDER"
+	SETDELEGATE     = "SET_DELEGATE"
+	SOME            = "SOME"
+	SOURCE          = "SOURCE"
+	SWAP            = "SWAP"
+	TRANSFERTOKENS  = "TRANSFER_TOKENS"
+	UNIT            = "UNIT"
+	UNPAIR          = "UNPAIR"
+)
@@ -0,0 +1,511 @@
+package lambda
+
+import (
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/pkg/errors"
+)
+
+// Errors
+var (
+	ErrStackUnderflow = errors.New("Stack underflow")
+	ErrInvalidCode    = errors.New("Invalid lambda code")
+
+	errUnsupported = errors.New("Unsupported instruction")
+	errFailed      = errors.New("Lambda reached FAILWITH")
+)
+
+// Operation - operation which is emitted by lambda. Fields which can not be computed statically are empty.
+type Operation struct {
+	Kind          string
+	Destination   string
+	Entrypoint    string
+	Amount        *int64
+	ParameterType *base.Node
+	Parameter     *base.Node
+	Delegate      *string
+}
+
+// Result - operations emitted by lambda.
+// `Complete` is false if lambda can not be evaluated statically. Then `Operations` contains all created operations in order of creation and some of their fields may be empty.
+// `Failed` is true if lambda reaches `FAILWITH` on evaluated path.
+type Result struct {
+	Operations []Operation
+	Complete   bool
+	Failed     bool
+}
+
+// Decode - statically evaluates lambda `code` with `input` argument and extracts operations which lambda returns.
+// `input` is nil if argument is unknown. Lambda should return `operation` or `list operation`.
+// Contracts received by `CONTRACT` are assumed to exist and to have requested type.
+func Decode(code *base.Node, input *base.Node) (*Result, error) {
+	d := &decoder{
+		stack:      make([]item, 0),
+		operations: make([]Operation, 0),
+		resolved:   make([]bool, 0),
+	}
+	if input != nil {
+		d.push(data(input))
+	} else {
+		d.push(unknown())
+	}
+
+	err := d.execute(code)
+	result := &Result{
+		Operations: d.operations,
+		Failed:     errors.Is(err, errFailed),
+	}
+	switch {
+	case err == nil:
+	case result.Failed || errors.Is(err, errUnsupported):
+		return result, nil
+	default:
+		return nil, err
+	}
+
+	if len(d.stack) != 1 {
+		return nil, errors.Wrap(ErrInvalidCode, "lambda must leave exactly one item on the stack")
+	}
+	returned := d.stack[0]
+	indices := make([]int, 0)
+	if returned.kind == kindOperation {
+		indices = append(indices, returned.operation)
+	} else {
+		items, ok := returned.list()
+		if !ok {
+			return result, nil
+		}
+		for i := range items {
+			if items[i].kind != kindOperation {
+				return result, nil
+			}
+			indices = append(indices, items[i].operation)
+		}
+	}
+
+	result.Complete = true
+	result.Operations = make([]Operation, len(indices))
+	for i, idx := range indices {
+		result.Operations[i] = d.operations[idx]
+		result.Complete = result.Complete && d.resolved[idx]
+	}
+	return result, nil
+}
+
+// decoder - the top of the stack is the last element of `stack`. `resolved` is true for operations whose fields are all known.
+type decoder struct {
+	stack      []item
+	operations []Operation
+	resolved   []bool
+}
+
+func (d *decoder) push(items ...item) {
+	d.stack = append(d.stack, items...)
+}
+
+func (d *decoder) pop() (item, error) {
+	if len(d.stack) == 0 {
+		return item{}, ErrStackUnderflow
+	}
+	it := d.stack[len(d.stack)-1]
+	d.stack = d.stack[:len(d.stack)-1]
+	return it, nil
+}
+
+// popN - pops `n` items. The first item of result is the top of the stack.
+func (d *decoder) popN(n int) ([]item, error) {
+	if n < 0 || len(d.stack) < n {
+		return nil, ErrStackUnderflow
+	}
+	result := make([]item, n)
+	for i := 0; i < n; i++ {
+		result[i] = d.stack[len(d.stack)-1-i]
+	}
+	d.stack = d.stack[:len(d.stack)-n]
+	return result, nil
+}
+
+func (d *decoder) execute(node *base.Node) error {
+	if node.Prim == consts.PrimArray {
+		for i := range node.Args {
+			if err := d.execute(node.Args[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch node.Prim {
+	case CAST, RENAME:
+		return nil
+	case AMOUNT, BALANCE, CHAINID, LEVEL, NOW, SELFADDRESS, SENDER, SOURCE:
+		d.push(unknown())
+		return nil
+	case FAILWITH:
+		return errFailed
+	case DROP:
+		n, err := getN(node, 1)
+		if err != nil {
+			return err
+		}
+		_, err = d.popN(n)
+		return err
+	case DUP:
+		n, err := getN(node, 1)
+		if err != nil {
+			return err
+		}
+		if n < 1 || len(d.stack) < n {
+			return ErrStackUnderflow
+		}
+		d.push(d.stack[len(d.stack)-n])
+		return nil
+	case SWAP:
+		items, err := d.popN(2)
+		if err != nil {
+			return err
+		}
+		d.push(items[0], items[1])
+		return nil
+	case DIG:
+		n, err := getN(node, -1)
+		if err != nil {
+			return err
+		}
+		items, err := d.popN(n + 1)
+		if err != nil {
+			return err
+		}
+		for i := n - 1; i >= 0; i-- {
+			d.push(items[i])
+		}
+		d.push(items[n])
+		return nil
+	case DUG:
+		n, err := getN(node, -1)
+		if err != nil {
+			return err
+		}
+		items, err := d.popN(n + 1)
+		if err != nil {
+			return err
+		}
+		d.push(items[0])
+		for i := n; i > 0; i-- {
+			d.push(items[i])
+		}
+		return nil
+	case DIP:
+		return d.dip(node)
+	case PUSH:
+		if len(node.Args) != 2 {
+			return ErrInvalidCode
+		}
+		d.push(data(node.Args[1]))
+		return nil
+	case UNIT:
+		d.push(data(&base.Node{Prim: consts.Unit}))
+		return nil
+	case NONE:
+		d.push(item{kind: kindNone})
+		return nil
+	case SOME:
+		it, err := d.pop()
+		if err != nil {
+			return err
+		}
+		d.push(item{kind: kindSome, args: []item{it}})
+		return nil
+	case NIL:
+		d.push(item{kind: kindList, args: make([]item, 0)})
+		return nil
+	case CONS:
+		items, err := d.popN(2)
+		if err != nil {
+			return err
+		}
+		list, ok := items[1].list()
+		if !ok {
+			d.push(unknown())
+			return nil
+		}
+		d.push(item{kind: kindList, args: append([]item{items[0]}, list...)})
+		return nil
+	case PAIR:
+		if len(node.Args) > 0 {
+			return errUnsupported
+		}
+		items, err := d.popN(2)
+		if err != nil {
+			return err
+		}
+		d.push(item{kind: kindPair, args: items})
+		return nil
+	case UNPAIR:
+		if len(node.Args) > 0 {
+			return errUnsupported
+		}
+		it, err := d.pop()
+		if err != nil {
+			return err
+		}
+		left, right, ok := it.pair()
+		if !ok {
+			left, right = unknown(), unknown()
+		}
+		d.push(right, left)
+		return nil
+	case CAR, CDR:
+		it, err := d.pop()
+		if err != nil {
+			return err
+		}
+		left, right, ok := it.pair()
+		switch {
+		case !ok:
+			d.push(unknown())
+		case node.Prim == CAR:
+			d.push(left)
+		default:
+			d.push(right)
+		}
+		return nil
+	case CONTRACT:
+		return d.contract(node)
+	case IMPLICITACCOUNT:
+		it, err := d.pop()
+		if err != nil {
+			return err
+		}
+		address, _ := it.keyHash()
+		d.push(item{kind: kindContract, contract: contract{
+			address: address,
+			typ:     &base.Node{Prim: consts.UNIT},
+		}})
+		return nil
+	case ADDRESS:
+		it, err := d.pop()
+		if err != nil {
+			return err
+		}
+		if value := it.node(); it.kind == kindContract && value != nil {
+			d.push(data(value))
+		} else {
+			d.push(unknown())
+		}
+		return nil
+	case TRANSFERTOKENS:
+		return d.transfer()
+	case SETDELEGATE:
+		return d.setDelegate()
+	case IF, IFCONS, IFLEFT, IFNONE:
+		return d.branch(node)
+	default:
+		return errors.Wrap(errUnsupported, node.Prim)
+	}
+}
+
+func (d *decoder) dip(node *base.Node) error {
+	n := 1
+	var code *base.Node
+	switch len(node.Args) {
+	case 1:
+		code = node.Args[0]
+	case 2:
+		value, err := toInt(node.Args[0])
+		if err != nil {
+			return err
+		}
+		n = value
+		code = node.Args[1]
+	default:
+		return ErrInvalidCode
+	}
+	items, err := d.popN(n)
+	if err != nil {
+		return err
+	}
+	if err := d.execute(code); err != nil {
+		return err
+	}
+	for i := n - 1; i >= 0; i-- {
+		d.push(items[i])
+	}
+	return nil
+}
+
+func (d *decoder) contract(node *base.Node) error {
+	if len(node.Args) != 1 {
+		return ErrInvalidCode
+	}
+	it, err := d.pop()
+	if err != nil {
+		return err
+	}
+	address, entrypoint, _ := it.address()
+	for _, annot := range node.Annots {
+		if strings.HasPrefix(annot, "%") {
+			entrypoint = strings.TrimPrefix(annot, "%")
+		}
+	}
+	d.push(item{kind: kindSome, args: []item{
+		{kind: kindContract, contract: contract{
+			address:    address,
+			entrypoint: entrypoint,
+			typ:        node.Args[0],
+		}},
+	}})
+	return nil
+}
+
+func (d *decoder) transfer() error {
+	items, err := d.popN(3)
+	if err != nil {
+		return err
+	}
+	operation := Operation{
+		Kind:      consts.Transaction,
+		Parameter: items[0].node(),
+	}
+	resolved := operation.Parameter != nil
+	if amount, ok := items[1].mutez(); ok {
+		operation.Amount = &amount
+	} else {
+		resolved = false
+	}
+	if items[2].kind == kindContract {
+		operation.Destination = items[2].contract.address
+		operation.Entrypoint = items[2].contract.entrypoint
+		operation.ParameterType = items[2].contract.typ
+	}
+	if operation.Entrypoint == "" {
+		operation.Entrypoint = consts.DefaultEntrypoint
+	}
+	resolved = resolved && operation.Destination != ""
+
+	d.pushOperation(operation, resolved)
+	return nil
+}
+
+func (d *decoder) setDelegate() error {
+	it, err := d.pop()
+	if err != nil {
+		return err
+	}
+	operation := Operation{
+		Kind: consts.Delegation,
+	}
+	value, isSome, resolved := it.option()
+	if isSome {
+		if delegate, ok := value.keyHash(); ok {
+			operation.Delegate = &delegate
+		} else {
+			resolved = false
+		}
+	}
+	d.pushOperation(operation, resolved)
+	return nil
+}
+
+func (d *decoder) pushOperation(operation Operation, resolved bool) {
+	d.operations = append(d.operations, operation)
+	d.resolved = append(d.resolved, resolved)
+	d.push(item{kind: kindOperation, operation: len(d.operations) - 1})
+}
+
+// branch - evaluates branch of conditional instruction. If condition is unknown and one of branches always fails, the other one is taken.
+func (d *decoder) branch(node *base.Node) error {
+	if len(node.Args) != 2 {
+		return ErrInvalidCode
+	}
+	it, err := d.pop()
+	if err != nil {
+		return err
+	}
+
+	var first, known bool
+	var values [2][]item
+	switch node.Prim {
+	case IF:
+		first, known = it.bool()
+	case IFNONE:
+		var value item
+		value, first, known = it.option()
+		first = !first
+		if !known {
+			value = unknown()
+		}
+		values[1] = []item{value}
+	case IFLEFT:
+		var value item
+		value, first, known = it.or()
+		if !known {
+			value = unknown()
+		}
+		values[0] = []item{value}
+		values[1] = []item{value}
+	case IFCONS:
+		list, ok := it.list()
+		known = ok
+		first = ok && len(list) > 0
+		if first {
+			values[0] = []item{{kind: kindList, args: list[1:]}, list[0]}
+		} else {
+			values[0] = []item{unknown(), unknown()}
+		}
+	}
+
+	if !known {
+		switch {
+		case alwaysFails(node.Args[0]) && !alwaysFails(node.Args[1]):
+			first = false
+		case alwaysFails(node.Args[1]) && !alwaysFails(node.Args[0]):
+			first = true
+		default:
+			return errors.Wrap(errUnsupported, node.Prim)
+		}
+	}
+
+	if first {
+		d.push(values[0]...)
+		return d.execute(node.Args[0])
+	}
+	d.push(values[1]...)
+	return d.execute(node.Args[1])
+}
+
+// alwaysFails - returns true if code block reaches `FAILWITH` unconditionally
+func alwaysFails(node *base.Node) bool {
+	if node.Prim == FAILWITH {
+		return true
+	}
+	if node.Prim != consts.PrimArray {
+		return false
+	}
+	for i := range node.Args {
+		if alwaysFails(node.Args[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// getN - returns integer argument of instruction or `def` if instruction has no arguments. Negative `def` means that argument is required.
+func getN(node *base.Node, def int) (int, error) {
+	if len(node.Args) == 0 {
+		if def < 0 {
+			return 0, ErrInvalidCode
+		}
+		return def, nil
+	}
+	return toInt(node.Args[0])
+}
+
+func toInt(node *base.Node) (int, error) {
+	if node.IntValue == nil || !node.IntValue.IsInt64() {
+		return 0, ErrInvalidCode
+	}
+	return int(node.IntValue.Int64()), nil
+}
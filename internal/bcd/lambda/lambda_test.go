@@ -0,0 +1,149 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func parseNode(t *testing.T, data string) *base.Node {
+	var node base.Node
+	if err := json.UnmarshalFromString(data, &node); err != nil {
+		t.Fatalf("UnmarshalFromString error: %v", err)
+	}
+	return &node
+}
+
+func marshalNode(t *testing.T, node *base.Node) string {
+	if node == nil {
+		return ""
+	}
+	s, err := json.MarshalToString(node)
+	if err != nil {
+		t.Fatalf("MarshalToString error: %v", err)
+	}
+	return s
+}
+
+func TestDecode(t *testing.T) {
+	type operation struct {
+		kind          string
+		destination   string
+		entrypoint    string
+		amount        *int64
+		parameterType string
+		parameter     string
+		delegate      *string
+	}
+
+	amount := func(value int64) *int64 { return &value }
+	delegate := func(value string) *string { return &value }
+
+	tests := []struct {
+		name     string
+		code     string
+		want     []operation
+		complete bool
+		failed   bool
+		wantErr  bool
+	}{
+		{
+			name:     "set delegate",
+			code:     `[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PUSH","args":[{"prim":"key_hash"},{"string":"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"}]},{"prim":"SOME"},{"prim":"SET_DELEGATE"},{"prim":"CONS"}]`,
+			complete: true,
+			want: []operation{
+				{kind: "delegation", delegate: delegate("tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6")},
+			},
+		}, {
+			name:     "remove delegate",
+			code:     `[{"prim":"DROP"},{"prim":"NONE","args":[{"prim":"key_hash"}]},{"prim":"SET_DELEGATE"}]`,
+			complete: true,
+			want: []operation{
+				{kind: "delegation"},
+			},
+		}, {
+			name:     "transfer to implicit account with optimized key hash",
+			code:     `[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PUSH","args":[{"prim":"key_hash"},{"bytes":"00a26828841890d3f3a2a1d4083839c7a882fe0501"}]},{"prim":"IMPLICIT_ACCOUNT"},{"prim":"PUSH","args":[{"prim":"mutez"},{"int":"1000000"}]},{"prim":"UNIT"},{"prim":"TRANSFER_TOKENS"},{"prim":"CONS"}]`,
+			complete: true,
+			want: []operation{
+				{kind: "transaction", destination: "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6", entrypoint: "default", amount: amount(1000000), parameterType: `{"prim":"unit"}`, parameter: `{"prim":"Unit"}`},
+			},
+		}, {
+			name:     "contract call with assert some",
+			code:     `[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PUSH","args":[{"prim":"address"},{"string":"KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"}]},{"prim":"CONTRACT","args":[{"prim":"pair","args":[{"prim":"address"},{"prim":"nat"}]}],"annots":["%transfer"]},[{"prim":"IF_NONE","args":[[[{"prim":"UNIT"},{"prim":"FAILWITH"}]],[]]}],{"prim":"PUSH","args":[{"prim":"mutez"},{"int":"0"}]},{"prim":"PUSH","args":[{"prim":"nat"},{"int":"100"}]},{"prim":"PUSH","args":[{"prim":"address"},{"string":"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"}]},{"prim":"PAIR"},{"prim":"TRANSFER_TOKENS"},{"prim":"CONS"}]`,
+			complete: true,
+			want: []operation{
+				{kind: "transaction", destination: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", entrypoint: "transfer", amount: amount(0), parameterType: `{"prim":"pair","args":[{"prim":"address"},{"prim":"nat"}]}`, parameter: `{"prim":"Pair","args":[{"string":"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"},{"int":"100"}]}`},
+			},
+		}, {
+			name:     "optimized address with entrypoint",
+			code:     `[{"prim":"DROP"},{"prim":"PUSH","args":[{"prim":"address"},{"bytes":"01a3d0f58d8964bd1b37fb0a0c197b38cf46608d49007472616e73666572"}]},{"prim":"CONTRACT","args":[{"prim":"nat"}]},{"prim":"IF_NONE","args":[[{"prim":"PUSH","args":[{"prim":"string"},{"string":"no contract"}]},{"prim":"FAILWITH"}],[]]},{"prim":"PUSH","args":[{"prim":"mutez"},{"int":"0"}]},{"prim":"PUSH","args":[{"prim":"nat"},{"int":"1"}]},{"prim":"TRANSFER_TOKENS"}]`,
+			complete: true,
+			want: []operation{
+				{kind: "transaction", destination: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", entrypoint: "transfer", amount: amount(0), parameterType: `{"prim":"nat"}`, parameter: `{"int":"1"}`},
+			},
+		}, {
+			name:     "operations order",
+			code:     `[{"prim":"DROP"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"NONE","args":[{"prim":"key_hash"}]},{"prim":"SET_DELEGATE"},{"prim":"CONS"},{"prim":"PUSH","args":[{"prim":"key_hash"},{"string":"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"}]},{"prim":"IMPLICIT_ACCOUNT"},{"prim":"PUSH","args":[{"prim":"mutez"},{"int":"5"}]},{"prim":"UNIT"},{"prim":"TRANSFER_TOKENS"},{"prim":"CONS"}]`,
+			complete: true,
+			want: []operation{
+				{kind: "transaction", destination: "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6", entrypoint: "default", amount: amount(5), parameterType: `{"prim":"unit"}`, parameter: `{"prim":"Unit"}`},
+				{kind: "delegation"},
+			},
+		}, {
+			name:     "unknown amount",
+			code:     `[{"prim":"DROP"},{"prim":"PUSH","args":[{"prim":"key_hash"},{"string":"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"}]},{"prim":"IMPLICIT_ACCOUNT"},{"prim":"BALANCE"},{"prim":"UNIT"},{"prim":"TRANSFER_TOKENS"}]`,
+			complete: false,
+			want: []operation{
+				{kind: "transaction", destination: "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6", entrypoint: "default", parameterType: `{"prim":"unit"}`, parameter: `{"prim":"Unit"}`},
+			},
+		}, {
+			name:     "unsupported instruction",
+			code:     `[{"prim":"DROP"},{"prim":"NONE","args":[{"prim":"key_hash"}]},{"prim":"SET_DELEGATE"},{"prim":"PUSH","args":[{"prim":"nat"},{"int":"1"}]},{"prim":"PUSH","args":[{"prim":"nat"},{"int":"1"}]},{"prim":"ADD"}]`,
+			complete: false,
+			want: []operation{
+				{kind: "delegation"},
+			},
+		}, {
+			name:     "failed",
+			code:     `[{"prim":"DROP"},{"prim":"PUSH","args":[{"prim":"string"},{"string":"rejected"}]},{"prim":"FAILWITH"}]`,
+			complete: false,
+			failed:   true,
+			want:     []operation{},
+		}, {
+			name:    "stack underflow",
+			code:    `[{"prim":"DROP"},{"prim":"SWAP"}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(parseNode(t, tt.code), parseNode(t, `{"prim":"Unit"}`))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			assert.Equal(t, tt.complete, got.Complete)
+			assert.Equal(t, tt.failed, got.Failed)
+			if !assert.Len(t, got.Operations, len(tt.want)) {
+				return
+			}
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].kind, got.Operations[i].Kind)
+				assert.Equal(t, tt.want[i].destination, got.Operations[i].Destination)
+				assert.Equal(t, tt.want[i].entrypoint, got.Operations[i].Entrypoint)
+				assert.Equal(t, tt.want[i].amount, got.Operations[i].Amount)
+				assert.Equal(t, tt.want[i].parameterType, marshalNode(t, got.Operations[i].ParameterType))
+				assert.Equal(t, tt.want[i].parameter, marshalNode(t, got.Operations[i].Parameter))
+				assert.Equal(t, tt.want[i].delegate, got.Operations[i].Delegate)
+			}
+		})
+	}
+}
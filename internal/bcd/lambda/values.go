@@ -0,0 +1,223 @@
+package lambda
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/forge"
+)
+
+type kind int
+
+const (
+	kindUnknown kind = iota
+	kindData
+	kindPair
+	kindSome
+	kindNone
+	kindList
+	kindContract
+	kindOperation
+)
+
+// item - symbolic value on the stack. Values which can not be computed statically have `kindUnknown`.
+type item struct {
+	kind      kind
+	data      *base.Node
+	args      []item
+	contract  contract
+	operation int
+}
+
+// contract - value of `contract` type. Empty address means that address is unknown.
+type contract struct {
+	address    string
+	entrypoint string
+	typ        *base.Node
+}
+
+func unknown() item {
+	return item{kind: kindUnknown}
+}
+
+func data(node *base.Node) item {
+	return item{kind: kindData, data: node}
+}
+
+func (it item) pair() (item, item, bool) {
+	switch it.kind {
+	case kindPair:
+		return it.args[0], it.args[1], true
+	case kindData:
+		if (it.data.Prim != consts.Pair && it.data.Prim != consts.PrimArray) || len(it.data.Args) < 2 {
+			return item{}, item{}, false
+		}
+		if len(it.data.Args) == 2 {
+			return data(it.data.Args[0]), data(it.data.Args[1]), true
+		}
+		return data(it.data.Args[0]), data(&base.Node{Prim: consts.Pair, Args: it.data.Args[1:]}), true
+	}
+	return item{}, item{}, false
+}
+
+// option - returns inner value if option is `Some`. The last result is false if option is unknown.
+func (it item) option() (item, bool, bool) {
+	switch it.kind {
+	case kindSome:
+		return it.args[0], true, true
+	case kindNone:
+		return item{}, false, true
+	case kindData:
+		switch it.data.Prim {
+		case consts.Some:
+			if len(it.data.Args) == 1 {
+				return data(it.data.Args[0]), true, true
+			}
+		case consts.None:
+			return item{}, false, true
+		}
+	}
+	return item{}, false, false
+}
+
+// or - returns inner value and true if it is `Left`. The last result is false if value is unknown.
+func (it item) or() (item, bool, bool) {
+	if it.kind != kindData || len(it.data.Args) != 1 {
+		return item{}, false, false
+	}
+	switch it.data.Prim {
+	case consts.Left:
+		return data(it.data.Args[0]), true, true
+	case consts.Right:
+		return data(it.data.Args[0]), false, true
+	}
+	return item{}, false, false
+}
+
+func (it item) bool() (bool, bool) {
+	if it.kind != kindData {
+		return false, false
+	}
+	switch it.data.Prim {
+	case consts.True:
+		return true, true
+	case consts.False:
+		return false, true
+	}
+	return false, false
+}
+
+func (it item) list() ([]item, bool) {
+	switch it.kind {
+	case kindList:
+		return it.args, true
+	case kindData:
+		if it.data.Prim != consts.PrimArray {
+			return nil, false
+		}
+		items := make([]item, len(it.data.Args))
+		for i := range it.data.Args {
+			items[i] = data(it.data.Args[i])
+		}
+		return items, true
+	}
+	return nil, false
+}
+
+func (it item) mutez() (int64, bool) {
+	if it.kind != kindData || it.data.IntValue == nil || !it.data.IntValue.IsInt64() {
+		return 0, false
+	}
+	return it.data.IntValue.Int64(), true
+}
+
+// address - returns address and entrypoint of `address` or `contract` value
+func (it item) address() (string, string, bool) {
+	switch it.kind {
+	case kindContract:
+		return it.contract.address, it.contract.entrypoint, it.contract.address != ""
+	case kindData:
+		switch {
+		case it.data.StringValue != nil:
+			parts := strings.SplitN(*it.data.StringValue, "%", 2)
+			if len(parts) == 2 {
+				return parts[0], parts[1], true
+			}
+			return parts[0], "", true
+		case it.data.BytesValue != nil:
+			value := *it.data.BytesValue
+			if len(value) < 44 {
+				return "", "", false
+			}
+			address, err := forge.UnforgeAddress(value[:44])
+			if err != nil {
+				return "", "", false
+			}
+			entrypoint, err := hex.DecodeString(value[44:])
+			if err != nil {
+				return "", "", false
+			}
+			return address, string(entrypoint), true
+		}
+	}
+	return "", "", false
+}
+
+func (it item) keyHash() (string, bool) {
+	if it.kind != kindData {
+		return "", false
+	}
+	switch {
+	case it.data.StringValue != nil:
+		return *it.data.StringValue, true
+	case it.data.BytesValue != nil && len(*it.data.BytesValue) == 42:
+		address, err := forge.UnforgeAddress("00" + *it.data.BytesValue)
+		if err != nil {
+			return "", false
+		}
+		return address, true
+	}
+	return "", false
+}
+
+// node - converts value to Micheline. Returns nil if value is not fully known.
+func (it item) node() *base.Node {
+	switch it.kind {
+	case kindData:
+		return it.data
+	case kindPair, kindSome:
+		args := make([]*base.Node, len(it.args))
+		for i := range it.args {
+			if args[i] = it.args[i].node(); args[i] == nil {
+				return nil
+			}
+		}
+		prim := consts.Pair
+		if it.kind == kindSome {
+			prim = consts.Some
+		}
+		return &base.Node{Prim: prim, Args: args}
+	case kindNone:
+		return &base.Node{Prim: consts.None}
+	case kindList:
+		args := make([]*base.Node, len(it.args))
+		for i := range it.args {
+			if args[i] = it.args[i].node(); args[i] == nil {
+				return nil
+			}
+		}
+		return &base.Node{Prim: consts.PrimArray, Args: args}
+	case kindContract:
+		if it.contract.address == "" {
+			return nil
+		}
+		value := it.contract.address
+		if it.contract.entrypoint != "" && it.contract.entrypoint != consts.DefaultEntrypoint {
+			value += "%" + it.contract.entrypoint
+		}
+		return &base.Node{StringValue: &value}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package profiler
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/formatter"
+	"github.com/pkg/errors"
+)
+
+const milligasInGas int64 = 1000
+
+// Step - state of execution after instruction at `Location`. `Gas` is remaining gas in node format, e.g. `1039923.655`.
+type Step struct {
+	Location   int64
+	Gas        string
+	StackDepth int
+}
+
+// Instruction - gas consumption of instruction summed over all its executions
+type Instruction struct {
+	Location         int64  `json:"location"`
+	Prim             string `json:"prim,omitempty"`
+	Row              int    `json:"row,omitempty"`
+	StartColumn      int    `json:"start_col,omitempty"`
+	EndColumn        int    `json:"end_col,omitempty"`
+	Executions       int64  `json:"executions"`
+	ConsumedMilligas int64  `json:"consumed_milligas"`
+	MaxStackDepth    int    `json:"max_stack_depth"`
+}
+
+// Block - gas consumption of code block `{ ... }` including all nested instructions
+type Block struct {
+	Location         int64 `json:"location"`
+	Row              int   `json:"row"`
+	StartColumn      int   `json:"start_col"`
+	EndColumn        int   `json:"end_col"`
+	Instructions     int64 `json:"instructions"`
+	ConsumedMilligas int64 `json:"consumed_milligas"`
+	MaxStackDepth    int   `json:"max_stack_depth"`
+}
+
+// Profile - gas consumption of script execution. Positions of instructions and blocks refer to `Code`.
+// `UnaccountedMilligas` is gas consumed before the first traced instruction (script parsing and type checking).
+type Profile struct {
+	Code                string        `json:"code"`
+	GasLimit            int64         `json:"gas_limit"`
+	ConsumedMilligas    int64         `json:"consumed_milligas"`
+	UnaccountedMilligas int64         `json:"unaccounted_milligas"`
+	Steps               int64         `json:"steps"`
+	MaxStackDepth       int           `json:"max_stack_depth"`
+	Instructions        []Instruction `json:"instructions"`
+	Blocks              []Block       `json:"blocks"`
+}
+
+// New - builds profile of execution `trace` of script with Michelson `code` and node positions `locations` received from `formatter.LocateNodes`.
+// `gasLimit` is gas limit of execution in gas units.
+func New(code string, locations map[int]formatter.NodeLocation, trace []Step, gasLimit int64) (*Profile, error) {
+	profile := &Profile{
+		Code:         code,
+		GasLimit:     gasLimit,
+		Instructions: make([]Instruction, 0),
+		Blocks:       make([]Block, 0),
+	}
+
+	instructions := make(map[int64]*Instruction)
+	blocks := make(map[int64]*Block)
+
+	remaining := gasLimit * milligasInGas
+	for i := range trace {
+		milligas, err := ParseMilligas(trace[i].Gas)
+		if err != nil {
+			return nil, errors.Wrapf(err, "step %d", i)
+		}
+		consumed := remaining - milligas
+		remaining = milligas
+		if i == 0 {
+			profile.UnaccountedMilligas = consumed
+			consumed = 0
+		}
+
+		profile.Steps++
+		if trace[i].StackDepth > profile.MaxStackDepth {
+			profile.MaxStackDepth = trace[i].StackDepth
+		}
+
+		instruction, ok := instructions[trace[i].Location]
+		if !ok {
+			instruction = &Instruction{
+				Location: trace[i].Location,
+			}
+			if location, ok := locations[int(trace[i].Location)]; ok {
+				instruction.Prim = location.Prim
+				instruction.Row = location.Row
+				instruction.StartColumn = location.StartColumn
+				instruction.EndColumn = location.EndColumn
+			}
+			instructions[trace[i].Location] = instruction
+		}
+		instruction.Executions++
+		instruction.ConsumedMilligas += consumed
+		if trace[i].StackDepth > instruction.MaxStackDepth {
+			instruction.MaxStackDepth = trace[i].StackDepth
+		}
+
+		for _, id := range getEnclosingBlocks(locations, int(trace[i].Location)) {
+			block, ok := blocks[int64(id)]
+			if !ok {
+				location := locations[id]
+				block = &Block{
+					Location:    int64(id),
+					Row:         location.Row,
+					StartColumn: location.StartColumn,
+					EndColumn:   location.EndColumn,
+				}
+				blocks[int64(id)] = block
+			}
+			block.Instructions++
+			block.ConsumedMilligas += consumed
+			if trace[i].StackDepth > block.MaxStackDepth {
+				block.MaxStackDepth = trace[i].StackDepth
+			}
+		}
+	}
+	if len(trace) > 0 {
+		profile.ConsumedMilligas = gasLimit*milligasInGas - remaining
+	}
+
+	for _, instruction := range instructions {
+		profile.Instructions = append(profile.Instructions, *instruction)
+	}
+	sort.Slice(profile.Instructions, func(i, j int) bool {
+		if profile.Instructions[i].ConsumedMilligas == profile.Instructions[j].ConsumedMilligas {
+			return profile.Instructions[i].Location < profile.Instructions[j].Location
+		}
+		return profile.Instructions[i].ConsumedMilligas > profile.Instructions[j].ConsumedMilligas
+	})
+
+	for _, block := range blocks {
+		profile.Blocks = append(profile.Blocks, *block)
+	}
+	sort.Slice(profile.Blocks, func(i, j int) bool {
+		if profile.Blocks[i].ConsumedMilligas == profile.Blocks[j].ConsumedMilligas {
+			return profile.Blocks[i].Location < profile.Blocks[j].Location
+		}
+		return profile.Blocks[i].ConsumedMilligas > profile.Blocks[j].ConsumedMilligas
+	})
+	return profile, nil
+}
+
+// ParseMilligas - converts gas in node format (`1039923.655` or `1039923`) to milligas
+func ParseMilligas(gas string) (int64, error) {
+	parts := strings.SplitN(gas, ".", 2)
+	value, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid gas value: %s", gas)
+	}
+	value *= milligasInGas
+
+	if len(parts) == 2 {
+		fraction := parts[1]
+		if fraction == "" || len(fraction) > 3 {
+			return 0, errors.Errorf("invalid gas value: %s", gas)
+		}
+		fraction += strings.Repeat("0", 3-len(fraction))
+		milligas, err := strconv.ParseInt(fraction, 10, 64)
+		if err != nil || milligas < 0 {
+			return 0, errors.Errorf("invalid gas value: %s", gas)
+		}
+		value += milligas
+	}
+	return value, nil
+}
+
+func getEnclosingBlocks(locations map[int]formatter.NodeLocation, id int) []int {
+	result := make([]int, 0)
+	location, ok := locations[id]
+	for ok {
+		parent, exists := locations[location.Parent]
+		if !exists {
+			break
+		}
+		if parent.Sequence {
+			result = append(result, location.Parent)
+		}
+		location = parent
+	}
+	return result
+}
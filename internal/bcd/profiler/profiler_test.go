@@ -0,0 +1,109 @@
+package profiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/formatter"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestParseMilligas(t *testing.T) {
+	tests := []struct {
+		name    string
+		gas     string
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "integer",
+			gas:  "1039923",
+			want: 1039923000,
+		}, {
+			name: "milligas",
+			gas:  "1039923.655",
+			want: 1039923655,
+		}, {
+			name: "short fraction",
+			gas:  "10.5",
+			want: 10500,
+		}, {
+			name:    "empty",
+			gas:     "",
+			wantErr: true,
+		}, {
+			name:    "long fraction",
+			gas:     "10.1234",
+			wantErr: true,
+		}, {
+			name:    "invalid fraction",
+			gas:     "10.-1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMilligas(tt.gas)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseMilligas() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	script := `[{"prim":"parameter","args":[{"prim":"bool"}]},{"prim":"storage","args":[{"prim":"nat"}]},{"prim":"code","args":[[{"prim":"UNPAIR"},{"prim":"IF","args":[[{"prim":"PUSH","args":[{"prim":"nat"},{"int":"1"}]},{"prim":"ADD"}],[]]},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`
+	code, locations, err := formatter.LocateNodes(gjson.Parse(script), formatter.DefLineSize)
+	if err != nil {
+		t.Fatalf("LocateNodes() error = %v", err)
+	}
+
+	trace := []Step{
+		{Location: 7, Gas: "999.900", StackDepth: 2},
+		{Location: 8, Gas: "999.850", StackDepth: 1},
+		{Location: 10, Gas: "999.800", StackDepth: 2},
+		{Location: 13, Gas: "999.600", StackDepth: 1},
+		{Location: 15, Gas: "999.590", StackDepth: 2},
+		{Location: 17, Gas: "999.580", StackDepth: 1},
+	}
+	profile, err := New(code, locations, trace, 1000)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	assert.Equal(t, code, profile.Code)
+	assert.Equal(t, int64(420), profile.ConsumedMilligas)
+	assert.Equal(t, int64(100), profile.UnaccountedMilligas)
+	assert.Equal(t, int64(6), profile.Steps)
+	assert.Equal(t, 2, profile.MaxStackDepth)
+
+	if assert.Len(t, profile.Instructions, 6) {
+		add := profile.Instructions[0]
+		assert.Equal(t, int64(13), add.Location)
+		assert.Equal(t, "ADD", add.Prim)
+		assert.Equal(t, int64(200), add.ConsumedMilligas)
+		assert.Equal(t, int64(1), add.Executions)
+		assert.Equal(t, 1, add.MaxStackDepth)
+
+		row := []rune(strings.Split(code, "\n")[add.Row-1])
+		assert.Equal(t, "ADD", string(row[add.StartColumn:add.EndColumn+1]))
+	}
+
+	if assert.Len(t, profile.Blocks, 2) {
+		assert.Equal(t, int64(6), profile.Blocks[0].Location)
+		assert.Equal(t, int64(6), profile.Blocks[0].Instructions)
+		assert.Equal(t, int64(320), profile.Blocks[0].ConsumedMilligas)
+		assert.Equal(t, 2, profile.Blocks[0].MaxStackDepth)
+		assert.Equal(t, int64(9), profile.Blocks[1].Location)
+		assert.Equal(t, int64(2), profile.Blocks[1].Instructions)
+		assert.Equal(t, int64(250), profile.Blocks[1].ConsumedMilligas)
+	}
+}
+
+func TestNew_InvalidGas(t *testing.T) {
+	_, err := New("", nil, []Step{{Location: 7, Gas: "invalid"}}, 1000)
+	assert.Error(t, err)
+}
@@ -32,45 +32,67 @@ type Config struct {
 	Domains      TezosDomainsConfig    `yaml:"domains"`
 
 	API struct {
-		ProjectName   string         `yaml:"project_name"`
-		Bind          string         `yaml:"bind"`
-		SwaggerHost   string         `yaml:"swagger_host"`
-		CorsEnabled   bool           `yaml:"cors_enabled"`
-		OAuthEnabled  bool           `yaml:"oauth_enabled"`
-		SentryEnabled bool           `yaml:"sentry_enabled"`
-		SeedEnabled   bool           `yaml:"seed_enabled"`
-		Frontend      FrontendConfig `yaml:"frontend"`
-		Seed          SeedConfig     `yaml:"seed"`
-		Networks      []string       `yaml:"networks"`
-		MQ            MQConfig       `yaml:"mq"`
-		Pinata        PinataConfig   `yaml:"pinata"`
+		ProjectName   string           `yaml:"project_name"`
+		Bind          string           `yaml:"bind"`
+		SwaggerHost   string           `yaml:"swagger_host"`
+		CorsEnabled   bool             `yaml:"cors_enabled"`
+		StreamOrigins []string         `yaml:"stream_origins"`
+		OAuthEnabled  bool             `yaml:"oauth_enabled"`
+		SentryEnabled bool             `yaml:"sentry_enabled"`
+		SeedEnabled   bool             `yaml:"seed_enabled"`
+		Frontend      FrontendConfig   `yaml:"frontend"`
+		Seed          SeedConfig       `yaml:"seed"`
+		Networks      []string         `yaml:"networks"`
+		MQ            MQConfig         `yaml:"mq"`
+		Pinata        PinataConfig     `yaml:"pinata"`
+		Prometheus    PrometheusConfig `yaml:"prometheus"`
+		RateLimit     RateLimitConfig  `yaml:"rate_limit"`
 	} `yaml:"api"`
 
 	Compiler struct {
-		ProjectName   string    `yaml:"project_name"`
-		SentryEnabled bool      `yaml:"sentry_enabled"`
-		AWS           AWSConfig `yaml:"aws"`
-		MQ            MQConfig  `yaml:"mq"`
+		ProjectName   string           `yaml:"project_name"`
+		SentryEnabled bool             `yaml:"sentry_enabled"`
+		AWS           AWSConfig        `yaml:"aws"`
+		MQ            MQConfig         `yaml:"mq"`
+		Prometheus    PrometheusConfig `yaml:"prometheus"`
 	} `yaml:"compiler"`
 
 	Indexer struct {
 		Networks map[string]struct {
-			Boost string `yaml:"boost"`
+			Boost       string         `yaml:"boost"`
+			Concurrency int            `yaml:"concurrency"`
+			Cassette    CassetteConfig `yaml:"cassette"`
 		} `yaml:"networks"`
 		ProjectName   string `yaml:"project_name"`
 		SentryEnabled bool   `yaml:"sentry_enabled"`
 
-		SkipDelegatorBlocks bool     `yaml:"skip_delegator_blocks"`
-		MQ                  MQConfig `yaml:"mq"`
+		SkipDelegatorBlocks bool             `yaml:"skip_delegator_blocks"`
+		ReorgWindow         int              `yaml:"reorg_window"`
+		MQ                  MQConfig         `yaml:"mq"`
+		Prometheus          PrometheusConfig `yaml:"prometheus"`
 	} `yaml:"indexer"`
 
 	Metrics struct {
-		ProjectName         string   `yaml:"project_name"`
-		SentryEnabled       bool     `yaml:"sentry_enabled"`
-		CacheAliasesSeconds int      `yaml:"cache_aliases_seconds"`
-		MQ                  MQConfig `yaml:"mq"`
+		ProjectName         string           `yaml:"project_name"`
+		SentryEnabled       bool             `yaml:"sentry_enabled"`
+		CacheAliasesSeconds int              `yaml:"cache_aliases_seconds"`
+		AuditRepair         bool             `yaml:"audit_repair"`
+		AuditMaxKeys        int64            `yaml:"audit_max_keys"`
+		MQ                  MQConfig         `yaml:"mq"`
+		Prometheus          PrometheusConfig `yaml:"prometheus"`
 	} `yaml:"metrics"`
 
+	Notifier struct {
+		ProjectName       string   `yaml:"project_name"`
+		SentryEnabled     bool     `yaml:"sentry_enabled"`
+		MaxAttempts       uint     `yaml:"max_attempts"`
+		BackoffSeconds    int      `yaml:"backoff_seconds"`
+		MaxBackoffSeconds int      `yaml:"max_backoff_seconds"`
+		TimeoutSeconds    int      `yaml:"timeout_seconds"`
+		Workers           int      `yaml:"workers"`
+		MQ                MQConfig `yaml:"mq"`
+	} `yaml:"notifier"`
+
 	Scripts struct {
 		AWS      AWSConfig `yaml:"aws"`
 		Networks []string  `yaml:"networks"`
@@ -84,6 +106,36 @@ type RPCConfig struct {
 	Timeout int    `yaml:"timeout"`
 }
 
+// CassetteConfig - file of recorded node RPC requests. `Mode` is `record` or `replay`.
+type CassetteConfig struct {
+	Mode string `yaml:"mode"`
+	Path string `yaml:"path"`
+}
+
+// PrometheusConfig - address of `/metrics` endpoint. Endpoint is disabled if `Bind` is empty.
+type PrometheusConfig struct {
+	Bind string `yaml:"bind"`
+}
+
+// RateLimitConfig - limits of API clients. Clients without API key are limited by IP. Rate limiting is disabled if `Enabled` is false.
+// Client IP is taken from `X-Forwarded-For` header only if request came from one of `TrustedProxies` (IPs or CIDRs).
+type RateLimitConfig struct {
+	Enabled        bool        `yaml:"enabled"`
+	IP             LimitConfig `yaml:"ip"`
+	Key            LimitConfig `yaml:"key"`
+	ExpensiveIP    LimitConfig `yaml:"expensive_ip"`
+	ExpensiveKey   LimitConfig `yaml:"expensive_key"`
+	DailyQuota     uint        `yaml:"daily_quota"`
+	FlushInterval  int         `yaml:"flush_interval"`
+	TrustedProxies []string    `yaml:"trusted_proxies"`
+}
+
+// LimitConfig - token bucket: `Rate` requests per second with bursts up to `Burst` requests. Zero value is unlimited.
+type LimitConfig struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
 // TzKTConfig -
 type TzKTConfig struct {
 	URI         string `yaml:"uri"`
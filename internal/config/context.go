@@ -11,7 +11,9 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/migration"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/models/saplingdiff"
 	"github.com/baking-bad/bcdhub/internal/models/tezosdomain"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
 	"github.com/baking-bad/bcdhub/internal/models/tokenbalance"
 	"github.com/baking-bad/bcdhub/internal/models/tokenmetadata"
 	"github.com/baking-bad/bcdhub/internal/models/transfer"
@@ -46,7 +48,9 @@ type Context struct {
 	Migrations    migration.Repository
 	Operations    operation.Repository
 	Protocols     protocol.Repository
+	SaplingDiffs  saplingdiff.Repository
 	TezosDomains  tezosdomain.Repository
+	Tickets       ticket.Repository
 	TokenBalances tokenbalance.Repository
 	TokenMetadata tokenmetadata.Repository
 	Transfers     transfer.Repository
@@ -3,10 +3,13 @@ package config
 import (
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/baking-bad/bcdhub/internal/aws"
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/ast/interfaces"
 	"github.com/baking-bad/bcdhub/internal/bcd/tezerrors"
 	"github.com/baking-bad/bcdhub/internal/database"
 	"github.com/baking-bad/bcdhub/internal/elastic/bigmapaction"
@@ -17,11 +20,14 @@ import (
 	"github.com/baking-bad/bcdhub/internal/elastic/migration"
 	"github.com/baking-bad/bcdhub/internal/elastic/operation"
 	"github.com/baking-bad/bcdhub/internal/elastic/protocol"
+	"github.com/baking-bad/bcdhub/internal/elastic/saplingdiff"
 	"github.com/baking-bad/bcdhub/internal/elastic/tezosdomain"
+	"github.com/baking-bad/bcdhub/internal/elastic/ticket"
 	"github.com/baking-bad/bcdhub/internal/elastic/tokenbalance"
 	"github.com/baking-bad/bcdhub/internal/elastic/tokenmetadata"
 	"github.com/baking-bad/bcdhub/internal/elastic/transfer"
 	"github.com/baking-bad/bcdhub/internal/elastic/tzip"
+	"github.com/baking-bad/bcdhub/internal/logger"
 
 	reindexerBMA "github.com/baking-bad/bcdhub/internal/reindexer/bigmapaction"
 	reindexerBMD "github.com/baking-bad/bcdhub/internal/reindexer/bigmapdiff"
@@ -31,12 +37,30 @@ import (
 	reindexerMigration "github.com/baking-bad/bcdhub/internal/reindexer/migration"
 	reindexerOperation "github.com/baking-bad/bcdhub/internal/reindexer/operation"
 	reindexerProtocol "github.com/baking-bad/bcdhub/internal/reindexer/protocol"
+	reindexerSapling "github.com/baking-bad/bcdhub/internal/reindexer/saplingdiff"
 	reindexerTD "github.com/baking-bad/bcdhub/internal/reindexer/tezosdomain"
+	reindexerTicket "github.com/baking-bad/bcdhub/internal/reindexer/ticket"
 	reindexerTB "github.com/baking-bad/bcdhub/internal/reindexer/tokenbalance"
 	reindexerTM "github.com/baking-bad/bcdhub/internal/reindexer/tokenmetadata"
 	reindexerTransfer "github.com/baking-bad/bcdhub/internal/reindexer/transfer"
 	reindexertzip "github.com/baking-bad/bcdhub/internal/reindexer/tzip"
 
+	pgBMA "github.com/baking-bad/bcdhub/internal/postgres/bigmapaction"
+	pgBMD "github.com/baking-bad/bcdhub/internal/postgres/bigmapdiff"
+	pgBlock "github.com/baking-bad/bcdhub/internal/postgres/block"
+	pgContract "github.com/baking-bad/bcdhub/internal/postgres/contract"
+	pgCore "github.com/baking-bad/bcdhub/internal/postgres/core"
+	pgMigration "github.com/baking-bad/bcdhub/internal/postgres/migration"
+	pgOperation "github.com/baking-bad/bcdhub/internal/postgres/operation"
+	pgProtocol "github.com/baking-bad/bcdhub/internal/postgres/protocol"
+	pgSapling "github.com/baking-bad/bcdhub/internal/postgres/saplingdiff"
+	pgTD "github.com/baking-bad/bcdhub/internal/postgres/tezosdomain"
+	pgTicket "github.com/baking-bad/bcdhub/internal/postgres/ticket"
+	pgTB "github.com/baking-bad/bcdhub/internal/postgres/tokenbalance"
+	pgTM "github.com/baking-bad/bcdhub/internal/postgres/tokenmetadata"
+	pgTransfer "github.com/baking-bad/bcdhub/internal/postgres/transfer"
+	pgTZIP "github.com/baking-bad/bcdhub/internal/postgres/tzip"
+
 	"github.com/baking-bad/bcdhub/internal/mq"
 	"github.com/baking-bad/bcdhub/internal/noderpc"
 	"github.com/baking-bad/bcdhub/internal/pinata"
@@ -83,12 +107,36 @@ func WithStorage(cfg StorageConfig) ContextOption {
 			ctx.Migrations = reindexerMigration.NewStorage(storage)
 			ctx.Operations = reindexerOperation.NewStorage(storage)
 			ctx.Protocols = reindexerProtocol.NewStorage(storage)
+			ctx.SaplingDiffs = reindexerSapling.NewStorage(storage)
 			ctx.TezosDomains = reindexerTD.NewStorage(storage)
+			ctx.Tickets = reindexerTicket.NewStorage(storage)
 			ctx.TokenBalances = reindexerTB.NewStorage(storage)
 			ctx.TokenMetadata = reindexerTM.NewStorage(storage)
 			ctx.Transfers = reindexerTransfer.NewStorage(storage)
 			ctx.TZIP = reindexertzip.NewStorage(storage)
 
+			if err := ctx.Storage.CreateIndexes(); err != nil {
+				panic(err)
+			}
+		} else if strings.HasPrefix(cfg.URI[0], "postgres://") || strings.HasPrefix(cfg.URI[0], "postgresql://") {
+			storage := pgCore.WaitNew(cfg.URI[0], cfg.Timeout)
+
+			ctx.Storage = storage
+			ctx.BigMapActions = pgBMA.NewStorage(storage)
+			ctx.BigMapDiffs = pgBMD.NewStorage(storage)
+			ctx.Blocks = pgBlock.NewStorage(storage)
+			ctx.Contracts = pgContract.NewStorage(storage)
+			ctx.Migrations = pgMigration.NewStorage(storage)
+			ctx.Operations = pgOperation.NewStorage(storage)
+			ctx.Protocols = pgProtocol.NewStorage(storage)
+			ctx.SaplingDiffs = pgSapling.NewStorage(storage)
+			ctx.TezosDomains = pgTD.NewStorage(storage)
+			ctx.Tickets = pgTicket.NewStorage(storage)
+			ctx.TokenBalances = pgTB.NewStorage(storage)
+			ctx.TokenMetadata = pgTM.NewStorage(storage)
+			ctx.Transfers = pgTransfer.NewStorage(storage)
+			ctx.TZIP = pgTZIP.NewStorage(storage)
+
 			if err := ctx.Storage.CreateIndexes(); err != nil {
 				panic(err)
 			}
@@ -103,7 +151,9 @@ func WithStorage(cfg StorageConfig) ContextOption {
 			ctx.Migrations = migration.NewStorage(es)
 			ctx.Operations = operation.NewStorage(es)
 			ctx.Protocols = protocol.NewStorage(es)
+			ctx.SaplingDiffs = saplingdiff.NewStorage(es)
 			ctx.TezosDomains = tezosdomain.NewStorage(es)
+			ctx.Tickets = ticket.NewStorage(es)
 			ctx.TokenBalances = tokenbalance.NewStorage(es)
 			ctx.TokenMetadata = tokenmetadata.NewStorage(es)
 			ctx.Transfers = transfer.NewStorage(es)
@@ -181,6 +231,19 @@ func WithLoadErrorDescriptions() ContextOption {
 	}
 }
 
+// WithLoadContractInterfaces - registers contract interfaces from `interfaces` directory of share path
+func WithLoadContractInterfaces(sharePath string) ContextOption {
+	return func(ctx *Context) {
+		count, err := ast.LoadContractInterfaces(filepath.Join(sharePath, interfaces.Directory))
+		if err != nil {
+			panic(err)
+		}
+		if count > 0 {
+			logger.Info("%d contract interfaces are loaded", count)
+		}
+	}
+}
+
 // WithAWS -
 func WithAWS(cfg AWSConfig) ContextOption {
 	return func(ctx *Context) {
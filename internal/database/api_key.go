@@ -0,0 +1,82 @@
+package database
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// APIKey - key which identifies requests of user to API. Only SHA-256 hash of key is stored, `Prefix` helps user to recognize the key.
+type APIKey struct {
+	ID         uint       `gorm:"primary_key" json:"id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"-"`
+	DeletedAt  *time.Time `sql:"index" json:"-"`
+	UserID     uint       `gorm:"index;not null" json:"-"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Hash       string     `gorm:"unique_index;not null" json:"-"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// APIKeyUsage - daily usage counters of API key
+type APIKeyUsage struct {
+	APIKeyID  uint      `gorm:"primary_key;auto_increment:false" json:"-"`
+	Day       time.Time `gorm:"primary_key;type:date" json:"day"`
+	Requests  uint      `gorm:"not null;default:0" json:"requests"`
+	Expensive uint      `gorm:"not null;default:0" json:"expensive"`
+	Rejected  uint      `gorm:"not null;default:0" json:"rejected"`
+}
+
+// CreateAPIKey -
+func (d *db) CreateAPIKey(key *APIKey) error {
+	return d.Create(key).Error
+}
+
+// GetAPIKeyByHash -
+func (d *db) GetAPIKeyByHash(hash string) (*APIKey, error) {
+	key := new(APIKey)
+	return key, d.Where("hash = ?", hash).First(key).Error
+}
+
+// ListAPIKeys -
+func (d *db) ListAPIKeys(userID uint) ([]APIKey, error) {
+	var keys []APIKey
+	return keys, d.Scopes(userIDScope(userID), createdAtDesc).Find(&keys).Error
+}
+
+// DeleteAPIKey - returns `gorm.ErrRecordNotFound` if user has no such key
+func (d *db) DeleteAPIKey(userID, id uint) error {
+	result := d.Scopes(userIDScope(userID), idScope(id)).Delete(&APIKey{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListAPIKeyUsage - returns daily usage of key since `since` day
+func (d *db) ListAPIKeyUsage(keyID uint, since time.Time) ([]APIKeyUsage, error) {
+	var usage []APIKeyUsage
+	return usage, d.
+		Where("api_key_id = ? AND day >= ?", keyID, since).
+		Order("day desc").
+		Find(&usage).Error
+}
+
+// IncrementAPIKeyUsage - adds `delta` to usage counters of key at `delta.Day` and updates last usage time of key
+func (d *db) IncrementAPIKeyUsage(delta APIKeyUsage, usedAt time.Time) error {
+	if err := d.Exec(`
+		INSERT INTO api_key_usages (api_key_id, day, requests, expensive, rejected) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (api_key_id, day) DO UPDATE SET
+			requests = api_key_usages.requests + EXCLUDED.requests,
+			expensive = api_key_usages.expensive + EXCLUDED.expensive,
+			rejected = api_key_usages.rejected + EXCLUDED.rejected`,
+		delta.APIKeyID, delta.Day, delta.Requests, delta.Expensive, delta.Rejected,
+	).Error; err != nil {
+		return err
+	}
+	return d.Model(&APIKey{}).Scopes(idScope(delta.APIKeyID)).UpdateColumn("last_used_at", usedAt).Error
+}
@@ -13,12 +13,14 @@ import (
 // DB -
 type DB interface {
 	IAccount
+	IAPIKey
 	IAssessment
 	ICompilationTask
 	IDeployment
 	ISubscription
 	IUser
 	IVerification
+	IWebhookDelivery
 
 	Close()
 }
@@ -28,6 +30,16 @@ type IAccount interface {
 	GetOrCreateAccount(*Account) error
 }
 
+// IAPIKey -
+type IAPIKey interface {
+	CreateAPIKey(key *APIKey) error
+	GetAPIKeyByHash(hash string) (*APIKey, error)
+	ListAPIKeys(userID uint) ([]APIKey, error)
+	DeleteAPIKey(userID, id uint) error
+	ListAPIKeyUsage(keyID uint, since time.Time) ([]APIKeyUsage, error)
+	IncrementAPIKeyUsage(delta APIKeyUsage, usedAt time.Time) error
+}
+
 // IAssessment -
 type IAssessment interface {
 	CreateAssessment(a *Assessments) error
@@ -62,6 +74,7 @@ type ISubscription interface {
 	GetSubscription(userID uint, address, network string) (Subscription, error)
 	GetSubscriptions(address, network string) ([]Subscription, error)
 	ListSubscriptions(userID uint) ([]Subscription, error)
+	ListWebhookSubscriptions() ([]Subscription, error)
 	UpsertSubscription(s *Subscription) error
 	DeleteSubscription(s *Subscription) error
 	GetSubscriptionsCount(address, network string) (int, error)
@@ -82,6 +95,16 @@ type IVerification interface {
 	CountVerifications(userID uint) (int64, error)
 }
 
+// IWebhookDelivery -
+type IWebhookDelivery interface {
+	CreateWebhookDelivery(wd *WebhookDelivery) error
+	UpdateWebhookDelivery(wd *WebhookDelivery) error
+	GetWebhookDelivery(userID, id uint) (*WebhookDelivery, error)
+	ListWebhookDeliveries(userID, limit, offset uint) ([]WebhookDelivery, error)
+	CountWebhookDeliveries(userID uint) (int64, error)
+	GetPendingWebhookDeliveries(before time.Time, limit uint) ([]WebhookDelivery, error)
+}
+
 type db struct {
 	*gorm.DB
 }
@@ -104,6 +127,9 @@ func New(connectionString string) (DB, error) {
 		&CompilationTaskResult{},
 		&Verification{},
 		&Deployment{},
+		&WebhookDelivery{},
+		&APIKey{},
+		&APIKeyUsage{},
 	)
 
 	gormDB = gormDB.Set("gorm:auto_preload", false)
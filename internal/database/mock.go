@@ -7,6 +7,7 @@ package database
 import (
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+	time "time"
 )
 
 // MockDB is a mock of DB interface
@@ -46,6 +47,93 @@ func (mr *MockDBMockRecorder) GetOrCreateAccount(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateAccount", reflect.TypeOf((*MockDB)(nil).GetOrCreateAccount), arg0)
 }
 
+// CreateAPIKey mocks base method
+func (m *MockDB) CreateAPIKey(key *APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey
+func (mr *MockDBMockRecorder) CreateAPIKey(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockDB)(nil).CreateAPIKey), key)
+}
+
+// GetAPIKeyByHash mocks base method
+func (m *MockDB) GetAPIKeyByHash(hash string) (*APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", hash)
+	ret0, _ := ret[0].(*APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash
+func (mr *MockDBMockRecorder) GetAPIKeyByHash(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockDB)(nil).GetAPIKeyByHash), hash)
+}
+
+// ListAPIKeys mocks base method
+func (m *MockDB) ListAPIKeys(userID uint) ([]APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", userID)
+	ret0, _ := ret[0].([]APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys
+func (mr *MockDBMockRecorder) ListAPIKeys(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockDB)(nil).ListAPIKeys), userID)
+}
+
+// DeleteAPIKey mocks base method
+func (m *MockDB) DeleteAPIKey(userID uint, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIKey", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAPIKey indicates an expected call of DeleteAPIKey
+func (mr *MockDBMockRecorder) DeleteAPIKey(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIKey", reflect.TypeOf((*MockDB)(nil).DeleteAPIKey), userID, id)
+}
+
+// ListAPIKeyUsage mocks base method
+func (m *MockDB) ListAPIKeyUsage(keyID uint, since time.Time) ([]APIKeyUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeyUsage", keyID, since)
+	ret0, _ := ret[0].([]APIKeyUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeyUsage indicates an expected call of ListAPIKeyUsage
+func (mr *MockDBMockRecorder) ListAPIKeyUsage(keyID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeyUsage", reflect.TypeOf((*MockDB)(nil).ListAPIKeyUsage), keyID, since)
+}
+
+// IncrementAPIKeyUsage mocks base method
+func (m *MockDB) IncrementAPIKeyUsage(delta APIKeyUsage, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementAPIKeyUsage", delta, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementAPIKeyUsage indicates an expected call of IncrementAPIKeyUsage
+func (mr *MockDBMockRecorder) IncrementAPIKeyUsage(delta, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementAPIKeyUsage", reflect.TypeOf((*MockDB)(nil).IncrementAPIKeyUsage), delta, usedAt)
+}
+
 // CreateAssessment mocks base method
 func (m *MockDB) CreateAssessment(a *Assessments) error {
 	m.ctrl.T.Helper()
@@ -75,18 +163,18 @@ func (mr *MockDBMockRecorder) CreateOrUpdateAssessment(a interface{}) *gomock.Ca
 }
 
 // GetAssessmentsWithValue mocks base method
-func (m *MockDB) GetAssessmentsWithValue(arg0, arg1, arg2 uint) ([]Assessments, error) {
+func (m *MockDB) GetAssessmentsWithValue(userID, assessment, size uint) ([]Assessments, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAssessmentsWithValue", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetAssessmentsWithValue", userID, assessment, size)
 	ret0, _ := ret[0].([]Assessments)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAssessmentsWithValue indicates an expected call of GetAssessmentsWithValue
-func (mr *MockDBMockRecorder) GetAssessmentsWithValue(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockDBMockRecorder) GetAssessmentsWithValue(userID, assessment, size interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssessmentsWithValue", reflect.TypeOf((*MockDB)(nil).GetAssessmentsWithValue), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssessmentsWithValue", reflect.TypeOf((*MockDB)(nil).GetAssessmentsWithValue), userID, assessment, size)
 }
 
 // GetUserCompletedAssesments mocks base method
@@ -250,6 +338,21 @@ func (mr *MockDBMockRecorder) GetDeploymentBy(opHash interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentBy", reflect.TypeOf((*MockDB)(nil).GetDeploymentBy), opHash)
 }
 
+// GetDeploymentsByAddressNetwork mocks base method
+func (m *MockDB) GetDeploymentsByAddressNetwork(address, network string) ([]Deployment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeploymentsByAddressNetwork", address, network)
+	ret0, _ := ret[0].([]Deployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeploymentsByAddressNetwork indicates an expected call of GetDeploymentsByAddressNetwork
+func (mr *MockDBMockRecorder) GetDeploymentsByAddressNetwork(address, network interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentsByAddressNetwork", reflect.TypeOf((*MockDB)(nil).GetDeploymentsByAddressNetwork), address, network)
+}
+
 // UpdateDeployment mocks base method
 func (m *MockDB) UpdateDeployment(dt *Deployment) error {
 	m.ctrl.T.Helper()
@@ -324,32 +427,47 @@ func (mr *MockDBMockRecorder) ListSubscriptions(userID interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockDB)(nil).ListSubscriptions), userID)
 }
 
+// ListWebhookSubscriptions mocks base method
+func (m *MockDB) ListWebhookSubscriptions() ([]Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookSubscriptions")
+	ret0, _ := ret[0].([]Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookSubscriptions indicates an expected call of ListWebhookSubscriptions
+func (mr *MockDBMockRecorder) ListWebhookSubscriptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookSubscriptions", reflect.TypeOf((*MockDB)(nil).ListWebhookSubscriptions))
+}
+
 // UpsertSubscription mocks base method
-func (m *MockDB) UpsertSubscription(arg0 *Subscription) error {
+func (m *MockDB) UpsertSubscription(s *Subscription) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertSubscription", arg0)
+	ret := m.ctrl.Call(m, "UpsertSubscription", s)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpsertSubscription indicates an expected call of UpsertSubscription
-func (mr *MockDBMockRecorder) UpsertSubscription(arg0 interface{}) *gomock.Call {
+func (mr *MockDBMockRecorder) UpsertSubscription(s interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSubscription", reflect.TypeOf((*MockDB)(nil).UpsertSubscription), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSubscription", reflect.TypeOf((*MockDB)(nil).UpsertSubscription), s)
 }
 
 // DeleteSubscription mocks base method
-func (m *MockDB) DeleteSubscription(arg0 *Subscription) error {
+func (m *MockDB) DeleteSubscription(s *Subscription) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSubscription", arg0)
+	ret := m.ctrl.Call(m, "DeleteSubscription", s)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteSubscription indicates an expected call of DeleteSubscription
-func (mr *MockDBMockRecorder) DeleteSubscription(arg0 interface{}) *gomock.Call {
+func (mr *MockDBMockRecorder) DeleteSubscription(s interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockDB)(nil).DeleteSubscription), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockDB)(nil).DeleteSubscription), s)
 }
 
 // GetSubscriptionsCount mocks base method
@@ -368,46 +486,46 @@ func (mr *MockDBMockRecorder) GetSubscriptionsCount(address, network interface{}
 }
 
 // GetOrCreateUser mocks base method
-func (m *MockDB) GetOrCreateUser(arg0 *User, arg1 string) error {
+func (m *MockDB) GetOrCreateUser(u *User, token string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrCreateUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetOrCreateUser", u, token)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // GetOrCreateUser indicates an expected call of GetOrCreateUser
-func (mr *MockDBMockRecorder) GetOrCreateUser(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockDBMockRecorder) GetOrCreateUser(u, token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateUser", reflect.TypeOf((*MockDB)(nil).GetOrCreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateUser", reflect.TypeOf((*MockDB)(nil).GetOrCreateUser), u, token)
 }
 
 // GetUser mocks base method
-func (m *MockDB) GetUser(arg0 uint) (*User, error) {
+func (m *MockDB) GetUser(userID uint) (*User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0)
+	ret := m.ctrl.Call(m, "GetUser", userID)
 	ret0, _ := ret[0].(*User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUser indicates an expected call of GetUser
-func (mr *MockDBMockRecorder) GetUser(arg0 interface{}) *gomock.Call {
+func (mr *MockDBMockRecorder) GetUser(userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockDB)(nil).GetUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockDB)(nil).GetUser), userID)
 }
 
 // UpdateUserMarkReadAt mocks base method
-func (m *MockDB) UpdateUserMarkReadAt(arg0 uint, arg1 int64) error {
+func (m *MockDB) UpdateUserMarkReadAt(userID uint, ts int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserMarkReadAt", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateUserMarkReadAt", userID, ts)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateUserMarkReadAt indicates an expected call of UpdateUserMarkReadAt
-func (mr *MockDBMockRecorder) UpdateUserMarkReadAt(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockDBMockRecorder) UpdateUserMarkReadAt(userID, ts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserMarkReadAt", reflect.TypeOf((*MockDB)(nil).UpdateUserMarkReadAt), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserMarkReadAt", reflect.TypeOf((*MockDB)(nil).UpdateUserMarkReadAt), userID, ts)
 }
 
 // ListVerifications mocks base method
@@ -469,6 +587,94 @@ func (mr *MockDBMockRecorder) CountVerifications(userID interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountVerifications", reflect.TypeOf((*MockDB)(nil).CountVerifications), userID)
 }
 
+// CreateWebhookDelivery mocks base method
+func (m *MockDB) CreateWebhookDelivery(wd *WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDelivery", wd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhookDelivery indicates an expected call of CreateWebhookDelivery
+func (mr *MockDBMockRecorder) CreateWebhookDelivery(wd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDelivery", reflect.TypeOf((*MockDB)(nil).CreateWebhookDelivery), wd)
+}
+
+// UpdateWebhookDelivery mocks base method
+func (m *MockDB) UpdateWebhookDelivery(wd *WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookDelivery", wd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookDelivery indicates an expected call of UpdateWebhookDelivery
+func (mr *MockDBMockRecorder) UpdateWebhookDelivery(wd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookDelivery", reflect.TypeOf((*MockDB)(nil).UpdateWebhookDelivery), wd)
+}
+
+// GetWebhookDelivery mocks base method
+func (m *MockDB) GetWebhookDelivery(userID, id uint) (*WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", userID, id)
+	ret0, _ := ret[0].(*WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery
+func (mr *MockDBMockRecorder) GetWebhookDelivery(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockDB)(nil).GetWebhookDelivery), userID, id)
+}
+
+// ListWebhookDeliveries mocks base method
+func (m *MockDB) ListWebhookDeliveries(userID, limit, offset uint) ([]WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", userID, limit, offset)
+	ret0, _ := ret[0].([]WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries
+func (mr *MockDBMockRecorder) ListWebhookDeliveries(userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockDB)(nil).ListWebhookDeliveries), userID, limit, offset)
+}
+
+// CountWebhookDeliveries mocks base method
+func (m *MockDB) CountWebhookDeliveries(userID uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountWebhookDeliveries", userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountWebhookDeliveries indicates an expected call of CountWebhookDeliveries
+func (mr *MockDBMockRecorder) CountWebhookDeliveries(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountWebhookDeliveries", reflect.TypeOf((*MockDB)(nil).CountWebhookDeliveries), userID)
+}
+
+// GetPendingWebhookDeliveries mocks base method
+func (m *MockDB) GetPendingWebhookDeliveries(before time.Time, limit uint) ([]WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingWebhookDeliveries", before, limit)
+	ret0, _ := ret[0].([]WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingWebhookDeliveries indicates an expected call of GetPendingWebhookDeliveries
+func (mr *MockDBMockRecorder) GetPendingWebhookDeliveries(before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingWebhookDeliveries", reflect.TypeOf((*MockDB)(nil).GetPendingWebhookDeliveries), before, limit)
+}
+
 // Close mocks base method
 func (m *MockDB) Close() {
 	m.ctrl.T.Helper()
@@ -570,18 +776,18 @@ func (mr *MockIAssessmentMockRecorder) CreateOrUpdateAssessment(a interface{}) *
 }
 
 // GetAssessmentsWithValue mocks base method
-func (m *MockIAssessment) GetAssessmentsWithValue(arg0, arg1, arg2 uint) ([]Assessments, error) {
+func (m *MockIAssessment) GetAssessmentsWithValue(userID, assessment, size uint) ([]Assessments, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAssessmentsWithValue", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetAssessmentsWithValue", userID, assessment, size)
 	ret0, _ := ret[0].([]Assessments)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAssessmentsWithValue indicates an expected call of GetAssessmentsWithValue
-func (mr *MockIAssessmentMockRecorder) GetAssessmentsWithValue(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockIAssessmentMockRecorder) GetAssessmentsWithValue(userID, assessment, size interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssessmentsWithValue", reflect.TypeOf((*MockIAssessment)(nil).GetAssessmentsWithValue), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssessmentsWithValue", reflect.TypeOf((*MockIAssessment)(nil).GetAssessmentsWithValue), userID, assessment, size)
 }
 
 // GetUserCompletedAssesments mocks base method
@@ -791,6 +997,21 @@ func (mr *MockIDeploymentMockRecorder) GetDeploymentBy(opHash interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentBy", reflect.TypeOf((*MockIDeployment)(nil).GetDeploymentBy), opHash)
 }
 
+// GetDeploymentsByAddressNetwork mocks base method
+func (m *MockIDeployment) GetDeploymentsByAddressNetwork(address, network string) ([]Deployment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeploymentsByAddressNetwork", address, network)
+	ret0, _ := ret[0].([]Deployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeploymentsByAddressNetwork indicates an expected call of GetDeploymentsByAddressNetwork
+func (mr *MockIDeploymentMockRecorder) GetDeploymentsByAddressNetwork(address, network interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentsByAddressNetwork", reflect.TypeOf((*MockIDeployment)(nil).GetDeploymentsByAddressNetwork), address, network)
+}
+
 // UpdateDeployment mocks base method
 func (m *MockIDeployment) UpdateDeployment(dt *Deployment) error {
 	m.ctrl.T.Helper()
@@ -888,32 +1109,47 @@ func (mr *MockISubscriptionMockRecorder) ListSubscriptions(userID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockISubscription)(nil).ListSubscriptions), userID)
 }
 
+// ListWebhookSubscriptions mocks base method
+func (m *MockISubscription) ListWebhookSubscriptions() ([]Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookSubscriptions")
+	ret0, _ := ret[0].([]Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookSubscriptions indicates an expected call of ListWebhookSubscriptions
+func (mr *MockISubscriptionMockRecorder) ListWebhookSubscriptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookSubscriptions", reflect.TypeOf((*MockISubscription)(nil).ListWebhookSubscriptions))
+}
+
 // UpsertSubscription mocks base method
-func (m *MockISubscription) UpsertSubscription(arg0 *Subscription) error {
+func (m *MockISubscription) UpsertSubscription(s *Subscription) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertSubscription", arg0)
+	ret := m.ctrl.Call(m, "UpsertSubscription", s)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpsertSubscription indicates an expected call of UpsertSubscription
-func (mr *MockISubscriptionMockRecorder) UpsertSubscription(arg0 interface{}) *gomock.Call {
+func (mr *MockISubscriptionMockRecorder) UpsertSubscription(s interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSubscription", reflect.TypeOf((*MockISubscription)(nil).UpsertSubscription), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSubscription", reflect.TypeOf((*MockISubscription)(nil).UpsertSubscription), s)
 }
 
 // DeleteSubscription mocks base method
-func (m *MockISubscription) DeleteSubscription(arg0 *Subscription) error {
+func (m *MockISubscription) DeleteSubscription(s *Subscription) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSubscription", arg0)
+	ret := m.ctrl.Call(m, "DeleteSubscription", s)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteSubscription indicates an expected call of DeleteSubscription
-func (mr *MockISubscriptionMockRecorder) DeleteSubscription(arg0 interface{}) *gomock.Call {
+func (mr *MockISubscriptionMockRecorder) DeleteSubscription(s interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockISubscription)(nil).DeleteSubscription), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockISubscription)(nil).DeleteSubscription), s)
 }
 
 // GetSubscriptionsCount mocks base method
@@ -955,46 +1191,46 @@ func (m *MockIUser) EXPECT() *MockIUserMockRecorder {
 }
 
 // GetOrCreateUser mocks base method
-func (m *MockIUser) GetOrCreateUser(arg0 *User, arg1 string) error {
+func (m *MockIUser) GetOrCreateUser(u *User, token string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrCreateUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetOrCreateUser", u, token)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // GetOrCreateUser indicates an expected call of GetOrCreateUser
-func (mr *MockIUserMockRecorder) GetOrCreateUser(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockIUserMockRecorder) GetOrCreateUser(u, token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateUser", reflect.TypeOf((*MockIUser)(nil).GetOrCreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateUser", reflect.TypeOf((*MockIUser)(nil).GetOrCreateUser), u, token)
 }
 
 // GetUser mocks base method
-func (m *MockIUser) GetUser(arg0 uint) (*User, error) {
+func (m *MockIUser) GetUser(userID uint) (*User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0)
+	ret := m.ctrl.Call(m, "GetUser", userID)
 	ret0, _ := ret[0].(*User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUser indicates an expected call of GetUser
-func (mr *MockIUserMockRecorder) GetUser(arg0 interface{}) *gomock.Call {
+func (mr *MockIUserMockRecorder) GetUser(userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockIUser)(nil).GetUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockIUser)(nil).GetUser), userID)
 }
 
 // UpdateUserMarkReadAt mocks base method
-func (m *MockIUser) UpdateUserMarkReadAt(arg0 uint, arg1 int64) error {
+func (m *MockIUser) UpdateUserMarkReadAt(userID uint, ts int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserMarkReadAt", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateUserMarkReadAt", userID, ts)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateUserMarkReadAt indicates an expected call of UpdateUserMarkReadAt
-func (mr *MockIUserMockRecorder) UpdateUserMarkReadAt(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockIUserMockRecorder) UpdateUserMarkReadAt(userID, ts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserMarkReadAt", reflect.TypeOf((*MockIUser)(nil).UpdateUserMarkReadAt), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserMarkReadAt", reflect.TypeOf((*MockIUser)(nil).UpdateUserMarkReadAt), userID, ts)
 }
 
 // MockIVerification is a mock of IVerification interface
@@ -1078,3 +1314,114 @@ func (mr *MockIVerificationMockRecorder) CountVerifications(userID interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountVerifications", reflect.TypeOf((*MockIVerification)(nil).CountVerifications), userID)
 }
+
+// MockIWebhookDelivery is a mock of IWebhookDelivery interface
+type MockIWebhookDelivery struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookDeliveryMockRecorder
+}
+
+// MockIWebhookDeliveryMockRecorder is the mock recorder for MockIWebhookDelivery
+type MockIWebhookDeliveryMockRecorder struct {
+	mock *MockIWebhookDelivery
+}
+
+// NewMockIWebhookDelivery creates a new mock instance
+func NewMockIWebhookDelivery(ctrl *gomock.Controller) *MockIWebhookDelivery {
+	mock := &MockIWebhookDelivery{ctrl: ctrl}
+	mock.recorder = &MockIWebhookDeliveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockIWebhookDelivery) EXPECT() *MockIWebhookDeliveryMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhookDelivery mocks base method
+func (m *MockIWebhookDelivery) CreateWebhookDelivery(wd *WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDelivery", wd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhookDelivery indicates an expected call of CreateWebhookDelivery
+func (mr *MockIWebhookDeliveryMockRecorder) CreateWebhookDelivery(wd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDelivery", reflect.TypeOf((*MockIWebhookDelivery)(nil).CreateWebhookDelivery), wd)
+}
+
+// UpdateWebhookDelivery mocks base method
+func (m *MockIWebhookDelivery) UpdateWebhookDelivery(wd *WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookDelivery", wd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookDelivery indicates an expected call of UpdateWebhookDelivery
+func (mr *MockIWebhookDeliveryMockRecorder) UpdateWebhookDelivery(wd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookDelivery", reflect.TypeOf((*MockIWebhookDelivery)(nil).UpdateWebhookDelivery), wd)
+}
+
+// GetWebhookDelivery mocks base method
+func (m *MockIWebhookDelivery) GetWebhookDelivery(userID, id uint) (*WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", userID, id)
+	ret0, _ := ret[0].(*WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery
+func (mr *MockIWebhookDeliveryMockRecorder) GetWebhookDelivery(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockIWebhookDelivery)(nil).GetWebhookDelivery), userID, id)
+}
+
+// ListWebhookDeliveries mocks base method
+func (m *MockIWebhookDelivery) ListWebhookDeliveries(userID, limit, offset uint) ([]WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", userID, limit, offset)
+	ret0, _ := ret[0].([]WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries
+func (mr *MockIWebhookDeliveryMockRecorder) ListWebhookDeliveries(userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockIWebhookDelivery)(nil).ListWebhookDeliveries), userID, limit, offset)
+}
+
+// CountWebhookDeliveries mocks base method
+func (m *MockIWebhookDelivery) CountWebhookDeliveries(userID uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountWebhookDeliveries", userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountWebhookDeliveries indicates an expected call of CountWebhookDeliveries
+func (mr *MockIWebhookDeliveryMockRecorder) CountWebhookDeliveries(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountWebhookDeliveries", reflect.TypeOf((*MockIWebhookDelivery)(nil).CountWebhookDeliveries), userID)
+}
+
+// GetPendingWebhookDeliveries mocks base method
+func (m *MockIWebhookDelivery) GetPendingWebhookDeliveries(before time.Time, limit uint) ([]WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingWebhookDeliveries", before, limit)
+	ret0, _ := ret[0].([]WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingWebhookDeliveries indicates an expected call of GetPendingWebhookDeliveries
+func (mr *MockIWebhookDeliveryMockRecorder) GetPendingWebhookDeliveries(before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingWebhookDeliveries", reflect.TypeOf((*MockIWebhookDelivery)(nil).GetPendingWebhookDeliveries), before, limit)
+}
@@ -2,15 +2,29 @@ package database
 
 import "github.com/jinzhu/gorm"
 
+// Subscription flags
+const (
+	WatchSame uint = 1 << iota
+	WatchSimilar
+	WatchMempool
+	WatchMigrations
+	WatchDeployments
+	WatchCalls
+	WatchErrors
+	SentryEnabled
+)
+
 // Subscription model
 type Subscription struct {
 	gorm.Model
-	UserID    uint   `gorm:"primary_key;not null"`
-	Address   string `gorm:"primary_key;not null"`
-	Network   string `gorm:"primary_key;not null"`
-	Alias     string
-	WatchMask uint
-	SentryDSN string
+	UserID        uint   `gorm:"primary_key;not null"`
+	Address       string `gorm:"primary_key;not null"`
+	Network       string `gorm:"primary_key;not null"`
+	Alias         string
+	WatchMask     uint
+	SentryDSN     string
+	WebhookURL    string
+	WebhookSecret string
 }
 
 func (d *db) GetSubscription(userID uint, address, network string) (s Subscription, err error) {
@@ -30,6 +44,16 @@ func (d *db) GetSubscriptions(address, network string) ([]Subscription, error) {
 	return subs, err
 }
 
+func (d *db) ListWebhookSubscriptions() ([]Subscription, error) {
+	var subs []Subscription
+
+	err := d.
+		Where("webhook_url <> ''").
+		Find(&subs).Error
+
+	return subs, err
+}
+
 func (d *db) ListSubscriptions(userID uint) ([]Subscription, error) {
 	var subs []Subscription
 
@@ -44,7 +68,7 @@ func (d *db) ListSubscriptions(userID uint) ([]Subscription, error) {
 func (d *db) UpsertSubscription(s *Subscription) error {
 	return d.
 		Scopes(userIDScope(s.UserID), contract(s.Address, s.Network)).
-		Assign(Subscription{Alias: s.Alias, WatchMask: s.WatchMask, SentryDSN: s.SentryDSN}).
+		Assign(Subscription{Alias: s.Alias, WatchMask: s.WatchMask, SentryDSN: s.SentryDSN, WebhookURL: s.WebhookURL, WebhookSecret: s.WebhookSecret}).
 		FirstOrCreate(s).Error
 }
 
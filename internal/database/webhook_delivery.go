@@ -0,0 +1,80 @@
+package database
+
+import (
+	"time"
+)
+
+// Webhook delivery statuses
+const (
+	WebhookStatusPending = "pending"
+	WebhookStatusSuccess = "success"
+	WebhookStatusFailed  = "failed"
+)
+
+// WebhookDelivery - log of event delivery to user's webhook
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primary_key" json:"id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `sql:"index" json:"-"`
+	UserID         uint       `gorm:"index;not null" json:"-"`
+	SubscriptionID uint       `json:"-"`
+	URL            string     `json:"url"`
+	EventType      string     `json:"event_type"`
+	Address        string     `json:"address"`
+	Network        string     `json:"network"`
+	Payload        string     `gorm:"type:text" json:"payload"`
+	Status         string     `gorm:"index" json:"status"`
+	Attempts       uint       `json:"attempts"`
+	ResponseCode   int        `json:"response_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time  `gorm:"index" json:"next_attempt_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
+
+// CreateWebhookDelivery -
+func (d *db) CreateWebhookDelivery(wd *WebhookDelivery) error {
+	return d.Create(wd).Error
+}
+
+// UpdateWebhookDelivery -
+func (d *db) UpdateWebhookDelivery(wd *WebhookDelivery) error {
+	return d.Save(wd).Error
+}
+
+// GetWebhookDelivery -
+func (d *db) GetWebhookDelivery(userID, id uint) (*WebhookDelivery, error) {
+	wd := new(WebhookDelivery)
+	return wd, d.Scopes(userIDScope(userID), idScope(id)).First(wd).Error
+}
+
+// ListWebhookDeliveries -
+func (d *db) ListWebhookDeliveries(userID, limit, offset uint) ([]WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+
+	req := d.Scopes(
+		userIDScope(userID),
+		pagination(limit, offset),
+		createdAtDesc,
+	)
+
+	return deliveries, req.Find(&deliveries).Error
+}
+
+// CountWebhookDeliveries -
+func (d *db) CountWebhookDeliveries(userID uint) (int64, error) {
+	var count int64
+	return count, d.Model(&WebhookDelivery{}).Scopes(userIDScope(userID)).Count(&count).Error
+}
+
+// GetPendingWebhookDeliveries - returns pending deliveries which have to be attempted before `before`
+func (d *db) GetPendingWebhookDeliveries(before time.Time, limit uint) ([]WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+
+	req := d.
+		Where("status = ? AND next_attempt_at <= ?", WebhookStatusPending, before).
+		Order("next_attempt_at asc").
+		Scopes(pagination(limit, 0))
+
+	return deliveries, req.Find(&deliveries).Error
+}
@@ -0,0 +1,64 @@
+package dump
+
+import (
+	stdJSON "encoding/json"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// Version - version of archive format. Archives with other version can not be restored.
+const Version = 1
+
+// Header - first entry of archive
+type Header struct {
+	Version   int       `json:"version"`
+	Network   string    `json:"network"`
+	CreatedAt time.Time `json:"created_at"`
+	Indices   []string  `json:"indices"`
+}
+
+// Record - one document of archive. `Data` is JSON representation of model.
+type Record struct {
+	Index string             `json:"index"`
+	ID    string             `json:"id"`
+	Data  stdJSON.RawMessage `json:"data"`
+}
+
+// Summary - last entry of archive. It's used to check archive is not truncated.
+type Summary struct {
+	Counts []Count `json:"counts"`
+}
+
+// Count - count of documents of index
+type Count struct {
+	Index string `json:"index"`
+	Count int64  `json:"count"`
+}
+
+// Total - returns count of all documents
+func (s Summary) Total() (total int64) {
+	for i := range s.Counts {
+		total += s.Counts[i].Count
+	}
+	return
+}
+
+func (s *Summary) add(index string, count int64) {
+	for i := range s.Counts {
+		if s.Counts[i].Index == index {
+			s.Counts[i].Count += count
+			return
+		}
+	}
+	s.Counts = append(s.Counts, Count{Index: index, Count: count})
+}
+
+// entry - line of archive. Only one field is set.
+type entry struct {
+	Header  *Header  `json:"header,omitempty"`
+	Record  *Record  `json:"record,omitempty"`
+	Summary *Summary `json:"summary,omitempty"`
+}
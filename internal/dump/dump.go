@@ -0,0 +1,206 @@
+package dump
+
+import (
+	"bufio"
+	"compress/gzip"
+	stdJSON "encoding/json"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/pkg/errors"
+)
+
+const defaultChunkSize = 1000
+
+// errors
+var (
+	ErrInvalidVersion   = errors.New("Invalid archive version")
+	ErrUnknownIndex     = errors.New("Unknown index")
+	ErrTruncatedArchive = errors.New("Archive is truncated")
+	ErrCountMismatch    = errors.New("Count of restored documents is not equal to archive summary")
+)
+
+// Dump - writes all documents of `network` to `w` as gzip compressed stream of JSON entries: header, records of each index and summary.
+func Dump(storage models.GeneralRepository, network string, w io.Writer, chunkSize int64) (Summary, error) {
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+
+	var summary Summary
+	gz := gzip.NewWriter(w)
+	encoder := json.NewEncoder(gz)
+
+	allModels := models.AllModels()
+	header := Header{
+		Version:   Version,
+		Network:   network,
+		CreatedAt: time.Now().UTC(),
+		Indices:   make([]string, len(allModels)),
+	}
+	for i := range allModels {
+		header.Indices[i] = allModels[i].GetIndex()
+	}
+	if err := encoder.Encode(entry{Header: &header}); err != nil {
+		return summary, err
+	}
+
+	for _, model := range allModels {
+		index := model.GetIndex()
+		output := reflect.New(reflect.SliceOf(reflect.TypeOf(model).Elem()))
+
+		var count int64
+		if err := storage.ScrollByNetwork(network, output.Interface(), chunkSize, func() error {
+			chunk := output.Elem()
+			for i := 0; i < chunk.Len(); i++ {
+				item, ok := chunk.Index(i).Addr().Interface().(models.Model)
+				if !ok {
+					return errors.Wrap(ErrUnknownIndex, index)
+				}
+				data, err := json.Marshal(item)
+				if err != nil {
+					return err
+				}
+				if err := encoder.Encode(entry{Record: &Record{
+					Index: index,
+					ID:    item.GetID(),
+					Data:  data,
+				}}); err != nil {
+					return err
+				}
+			}
+			count += int64(chunk.Len())
+			return nil
+		}); err != nil {
+			return summary, errors.Wrap(err, index)
+		}
+
+		summary.add(index, count)
+		logger.Info("[%s] %s: %d documents are dumped", network, index, count)
+	}
+
+	if err := encoder.Encode(entry{Summary: &summary}); err != nil {
+		return summary, err
+	}
+	return summary, gz.Close()
+}
+
+// Restore - reads archive written by `Dump` from `r` and saves documents to `storage` by chunks of `chunkSize`
+func Restore(storage models.GeneralRepository, r io.Reader, chunkSize int64) (Header, Summary, error) {
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+
+	var header Header
+	var restored Summary
+
+	gz, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return header, restored, err
+	}
+	defer gz.Close()
+
+	// standard decoder is used because jsoniter does not return io.EOF after trailing new line
+	decoder := stdJSON.NewDecoder(gz)
+
+	var first entry
+	if err := decoder.Decode(&first); err != nil {
+		return header, restored, err
+	}
+	if first.Header == nil {
+		return header, restored, errors.Wrap(ErrInvalidVersion, "header is not found")
+	}
+	header = *first.Header
+	if header.Version != Version {
+		return header, restored, errors.Wrapf(ErrInvalidVersion, "%d != %d", header.Version, Version)
+	}
+
+	types := make(map[string]reflect.Type)
+	for _, model := range models.AllModels() {
+		types[model.GetIndex()] = reflect.TypeOf(model).Elem()
+	}
+
+	chunk := make([]models.Model, 0, chunkSize)
+	save := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		if err := storage.BulkInsert(chunk); err != nil {
+			return err
+		}
+		for i := range chunk {
+			restored.add(chunk[i].GetIndex(), 1)
+		}
+		chunk = chunk[:0]
+		return nil
+	}
+
+	for {
+		var e entry
+		if err := decoder.Decode(&e); err != nil {
+			if errors.Is(err, io.EOF) {
+				return header, restored, ErrTruncatedArchive
+			}
+			return header, restored, err
+		}
+
+		switch {
+		case e.Record != nil:
+			typ, ok := types[e.Record.Index]
+			if !ok {
+				return header, restored, errors.Wrap(ErrUnknownIndex, e.Record.Index)
+			}
+			model, err := parseRecord(*e.Record, typ)
+			if err != nil {
+				return header, restored, err
+			}
+			chunk = append(chunk, model)
+			if int64(len(chunk)) == chunkSize {
+				if err := save(); err != nil {
+					return header, restored, err
+				}
+			}
+		case e.Summary != nil:
+			if err := save(); err != nil {
+				return header, restored, err
+			}
+			return header, restored, checkSummary(*e.Summary, restored)
+		}
+	}
+}
+
+func parseRecord(record Record, typ reflect.Type) (models.Model, error) {
+	ptr := reflect.New(typ)
+	if err := json.Unmarshal(record.Data, ptr.Interface()); err != nil {
+		return nil, errors.Wrap(err, record.Index)
+	}
+
+	// identifiers of most models are not serialized to JSON
+	if field := ptr.Elem().FieldByName("ID"); field.IsValid() && field.CanSet() && field.Kind() == reflect.String {
+		field.SetString(record.ID)
+	}
+
+	model, ok := ptr.Interface().(models.Model)
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownIndex, record.Index)
+	}
+	return model, nil
+}
+
+func checkSummary(expected, restored Summary) error {
+	for _, count := range expected.Counts {
+		var got int64
+		for i := range restored.Counts {
+			if restored.Counts[i].Index == count.Index {
+				got = restored.Counts[i].Count
+				break
+			}
+		}
+		if got != count.Count {
+			return errors.Wrapf(ErrCountMismatch, "%s: %d != %d", count.Index, got, count.Count)
+		}
+	}
+	return nil
+}
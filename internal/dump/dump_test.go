@@ -0,0 +1,134 @@
+package dump
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	mock_general "github.com/baking-bad/bcdhub/internal/models/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const testNetwork = "edo2net"
+
+func testDocuments() map[string][]models.Model {
+	ts := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	return map[string][]models.Model{
+		models.DocBlocks: {
+			&block.Block{ID: "block_1", Network: testNetwork, Level: 1, Hash: "BLock1", Timestamp: ts},
+			&block.Block{ID: "block_2", Network: testNetwork, Level: 2, Hash: "BLock2", Timestamp: ts},
+			&block.Block{ID: "block_3", Network: testNetwork, Level: 3, Hash: "BLock3", Timestamp: ts},
+		},
+		models.DocContracts: {
+			&contract.Contract{Network: testNetwork, Address: "KT1C2MfcjWb5R1ZDDxVULCsGuxrf5fEn5264", Level: 2, Timestamp: ts},
+		},
+	}
+}
+
+func newScrollMock(ctrl *gomock.Controller, documents map[string][]models.Model) *mock_general.MockGeneralRepository {
+	storage := mock_general.NewMockGeneralRepository(ctrl)
+	storage.
+		EXPECT().
+		ScrollByNetwork(testNetwork, gomock.Any(), int64(2), gomock.Any()).
+		DoAndReturn(func(network string, output interface{}, size int64, handler func() error) error {
+			switch typ := output.(type) {
+			case *[]block.Block:
+				for _, doc := range documents[models.DocBlocks] {
+					*typ = append((*typ)[:0], *doc.(*block.Block))
+					if err := handler(); err != nil {
+						return err
+					}
+				}
+			case *[]contract.Contract:
+				for _, doc := range documents[models.DocContracts] {
+					*typ = append((*typ)[:0], *doc.(*contract.Contract))
+					if err := handler(); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}).
+		Times(len(models.AllModels()))
+	return storage
+}
+
+func TestDumpRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	documents := testDocuments()
+
+	var buf bytes.Buffer
+	summary, err := Dump(newScrollMock(ctrl, documents), testNetwork, &buf, 2)
+	if err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	assert.Equal(t, int64(4), summary.Total())
+
+	restored := make([]models.Model, 0)
+	storage := mock_general.NewMockGeneralRepository(ctrl)
+	storage.
+		EXPECT().
+		BulkInsert(gomock.Any()).
+		DoAndReturn(func(items []models.Model) error {
+			restored = append(restored, items...)
+			return nil
+		}).
+		Times(2)
+
+	header, restoredSummary, err := Restore(storage, bytes.NewReader(buf.Bytes()), 3)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	assert.Equal(t, Version, header.Version)
+	assert.Equal(t, testNetwork, header.Network)
+	assert.Equal(t, int64(4), restoredSummary.Total())
+
+	expected := append(documents[models.DocBlocks], documents[models.DocContracts]...)
+	assert.Equal(t, expected, restored)
+}
+
+func TestRestore_Truncated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(gz)
+	if err := encoder.Encode(entry{Header: &Header{Version: Version, Network: testNetwork}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := encoder.Encode(entry{Record: &Record{Index: models.DocBlocks, ID: "block_1", Data: []byte(`{"network":"edo2net","level":1}`)}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := Restore(mock_general.NewMockGeneralRepository(ctrl), &buf, 10)
+	assert.Equal(t, ErrTruncatedArchive, err)
+}
+
+func TestRestore_InvalidVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(entry{Header: &Header{Version: Version + 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := Restore(mock_general.NewMockGeneralRepository(ctrl), &buf, 10)
+	assert.True(t, errors.Is(err, ErrInvalidVersion))
+}
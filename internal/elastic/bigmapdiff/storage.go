@@ -87,6 +87,38 @@ func (storage *Storage) GetForAddress(address string) ([]bigmapdiff.BigMapDiff,
 	return diffs, nil
 }
 
+// GetStates - returns last states of all keys of big map `ptr` at `maxLevel`. If `maxLevel` is 0 current states are returned.
+func (storage *Storage) GetStates(network string, ptr, maxLevel int64) ([]bigmapdiff.BigMapDiff, error) {
+	filters := []core.Item{
+		core.MatchPhrase("network", network),
+		core.Term("ptr", ptr),
+	}
+	if maxLevel > 0 {
+		filters = append(filters, core.Range("level", core.Item{"lte": maxLevel}))
+	}
+	query := core.NewQuery().Query(
+		core.Bool(
+			core.Filter(filters...),
+		),
+	).Sort("indexed_time", "desc")
+
+	states := make([]bigmapdiff.BigMapDiff, 0)
+	keys := make(map[string]struct{})
+	chunk := make([]bigmapdiff.BigMapDiff, 0)
+	ctx := core.NewScrollContext(storage.es, query, 0, 0)
+	err := ctx.Iterate(&chunk, func() error {
+		for i := range chunk {
+			if _, ok := keys[chunk[i].KeyHash]; ok {
+				continue
+			}
+			keys[chunk[i].KeyHash] = struct{}{}
+			states = append(states, chunk[i])
+		}
+		return nil
+	})
+	return states, err
+}
+
 // GetByAddress -
 func (storage *Storage) GetByAddress(network, address string) (response []bigmapdiff.BigMapDiff, err error) {
 	query := core.NewQuery().Query(
@@ -7,6 +7,7 @@ import (
 	"math/rand"
 	"time"
 
+	constants "github.com/baking-bad/bcdhub/internal/bcd/consts"
 	"github.com/baking-bad/bcdhub/internal/elastic/consts"
 	"github.com/baking-bad/bcdhub/internal/elastic/core"
 	"github.com/baking-bad/bcdhub/internal/models"
@@ -493,8 +494,8 @@ func (storage *Storage) GetDiffTasks() ([]contract.DiffTask, error) {
 
 // GetTokens -
 func (storage *Storage) GetTokens(network, tokenInterface string, offset, size int64) ([]contract.Contract, int64, error) {
-	tags := []string{"fa12", "fa1", "fa2"}
-	if tokenInterface == "fa12" || tokenInterface == "fa1" || tokenInterface == "fa2" {
+	tags := []string{constants.FA12Tag, constants.FA1Tag, constants.FA2Tag}
+	if tokenInterface != "" {
 		tags = []string{tokenInterface}
 	}
 
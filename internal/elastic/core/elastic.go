@@ -37,6 +37,7 @@ func New(addresses []string) (*Elastic, error) {
 			return retryBackoff.NextBackOff()
 		},
 		MaxRetries: 5,
+		Transport:  newTransport(),
 	}
 	es, err := elasticsearch.NewClient(elasticConfig)
 	if err != nil {
@@ -72,6 +72,20 @@ func (e *Elastic) GetByNetworkWithSort(network, sortField, sortOrder string, out
 	return e.GetAllByQuery(query, output)
 }
 
+// ScrollByNetwork -
+func (e *Elastic) ScrollByNetwork(network string, output interface{}, size int64, handler func() error) error {
+	query := NewQuery().Query(
+		Bool(
+			Filter(
+				MatchPhrase("network", network),
+			),
+		),
+	).Sort("_doc", "asc")
+
+	ctx := NewScrollContext(e, query, 0, size)
+	return ctx.Iterate(output, handler)
+}
+
 // GetAllByQuery -
 func (e *Elastic) GetAllByQuery(query Base, output interface{}) error {
 	ctx := NewScrollContext(e, query, 0, defaultScrollSize)
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"fmt"
+
 	"github.com/baking-bad/bcdhub/internal/helpers"
 )
 
@@ -361,6 +363,25 @@ func (q Base) Sort(key, order string) Base {
 	return q
 }
 
+// SortNumericString - sorts by keyword field containing non-negative decimal integer in numeric order
+func (q Base) SortNumericString(key, order string) Base {
+	q["sort"] = List{
+		Item{
+			"_script": Item{
+				"type":   "number",
+				"order":  order,
+				"script": fmt.Sprintf("doc['%s'].value.length()", key),
+			},
+		},
+		Item{
+			key: Item{
+				"order": order,
+			},
+		},
+	}
+	return q
+}
+
 // SearchAfter -
 func (q Base) SearchAfter(value []interface{}) Base {
 	q["search_after"] = value
@@ -170,6 +170,49 @@ func (ctx *ScrollContext) Get(output interface{}) error {
 	return ctx.clear()
 }
 
+// Iterate - fills `output` which is pointer to slice with chunks of found documents and calls `handler` after each chunk
+func (ctx *ScrollContext) Iterate(output interface{}, handler func() error) error {
+	typ, err := getElementType(output)
+	if err != nil {
+		return err
+	}
+	el := reflect.ValueOf(output).Elem()
+	if el.Kind() != reflect.Slice {
+		return errors.Errorf("Invalid `output` type: %s", el.Kind())
+	}
+	index, err := getIndex(typ)
+	if err != nil {
+		return err
+	}
+
+	result, err := ctx.createScroll(index, ctx.Query)
+	if err != nil {
+		return err
+	}
+	for len(result.Hits.Hits) > 0 {
+		ctx.scrollIds[result.ScrollID] = struct{}{}
+
+		el.Set(reflect.MakeSlice(el.Type(), 0, len(result.Hits.Hits)))
+		for _, item := range result.Hits.Hits {
+			n, err := parseResponseItem(item, typ)
+			if err != nil {
+				return err
+			}
+			el.Set(reflect.Append(el, n))
+		}
+		if err := handler(); err != nil {
+			return err
+		}
+
+		result, err = ctx.queryScroll(result.ScrollID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ctx.clear()
+}
+
 func (ctx *ScrollContext) clear() error {
 	ctx.Query = nil
 	ctx.Size = 0
@@ -88,6 +88,18 @@ func prepareSearchFilters(filters map[string]interface{}) (string, error) {
 				str = fmt.Sprintf("language:%s", val[0])
 			}
 			builder.WriteString(str)
+		case "tags":
+			val, ok := v.([]string)
+			if !ok {
+				return "", errors.Errorf("Invalid type for 'tags' filter (wait []string): %T", v)
+			}
+			var str string
+			if len(val) > 1 {
+				str = fmt.Sprintf("tags:(%s)", strings.Join(val, " OR "))
+			} else {
+				str = fmt.Sprintf("tags:%s", val[0])
+			}
+			builder.WriteString(str)
 		default:
 			return "", errors.Errorf("Unknown search filter: %s", k)
 		}
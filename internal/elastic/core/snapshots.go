@@ -276,3 +276,48 @@ func (e *Elastic) CreateMapping(index string, r io.Reader) error {
 	}
 	return nil
 }
+
+// ReindexWithScript - copies all documents of `source` index to `dest` index applying painless `script` to every document if it's not empty
+func (e *Elastic) ReindexWithScript(source, dest, script string) error {
+	query := map[string]interface{}{
+		"source": map[string]interface{}{
+			"index": source,
+		},
+		"dest": map[string]interface{}{
+			"index": dest,
+		},
+	}
+	if script != "" {
+		query["script"] = map[string]interface{}{
+			"source": script,
+			"lang":   "painless",
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return err
+	}
+
+	resp, err := e.Client.Reindex(
+		&buf,
+		e.Client.Reindex.WithContext(context.Background()),
+		e.Client.Reindex.WithRefresh(true),
+		e.Client.Reindex.WithWaitForCompletion(true),
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Failures []interface{} `json:"failures"`
+	}
+	if err := e.GetResponse(resp, &response); err != nil {
+		return err
+	}
+	if len(response.Failures) > 0 {
+		return errors.Errorf("reindex %s -> %s: %d failures", source, dest, len(response.Failures))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/monitoring"
+)
+
+const allIndices = "all"
+
+// transport - observes duration of requests to elastic. Requests are labeled by index which is the repository of model.
+type transport struct {
+	next http.RoundTripper
+}
+
+func newTransport() *transport {
+	return &transport{http.DefaultTransport}
+}
+
+// RoundTrip -
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	index, method := parseRequestPath(req.Method, req.URL.Path)
+	monitoring.ObserveStorageRequest(index, method, time.Since(start))
+	return resp, err
+}
+
+// parseRequestPath - returns index and API method of request. Requests to several indices or without index (e.g. `_bulk`) are labeled by `all` index.
+// API method is the last segment started with underscore, `httpMethod` is returned if there is no such segment.
+func parseRequestPath(httpMethod, path string) (string, string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	index := allIndices
+	if parts[0] != "" && !strings.HasPrefix(parts[0], "_") && !strings.Contains(parts[0], ",") {
+		index = parts[0]
+	}
+
+	method := httpMethod
+	for i := len(parts) - 1; i >= 0; i-- {
+		if strings.HasPrefix(parts[i], "_") {
+			method = parts[i]
+			break
+		}
+	}
+	return index, method
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseRequestPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		httpMethod string
+		path       string
+		wantIndex  string
+		wantMethod string
+	}{
+		{
+			name:       "search",
+			httpMethod: http.MethodPost,
+			path:       "/operation/_search",
+			wantIndex:  "operation",
+			wantMethod: "_search",
+		}, {
+			name:       "get by id",
+			httpMethod: http.MethodGet,
+			path:       "/contract/_doc/a3b7",
+			wantIndex:  "contract",
+			wantMethod: "_doc",
+		}, {
+			name:       "bulk",
+			httpMethod: http.MethodPost,
+			path:       "/_bulk",
+			wantIndex:  allIndices,
+			wantMethod: "_bulk",
+		}, {
+			name:       "several indices",
+			httpMethod: http.MethodPost,
+			path:       "/operation,contract/_search",
+			wantIndex:  allIndices,
+			wantMethod: "_search",
+		}, {
+			name:       "create index",
+			httpMethod: http.MethodPut,
+			path:       "/block",
+			wantIndex:  "block",
+			wantMethod: http.MethodPut,
+		}, {
+			name:       "ping",
+			httpMethod: http.MethodHead,
+			path:       "/",
+			wantIndex:  allIndices,
+			wantMethod: http.MethodHead,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, method := parseRequestPath(tt.httpMethod, tt.path)
+			assert.Equal(t, tt.wantIndex, index)
+			assert.Equal(t, tt.wantMethod, method)
+		})
+	}
+}
@@ -167,6 +167,40 @@ func (storage *Storage) Last(network, address string, indexedTime int64) (op ope
 	return
 }
 
+// LastAtLevel -
+func (storage *Storage) LastAtLevel(network, address string, level int64) (op operation.Operation, err error) {
+	filters := []core.Item{
+		core.MatchPhrase("destination", address),
+		core.Term("network", network),
+		core.Term("status", "applied"),
+	}
+	if level > 0 {
+		filters = append(filters, core.Range("level", core.Item{"lte": level}))
+	}
+
+	query := core.NewQuery().
+		Query(
+			core.Bool(
+				core.Filter(filters...),
+				core.MustNot(
+					core.Term("deffated_storage", ""),
+				),
+			),
+		).Sort("indexed_time", "desc").One()
+
+	var response core.SearchResponse
+	if err = storage.es.Query([]string{models.DocOperations}, query, &response); err != nil {
+		return
+	}
+
+	if response.Hits.Total.Value == 0 {
+		return op, core.NewRecordNotFoundError(models.DocOperations, "")
+	}
+	err = json.Unmarshal(response.Hits.Hits[0].Source, &op)
+	op.ID = response.Hits.Hits[0].ID
+	return
+}
+
 // Get -
 func (storage *Storage) Get(filters map[string]interface{}, size int64, sort bool) ([]operation.Operation, error) {
 	operations := make([]operation.Operation, 0)
@@ -0,0 +1,13 @@
+package saplingdiff
+
+import "github.com/baking-bad/bcdhub/internal/elastic/core"
+
+type getStatsResponse struct {
+	Agg struct {
+		Count       core.IntValue   `json:"count"`
+		Commitments core.FloatValue `json:"commitments"`
+		Nullifiers  core.FloatValue `json:"nullifiers"`
+		FirstLevel  core.FloatValue `json:"first_level"`
+		LastLevel   core.FloatValue `json:"last_level"`
+	} `json:"aggregations"`
+}
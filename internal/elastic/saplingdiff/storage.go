@@ -0,0 +1,116 @@
+package saplingdiff
+
+import (
+	"encoding/json"
+
+	"github.com/baking-bad/bcdhub/internal/elastic/consts"
+	"github.com/baking-bad/bcdhub/internal/elastic/core"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/saplingdiff"
+)
+
+// Storage -
+type Storage struct {
+	es *core.Elastic
+}
+
+// NewStorage -
+func NewStorage(es *core.Elastic) *Storage {
+	return &Storage{es}
+}
+
+func poolFilters(network string, ptr int64) core.Item {
+	return core.Filter(
+		core.Match("network", network),
+		core.Term("ptr", ptr),
+	)
+}
+
+// Get - returns sapling pool updates sorted by level descending
+func (storage *Storage) Get(network string, ptr int64, size, offset int64) ([]saplingdiff.SaplingDiff, error) {
+	if size == 0 {
+		size = consts.DefaultSize
+	}
+
+	query := core.NewQuery().Query(
+		core.Bool(
+			poolFilters(network, ptr),
+		),
+	).Sort("level", "desc").Size(size).From(offset)
+
+	var response core.SearchResponse
+	if err := storage.es.Query([]string{models.DocSaplingDiff}, query, &response); err != nil {
+		return nil, err
+	}
+
+	diffs := make([]saplingdiff.SaplingDiff, len(response.Hits.Hits))
+	for i := range response.Hits.Hits {
+		if err := json.Unmarshal(response.Hits.Hits[i].Source, &diffs[i]); err != nil {
+			return nil, err
+		}
+		diffs[i].ID = response.Hits.Hits[i].ID
+	}
+	return diffs, nil
+}
+
+// First - returns the earliest update of sapling pool. It contains pool allocation (or copy) with memo size.
+func (storage *Storage) First(network string, ptr int64) (diff saplingdiff.SaplingDiff, err error) {
+	query := core.NewQuery().Query(
+		core.Bool(
+			poolFilters(network, ptr),
+		),
+	).Sort("level", "asc").One()
+
+	var response core.SearchResponse
+	if err = storage.es.Query([]string{models.DocSaplingDiff}, query, &response); err != nil {
+		return
+	}
+
+	if response.Hits.Total.Value == 0 {
+		return diff, core.NewRecordNotFoundError(models.DocSaplingDiff, "")
+	}
+
+	err = json.Unmarshal(response.Hits.Hits[0].Source, &diff)
+	diff.ID = response.Hits.Hits[0].ID
+	return
+}
+
+// GetStats - returns aggregated updates of sapling pool. If `maxLevel` is positive updates after it are skipped.
+func (storage *Storage) GetStats(network string, ptr int64, maxLevel int64) (stats saplingdiff.Stats, err error) {
+	filters := []core.Item{
+		core.Match("network", network),
+		core.Term("ptr", ptr),
+	}
+	if maxLevel > 0 {
+		filters = append(filters, core.Range("level", core.Item{"lte": maxLevel}))
+	}
+
+	query := core.NewQuery().Query(
+		core.Bool(
+			core.Filter(filters...),
+		),
+	).Add(
+		core.Aggs(
+			core.AggItem{Name: "count", Body: core.Count("level")},
+			core.AggItem{Name: "commitments", Body: core.Sum("commitments_count")},
+			core.AggItem{Name: "nullifiers", Body: core.Sum("nullifiers_count")},
+			core.AggItem{Name: "first_level", Body: core.Min("level")},
+			core.AggItem{Name: "last_level", Body: core.Max("level")},
+		),
+	).Zero()
+
+	var response getStatsResponse
+	if err = storage.es.Query([]string{models.DocSaplingDiff}, query, &response); err != nil {
+		return
+	}
+
+	stats.Count = response.Agg.Count.Value
+	if stats.Count == 0 {
+		return
+	}
+	stats.Commitments = int64(response.Agg.Commitments.Value)
+	stats.Nullifiers = int64(response.Agg.Nullifiers.Value)
+	stats.FirstLevel = int64(response.Agg.FirstLevel.Value)
+	stats.LastLevel = int64(response.Agg.LastLevel.Value)
+	return
+}
@@ -0,0 +1,113 @@
+package ticket
+
+import (
+	"github.com/baking-bad/bcdhub/internal/elastic/core"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
+)
+
+// Storage -
+type Storage struct {
+	es *core.Elastic
+}
+
+// NewStorage -
+func NewStorage(es *core.Elastic) *Storage {
+	return &Storage{es}
+}
+
+// Update -
+func (storage *Storage) Update(updates []*ticket.Ticket) error {
+	if len(updates) == 0 {
+		return nil
+	}
+	buf := make([]ticket.Ticket, 0)
+	ids := make([]string, len(updates))
+	for i := range updates {
+		ids[i] = updates[i].GetID()
+	}
+	if err := storage.es.GetByIDs(&buf, ids...); err != nil {
+		if !storage.es.IsRecordNotFound(err) {
+			return err
+		}
+	}
+
+	updatedModels := make([]models.Model, 0)
+	insertedModels := make([]models.Model, 0)
+
+	for i := range updates {
+		var found bool
+		for j := range buf {
+			if buf[j].GetID() == updates[i].GetID() {
+				found = true
+				updates[i].Sum(&buf[j])
+				updatedModels = append(updatedModels, updates[i])
+				break
+			}
+		}
+
+		if !found {
+			insertedModels = append(insertedModels, updates[i])
+		}
+	}
+
+	if err := storage.es.BulkInsert(insertedModels); err != nil {
+		return err
+	}
+
+	return storage.es.BulkUpdate(updatedModels)
+}
+
+// GetByHolder -
+func (storage *Storage) GetByHolder(network, holder string) ([]ticket.Ticket, error) {
+	query := core.NewQuery().Query(
+		core.Bool(
+			core.Filter(
+				core.Match("network", network),
+				core.MatchPhrase("holder", holder),
+			),
+			core.MustNot(
+				core.Term("amount", "0"),
+			),
+		),
+	).All()
+
+	tickets := make([]ticket.Ticket, 0)
+	err := storage.es.GetAllByQuery(query, &tickets)
+	return tickets, err
+}
+
+// GetByTicketer -
+func (storage *Storage) GetByTicketer(network, ticketer string) ([]ticket.Ticket, error) {
+	query := core.NewQuery().Query(
+		core.Bool(
+			core.Filter(
+				core.Match("network", network),
+				core.MatchPhrase("ticketer", ticketer),
+			),
+			core.MustNot(
+				core.Term("amount", "0"),
+			),
+		),
+	).All()
+
+	tickets := make([]ticket.Ticket, 0)
+	err := storage.es.GetAllByQuery(query, &tickets)
+	return tickets, err
+}
+
+// GetUpdates -
+func (storage *Storage) GetUpdates(network string, level int64) ([]ticket.Update, error) {
+	query := core.NewQuery().Query(
+		core.Bool(
+			core.Filter(
+				core.Match("network", network),
+				core.Range("level", core.Item{"gt": level}),
+			),
+		),
+	)
+
+	updates := make([]ticket.Update, 0)
+	err := storage.es.GetAllByQuery(query, &updates)
+	return updates, err
+}
@@ -1,6 +1,7 @@
 package tokenbalance
 
 import (
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/baking-bad/bcdhub/internal/elastic/consts"
 	"github.com/baking-bad/bcdhub/internal/elastic/core"
 	"github.com/baking-bad/bcdhub/internal/models"
@@ -65,13 +66,13 @@ func (storage *Storage) Update(updates []*tokenbalance.TokenBalance) error {
 }
 
 // GetHolders -
-func (storage *Storage) GetHolders(network, contract string, tokenID int64) ([]tokenbalance.TokenBalance, error) {
+func (storage *Storage) GetHolders(network, contract string, tokenID *types.BigInt) ([]tokenbalance.TokenBalance, error) {
 	query := core.NewQuery().Query(
 		core.Bool(
 			core.Filter(
 				core.Match("network", network),
 				core.MatchPhrase("contract", contract),
-				core.Term("token_id", tokenID),
+				core.Term("token_id", tokenID.String()),
 			),
 			core.MustNot(
 				core.Term("balance", "0"),
@@ -96,7 +97,7 @@ func (storage *Storage) GetAccountBalances(network, address string, size, offset
 				core.Term("balance", "0"),
 			),
 		),
-	).Sort("token_id", "desc").All()
+	).SortNumericString("token_id", "desc").All()
 
 	if size == 0 {
 		size = consts.DefaultSize
@@ -129,13 +130,13 @@ func (storage *Storage) GetAccountBalances(network, address string, size, offset
 }
 
 // BurnNft -
-func (storage *Storage) BurnNft(network, contract string, tokenID int64) error {
+func (storage *Storage) BurnNft(network, contract string, tokenID *types.BigInt) error {
 	query := core.NewQuery().Query(
 		core.Bool(
 			core.Filter(
 				core.Term("network", network),
 				core.MatchPhrase("contract", contract),
-				core.Term("token_id", tokenID),
+				core.Term("token_id", tokenID.String()),
 			),
 		),
 	)
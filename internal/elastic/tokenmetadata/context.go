@@ -22,8 +22,8 @@ func buildGetTokenMetadataContext(ctx ...tokenmetadata.GetContext) core.Base {
 		if c.MinLevel > 0 {
 			filter = append(filter, core.Range("level", core.Item{"gt": c.MinLevel}))
 		}
-		if c.TokenID != -1 {
-			filter = append(filter, core.Term("token_id", c.TokenID))
+		if c.TokenID != nil {
+			filter = append(filter, core.Term("token_id", c.TokenID.String()))
 		}
 
 		filters = append(filters, core.Bool(
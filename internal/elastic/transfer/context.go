@@ -82,8 +82,8 @@ func filterAddress(ctx transfer.GetContext) core.Item {
 }
 
 func filterTokenID(ctx transfer.GetContext) core.Item {
-	if ctx.TokenID >= 0 {
-		return core.Term("token_id", ctx.TokenID)
+	if ctx.TokenID != nil {
+		return core.Term("token_id", ctx.TokenID.String())
 	}
 	return nil
 }
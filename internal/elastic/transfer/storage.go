@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/baking-bad/bcdhub/internal/elastic/core"
 	"github.com/baking-bad/bcdhub/internal/models"
 	"github.com/baking-bad/bcdhub/internal/models/transfer"
@@ -86,13 +87,13 @@ func (storage *Storage) GetAll(network string, level int64) ([]transfer.Transfer
 }
 
 // GetTokenSupply -
-func (storage *Storage) GetTokenSupply(network, address string, tokenID int64) (result transfer.TokenSupply, err error) {
+func (storage *Storage) GetTokenSupply(network, address string, tokenID *types.BigInt) (result transfer.TokenSupply, err error) {
 	query := core.NewQuery().Query(
 		core.Bool(
 			core.Filter(
 				core.Match("network", network),
 				core.MatchPhrase("contract", address),
-				core.Term("token_id", tokenID),
+				core.Term("token_id", tokenID.String()),
 				core.Match("status", consts.Applied),
 			),
 		),
@@ -136,14 +137,14 @@ func (storage *Storage) GetTokenSupply(network, address string, tokenID int64) (
 }
 
 // GetToken24HoursVolume - returns token volume for last 24 hours
-func (storage *Storage) GetToken24HoursVolume(network, contract string, initiators, entrypoints []string, tokenID int64) (float64, error) {
+func (storage *Storage) GetToken24HoursVolume(network, contract string, initiators, entrypoints []string, tokenID *types.BigInt) (float64, error) {
 	query := core.NewQuery().Query(
 		core.Bool(
 			core.Filter(
 				core.Term("contract.keyword", contract),
 				core.Term("network", network),
 				core.Term("status", consts.Applied),
-				core.Term("token_id", tokenID),
+				core.Term("token_id", tokenID.String()),
 				core.Range("timestamp", core.Item{
 					"lte": "now",
 					"gt":  "now-24h",
@@ -167,7 +168,7 @@ func (storage *Storage) GetToken24HoursVolume(network, contract string, initiato
 }
 
 // GetTokenVolumeSeries -
-func (storage *Storage) GetTokenVolumeSeries(network, period string, contracts []string, entrypoints []tzip.DAppContract, tokenID uint) ([][]float64, error) {
+func (storage *Storage) GetTokenVolumeSeries(network, period string, contracts []string, entrypoints []tzip.DAppContract, tokenID *types.BigInt) ([][]float64, error) {
 	hist := core.Item{
 		"date_histogram": core.Item{
 			"field":             "timestamp",
@@ -193,7 +194,7 @@ func (storage *Storage) GetTokenVolumeSeries(network, period string, contracts [
 		},
 		core.Match("network", network),
 		core.Match("status", consts.Applied),
-		core.Term("token_id", tokenID),
+		core.Term("token_id", tokenID.String()),
 	}
 	if len(contracts) > 0 {
 		addresses := make([]core.Item, len(contracts))
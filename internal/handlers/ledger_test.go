@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/ast"
@@ -30,7 +31,7 @@ func TestLedger_getResultModels(t *testing.T) {
 					Contract: "KT1VYsVfmobT7rsMVivvZ4J8i3bPiqz12NaH",
 					Network:  "mainnet",
 					Value:    big.NewInt(1000000),
-					TokenID:  0,
+					TokenID:  types.NewBigInt(0),
 				},
 			},
 		}, {
@@ -43,7 +44,7 @@ func TestLedger_getResultModels(t *testing.T) {
 					Contract: "KT1VYsVfmobT7rsMVivvZ4J8i3bPiqz12NaH",
 					Network:  "mainnet",
 					Value:    big.NewInt(0),
-					TokenID:  0,
+					TokenID:  types.NewBigInt(0),
 				},
 			},
 		},
@@ -76,3 +76,11 @@ func MinInt(a, b int) int {
 	}
 	return a
 }
+
+// MinInt64 -
+func MinInt64(a, b int64) int64 {
+	if a > b {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,145 @@
+package mempool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultTTL        = 2 * time.Minute
+	defaultRetryDelay = 5 * time.Second
+	minStreamDuration = time.Second
+)
+
+// errors
+var (
+	ErrUnknownNetwork = errors.New("Mempool of network is not monitored")
+)
+
+// Monitor - keeps pools of networks up to date by streaming mempool of their nodes
+type Monitor struct {
+	pools    map[string]*Pool
+	nodes    map[string]noderpc.IMempoolMonitor
+	statuses []string
+
+	ttl        time.Duration
+	retryDelay time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// MonitorOption -
+type MonitorOption func(m *Monitor)
+
+// WithTTL - sets time during which operation is kept after it was received last time
+func WithTTL(ttl time.Duration) MonitorOption {
+	return func(m *Monitor) {
+		if ttl > 0 {
+			m.ttl = ttl
+		}
+	}
+}
+
+// WithRetryDelay - sets delay before reconnection to node after error
+func WithRetryDelay(delay time.Duration) MonitorOption {
+	return func(m *Monitor) {
+		if delay > 0 {
+			m.retryDelay = delay
+		}
+	}
+}
+
+// WithStatuses - sets monitored statuses. By default all statuses are monitored.
+func WithStatuses(statuses ...string) MonitorOption {
+	return func(m *Monitor) {
+		if len(statuses) > 0 {
+			m.statuses = statuses
+		}
+	}
+}
+
+// NewMonitor -
+func NewMonitor(nodes map[string]noderpc.IMempoolMonitor, opts ...MonitorOption) *Monitor {
+	m := &Monitor{
+		pools:      make(map[string]*Pool),
+		nodes:      nodes,
+		statuses:   noderpc.MempoolStatuses,
+		ttl:        defaultTTL,
+		retryDelay: defaultRetryDelay,
+	}
+	for i := range opts {
+		opts[i](m)
+	}
+	for network := range nodes {
+		m.pools[network] = NewPool(network, m.ttl)
+	}
+	return m
+}
+
+// Start - starts streaming of all networks
+func (m *Monitor) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+
+	for network, node := range m.nodes {
+		for _, status := range m.statuses {
+			m.wg.Add(1)
+			go m.listen(ctx, network, status, node)
+		}
+	}
+}
+
+// Close -
+func (m *Monitor) Close() error {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.wg.Wait()
+	return nil
+}
+
+// Get - returns pool of `network`
+func (m *Monitor) Get(network string) (*Pool, error) {
+	pool, ok := m.pools[network]
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownNetwork, network)
+	}
+	return pool, nil
+}
+
+func (m *Monitor) listen(ctx context.Context, network, status string, node noderpc.IMempoolMonitor) {
+	defer m.wg.Done()
+
+	pool := m.pools[network]
+	handler := func(operations []noderpc.MempoolOperation) {
+		if err := pool.Add(status, operations); err != nil {
+			logger.Errorf("[%s mempool] %s", network, err)
+		}
+	}
+
+	for {
+		start := time.Now()
+		err := node.MonitorMempool(ctx, status, handler)
+		if err != nil {
+			logger.Warning("[%s mempool] %s stream: %s", network, status, err)
+		}
+
+		// node closes stream on new head, so reconnection is immediate if stream was alive for a while
+		delay := time.Duration(0)
+		if err != nil || time.Since(start) < minStreamDuration {
+			delay = m.retryDelay
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
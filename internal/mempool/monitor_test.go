@@ -0,0 +1,57 @@
+package mempool
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/stretchr/testify/assert"
+)
+
+const monitorTestChunk = `[{"protocol":"PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA","hash":"ooTest","branch":"BLTest","contents":[{"kind":"transaction","source":"tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6","fee":"1000","counter":"10","gas_limit":"10000","storage_limit":"0","amount":"5","destination":"KT1C2MfcjWb5R1ZDDxVULCsGuxrf5fEn5264"}],"signature":"sigTest"}]`
+
+func TestMonitor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chains/main/mempool/monitor_operations" || r.URL.Query().Get(noderpc.MempoolApplied) != "true" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(monitorTestChunk + "\n"))
+		_, _ = w.Write([]byte("[]\n"))
+	}))
+	defer server.Close()
+
+	monitor := NewMonitor(
+		map[string]noderpc.IMempoolMonitor{
+			"sandboxnet": noderpc.NewNodeRPC(server.URL),
+		},
+		WithStatuses(noderpc.MempoolApplied),
+		WithRetryDelay(10*time.Millisecond),
+	)
+	monitor.Start()
+	defer monitor.Close()
+
+	_, err := monitor.Get("mainnet")
+	assert.Error(t, err)
+
+	pool, err := monitor.Get("sandboxnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for pool.Len() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ops := pool.GetByHash("ooTest")
+	if !assert.Len(t, ops, 1) {
+		return
+	}
+	assert.Equal(t, "KT1C2MfcjWb5R1ZDDxVULCsGuxrf5fEn5264", ops[0].Destination)
+	assert.Equal(t, int64(1000), ops[0].Fee)
+	assert.Equal(t, int64(5), ops[0].Amount)
+	assert.Equal(t, noderpc.MempoolApplied, ops[0].Status)
+}
@@ -0,0 +1,74 @@
+package mempool
+
+import (
+	stdJSON "encoding/json"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+)
+
+// Operation - content of mempool operation group
+type Operation struct {
+	Network      string
+	Protocol     string
+	Hash         string
+	Branch       string
+	Signature    string
+	Status       string
+	Kind         string
+	Source       string
+	Destination  string
+	Fee          int64
+	Counter      int64
+	GasLimit     int64
+	StorageLimit int64
+	Amount       int64
+	Parameters   stdJSON.RawMessage
+	Errors       stdJSON.RawMessage
+	Raw          stdJSON.RawMessage
+
+	// Timestamp - time when operation was received first time
+	Timestamp time.Time
+
+	lastSeen time.Time
+}
+
+func newOperation(network, status string, group noderpc.MempoolOperation, content noderpc.Operation) (Operation, error) {
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return Operation{}, err
+	}
+
+	op := Operation{
+		Network:      network,
+		Protocol:     group.Protocol,
+		Hash:         group.Hash,
+		Branch:       group.Branch,
+		Signature:    group.Signature,
+		Status:       status,
+		Kind:         content.Kind,
+		Source:       content.Source,
+		Fee:          content.Fee,
+		Counter:      content.Counter,
+		GasLimit:     content.GasLimit,
+		StorageLimit: content.StorageLimit,
+		Parameters:   content.Parameters,
+		Errors:       group.Error,
+		Raw:          raw,
+	}
+	if content.Destination != nil {
+		op.Destination = *content.Destination
+	}
+	if content.Amount != nil {
+		op.Amount = *content.Amount
+	}
+	if content.Balance != nil {
+		op.Amount = *content.Balance
+	}
+	return op, nil
+}
+
+// key - operation is identified by its source and counter
+func (op Operation) key() string {
+	return op.Source + "_" + fmtInt(op.Counter)
+}
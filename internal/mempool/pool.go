@@ -0,0 +1,126 @@
+package mempool
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// Kinds - kinds of operations which are kept in pool
+var Kinds = []string{consts.Transaction, consts.Origination, consts.OriginationNew}
+
+// Pool - expiring in-memory mempool of the network. Operation is removed if it's not received from node during `ttl`.
+type Pool struct {
+	network    string
+	ttl        time.Duration
+	operations map[string]*Operation
+	mx         sync.RWMutex
+}
+
+// NewPool -
+func NewPool(network string, ttl time.Duration) *Pool {
+	return &Pool{
+		network:    network,
+		ttl:        ttl,
+		operations: make(map[string]*Operation),
+	}
+}
+
+// Add - puts operation groups received from node with `status` to pool
+func (p *Pool) Add(status string, groups []noderpc.MempoolOperation) error {
+	now := time.Now().UTC()
+
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	for i := range groups {
+		for j := range groups[i].Contents {
+			if !helpers.StringInArray(groups[i].Contents[j].Kind, Kinds) {
+				continue
+			}
+			op, err := newOperation(p.network, status, groups[i], groups[i].Contents[j])
+			if err != nil {
+				return err
+			}
+
+			key := op.key()
+			if old, ok := p.operations[key]; ok && old.Hash == op.Hash {
+				op.Timestamp = old.Timestamp
+			} else {
+				op.Timestamp = now
+			}
+			op.lastSeen = now
+			p.operations[key] = &op
+		}
+	}
+
+	p.expire(now)
+	return nil
+}
+
+// GetByAddress - returns operations where `address` is source or destination. The newest operations are the first.
+func (p *Pool) GetByAddress(address string) []Operation {
+	return p.filter(func(op *Operation) bool {
+		return op.Source == address || op.Destination == address
+	})
+}
+
+// GetByHash - returns contents of operation group with `hash`
+func (p *Pool) GetByHash(hash string) []Operation {
+	return p.filter(func(op *Operation) bool {
+		return op.Hash == hash
+	})
+}
+
+// Len -
+func (p *Pool) Len() int {
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+	return len(p.operations)
+}
+
+func (p *Pool) filter(check func(op *Operation) bool) []Operation {
+	deadline := time.Now().UTC().Add(-p.ttl)
+
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+
+	result := make([]Operation, 0)
+	for _, op := range p.operations {
+		if op.lastSeen.Before(deadline) {
+			continue
+		}
+		if check(op) {
+			result = append(result, *op)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Timestamp.Equal(result[j].Timestamp) {
+			return result[i].Counter > result[j].Counter
+		}
+		return result[i].Timestamp.After(result[j].Timestamp)
+	})
+	return result
+}
+
+func (p *Pool) expire(now time.Time) {
+	deadline := now.Add(-p.ttl)
+	for key, op := range p.operations {
+		if op.lastSeen.Before(deadline) {
+			delete(p.operations, key)
+		}
+	}
+}
+
+func fmtInt(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
@@ -0,0 +1,96 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGroup(hash, source, destination string, counter int64) noderpc.MempoolOperation {
+	amount := int64(100)
+	return noderpc.MempoolOperation{
+		Protocol: "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA",
+		Hash:     hash,
+		Contents: []noderpc.Operation{
+			{
+				Kind:    "reveal",
+				Source:  source,
+				Counter: counter - 1,
+			},
+			{
+				Kind:        "transaction",
+				Source:      source,
+				Destination: &destination,
+				Counter:     counter,
+				Amount:      &amount,
+				Parameters:  []byte(`{"entrypoint":"transfer","value":{"prim":"Unit"}}`),
+			},
+		},
+	}
+}
+
+func TestPool_Add(t *testing.T) {
+	const (
+		source   = "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"
+		contract = "KT1C2MfcjWb5R1ZDDxVULCsGuxrf5fEn5264"
+	)
+
+	pool := NewPool("edo2net", time.Minute)
+	if err := pool.Add(noderpc.MempoolApplied, []noderpc.MempoolOperation{
+		newTestGroup("oo1", source, contract, 10),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, pool.Len(), "reveal has to be skipped")
+
+	ops := pool.GetByAddress(contract)
+	if !assert.Len(t, ops, 1) {
+		return
+	}
+	assert.Equal(t, "edo2net", ops[0].Network)
+	assert.Equal(t, noderpc.MempoolApplied, ops[0].Status)
+	assert.Equal(t, int64(100), ops[0].Amount)
+	assert.Equal(t, `{"entrypoint":"transfer","value":{"prim":"Unit"}}`, string(ops[0].Parameters))
+	firstSeen := ops[0].Timestamp
+
+	// the same operation is received again with other status
+	if err := pool.Add(noderpc.MempoolRefused, []noderpc.MempoolOperation{
+		newTestGroup("oo1", source, contract, 10),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	ops = pool.GetByHash("oo1")
+	if !assert.Len(t, ops, 1) {
+		return
+	}
+	assert.Equal(t, noderpc.MempoolRefused, ops[0].Status)
+	assert.Equal(t, firstSeen, ops[0].Timestamp)
+
+	assert.Len(t, pool.GetByAddress(source), 1)
+	assert.Len(t, pool.GetByAddress("KT1unknown"), 0)
+}
+
+func TestPool_Expire(t *testing.T) {
+	const ttl = 50 * time.Millisecond
+
+	pool := NewPool("edo2net", ttl)
+	if err := pool.Add(noderpc.MempoolApplied, []noderpc.MempoolOperation{
+		newTestGroup("oo1", "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6", "KT1C2MfcjWb5R1ZDDxVULCsGuxrf5fEn5264", 10),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(2 * ttl)
+	assert.Len(t, pool.GetByHash("oo1"), 0, "expired operation has to be skipped")
+	assert.Equal(t, 1, pool.Len())
+
+	if err := pool.Add(noderpc.MempoolApplied, []noderpc.MempoolOperation{
+		newTestGroup("oo2", "tz1KqTpEZ7Yob7QbPE4Hy4Wo8fHG8LhKxZSx", "KT1C2MfcjWb5R1ZDDxVULCsGuxrf5fEn5264", 5),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, pool.Len(), "expired operation has to be removed")
+	assert.Len(t, pool.GetByHash("oo2"), 1)
+}
@@ -44,6 +44,37 @@ func (h *Handler) UpdateContractStats(c *contract.Contract) error {
 	return nil
 }
 
+// SetContractDelegate - sets delegate of the last applied delegation of contract or delegate of its origination if contract has never changed it. It restores delegate after rollback.
+func (h *Handler) SetContractDelegate(c *contract.Contract) error {
+	delegations, err := h.Operations.Get(map[string]interface{}{
+		"network": c.Network,
+		"source":  c.Address,
+		"kind":    consts.Delegation,
+		"status":  consts.Applied,
+	}, 1, true)
+	if err != nil {
+		return err
+	}
+	if len(delegations) == 0 {
+		delegations, err = h.Operations.Get(map[string]interface{}{
+			"network":     c.Network,
+			"destination": c.Address,
+			"kind":        consts.Origination,
+			"status":      consts.Applied,
+		}, 1, true)
+		if err != nil {
+			return err
+		}
+	}
+	if len(delegations) == 0 || delegations[0].Delegate == c.Delegate {
+		return nil
+	}
+
+	c.Delegate = delegations[0].Delegate
+	c.DelegateAlias = ""
+	return nil
+}
+
 // SetContractProjectID -
 func (h *Handler) SetContractProjectID(c *contract.Contract) error {
 	buckets, err := h.Contracts.GetProjectsLastContract(c)
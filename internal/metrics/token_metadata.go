@@ -41,7 +41,6 @@ func (h *Handler) FixTokenMetadata(rpc noderpc.INode, sharePath string, contract
 	tokenMetadatas, err := h.TokenMetadata.GetAll(tokenmetadata.GetContext{
 		Contract: operation.Destination,
 		Network:  operation.Network,
-		TokenID:  -1,
 	})
 	if err != nil {
 		if !h.Storage.IsRecordNotFound(err) {
@@ -155,7 +154,7 @@ func (h *Handler) ExecuteInitialStorageEvent(rpc noderpc.INode, network, contrac
 					return nil, err
 				}
 
-				balances, err := events.Execute(rpc, event, events.Context{
+				balances, err := events.Execute(noderpc.NewInterpreter(rpc), event, events.Context{
 					Network:                  tzip.Network,
 					Parameters:               storageType,
 					Source:                   origination.Source,
@@ -8,6 +8,7 @@ type Repository interface {
 	GetByPtr(string, string, int64) ([]BigMapDiff, error)
 	GetByPtrAndKeyHash(int64, string, string, int64, int64) ([]BigMapDiff, int64, error)
 	GetForAddress(string) ([]BigMapDiff, error)
+	GetStates(network string, ptr, maxLevel int64) ([]BigMapDiff, error)
 	GetValuesByKey(string) ([]BigMapDiff, error)
 	GetUniqueByOperationID(string) ([]BigMapDiff, error)
 	Count(network string, ptr int64) (int64, error)
@@ -0,0 +1,23 @@
+package block
+
+import "time"
+
+// Reorg - chain reorganization event. Blocks from `FromLevel` to `ToLevel` (inclusive) were orphaned and removed from index.
+type Reorg struct {
+	Network        string    `json:"network"`
+	FromLevel      int64     `json:"from_level"`
+	ToLevel        int64     `json:"to_level"`
+	AncestorHash   string    `json:"ancestor_hash,omitempty"`
+	OrphanedHashes []string  `json:"orphaned_hashes"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// GetQueues -
+func (r *Reorg) GetQueues() []string {
+	return []string{"reorgs"}
+}
+
+// MarshalToQueue -
+func (r *Reorg) MarshalToQueue() ([]byte, error) {
+	return json.Marshal(r)
+}
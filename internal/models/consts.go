@@ -8,7 +8,9 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/migration"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/models/saplingdiff"
 	"github.com/baking-bad/bcdhub/internal/models/tezosdomain"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
 	"github.com/baking-bad/bcdhub/internal/models/tokenbalance"
 	"github.com/baking-bad/bcdhub/internal/models/tokenmetadata"
 	"github.com/baking-bad/bcdhub/internal/models/transfer"
@@ -24,7 +26,10 @@ const (
 	DocMigrations    = "migration"
 	DocOperations    = "operation"
 	DocProtocol      = "protocol"
+	DocSaplingDiff   = "sapling_diff"
 	DocTezosDomains  = "tezos_domain"
+	DocTickets       = "ticket"
+	DocTicketUpdates = "ticket_update"
 	DocTokenBalances = "token_balance"
 	DocTokenMetadata = "token_metadata"
 	DocTransfers     = "transfer"
@@ -41,7 +46,10 @@ func AllDocuments() []string {
 		DocMigrations,
 		DocOperations,
 		DocProtocol,
+		DocSaplingDiff,
 		DocTezosDomains,
+		DocTickets,
+		DocTicketUpdates,
 		DocTokenBalances,
 		DocTokenMetadata,
 		DocTransfers,
@@ -59,7 +67,10 @@ func AllModels() []Model {
 		&migration.Migration{},
 		&operation.Operation{},
 		&protocol.Protocol{},
+		&saplingdiff.SaplingDiff{},
 		&tezosdomain.TezosDomain{},
+		&ticket.Ticket{},
+		&ticket.Update{},
 		&tokenbalance.TokenBalance{},
 		&tokenmetadata.TokenMetadata{},
 		&transfer.Transfer{},
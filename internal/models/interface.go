@@ -15,6 +15,8 @@ type GeneralRepository interface {
 	GetByIDs(output interface{}, ids ...string) error
 	GetByNetwork(string, interface{}) error
 	GetByNetworkWithSort(string, string, string, interface{}) error
+	// ScrollByNetwork - fills `output` (pointer to slice of models) with chunks of `size` documents of `network` and calls `handler` after each chunk
+	ScrollByNetwork(network string, output interface{}, size int64, handler func() error) error
 	UpdateDoc(model Model) (err error)
 	UpdateFields(index, id string, data interface{}, fields ...string) error
 	GetEvents([]SubscriptionRequest, int64, int64) ([]Event, error)
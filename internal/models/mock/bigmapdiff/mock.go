@@ -124,6 +124,21 @@ func (mr *MockRepositoryMockRecorder) GetForAddress(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForAddress", reflect.TypeOf((*MockRepository)(nil).GetForAddress), arg0)
 }
 
+// GetStates mocks base method
+func (m *MockRepository) GetStates(network string, ptr, maxLevel int64) ([]bigmapdiff.BigMapDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStates", network, ptr, maxLevel)
+	ret0, _ := ret[0].([]bigmapdiff.BigMapDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStates indicates an expected call of GetStates
+func (mr *MockRepositoryMockRecorder) GetStates(network, ptr, maxLevel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStates", reflect.TypeOf((*MockRepository)(nil).GetStates), network, ptr, maxLevel)
+}
+
 // GetValuesByKey mocks base method
 func (m *MockRepository) GetValuesByKey(arg0 string) ([]bigmapdiff.BigMapDiff, error) {
 	m.ctrl.T.Helper()
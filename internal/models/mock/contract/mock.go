@@ -64,18 +64,18 @@ func (mr *MockRepositoryMockRecorder) GetMany(by interface{}) *gomock.Call {
 }
 
 // GetRandom mocks base method
-func (m *MockRepository) GetRandom() (contractModel.Contract, error) {
+func (m *MockRepository) GetRandom(network string) (contractModel.Contract, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRandom")
+	ret := m.ctrl.Call(m, "GetRandom", network)
 	ret0, _ := ret[0].(contractModel.Contract)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRandom indicates an expected call of GetRandom
-func (mr *MockRepositoryMockRecorder) GetRandom() *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetRandom(network interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRandom", reflect.TypeOf((*MockRepository)(nil).GetRandom))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRandom", reflect.TypeOf((*MockRepository)(nil).GetRandom), network)
 }
 
 // GetAddressesByNetworkAndLevel mocks base method
@@ -247,3 +247,18 @@ func (mr *MockRepositoryMockRecorder) UpdateField(where interface{}, fields ...i
 	varargs := append([]interface{}{where}, fields...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateField", reflect.TypeOf((*MockRepository)(nil).UpdateField), varargs...)
 }
+
+// Stats mocks base method
+func (m *MockRepository) Stats(contract contractModel.Contract) (contractModel.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", contract)
+	ret0, _ := ret[0].(contractModel.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats
+func (mr *MockRepositoryMockRecorder) Stats(contract interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockRepository)(nil).Stats), contract)
+}
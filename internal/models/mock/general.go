@@ -213,6 +213,20 @@ func (mr *MockGeneralRepositoryMockRecorder) GetEvents(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockGeneralRepository)(nil).GetEvents), arg0, arg1, arg2)
 }
 
+// ScrollByNetwork mocks base method
+func (m *MockGeneralRepository) ScrollByNetwork(network string, output interface{}, size int64, handler func() error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScrollByNetwork", network, output, size, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScrollByNetwork indicates an expected call of ScrollByNetwork
+func (mr *MockGeneralRepositoryMockRecorder) ScrollByNetwork(network, output, size, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScrollByNetwork", reflect.TypeOf((*MockGeneralRepository)(nil).ScrollByNetwork), network, output, size, handler)
+}
+
 // SearchByText mocks base method
 func (m *MockGeneralRepository) SearchByText(arg0 string, arg1 int64, arg2 []string, arg3 map[string]interface{}, arg4 bool) (models.Result, error) {
 	m.ctrl.T.Helper()
@@ -33,17 +33,32 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
-// GetMigrations mocks base method
-func (m *MockRepository) GetMigrations(arg0, arg1 string) ([]migration.Migration, error) {
+// Get mocks base method
+func (m *MockRepository) Get(arg0, arg1 string) ([]migration.Migration, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMigrations", arg0, arg1)
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
 	ret0, _ := ret[0].([]migration.Migration)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMigrations indicates an expected call of GetMigrations
-func (mr *MockRepositoryMockRecorder) GetMigrations(arg0, arg1 interface{}) *gomock.Call {
+// Get indicates an expected call of Get
+func (mr *MockRepositoryMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMigrations", reflect.TypeOf((*MockRepository)(nil).GetMigrations), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), arg0, arg1)
+}
+
+// Count mocks base method
+func (m *MockRepository) Count(arg0, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count
+func (mr *MockRepositoryMockRecorder) Count(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockRepository)(nil).Count), arg0, arg1)
 }
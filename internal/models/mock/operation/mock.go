@@ -78,6 +78,21 @@ func (mr *MockRepositoryMockRecorder) Last(network, address, indexedTime interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Last", reflect.TypeOf((*MockRepository)(nil).Last), network, address, indexedTime)
 }
 
+// LastAtLevel mocks base method
+func (m *MockRepository) LastAtLevel(network, address string, level int64) (operation.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastAtLevel", network, address, level)
+	ret0, _ := ret[0].(operation.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastAtLevel indicates an expected call of LastAtLevel
+func (mr *MockRepositoryMockRecorder) LastAtLevel(network, address, level interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastAtLevel", reflect.TypeOf((*MockRepository)(nil).LastAtLevel), network, address, level)
+}
+
 // Get mocks base method
 func (m *MockRepository) Get(filter map[string]interface{}, size int64, sort bool) ([]operation.Operation, error) {
 	m.ctrl.T.Helper()
@@ -5,6 +5,7 @@
 package tokenbalance
 
 import (
+	types "github.com/baking-bad/bcdhub/internal/bcd/types"
 	tb "github.com/baking-bad/bcdhub/internal/models/tokenbalance"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
@@ -64,7 +65,7 @@ func (mr *MockRepositoryMockRecorder) Update(updates interface{}) *gomock.Call {
 }
 
 // GetHolders mocks base method
-func (m *MockRepository) GetHolders(network, contract string, tokenID int64) ([]tb.TokenBalance, error) {
+func (m *MockRepository) GetHolders(network, contract string, tokenID *types.BigInt) ([]tb.TokenBalance, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetHolders", network, contract, tokenID)
 	ret0, _ := ret[0].([]tb.TokenBalance)
@@ -79,7 +80,7 @@ func (mr *MockRepositoryMockRecorder) GetHolders(network, contract, tokenID inte
 }
 
 // BurnNft mocks base method
-func (m *MockRepository) BurnNft(network, contract string, tokenID int64) error {
+func (m *MockRepository) BurnNft(network, contract string, tokenID *types.BigInt) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "BurnNft", network, contract, tokenID)
 	ret0, _ := ret[0].(error)
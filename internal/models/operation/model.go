@@ -46,6 +46,7 @@ type Operation struct {
 
 	Result                             *Result            `json:"result,omitempty"`
 	Errors                             []*tezerrors.Error `json:"errors,omitempty"`
+	BalanceUpdates                     []BalanceUpdate    `json:"balance_updates,omitempty"`
 	Burned                             int64              `json:"burned,omitempty"`
 	AllocatedDestinationContractBurned int64              `json:"allocated_destination_contract_burned,omitempty"`
 
@@ -158,6 +159,15 @@ type Result struct {
 	Errors                       []*tezerrors.Error `json:"-"`
 }
 
+// BalanceUpdate - change of balance caused by operation: fees paid to baker and balance changes of its result
+type BalanceUpdate struct {
+	Kind     string `json:"kind"`
+	Contract string `json:"contract,omitempty"`
+	Category string `json:"category,omitempty"`
+	Delegate string `json:"delegate,omitempty"`
+	Change   int64  `json:"change"`
+}
+
 // Stats -
 type Stats struct {
 	Count      int64
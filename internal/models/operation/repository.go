@@ -6,6 +6,8 @@ type Repository interface {
 	GetStats(network, address string) (Stats, error)
 	// Last - returns last operation. TODO: change network and address.
 	Last(network string, address string, indexedTime int64) (Operation, error)
+	// LastAtLevel - returns last applied operation which changed storage of `address` at or before `level`. If `level` is 0 the latest one is returned.
+	LastAtLevel(network string, address string, level int64) (Operation, error)
 
 	// GetOperations - get operation by `filter`. `Size` - if 0 - return all, else certain `size` operations.
 	// `Sort` - sort by time and content index by desc
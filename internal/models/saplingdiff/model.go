@@ -0,0 +1,78 @@
+package saplingdiff
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SaplingDiff - update of sapling state made by operation
+type SaplingDiff struct {
+	ID               string       `json:"-"`
+	Ptr              int64        `json:"ptr"`
+	Action           string       `json:"action"`
+	SourcePtr        *int64       `json:"source_ptr,omitempty"`
+	MemoSize         *int64       `json:"memo_size,omitempty"`
+	Commitments      []string     `json:"commitments"`
+	Ciphertexts      []Ciphertext `json:"ciphertexts"`
+	Nullifiers       []string     `json:"nullifiers"`
+	CommitmentsCount int64        `json:"commitments_count"`
+	NullifiersCount  int64        `json:"nullifiers_count"`
+	OperationID      string       `json:"operation_id"`
+	Level            int64        `json:"level"`
+	Address          string       `json:"address"`
+	Network          string       `json:"network"`
+	IndexedTime      int64        `json:"indexed_time"`
+	Timestamp        time.Time    `json:"timestamp"`
+	Protocol         string       `json:"protocol"`
+}
+
+// Ciphertext - encrypted output of shielded transaction
+type Ciphertext struct {
+	CV         string `json:"cv"`
+	EPK        string `json:"epk"`
+	PayloadEnc string `json:"payload_enc"`
+	NonceEnc   string `json:"nonce_enc"`
+	PayloadOut string `json:"payload_out"`
+	NonceOut   string `json:"nonce_out"`
+}
+
+// GetID -
+func (sd *SaplingDiff) GetID() string {
+	return sd.ID
+}
+
+// GetIndex -
+func (sd *SaplingDiff) GetIndex() string {
+	return "sapling_diff"
+}
+
+// GetQueues -
+func (sd *SaplingDiff) GetQueues() []string {
+	return nil
+}
+
+// MarshalToQueue -
+func (sd *SaplingDiff) MarshalToQueue() ([]byte, error) {
+	return nil, nil
+}
+
+// LogFields -
+func (sd *SaplingDiff) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"network":  sd.Network,
+		"contract": sd.Address,
+		"ptr":      sd.Ptr,
+		"block":    sd.Level,
+		"action":   sd.Action,
+	}
+}
+
+// Stats - aggregated sapling state updates
+type Stats struct {
+	Count       int64
+	Commitments int64
+	Nullifiers  int64
+	FirstLevel  int64
+	LastLevel   int64
+}
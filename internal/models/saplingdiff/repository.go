@@ -0,0 +1,8 @@
+package saplingdiff
+
+// Repository -
+type Repository interface {
+	Get(network string, ptr int64, size, offset int64) ([]SaplingDiff, error)
+	First(network string, ptr int64) (SaplingDiff, error)
+	GetStats(network string, ptr int64, maxLevel int64) (Stats, error)
+}
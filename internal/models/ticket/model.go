@@ -0,0 +1,97 @@
+package ticket
+
+import (
+	"fmt"
+	"math/big"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/sirupsen/logrus"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// Location of tickets in holder's storage
+const (
+	LocationStorage   = "storage"
+	LocationParameter = "parameter"
+)
+
+// BigMapLocation - returns location of tickets stored in big map `ptr` by key `keyHash`
+func BigMapLocation(ptr int64, keyHash string) string {
+	return fmt.Sprintf("%d_%s", ptr, keyHash)
+}
+
+// Ticket - amount of tickets with the same ticketer and content held by contract in one location of its storage
+type Ticket struct {
+	Network     string `json:"network"`
+	Holder      string `json:"holder"`
+	Location    string `json:"location"`
+	Ticketer    string `json:"ticketer"`
+	ContentType string `json:"content_type"`
+	Content     string `json:"content"`
+	ContentHash string `json:"content_hash"`
+	Amount      string `json:"amount"`
+
+	Value *big.Int `json:"-"`
+}
+
+// GetID -
+func (t *Ticket) GetID() string {
+	return fmt.Sprintf("%s_%s_%s_%s_%s", t.Network, t.Holder, t.Location, t.Ticketer, t.ContentHash)
+}
+
+// GetIndex -
+func (t *Ticket) GetIndex() string {
+	return "ticket"
+}
+
+// GetQueues -
+func (t *Ticket) GetQueues() []string {
+	return nil
+}
+
+// MarshalToQueue -
+func (t *Ticket) MarshalToQueue() ([]byte, error) {
+	return nil, nil
+}
+
+// LogFields -
+func (t *Ticket) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"network":  t.Network,
+		"holder":   t.Holder,
+		"location": t.Location,
+		"ticketer": t.Ticketer,
+		"content":  t.Content,
+		"amount":   t.Value.String(),
+	}
+}
+
+// Sum -
+func (t *Ticket) Sum(delta *Ticket) {
+	t.Value.Add(t.Value, delta.Value)
+}
+
+// UnmarshalJSON -
+func (t *Ticket) UnmarshalJSON(data []byte) error {
+	type buf Ticket
+	if err := json.Unmarshal(data, (*buf)(t)); err != nil {
+		return err
+	}
+	t.Value = big.NewInt(0)
+
+	if _, ok := t.Value.SetString(t.Amount, 10); !ok {
+		return fmt.Errorf("Can't set ticket amount: %s", t.Amount)
+	}
+	return nil
+}
+
+// MarshalJSON -
+func (t *Ticket) MarshalJSON() ([]byte, error) {
+	if t.Value == nil {
+		return nil, fmt.Errorf("Nil ticket amount")
+	}
+	t.Amount = t.Value.String()
+	type buf Ticket
+	return json.Marshal((*buf)(t))
+}
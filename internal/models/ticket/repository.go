@@ -0,0 +1,9 @@
+package ticket
+
+// Repository -
+type Repository interface {
+	GetByHolder(network, holder string) ([]Ticket, error)
+	GetByTicketer(network, ticketer string) ([]Ticket, error)
+	GetUpdates(network string, level int64) ([]Update, error)
+	Update(updates []*Ticket) error
+}
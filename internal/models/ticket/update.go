@@ -0,0 +1,108 @@
+package ticket
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Update - change of tickets amount in holder's storage made by operation. Updates in `parameter` location are tickets which are sent to holder by `Sender`
+// in transaction parameters: they don't change balances.
+type Update struct {
+	ID          string    `json:"-"`
+	Network     string    `json:"network"`
+	OperationID string    `json:"operation_id"`
+	Level       int64     `json:"level"`
+	Timestamp   time.Time `json:"timestamp"`
+	Holder      string    `json:"holder"`
+	Sender      string    `json:"sender,omitempty"`
+	Location    string    `json:"location"`
+	Ticketer    string    `json:"ticketer"`
+	ContentType string    `json:"content_type"`
+	Content     string    `json:"content"`
+	ContentHash string    `json:"content_hash"`
+	Amount      string    `json:"amount"`
+
+	Value *big.Int `json:"-"`
+}
+
+// GetID -
+func (u *Update) GetID() string {
+	return u.ID
+}
+
+// GetIndex -
+func (u *Update) GetIndex() string {
+	return "ticket_update"
+}
+
+// GetQueues -
+func (u *Update) GetQueues() []string {
+	return nil
+}
+
+// MarshalToQueue -
+func (u *Update) MarshalToQueue() ([]byte, error) {
+	return nil, nil
+}
+
+// LogFields -
+func (u *Update) LogFields() logrus.Fields {
+	return logrus.Fields{
+		"network":  u.Network,
+		"holder":   u.Holder,
+		"location": u.Location,
+		"ticketer": u.Ticketer,
+		"block":    u.Level,
+		"amount":   u.Value.String(),
+	}
+}
+
+// IsTransfer - returns true if update is tickets transfer by parameters
+func (u *Update) IsTransfer() bool {
+	return u.Location == LocationParameter
+}
+
+// ToTicket - returns ticket balance changed by update. If `revert` is true amount is negated.
+func (u *Update) ToTicket(revert bool) *Ticket {
+	value := new(big.Int).Set(u.Value)
+	if revert {
+		value.Neg(value)
+	}
+	return &Ticket{
+		Network:     u.Network,
+		Holder:      u.Holder,
+		Location:    u.Location,
+		Ticketer:    u.Ticketer,
+		ContentType: u.ContentType,
+		Content:     u.Content,
+		ContentHash: u.ContentHash,
+		Value:       value,
+	}
+}
+
+// UnmarshalJSON -
+func (u *Update) UnmarshalJSON(data []byte) error {
+	type buf Update
+	if err := json.Unmarshal(data, (*buf)(u)); err != nil {
+		return err
+	}
+	u.Value = big.NewInt(0)
+
+	if _, ok := u.Value.SetString(u.Amount, 10); !ok {
+		return fmt.Errorf("Can't set ticket update amount: %s", u.Amount)
+	}
+	return nil
+}
+
+// MarshalJSON -
+func (u *Update) MarshalJSON() ([]byte, error) {
+	if u.Value == nil {
+		return nil, fmt.Errorf("Nil ticket update amount")
+	}
+	u.Amount = u.Value.String()
+	type buf Update
+	return json.Marshal((*buf)(u))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/sirupsen/logrus"
 )
@@ -12,18 +13,18 @@ var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
 // TokenBalance -
 type TokenBalance struct {
-	Network  string `json:"network"`
-	Address  string `json:"address"`
-	Contract string `json:"contract"`
-	TokenID  int64  `json:"token_id"`
-	Balance  string `json:"balance"`
+	Network  string        `json:"network"`
+	Address  string        `json:"address"`
+	Contract string        `json:"contract"`
+	TokenID  *types.BigInt `json:"token_id"`
+	Balance  string        `json:"balance"`
 
 	Value *big.Int `json:"-"`
 }
 
 // GetID -
 func (tb *TokenBalance) GetID() string {
-	return fmt.Sprintf("%s_%s_%s_%d", tb.Network, tb.Address, tb.Contract, tb.TokenID)
+	return fmt.Sprintf("%s_%s_%s_%s", tb.Network, tb.Address, tb.Contract, tb.TokenID)
 }
 
 // GetIndex -
@@ -47,7 +48,7 @@ func (tb *TokenBalance) LogFields() logrus.Fields {
 		"network":  tb.Network,
 		"address":  tb.Address,
 		"contract": tb.Contract,
-		"token_id": tb.TokenID,
+		"token_id": tb.TokenID.String(),
 		"balance":  tb.Value.String(),
 	}
 }
@@ -1,9 +1,11 @@
 package tokenbalance
 
+import "github.com/baking-bad/bcdhub/internal/bcd/types"
+
 // Repository -
 type Repository interface {
 	GetAccountBalances(network string, address string, size, offset int64) ([]TokenBalance, int64, error)
 	Update(updates []*TokenBalance) error
-	GetHolders(network, contract string, tokenID int64) ([]TokenBalance, error)
-	BurnNft(network, contract string, tokenID int64) error
+	GetHolders(network, contract string, tokenID *types.BigInt) ([]TokenBalance, error)
+	BurnNft(network, contract string, tokenID *types.BigInt) error
 }
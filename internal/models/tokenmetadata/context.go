@@ -1,10 +1,12 @@
 package tokenmetadata
 
+import "github.com/baking-bad/bcdhub/internal/bcd/types"
+
 // GetContext -
 type GetContext struct {
 	Contract string
 	Network  string
-	TokenID  int64
+	TokenID  *types.BigInt
 	MaxLevel int64
 	MinLevel int64
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/sirupsen/logrus"
 )
 
@@ -13,7 +14,7 @@ type TokenMetadata struct {
 	Contract           string                 `json:"contract"`
 	Level              int64                  `json:"level"`
 	Timestamp          time.Time              `json:"timestamp"`
-	TokenID            int64                  `json:"token_id"`
+	TokenID            *types.BigInt          `json:"token_id"`
 	Symbol             string                 `json:"symbol"`
 	Name               string                 `json:"name"`
 	Decimals           *int64                 `json:"decimals,omitempty"`
@@ -48,11 +49,11 @@ type ByTokenID []TokenMetadata
 
 func (tm ByTokenID) Len() int           { return len(tm) }
 func (tm ByTokenID) Swap(i, j int)      { tm[i], tm[j] = tm[j], tm[i] }
-func (tm ByTokenID) Less(i, j int) bool { return tm[i].TokenID < tm[j].TokenID }
+func (tm ByTokenID) Less(i, j int) bool { return tm[i].TokenID.Cmp(tm[j].TokenID.Int) < 0 }
 
 // GetID -
 func (t *TokenMetadata) GetID() string {
-	return fmt.Sprintf("%s_%s_%d", t.Network, t.Contract, t.TokenID)
+	return fmt.Sprintf("%s_%s_%s", t.Network, t.Contract, t.TokenID)
 }
 
 // GetIndex -
@@ -75,6 +76,6 @@ func (t *TokenMetadata) LogFields() logrus.Fields {
 	return logrus.Fields{
 		"network":  t.Network,
 		"contract": t.Contract,
-		"token_id": t.TokenID,
+		"token_id": t.TokenID.String(),
 	}
 }
@@ -1,5 +1,7 @@
 package transfer
 
+import "github.com/baking-bad/bcdhub/internal/bcd/types"
+
 // GetContext -
 type GetContext struct {
 	Contracts []string
@@ -12,7 +14,7 @@ type GetContext struct {
 	LastID    string
 	Size      int64
 	Offset    int64
-	TokenID   int64
+	TokenID   *types.BigInt
 	Nonce     *int64
 	Counter   *int64
 }
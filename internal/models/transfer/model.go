@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/baking-bad/bcdhub/internal/helpers"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/tokenbalance"
@@ -17,24 +18,24 @@ var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
 // Transfer -
 type Transfer struct {
-	ID           string    `json:"-"`
-	IndexedTime  int64     `json:"indexed_time"`
-	Network      string    `json:"network"`
-	Contract     string    `json:"contract"`
-	Initiator    string    `json:"initiator"`
-	Hash         string    `json:"hash"`
-	Status       string    `json:"status"`
-	Timestamp    time.Time `json:"timestamp"`
-	Level        int64     `json:"level"`
-	From         string    `json:"from"`
-	To           string    `json:"to"`
-	TokenID      int64     `json:"token_id"`
-	Amount       float64   `json:"amount"`
-	AmountStr    string    `json:"amount_str"`
-	AmountBigInt *big.Int  `json:"-"`
-	Counter      int64     `json:"counter"`
-	Nonce        *int64    `json:"nonce,omitempty"`
-	Parent       string    `json:"parent,omitempty"`
+	ID           string        `json:"-"`
+	IndexedTime  int64         `json:"indexed_time"`
+	Network      string        `json:"network"`
+	Contract     string        `json:"contract"`
+	Initiator    string        `json:"initiator"`
+	Hash         string        `json:"hash"`
+	Status       string        `json:"status"`
+	Timestamp    time.Time     `json:"timestamp"`
+	Level        int64         `json:"level"`
+	From         string        `json:"from"`
+	To           string        `json:"to"`
+	TokenID      *types.BigInt `json:"token_id"`
+	Amount       float64       `json:"amount"`
+	AmountStr    string        `json:"amount_str"`
+	AmountBigInt *big.Int      `json:"-"`
+	Counter      int64         `json:"counter"`
+	Nonce        *int64        `json:"nonce,omitempty"`
+	Parent       string        `json:"parent,omitempty"`
 }
 
 // GetID -
@@ -80,6 +81,7 @@ func EmptyTransfer(o operation.Operation) *Transfer {
 		Timestamp:    o.Timestamp,
 		Level:        o.Level,
 		Initiator:    o.Source,
+		TokenID:      types.NewBigInt(0),
 		AmountBigInt: big.NewInt(0),
 		Counter:      o.Counter,
 		Nonce:        o.Nonce,
@@ -89,7 +91,7 @@ func EmptyTransfer(o operation.Operation) *Transfer {
 // GetFromTokenBalanceID -
 func (t *Transfer) GetFromTokenBalanceID() string {
 	if t.From != "" {
-		return fmt.Sprintf("%s_%s_%s_%d", t.Network, t.From, t.Contract, t.TokenID)
+		return fmt.Sprintf("%s_%s_%s_%s", t.Network, t.From, t.Contract, t.TokenID)
 	}
 	return ""
 }
@@ -97,7 +99,7 @@ func (t *Transfer) GetFromTokenBalanceID() string {
 // GetToTokenBalanceID -
 func (t *Transfer) GetToTokenBalanceID() string {
 	if t.To != "" {
-		return fmt.Sprintf("%s_%s_%s_%d", t.Network, t.To, t.Contract, t.TokenID)
+		return fmt.Sprintf("%s_%s_%s_%s", t.Network, t.To, t.Contract, t.TokenID)
 	}
 	return ""
 }
@@ -130,7 +132,7 @@ func (t *Transfer) MakeTokenBalanceUpdate(from, rollback bool) *tokenbalance.Tok
 // TokenBalance -
 type TokenBalance struct {
 	Address string
-	TokenID int64
+	TokenID *types.BigInt
 }
 
 // TokenSupply -
@@ -1,12 +1,15 @@
 package transfer
 
-import "github.com/baking-bad/bcdhub/internal/models/tzip"
+import (
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
+	"github.com/baking-bad/bcdhub/internal/models/tzip"
+)
 
 // Repository -
 type Repository interface {
 	Get(ctx GetContext) (Pageable, error)
 	GetAll(network string, level int64) ([]Transfer, error)
-	GetTokenSupply(network, address string, tokenID int64) (result TokenSupply, err error)
-	GetToken24HoursVolume(network, contract string, initiators, entrypoints []string, tokenID int64) (float64, error)
-	GetTokenVolumeSeries(network, period string, contracts []string, entrypoints []tzip.DAppContract, tokenID uint) ([][]float64, error)
+	GetTokenSupply(network, address string, tokenID *types.BigInt) (result TokenSupply, err error)
+	GetToken24HoursVolume(network, contract string, initiators, entrypoints []string, tokenID *types.BigInt) (float64, error)
+	GetTokenVolumeSeries(network, period string, contracts []string, entrypoints []tzip.DAppContract, tokenID *types.BigInt) ([][]float64, error)
 }
@@ -52,5 +52,19 @@ type View struct {
 
 // ViewImplementation -
 type ViewImplementation struct {
-	MichelsonStorageView Sections `json:"michelsonStorageView"`
+	MichelsonStorageView Sections      `json:"michelsonStorageView"`
+	RestAPIQuery         *RestAPIQuery `json:"restApiQuery,omitempty"`
+}
+
+// RestAPIQuery - view implemented by call of REST API described with OpenAPI specification
+type RestAPIQuery struct {
+	SpecificationURI string `json:"specificationUri"`
+	BaseURI          string `json:"baseUri,omitempty"`
+	Path             string `json:"path"`
+	Method           string `json:"method,omitempty"`
+}
+
+// Empty -
+func (q *RestAPIQuery) Empty() bool {
+	return q == nil || q.SpecificationURI == "" || q.Path == ""
 }
@@ -1,6 +1,7 @@
 package tzip
 
 import (
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/tidwall/gjson"
 )
 
@@ -44,8 +45,8 @@ func (t *Picture) ParseElasticJSON(resp gjson.Result) {
 
 // DexToken -
 type DexToken struct {
-	TokenID  int64  `json:"token_id"`
-	Contract string `json:"contract"`
+	TokenID  *types.BigInt `json:"token_id"`
+	Contract string        `json:"contract"`
 }
 
 // DAppContract -
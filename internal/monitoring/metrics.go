@@ -0,0 +1,168 @@
+package monitoring
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	namespace    = "bcd"
+	unknownRoute = "unknown"
+)
+
+var (
+	indexerLevel = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "indexer",
+		Name:      "level",
+		Help:      "Level of the last indexed block.",
+	}, []string{"network"})
+	nodeHead = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "indexer",
+		Name:      "node_head_level",
+		Help:      "Level of the node head.",
+	}, []string{"network"})
+	indexerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "indexer",
+		Name:      "lag_blocks",
+		Help:      "Count of blocks which are not indexed yet.",
+	}, []string{"network"})
+	indexedBlocks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "indexer",
+		Name:      "blocks_total",
+		Help:      "Count of parsed blocks.",
+	}, []string{"network"})
+	indexedOperations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "indexer",
+		Name:      "operations_total",
+		Help:      "Count of parsed operations.",
+	}, []string{"network"})
+
+	rpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "node_rpc",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of node RPC requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"node", "endpoint"})
+	rpcErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "node_rpc",
+		Name:      "errors_total",
+		Help:      "Count of failed node RPC requests.",
+	}, []string{"node", "endpoint"})
+
+	queueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "bulk",
+		Name:      "queue_depth",
+		Help:      "Count of messages waiting for flush.",
+	}, []string{"queue"})
+	flushDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "bulk",
+		Name:      "flush_duration_seconds",
+		Help:      "Duration of bulk flushes.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+	}, []string{"queue"})
+
+	storageDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "storage",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of storage requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"repository", "method"})
+
+	apiDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "api",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of API requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+// levels - indexed levels and node heads of networks which are used to compute lag
+var levels = struct {
+	sync.Mutex
+	indexed map[string]int64
+	head    map[string]int64
+}{
+	indexed: make(map[string]int64),
+	head:    make(map[string]int64),
+}
+
+func updateLag(network string) {
+	head, ok := levels.head[network]
+	if !ok {
+		return
+	}
+	lag := head - levels.indexed[network]
+	if lag < 0 {
+		lag = 0
+	}
+	indexerLag.WithLabelValues(network).Set(float64(lag))
+}
+
+// SetIndexerLevel -
+func SetIndexerLevel(network string, level int64) {
+	levels.Lock()
+	defer levels.Unlock()
+
+	levels.indexed[network] = level
+	indexerLevel.WithLabelValues(network).Set(float64(level))
+	updateLag(network)
+}
+
+// SetNodeHead -
+func SetNodeHead(network string, level int64) {
+	levels.Lock()
+	defer levels.Unlock()
+
+	levels.head[network] = level
+	nodeHead.WithLabelValues(network).Set(float64(level))
+	updateLag(network)
+}
+
+// AddIndexedBlock - counts parsed block and its operations
+func AddIndexedBlock(network string, operations int) {
+	indexedBlocks.WithLabelValues(network).Inc()
+	indexedOperations.WithLabelValues(network).Add(float64(operations))
+}
+
+// ObserveRPCRequest -
+func ObserveRPCRequest(node, endpoint string, duration time.Duration, failed bool) {
+	rpcDuration.WithLabelValues(node, endpoint).Observe(duration.Seconds())
+	if failed {
+		rpcErrors.WithLabelValues(node, endpoint).Inc()
+	}
+}
+
+// SetQueueDepth -
+func SetQueueDepth(queue string, depth int) {
+	queueDepth.WithLabelValues(queue).Set(float64(depth))
+}
+
+// ObserveFlush -
+func ObserveFlush(queue string, duration time.Duration) {
+	flushDuration.WithLabelValues(queue).Observe(duration.Seconds())
+}
+
+// ObserveStorageRequest -
+func ObserveStorageRequest(repository, method string, duration time.Duration) {
+	storageDuration.WithLabelValues(repository, method).Observe(duration.Seconds())
+}
+
+// ObserveAPIRequest -
+func ObserveAPIRequest(method, route string, status int, duration time.Duration) {
+	apiDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
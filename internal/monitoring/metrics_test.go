@@ -0,0 +1,24 @@
+package monitoring
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLag(t *testing.T) {
+	network := "test"
+
+	SetIndexerLevel(network, 100)
+	assert.Equal(t, float64(0), testutil.ToFloat64(indexerLag.WithLabelValues(network)), "head is unknown")
+
+	SetNodeHead(network, 110)
+	assert.Equal(t, float64(10), testutil.ToFloat64(indexerLag.WithLabelValues(network)))
+
+	SetIndexerLevel(network, 110)
+	assert.Equal(t, float64(0), testutil.ToFloat64(indexerLag.WithLabelValues(network)))
+
+	SetNodeHead(network, 105)
+	assert.Equal(t, float64(0), testutil.ToFloat64(indexerLag.WithLabelValues(network)), "rollback is pending")
+}
@@ -0,0 +1,55 @@
+package monitoring
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Handler - returns handler of `/metrics` endpoint
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+var enabled bool
+
+// IsEnabled - returns true if `/metrics` endpoint is exposed. Expensive observations (e.g. of storage queries) are skipped otherwise.
+func IsEnabled() bool {
+	return enabled
+}
+
+// Serve - exposes `/metrics` endpoint on `bind` address in background. Nothing is exposed if `bind` is empty.
+// It has to be called before connection to storage is created.
+func Serve(bind string) {
+	if bind == "" {
+		return
+	}
+	enabled = true
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+
+	go func() {
+		logger.Info("Prometheus metrics are exposed on %s/metrics", bind)
+		if err := http.ListenAndServe(bind, mux); err != nil {
+			logger.Errorf("Prometheus metrics server: %s", err)
+		}
+	}()
+}
+
+// Middleware - gin middleware which observes duration of API requests. Requests are labeled by route pattern, so unknown routes are labeled as `unknown`.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unknownRoute
+		}
+		ObserveAPIRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
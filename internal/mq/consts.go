@@ -18,6 +18,8 @@ const (
 	QueueCompilations = "compilations"
 	QueueBigMapDiffs  = "bigmapdiffs"
 	QueueBlocks       = "blocks"
+	QueueReorgs       = "reorgs"
+	QueueAudit        = "audit"
 )
 
 // URL Prefixes
@@ -0,0 +1,386 @@
+package noderpc
+
+import (
+	"bufio"
+	"context"
+	stdJSON "encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/pkg/errors"
+)
+
+// maxCassetteLineSize - limit of recorded interaction size. Operation groups of block can be large.
+const maxCassetteLineSize = 256 * 1024 * 1024
+
+// Errors
+var (
+	ErrNotRecorded       = errors.New("Request is not recorded in cassette")
+	ErrHeadsNotSupported = errors.New("Heads stream is not supported by node")
+)
+
+// interaction - line of cassette: request of `Method` with `Args` and node's response. Failed requests are recorded only if node rejected them (`InvalidNodeResponse`).
+type interaction struct {
+	Method    string             `json:"method"`
+	Args      stdJSON.RawMessage `json:"args"`
+	Response  stdJSON.RawMessage `json:"response,omitempty"`
+	NodeError []byte             `json:"node_error,omitempty"`
+}
+
+func newInteraction(method string, args []interface{}) (interaction, error) {
+	if args == nil {
+		args = make([]interface{}, 0)
+	}
+	data, err := stdJSON.Marshal(args)
+	if err != nil {
+		return interaction{}, err
+	}
+	return interaction{
+		Method: method,
+		Args:   data,
+	}, nil
+}
+
+func (i interaction) key() string {
+	return i.Method + string(i.Args)
+}
+
+// Recorder - INode decorator which writes every request and response to cassette file. Cassette is a JSON lines file which is appended if it exists.
+type Recorder struct {
+	node INode
+
+	mx   sync.Mutex
+	file *os.File
+}
+
+// NewRecorder -
+func NewRecorder(node INode, filename string) (*Recorder, error) {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{
+		node: node,
+		file: file,
+	}, nil
+}
+
+// Close -
+func (r *Recorder) Close() error {
+	return r.file.Close()
+}
+
+// record - writes interaction to cassette and returns `callErr`. Transport errors are not recorded because they are not answers of node.
+func (r *Recorder) record(method string, args []interface{}, response interface{}, callErr error) error {
+	item, err := newInteraction(method, args)
+	if err != nil {
+		return err
+	}
+
+	if callErr != nil {
+		var e InvalidNodeResponse
+		if !errors.As(callErr, &e) {
+			return callErr
+		}
+		item.NodeError = e.Raw
+	} else {
+		if item.Response, err = stdJSON.Marshal(response); err != nil {
+			return err
+		}
+	}
+
+	data, err := stdJSON.Marshal(item)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if _, err := r.file.Write(data); err != nil {
+		return err
+	}
+	return callErr
+}
+
+// MonitorHeads - forwards heads stream of decorated node. Heads are not recorded: stream only triggers requests which are recorded.
+func (r *Recorder) MonitorHeads(ctx context.Context, handler HeadHandler) error {
+	monitor, ok := r.node.(IHeadMonitor)
+	if !ok {
+		return ErrHeadsNotSupported
+	}
+	return monitor.MonitorHeads(ctx, handler)
+}
+
+// GetHead -
+func (r *Recorder) GetHead() (Header, error) {
+	response, err := r.node.GetHead()
+	return response, r.record("GetHead", nil, response, err)
+}
+
+// GetHeader -
+func (r *Recorder) GetHeader(level int64) (Header, error) {
+	response, err := r.node.GetHeader(level)
+	return response, r.record("GetHeader", []interface{}{level}, response, err)
+}
+
+// GetLevel -
+func (r *Recorder) GetLevel() (int64, error) {
+	response, err := r.node.GetLevel()
+	return response, r.record("GetLevel", nil, response, err)
+}
+
+// GetLevelTime -
+func (r *Recorder) GetLevelTime(level int) (time.Time, error) {
+	response, err := r.node.GetLevelTime(level)
+	return response, r.record("GetLevelTime", []interface{}{level}, response, err)
+}
+
+// GetScriptJSON -
+func (r *Recorder) GetScriptJSON(address string, level int64) (Script, error) {
+	response, err := r.node.GetScriptJSON(address, level)
+	return response, r.record("GetScriptJSON", []interface{}{address, level}, response, err)
+}
+
+// GetScriptStorageRaw -
+func (r *Recorder) GetScriptStorageRaw(address string, level int64) ([]byte, error) {
+	response, err := r.node.GetScriptStorageRaw(address, level)
+	return response, r.record("GetScriptStorageRaw", []interface{}{address, level}, response, err)
+}
+
+// GetContractBalance -
+func (r *Recorder) GetContractBalance(address string, level int64) (int64, error) {
+	response, err := r.node.GetContractBalance(address, level)
+	return response, r.record("GetContractBalance", []interface{}{address, level}, response, err)
+}
+
+// GetContractData -
+func (r *Recorder) GetContractData(address string, level int64) (ContractData, error) {
+	response, err := r.node.GetContractData(address, level)
+	return response, r.record("GetContractData", []interface{}{address, level}, response, err)
+}
+
+// GetBigMapValue -
+func (r *Recorder) GetBigMapValue(ptr int64, keyHash string, level int64) ([]byte, error) {
+	response, err := r.node.GetBigMapValue(ptr, keyHash, level)
+	return response, r.record("GetBigMapValue", []interface{}{ptr, keyHash, level}, response, err)
+}
+
+// GetOPG -
+func (r *Recorder) GetOPG(block int64) ([]OperationGroup, error) {
+	response, err := r.node.GetOPG(block)
+	return response, r.record("GetOPG", []interface{}{block}, response, err)
+}
+
+// GetContractsByBlock -
+func (r *Recorder) GetContractsByBlock(block int64) ([]string, error) {
+	response, err := r.node.GetContractsByBlock(block)
+	return response, r.record("GetContractsByBlock", []interface{}{block}, response, err)
+}
+
+// GetNetworkConstants -
+func (r *Recorder) GetNetworkConstants(level int64) (Constants, error) {
+	response, err := r.node.GetNetworkConstants(level)
+	return response, r.record("GetNetworkConstants", []interface{}{level}, response, err)
+}
+
+// RunCode -
+func (r *Recorder) RunCode(script, storage, input []byte, chainID, source, payer, entrypoint, proto string, amount, gas int64) (RunCodeResponse, error) {
+	response, err := r.node.RunCode(script, storage, input, chainID, source, payer, entrypoint, proto, amount, gas)
+	return response, r.record("RunCode", []interface{}{script, storage, input, chainID, source, payer, entrypoint, proto, amount, gas}, response, err)
+}
+
+// TraceCode -
+func (r *Recorder) TraceCode(script, storage, input []byte, chainID, source, payer, entrypoint, proto string, amount, gas int64) (TraceCodeResponse, error) {
+	response, err := r.node.TraceCode(script, storage, input, chainID, source, payer, entrypoint, proto, amount, gas)
+	return response, r.record("TraceCode", []interface{}{script, storage, input, chainID, source, payer, entrypoint, proto, amount, gas}, response, err)
+}
+
+// RunOperation -
+func (r *Recorder) RunOperation(chainID, branch string, contents []Operation) (OperationGroup, error) {
+	response, err := r.node.RunOperation(chainID, branch, contents)
+	return response, r.record("RunOperation", []interface{}{chainID, branch, contents}, response, err)
+}
+
+// GetCounter -
+func (r *Recorder) GetCounter(address string) (int64, error) {
+	response, err := r.node.GetCounter(address)
+	return response, r.record("GetCounter", []interface{}{address}, response, err)
+}
+
+// GetCode -
+func (r *Recorder) GetCode(address string, level int64) (*ast.Script, error) {
+	response, err := r.node.GetCode(address, level)
+	return response, r.record("GetCode", []interface{}{address, level}, response, err)
+}
+
+// Replayer - INode which serves responses recorded to cassette by `Recorder`. Responses of the same request are served in order of recording,
+// the last one is repeated. Request which is absent in cassette returns `ErrNotRecorded`.
+type Replayer struct {
+	mx           sync.Mutex
+	interactions map[string][]interaction
+}
+
+// NewReplayer -
+func NewReplayer(filename string) (*Replayer, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r := &Replayer{
+		interactions: make(map[string][]interaction),
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxCassetteLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var item interaction
+		if err := stdJSON.Unmarshal(scanner.Bytes(), &item); err != nil {
+			return nil, errors.Wrapf(err, "%s:%d", filename, line)
+		}
+		key := item.key()
+		r.interactions[key] = append(r.interactions[key], item)
+	}
+	return r, scanner.Err()
+}
+
+// replay - decodes recorded response of request to `response`
+func (r *Replayer) replay(method string, args []interface{}, response interface{}) error {
+	request, err := newInteraction(method, args)
+	if err != nil {
+		return err
+	}
+	key := request.key()
+
+	r.mx.Lock()
+	queue, ok := r.interactions[key]
+	if !ok || len(queue) == 0 {
+		r.mx.Unlock()
+		return errors.Wrapf(ErrNotRecorded, "%s %s", method, request.Args)
+	}
+	item := queue[0]
+	if len(queue) > 1 {
+		r.interactions[key] = queue[1:]
+	}
+	r.mx.Unlock()
+
+	if item.NodeError != nil {
+		e := newInvalidNodeResponse()
+		e.Raw = item.NodeError
+		if err := json.Unmarshal(item.NodeError, &e.Errors); err != nil {
+			return errors.Wrap(e, err.Error())
+		}
+		return e
+	}
+	return stdJSON.Unmarshal(item.Response, response)
+}
+
+// GetHead -
+func (r *Replayer) GetHead() (response Header, err error) {
+	err = r.replay("GetHead", nil, &response)
+	return
+}
+
+// GetHeader -
+func (r *Replayer) GetHeader(level int64) (response Header, err error) {
+	err = r.replay("GetHeader", []interface{}{level}, &response)
+	return
+}
+
+// GetLevel -
+func (r *Replayer) GetLevel() (response int64, err error) {
+	err = r.replay("GetLevel", nil, &response)
+	return
+}
+
+// GetLevelTime -
+func (r *Replayer) GetLevelTime(level int) (response time.Time, err error) {
+	err = r.replay("GetLevelTime", []interface{}{level}, &response)
+	return
+}
+
+// GetScriptJSON -
+func (r *Replayer) GetScriptJSON(address string, level int64) (response Script, err error) {
+	err = r.replay("GetScriptJSON", []interface{}{address, level}, &response)
+	return
+}
+
+// GetScriptStorageRaw -
+func (r *Replayer) GetScriptStorageRaw(address string, level int64) (response []byte, err error) {
+	err = r.replay("GetScriptStorageRaw", []interface{}{address, level}, &response)
+	return
+}
+
+// GetContractBalance -
+func (r *Replayer) GetContractBalance(address string, level int64) (response int64, err error) {
+	err = r.replay("GetContractBalance", []interface{}{address, level}, &response)
+	return
+}
+
+// GetContractData -
+func (r *Replayer) GetContractData(address string, level int64) (response ContractData, err error) {
+	err = r.replay("GetContractData", []interface{}{address, level}, &response)
+	return
+}
+
+// GetBigMapValue -
+func (r *Replayer) GetBigMapValue(ptr int64, keyHash string, level int64) (response []byte, err error) {
+	err = r.replay("GetBigMapValue", []interface{}{ptr, keyHash, level}, &response)
+	return
+}
+
+// GetOPG -
+func (r *Replayer) GetOPG(block int64) (response []OperationGroup, err error) {
+	err = r.replay("GetOPG", []interface{}{block}, &response)
+	return
+}
+
+// GetContractsByBlock -
+func (r *Replayer) GetContractsByBlock(block int64) (response []string, err error) {
+	err = r.replay("GetContractsByBlock", []interface{}{block}, &response)
+	return
+}
+
+// GetNetworkConstants -
+func (r *Replayer) GetNetworkConstants(level int64) (response Constants, err error) {
+	err = r.replay("GetNetworkConstants", []interface{}{level}, &response)
+	return
+}
+
+// RunCode -
+func (r *Replayer) RunCode(script, storage, input []byte, chainID, source, payer, entrypoint, proto string, amount, gas int64) (response RunCodeResponse, err error) {
+	err = r.replay("RunCode", []interface{}{script, storage, input, chainID, source, payer, entrypoint, proto, amount, gas}, &response)
+	return
+}
+
+// TraceCode -
+func (r *Replayer) TraceCode(script, storage, input []byte, chainID, source, payer, entrypoint, proto string, amount, gas int64) (response TraceCodeResponse, err error) {
+	err = r.replay("TraceCode", []interface{}{script, storage, input, chainID, source, payer, entrypoint, proto, amount, gas}, &response)
+	return
+}
+
+// RunOperation -
+func (r *Replayer) RunOperation(chainID, branch string, contents []Operation) (response OperationGroup, err error) {
+	err = r.replay("RunOperation", []interface{}{chainID, branch, contents}, &response)
+	return
+}
+
+// GetCounter -
+func (r *Replayer) GetCounter(address string) (response int64, err error) {
+	err = r.replay("GetCounter", []interface{}{address}, &response)
+	return
+}
+
+// GetCode -
+func (r *Replayer) GetCode(address string, level int64) (*ast.Script, error) {
+	var response *ast.Script
+	err := r.replay("GetCode", []interface{}{address, level}, &response)
+	return response, err
+}
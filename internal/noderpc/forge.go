@@ -0,0 +1,577 @@
+package noderpc
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	stdJSON "encoding/json"
+
+	"github.com/baking-bad/bcdhub/internal/bcd"
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/encoding"
+	"github.com/baking-bad/bcdhub/internal/bcd/forge"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// tags of manager operations
+const (
+	tagReveal      byte = 107
+	tagTransaction byte = 108
+	tagOrigination byte = 109
+	tagDelegation  byte = 110
+)
+
+// sizes of binary fields
+const (
+	branchSize        = 32
+	signatureSize     = 64
+	keyHashSize       = 21
+	contractIDSize    = 22
+	ed25519KeySize    = 33
+	secp256k1KeySize  = 34
+	maxEntrypointSize = 31
+)
+
+// presence flags of optional fields
+const (
+	byteNone byte = 0x00
+	byteSome byte = 0xff
+)
+
+// tag of named entrypoint
+const entrypointNamed byte = 255
+
+var entrypointTags = map[string]byte{
+	consts.DefaultEntrypoint: 0,
+	"root":                   1,
+	"do":                     2,
+	"set_delegate":           3,
+	"remove_delegate":        4,
+}
+
+var tagKinds = map[byte]string{
+	tagReveal:      consts.Reveal,
+	tagTransaction: consts.Transaction,
+	tagOrigination: consts.Origination,
+	tagDelegation:  consts.Delegation,
+}
+
+// ErrUnknownOperationTag -
+var ErrUnknownOperationTag = errors.New("unknown operation tag")
+
+// ForgeOperationGroup - forges operation group to bytes which can be signed and injected. Signature is appended if it's set.
+func ForgeOperationGroup(group OperationGroup) ([]byte, error) {
+	data, err := encoding.DecodeBase58(group.Branch)
+	if err != nil {
+		return nil, errors.Wrap(err, "branch")
+	}
+	if len(data) != branchSize {
+		return nil, errors.Errorf("invalid branch: %s", group.Branch)
+	}
+
+	for i := range group.Contents {
+		content, err := ForgeOperation(group.Contents[i])
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, content...)
+	}
+
+	if group.Signature != "" {
+		signature, err := encoding.DecodeBase58(group.Signature)
+		if err != nil {
+			return nil, errors.Wrap(err, "signature")
+		}
+		if len(signature) != signatureSize {
+			return nil, errors.Errorf("invalid signature: %s", group.Signature)
+		}
+		data = append(data, signature...)
+	}
+	return data, nil
+}
+
+// UnforgeOperationGroup - decodes forged operation group. `data` may be signed or not. Hash is computed only for signed groups.
+func UnforgeOperationGroup(data []byte) (OperationGroup, error) {
+	group, err := unforgeOperationGroup(data)
+	if err == nil {
+		return group, nil
+	}
+	if len(data) < branchSize+signatureSize {
+		return group, err
+	}
+
+	group, signedErr := unforgeOperationGroup(data[:len(data)-signatureSize])
+	if signedErr != nil {
+		return group, err
+	}
+	if group.Signature, err = encoding.EncodeBase58(data[len(data)-signatureSize:], []byte(encoding.PrefixGenericSignature)); err != nil {
+		return group, err
+	}
+	hash := blake2b.Sum256(data)
+	group.Hash, err = encoding.EncodeBase58(hash[:], []byte(encoding.PrefixOperationHash))
+	return group, err
+}
+
+// UnforgeOperationGroupString -
+func UnforgeOperationGroupString(str string) (OperationGroup, error) {
+	data, err := hex.DecodeString(str)
+	if err != nil {
+		return OperationGroup{}, err
+	}
+	return UnforgeOperationGroup(data)
+}
+
+func unforgeOperationGroup(data []byte) (group OperationGroup, err error) {
+	r := &forgeReader{data: data}
+	branch, err := r.next(branchSize)
+	if err != nil {
+		return
+	}
+	if group.Branch, err = encoding.EncodeBase58(branch, []byte(encoding.PrefixBlockHash)); err != nil {
+		return
+	}
+
+	group.Contents = make([]Operation, 0)
+	for !r.done() {
+		operation, err := r.operation()
+		if err != nil {
+			return group, err
+		}
+		group.Contents = append(group.Contents, operation)
+	}
+	if len(group.Contents) == 0 {
+		return group, errors.Wrap(forge.ErrTooFewBytes, "empty operation group")
+	}
+	return group, nil
+}
+
+// ForgeOperation - forges one manager operation: reveal, transaction, origination or delegation
+func ForgeOperation(operation Operation) ([]byte, error) {
+	var tag byte
+	switch operation.Kind {
+	case consts.Reveal:
+		tag = tagReveal
+	case consts.Transaction:
+		tag = tagTransaction
+	case consts.Origination:
+		tag = tagOrigination
+	case consts.Delegation:
+		tag = tagDelegation
+	default:
+		return nil, errors.Errorf("unsupported operation kind for forging: %s", operation.Kind)
+	}
+
+	w := new(forgeWriter)
+	w.byte(tag)
+	if err := w.keyHash(operation.Source); err != nil {
+		return nil, errors.Wrap(err, "source")
+	}
+	for _, value := range []int64{operation.Fee, operation.Counter, operation.GasLimit, operation.StorageLimit} {
+		if err := w.nat(value); err != nil {
+			return nil, err
+		}
+	}
+
+	switch operation.Kind {
+	case consts.Reveal:
+		if err := w.publicKey(operation.PublicKey); err != nil {
+			return nil, errors.Wrap(err, "public_key")
+		}
+	case consts.Transaction:
+		if operation.Destination == nil {
+			return nil, errors.New("empty transaction destination")
+		}
+		if err := w.nat(getInt64(operation.Amount)); err != nil {
+			return nil, err
+		}
+		if err := w.contractID(*operation.Destination); err != nil {
+			return nil, errors.Wrap(err, "destination")
+		}
+		if err := w.parameters(operation.Parameters); err != nil {
+			return nil, errors.Wrap(err, "parameters")
+		}
+	case consts.Origination:
+		if err := w.nat(getInt64(operation.Balance)); err != nil {
+			return nil, err
+		}
+		if err := w.delegate(operation.Delegate); err != nil {
+			return nil, errors.Wrap(err, "delegate")
+		}
+		if err := w.script(operation.Script); err != nil {
+			return nil, errors.Wrap(err, "script")
+		}
+	case consts.Delegation:
+		if err := w.delegate(operation.Delegate); err != nil {
+			return nil, errors.Wrap(err, "delegate")
+		}
+	}
+	return w.data, nil
+}
+
+func getInt64(value *int64) int64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+type forgedScript struct {
+	Code    stdJSON.RawMessage `json:"code"`
+	Storage stdJSON.RawMessage `json:"storage"`
+}
+
+type forgeWriter struct {
+	data []byte
+}
+
+func (w *forgeWriter) byte(b byte) {
+	w.data = append(w.data, b)
+}
+
+func (w *forgeWriter) nat(value int64) error {
+	data, err := forge.NewNatFromInt64(value).Forge()
+	if err != nil {
+		return err
+	}
+	w.data = append(w.data, data...)
+	return nil
+}
+
+func (w *forgeWriter) keyHash(address string) error {
+	if !bcd.IsAddress(address) || bcd.IsContract(address) {
+		return errors.Wrap(consts.ErrInvalidAddress, address)
+	}
+	data, err := forge.Address(address, true)
+	if err != nil {
+		return err
+	}
+	if len(data) != keyHashSize {
+		return errors.Wrap(consts.ErrInvalidAddress, address)
+	}
+	w.data = append(w.data, data...)
+	return nil
+}
+
+func (w *forgeWriter) contractID(address string) error {
+	if !bcd.IsAddress(address) {
+		return errors.Wrap(consts.ErrInvalidAddress, address)
+	}
+	data, err := forge.Address(address, false)
+	if err != nil {
+		return err
+	}
+	w.data = append(w.data, data...)
+	return nil
+}
+
+func (w *forgeWriter) publicKey(key string) error {
+	data, err := forge.PublicKey(key)
+	if err != nil {
+		return err
+	}
+	w.data = append(w.data, data...)
+	return nil
+}
+
+func (w *forgeWriter) delegate(delegate string) error {
+	if delegate == "" {
+		w.byte(byteNone)
+		return nil
+	}
+	w.byte(byteSome)
+	return w.keyHash(delegate)
+}
+
+func (w *forgeWriter) micheline(data []byte) error {
+	var node base.Node
+	if err := json.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	forged, err := forge.Forge(&node)
+	if err != nil {
+		return err
+	}
+	w.bytes(forged)
+	return nil
+}
+
+// bytes - writes `data` with 4 bytes length prefix
+func (w *forgeWriter) bytes(data []byte) {
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(data)))
+	w.data = append(w.data, length...)
+	w.data = append(w.data, data...)
+}
+
+func (w *forgeWriter) parameters(data []byte) error {
+	if len(data) == 0 {
+		w.byte(byteNone)
+		return nil
+	}
+	params := types.NewParameters(data)
+	w.byte(byteSome)
+
+	if tag, ok := entrypointTags[params.Entrypoint]; ok {
+		w.byte(tag)
+	} else {
+		if len(params.Entrypoint) > maxEntrypointSize {
+			return errors.Errorf("too long entrypoint name: %s", params.Entrypoint)
+		}
+		w.byte(entrypointNamed)
+		w.byte(byte(len(params.Entrypoint)))
+		w.data = append(w.data, params.Entrypoint...)
+	}
+	return w.micheline(params.Value)
+}
+
+func (w *forgeWriter) script(data []byte) error {
+	var script forgedScript
+	if err := json.Unmarshal(data, &script); err != nil {
+		return err
+	}
+	if len(script.Code) == 0 || len(script.Storage) == 0 {
+		return errors.New("script must contain code and storage")
+	}
+	if err := w.micheline(script.Code); err != nil {
+		return err
+	}
+	return w.micheline(script.Storage)
+}
+
+type forgeReader struct {
+	data   []byte
+	offset int
+}
+
+func (r *forgeReader) done() bool {
+	return r.offset >= len(r.data)
+}
+
+func (r *forgeReader) next(n int) ([]byte, error) {
+	if r.offset+n > len(r.data) {
+		return nil, errors.Wrapf(forge.ErrTooFewBytes, "expected %d bytes at offset %d", n, r.offset)
+	}
+	data := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return data, nil
+}
+
+func (r *forgeReader) byte() (byte, error) {
+	data, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+	return data[0], nil
+}
+
+func (r *forgeReader) nat() (int64, error) {
+	if r.done() {
+		return 0, errors.Wrapf(forge.ErrTooFewBytes, "expected nat at offset %d", r.offset)
+	}
+	value := forge.NewNat()
+	n, err := value.Unforge(r.data[r.offset:])
+	if err != nil {
+		return 0, err
+	}
+	r.offset += n
+	if !value.IntValue.IsInt64() {
+		return 0, errors.Errorf("too big value: %s", value.IntValue.String())
+	}
+	return value.IntValue.Int64(), nil
+}
+
+func (r *forgeReader) keyHash() (string, error) {
+	data, err := r.next(keyHashSize)
+	if err != nil {
+		return "", err
+	}
+	return forge.UnforgeAddress("00" + hex.EncodeToString(data))
+}
+
+func (r *forgeReader) contractID() (string, error) {
+	data, err := r.next(contractIDSize)
+	if err != nil {
+		return "", err
+	}
+	return forge.UnforgeAddress(hex.EncodeToString(data))
+}
+
+func (r *forgeReader) publicKey() (string, error) {
+	tag, err := r.byte()
+	if err != nil {
+		return "", err
+	}
+	size := secp256k1KeySize
+	switch tag {
+	case 0:
+		size = ed25519KeySize
+	case 1, 2:
+	default:
+		return "", errors.Errorf("unknown public key tag: %d", tag)
+	}
+	data, err := r.next(size - 1)
+	if err != nil {
+		return "", err
+	}
+	return forge.UnforgePublicKey(hex.EncodeToString(append([]byte{tag}, data...)))
+}
+
+func (r *forgeReader) delegate() (string, error) {
+	flag, err := r.byte()
+	if err != nil {
+		return "", err
+	}
+	switch flag {
+	case byteNone:
+		return "", nil
+	case byteSome:
+		return r.keyHash()
+	default:
+		return "", errors.Errorf("invalid presence flag: %d", flag)
+	}
+}
+
+func (r *forgeReader) micheline() ([]byte, error) {
+	length, err := r.next(4)
+	if err != nil {
+		return nil, err
+	}
+	size := int(binary.BigEndian.Uint32(length))
+	data, err := r.next(size)
+	if err != nil {
+		return nil, err
+	}
+	unforger := forge.NewMichelson()
+	n, err := unforger.Unforge(data)
+	if err != nil {
+		return nil, err
+	}
+	if n != size || len(unforger.Nodes) != 1 {
+		return nil, errors.Errorf("invalid micheline expression: %x", data)
+	}
+	return json.Marshal(unforger.Nodes[0])
+}
+
+func (r *forgeReader) parameters() ([]byte, error) {
+	flag, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+	switch flag {
+	case byteNone:
+		return nil, nil
+	case byteSome:
+	default:
+		return nil, errors.Errorf("invalid presence flag: %d", flag)
+	}
+
+	tag, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+	var entrypoint string
+	if tag == entrypointNamed {
+		size, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		name, err := r.next(int(size))
+		if err != nil {
+			return nil, err
+		}
+		entrypoint = string(name)
+	} else {
+		for name, value := range entrypointTags {
+			if value == tag {
+				entrypoint = name
+				break
+			}
+		}
+		if entrypoint == "" {
+			return nil, errors.Errorf("unknown entrypoint tag: %d", tag)
+		}
+	}
+
+	value, err := r.micheline()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(types.Parameters{
+		Entrypoint: entrypoint,
+		Value:      value,
+	})
+}
+
+func (r *forgeReader) script() ([]byte, error) {
+	code, err := r.micheline()
+	if err != nil {
+		return nil, err
+	}
+	storage, err := r.micheline()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(forgedScript{
+		Code:    code,
+		Storage: storage,
+	})
+}
+
+func (r *forgeReader) operation() (operation Operation, err error) {
+	tag, err := r.byte()
+	if err != nil {
+		return
+	}
+	kind, ok := tagKinds[tag]
+	if !ok {
+		return operation, errors.Wrapf(ErrUnknownOperationTag, "%d", tag)
+	}
+	operation.Kind = kind
+
+	if operation.Source, err = r.keyHash(); err != nil {
+		return
+	}
+	for _, value := range []*int64{&operation.Fee, &operation.Counter, &operation.GasLimit, &operation.StorageLimit} {
+		if *value, err = r.nat(); err != nil {
+			return
+		}
+	}
+
+	switch kind {
+	case consts.Reveal:
+		operation.PublicKey, err = r.publicKey()
+	case consts.Transaction:
+		amount, err := r.nat()
+		if err != nil {
+			return operation, err
+		}
+		operation.Amount = &amount
+		destination, err := r.contractID()
+		if err != nil {
+			return operation, err
+		}
+		operation.Destination = &destination
+		operation.Parameters, err = r.parameters()
+		if err != nil {
+			return operation, err
+		}
+	case consts.Origination:
+		balance, err := r.nat()
+		if err != nil {
+			return operation, err
+		}
+		operation.Balance = &balance
+		if operation.Delegate, err = r.delegate(); err != nil {
+			return operation, err
+		}
+		operation.Script, err = r.script()
+		if err != nil {
+			return operation, err
+		}
+	case consts.Delegation:
+		operation.Delegate, err = r.delegate()
+	}
+	return
+}
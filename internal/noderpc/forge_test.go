@@ -0,0 +1,138 @@
+package noderpc
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Forged groups are operations included in mainnet and testnets: blake2b hash of forged signed bytes equals the operation hash computed by node, so bytes are the same as forged by node.
+// Delegation is built from the header of the revealed account in the first group, its tail is encoded by hand: 0xff presence flag and key hash of delegate.
+func TestForgeOperationGroup(t *testing.T) {
+	tests := []struct {
+		name  string
+		group string
+		hash  string
+		want  string
+	}{
+		{
+			name:  "reveal and transaction",
+			group: `{"branch":"BLE5pEbJ3gGR3c1qw43m6CuzN7CcZsFiuaNib93NW8ieUZPgoD7","contents":[{"kind":"reveal","source":"tz1RH7Zy2aJtxBvwCGWWvRzVToNqH7i9pLGK","fee":"1420","counter":"7062180","gas_limit":"10600","storage_limit":"0","public_key":"edpkvWXFbxKXjBaLe94duMzHkT8bZeorcP7p6ZRQ1A9YpH2qmdLgRR"},{"kind":"transaction","source":"tz1RH7Zy2aJtxBvwCGWWvRzVToNqH7i9pLGK","fee":"1385","counter":"7062181","gas_limit":"10309","storage_limit":"0","amount":"206884","destination":"tz1hkzS6pnfnHv9KzX1nbtqXVqUkzcem8FJs"}],"signature":"siguxU6TZXbodptEfaD94jAWDJqGXug9q2y9kc4td7V3GYXB8p4g68QUnLwTiQNWKHQYfQ6XhJsjABRpvsfwHxCzkrochNrc"}`,
+			hash:  "opToHHcqFhRTQWJv2oTGAtywucj9KM1nDnk5eHsEETYJyvJLsa5",
+			want:  "43aaebdd4c1edf20ef9dedd76ff29593ddda68076e79df03b45287bd9351cb4d6b003ddc1721810527d9cb1b149ce4b80a82c51b42018c0ba485af03e8520000f5e7b486d2d0eeebacc38ef37179ed49fe68a9ce21b41425372c90d54c73d7576c003ddc1721810527d9cb1b149ce4b80a82c51b4201e90aa585af03c55000a4d00c0000f2a3cb16b945b6f603feb245d4f78c5cb8dccf8f00f45ab428227d68957727019104ef02e8f377a4c3d286d0d6569afeafcfe942e00018f7a3bb4ac1cfd3f9a9dea70d17e0e1b479c1db8b91fd632536f5ec46070e",
+		}, {
+			name:  "transaction with parameters",
+			group: `{"branch":"BLYntWHzaTZFq2JS7ZoSsGB5sSK34ETXsjuVLrHPR23qu1g8XJu","contents":[{"kind":"transaction","source":"tz1djN1zPWUYpanMS1YhKJ2EmFSYs6qjf4bW","fee":"62628","counter":"554732","gas_limit":"622830","storage_limit":"154","amount":"0","destination":"KT1Dc6A6jTY9sG4UvqKciqbJNAGtXqb4n7vZ","parameters":{"entrypoint":"mint","value":{"prim":"Pair","args":[{"string":"tz1XvMBRHwmXtXS2K6XYZdmcc5kdwB9STFJu"},{"int":"8500"}]}}}],"signature":"sigYspLPxHhnLhmspGWrGdStkoUgmbT7P3UcEaygLddWdTTAFg19EMrxmsw5Y7jt89SyoA45zdKuD8S3NAtTRviniQ5xF2iT"}`,
+			hash:  "opQMNBmME834t76enxSBqhJcPqwV2R2BP2pTKv438bHaxRZen6x",
+			want:  "6e2597631cd4526478a8615dac052c0577d73e076d3cbdc16fd5410ba09439186c00c67479d5c0961a0fcac5c13a1a94b56a37236e98a4e903eced21ee81269a0100013718908e90796befd5f7e1fa7312e6acc12314e500ffff046d696e740000002f07070100000024747a3158764d425248776d58745853324b3658595a646d6363356b647742395354464a7500b484015339d1683ac92cf06f8d2519cf2229cff6dc6b53cb127aa380cc17eb8f17340d532bd9a2f37775e75a01fc47ee15484e56dab276cd715d11fc59923f6a27e20f",
+		}, {
+			name:  "origination",
+			group: `{"branch":"BKsraGbp6aJxQu893yg4WETRuJLHifLT7SdEFnUBat3RMUv1AEC","contents":[{"kind":"origination","source":"tz1SX7SPdx4ZJb6uP5Hh5XBVZhh9wTfFaud3","fee":"510","counter":"654594","gas_limit":"1870","storage_limit":"371","balance":"0","script":{"code":[{"prim":"parameter","args":[{"prim":"or","args":[{"prim":"int","annots":["%decrement"]},{"prim":"int","annots":["%increment"]}]}]},{"prim":"storage","args":[{"prim":"int"}]},{"prim":"code","args":[[{"prim":"DUP"},{"prim":"CDR"},{"prim":"SWAP"},{"prim":"CAR"},{"prim":"IF_LEFT","args":[[{"prim":"SWAP"},{"prim":"SUB"}],[{"prim":"ADD"}]]},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}],"storage":{"int":"0"}}}],"signature":"sige4egXV8rUA5jQL2pJXgioeCuF1odshcEtyR6RBVntX1SaHcEDerYMkejj8nvSfm77ALh9wcA5VkdhUHHC8ome1YW1B4LB"}`,
+			hash:  "onzUDQhwunz2yqzfEsoURXEBz9p7Gk8DgY4QBva52Z4b3AJCZjt",
+			want:  "15bca297861df2de49eae04e507121cb8e39aa594886e72ff1fa4e1920621b336d004b79ee39f09bcb2131c85ea2db4d522a10ef7a75fe0382fa27ce0ef302000000000054020000004f05000764045b0000000a2564656372656d656e74045b0000000a25696e6372656d656e740501035b0502020000002003210317034c0316072e0200000004034c034b02000000020312053d036d03420000000200007adf1dad6acd07eb1fef02045fd7855d832a65ed1f95c756ee68f762a8969b82daba75b31a6dc996b4870fc6b49e830c4dbf53adc205353366418334bdb0b80c",
+		}, {
+			name:  "delegation",
+			group: `{"branch":"BLE5pEbJ3gGR3c1qw43m6CuzN7CcZsFiuaNib93NW8ieUZPgoD7","contents":[{"kind":"delegation","source":"tz1RH7Zy2aJtxBvwCGWWvRzVToNqH7i9pLGK","fee":"1257","counter":"7062182","gas_limit":"10000","storage_limit":"0","delegate":"tz1hkzS6pnfnHv9KzX1nbtqXVqUkzcem8FJs"}]}`,
+			want:  "43aaebdd4c1edf20ef9dedd76ff29593ddda68076e79df03b45287bd9351cb4d6e003ddc1721810527d9cb1b149ce4b80a82c51b4201e909a685af03904e00ff00f2a3cb16b945b6f603feb245d4f78c5cb8dccf8f",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var group OperationGroup
+			if err := json.Unmarshal([]byte(tt.group), &group); err != nil {
+				t.Errorf("Unmarshal() error = %v", err)
+				return
+			}
+
+			forged, err := ForgeOperationGroup(group)
+			if err != nil {
+				t.Errorf("ForgeOperationGroup() error = %v", err)
+				return
+			}
+			assert.Equal(t, tt.want, hex.EncodeToString(forged))
+
+			unforged, err := UnforgeOperationGroupString(tt.want)
+			if err != nil {
+				t.Errorf("UnforgeOperationGroupString() error = %v", err)
+				return
+			}
+			assert.Equal(t, tt.hash, unforged.Hash)
+			assert.Equal(t, group.Branch, unforged.Branch)
+			assert.Equal(t, group.Signature, unforged.Signature)
+			if !assert.Len(t, unforged.Contents, len(group.Contents)) {
+				return
+			}
+			for i := range group.Contents {
+				compareForgedOperation(t, group.Contents[i], unforged.Contents[i])
+			}
+
+			if group.Signature == "" {
+				return
+			}
+			// unsigned bytes are the ones which are signed by wallet
+			group.Signature = ""
+			unsigned, err := ForgeOperationGroup(group)
+			if err != nil {
+				t.Errorf("ForgeOperationGroup() unsigned error = %v", err)
+				return
+			}
+			assert.Equal(t, tt.want[:len(tt.want)-signatureSize*2], hex.EncodeToString(unsigned))
+
+			unforged, err = UnforgeOperationGroup(unsigned)
+			if assert.NoError(t, err) {
+				assert.Empty(t, unforged.Hash)
+				assert.Empty(t, unforged.Signature)
+				assert.Len(t, unforged.Contents, len(group.Contents))
+			}
+		})
+	}
+}
+
+func compareForgedOperation(t *testing.T, want, got Operation) {
+	assert.Equal(t, want.Kind, got.Kind)
+	assert.Equal(t, want.Source, got.Source)
+	assert.Equal(t, want.Fee, got.Fee)
+	assert.Equal(t, want.Counter, got.Counter)
+	assert.Equal(t, want.GasLimit, got.GasLimit)
+	assert.Equal(t, want.StorageLimit, got.StorageLimit)
+	assert.Equal(t, want.PublicKey, got.PublicKey)
+	assert.Equal(t, want.Delegate, got.Delegate)
+	assert.Equal(t, want.Destination, got.Destination)
+	assert.Equal(t, getInt64(want.Amount), getInt64(got.Amount))
+	assert.Equal(t, getInt64(want.Balance), getInt64(got.Balance))
+	if len(want.Parameters) > 0 {
+		assert.JSONEq(t, string(want.Parameters), string(got.Parameters))
+	} else {
+		assert.Empty(t, got.Parameters)
+	}
+	if len(want.Script) > 0 {
+		assert.JSONEq(t, string(want.Script), string(got.Script))
+	} else {
+		assert.Empty(t, got.Script)
+	}
+}
+
+func TestUnforgeOperationGroup_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "empty group",
+			data: "43aaebdd4c1edf20ef9dedd76ff29593ddda68076e79df03b45287bd9351cb4d",
+		}, {
+			name: "unknown tag",
+			data: "43aaebdd4c1edf20ef9dedd76ff29593ddda68076e79df03b45287bd9351cb4d08003ddc1721810527d9cb1b149ce4b80a82c51b42",
+		}, {
+			name: "truncated operation",
+			data: "43aaebdd4c1edf20ef9dedd76ff29593ddda68076e79df03b45287bd9351cb4d6e003ddc1721810527d9cb1b149ce4b80a82c51b4201e909a685af03904e00ff00f2a3cb16b945",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnforgeOperationGroupString(tt.data)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package noderpc
+
+import (
+	"context"
+	stdJSON "encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/pkg/errors"
+)
+
+// MonitoredHead - block header announced by heads monitor. `Proto` is index of protocol, not its hash.
+type MonitoredHead struct {
+	Hash        string    `json:"hash"`
+	Level       int64     `json:"level"`
+	Proto       int64     `json:"proto"`
+	Predecessor string    `json:"predecessor"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// HeadHandler - receives heads of heads monitor stream
+type HeadHandler func(head MonitoredHead)
+
+// IHeadMonitor -
+type IHeadMonitor interface {
+	MonitorHeads(ctx context.Context, handler HeadHandler) error
+}
+
+// MonitorHeads - streams new heads of main chain to `handler`. It returns nil when node or context closes the stream, so it has to be called again.
+func (rpc *NodeRPC) MonitorHeads(ctx context.Context, handler HeadHandler) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, helpers.URLJoin(rpc.baseURL, "monitor/heads/main"), nil)
+	if err != nil {
+		return errors.Errorf("MonitorHeads.NewRequest: %v", err)
+	}
+
+	// stream has no end, so only context cancels the request
+	client := http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := rpc.checkStatusCode(resp, true); err != nil {
+		return err
+	}
+
+	// standard decoder is used because jsoniter does not return io.EOF after trailing new line of chunk
+	decoder := stdJSON.NewDecoder(resp.Body)
+	for {
+		var head MonitoredHead
+		if err := decoder.Decode(&head); err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		handler(head)
+	}
+}
+
+// MonitorHeads -
+func (p Pool) MonitorHeads(ctx context.Context, handler HeadHandler) error {
+	node, err := p.getNode()
+	if err != nil {
+		return err
+	}
+	if err := node.node.MonitorHeads(ctx, handler); err != nil {
+		if IsNodeUnavailiableError(err) {
+			node.block()
+		}
+		return err
+	}
+	return nil
+}
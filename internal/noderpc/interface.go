@@ -16,11 +16,13 @@ type INode interface {
 	GetScriptStorageRaw(string, int64) ([]byte, error)
 	GetContractBalance(string, int64) (int64, error)
 	GetContractData(string, int64) (ContractData, error)
+	GetBigMapValue(ptr int64, keyHash string, level int64) ([]byte, error)
 	GetOPG(block int64) ([]OperationGroup, error)
 	GetContractsByBlock(int64) ([]string, error)
 	GetNetworkConstants(int64) (Constants, error)
 	RunCode([]byte, []byte, []byte, string, string, string, string, string, int64, int64) (RunCodeResponse, error)
-	RunOperation(string, string, string, string, int64, int64, int64, int64, int64, []byte) (OperationGroup, error)
+	TraceCode([]byte, []byte, []byte, string, string, string, string, string, int64, int64) (TraceCodeResponse, error)
+	RunOperation(string, string, []Operation) (OperationGroup, error)
 	GetCounter(string) (int64, error)
 	GetCode(address string, level int64) (*ast.Script, error)
 }
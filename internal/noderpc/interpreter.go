@@ -0,0 +1,190 @@
+package noderpc
+
+import (
+	stdJSON "encoding/json"
+	"fmt"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/interpreter"
+	"github.com/pkg/errors"
+)
+
+// Interpreter - `INode` which executes `RunCode` with in-process Michelson interpreter.
+// If script can not be executed without node (e.g. it calls other contracts or reads block context) or interpreter runs out of gas, request is sent to the embedded node.
+// Gas consumption of interpreter is approximate, so out of gas is confirmed by node.
+type Interpreter struct {
+	INode
+
+	opts []interpreter.Option
+}
+
+// NewInterpreter - `node` may be nil. In that case unsupported scripts return error.
+func NewInterpreter(node INode, opts ...interpreter.Option) *Interpreter {
+	return &Interpreter{
+		INode: node,
+		opts:  opts,
+	}
+}
+
+// RunCode -
+func (i *Interpreter) RunCode(script, storage, input []byte, chainID, source, payer, entrypoint, proto string, amount, gas int64) (RunCodeResponse, error) {
+	result, err := i.run(script, storage, input, chainID, source, payer, entrypoint, amount, gas, i.opts...)
+	if err != nil {
+		if i.needNode(err) {
+			return i.INode.RunCode(script, storage, input, chainID, source, payer, entrypoint, proto, amount, gas)
+		}
+		return RunCodeResponse{}, newInterpreterError(err, proto)
+	}
+	return newRunCodeResponse(result)
+}
+
+// TraceCode -
+func (i *Interpreter) TraceCode(script, storage, input []byte, chainID, source, payer, entrypoint, proto string, amount, gas int64) (TraceCodeResponse, error) {
+	opts := append([]interpreter.Option{interpreter.WithTrace()}, i.opts...)
+	result, err := i.run(script, storage, input, chainID, source, payer, entrypoint, amount, gas, opts...)
+	if err != nil {
+		if i.needNode(err) {
+			return i.INode.TraceCode(script, storage, input, chainID, source, payer, entrypoint, proto, amount, gas)
+		}
+		return TraceCodeResponse{}, newInterpreterError(err, proto)
+	}
+	return newTraceCodeResponse(result)
+}
+
+// needNode - returns true if script has to be executed by node: interpreter doesn't support it or its gas estimation may differ from node's one
+func (i *Interpreter) needNode(err error) bool {
+	if i.INode == nil {
+		return false
+	}
+	return interpreter.IsUnsupported(err) || errors.Is(err, interpreter.ErrGasExhausted)
+}
+
+func (i *Interpreter) run(script, storage, input []byte, chainID, source, payer, entrypoint string, amount, gas int64, opts ...interpreter.Option) (*interpreter.Result, error) {
+	parsed, err := interpreter.ParseScript(script)
+	if err != nil {
+		return nil, err
+	}
+	var storageNode, inputNode base.Node
+	if err := json.Unmarshal(storage, &storageNode); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(input, &inputNode); err != nil {
+		return nil, err
+	}
+
+	// block context (balance, level and timestamp) isn't passed to `RunCode`, so scripts reading it are executed by node
+	opts = append([]interpreter.Option{interpreter.WithStrictContext()}, opts...)
+	vm := interpreter.New(interpreter.Context{
+		Source:   payer,
+		Sender:   source,
+		Amount:   amount,
+		ChainID:  chainID,
+		GasLimit: gas,
+	}, opts...)
+
+	return vm.Run(parsed, entrypoint, &inputNode, &storageNode)
+}
+
+func newTraceCodeResponse(result *interpreter.Result) (response TraceCodeResponse, err error) {
+	if response.RunCodeResponse, err = newRunCodeResponse(result); err != nil {
+		return
+	}
+
+	response.Trace = make([]TraceStep, len(result.Trace))
+	for j, item := range result.Trace {
+		step := TraceStep{
+			Location: item.Location,
+			Gas:      fmt.Sprintf("%d.%03d", item.Milligas/1000, item.Milligas%1000),
+			Stack:    make([]stdJSON.RawMessage, len(item.Stack)),
+		}
+		for k := range item.Stack {
+			if step.Stack[k], err = json.Marshal(item.Stack[k].Value); err != nil {
+				return
+			}
+		}
+		response.Trace[j] = step
+	}
+	return
+}
+
+func newRunCodeResponse(result *interpreter.Result) (response RunCodeResponse, err error) {
+	if response.Storage, err = json.Marshal(result.Storage); err != nil {
+		return
+	}
+
+	response.Operations = make([]Operation, len(result.Operations))
+	for j, op := range result.Operations {
+		operation := Operation{
+			Kind:   op.Kind,
+			Source: interpreter.DefaultSelf,
+		}
+		switch op.Kind {
+		case consts.Transaction:
+			destination, amount := op.Destination, op.Amount
+			operation.Destination = &destination
+			operation.Amount = &amount
+			parameters := map[string]interface{}{
+				"entrypoint": op.Entrypoint,
+				"value":      op.Parameters,
+			}
+			if operation.Parameters, err = json.Marshal(parameters); err != nil {
+				return
+			}
+		case consts.Delegation:
+			if op.Delegate != nil {
+				operation.Delegate = *op.Delegate
+			}
+		}
+		response.Operations[j] = operation
+	}
+
+	response.BigMapDiffs = make([]BigMapDiff, len(result.BigMapDiffs))
+	for j, diff := range result.BigMapDiffs {
+		ptr := diff.Ptr
+		bmd := BigMapDiff{
+			Action:  "update",
+			BigMap:  &ptr,
+			KeyHash: diff.KeyHash,
+		}
+		if bmd.Key, err = json.Marshal(diff.Key); err != nil {
+			return
+		}
+		if diff.Value != nil {
+			if bmd.Value, err = json.Marshal(diff.Value); err != nil {
+				return
+			}
+		}
+		response.BigMapDiffs[j] = bmd
+	}
+	return
+}
+
+// newInterpreterError - converts runtime errors of interpreter to the node format
+func newInterpreterError(err error, proto string) error {
+	nodeError := map[string]interface{}{
+		"kind": "temporary",
+	}
+	var rejected interpreter.ScriptRejectedError
+	switch {
+	case errors.As(err, &rejected):
+		nodeError["id"] = fmt.Sprintf("proto.%s.%s", proto, consts.ScriptRejectedError)
+		nodeError["location"] = rejected.Location
+		nodeError["with"] = rejected.With
+	case errors.Is(err, interpreter.ErrGasExhausted):
+		nodeError["id"] = fmt.Sprintf("proto.%s.%s", proto, consts.GasExhaustedError)
+	default:
+		return err
+	}
+
+	raw, marshalErr := json.Marshal([]interface{}{nodeError})
+	if marshalErr != nil {
+		return err
+	}
+	response := newInvalidNodeResponse()
+	response.Raw = raw
+	response.Errors = append(response.Errors, RunCodeError{
+		ID: nodeError["id"].(string),
+	})
+	return response
+}
@@ -0,0 +1,30 @@
+package noderpc
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpreter_RunCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	node := NewMockINode(ctrl)
+
+	local := []byte(`[{"prim":"parameter","args":[{"prim":"nat"}]},{"prim":"storage","args":[{"prim":"nat"}]},{"prim":"code","args":[[{"prim":"CAR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`)
+	response, err := NewInterpreter(node).RunCode(local, []byte(`{"int":"0"}`), []byte(`{"int":"5"}`), "", "", "", "default", "", 0, 1040000)
+	if assert.NoError(t, err, "script has to be executed without node") {
+		assert.JSONEq(t, `{"int":"5"}`, string(response.Storage))
+	}
+
+	balance := []byte(`[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"mutez"}]},{"prim":"code","args":[[{"prim":"DROP"},{"prim":"BALANCE"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`)
+	node.EXPECT().
+		RunCode(balance, gomock.Any(), gomock.Any(), "", "", "", "default", "", int64(0), int64(1040000)).
+		Return(RunCodeResponse{Storage: []byte(`{"int":"100"}`)}, nil).
+		Times(1)
+	response, err = NewInterpreter(node).RunCode(balance, []byte(`{"int":"0"}`), []byte(`{"prim":"Unit"}`), "", "", "", "default", "", 0, 1040000)
+	if assert.NoError(t, err, "script reading block context has to be executed by node") {
+		assert.JSONEq(t, `{"int":"100"}`, string(response.Storage))
+	}
+}
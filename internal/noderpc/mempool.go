@@ -0,0 +1,96 @@
+package noderpc
+
+import (
+	"context"
+	stdJSON "encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/pkg/errors"
+)
+
+// Mempool statuses of operations. They are names of `monitor_operations` query parameters.
+const (
+	MempoolApplied       = "applied"
+	MempoolRefused       = "refused"
+	MempoolBranchRefused = "branch_refused"
+	MempoolBranchDelayed = "branch_delayed"
+)
+
+// MempoolStatuses - all statuses which can be monitored
+var MempoolStatuses = []string{MempoolApplied, MempoolRefused, MempoolBranchRefused, MempoolBranchDelayed}
+
+// MempoolOperation - operation group received from mempool monitor. `Error` is set for not applied operations.
+type MempoolOperation struct {
+	Protocol  string             `json:"protocol"`
+	Hash      string             `json:"hash"`
+	Branch    string             `json:"branch"`
+	Signature string             `json:"signature"`
+	Contents  []Operation        `json:"contents"`
+	Error     stdJSON.RawMessage `json:"error,omitempty"`
+}
+
+// MempoolHandler - receives chunks of mempool monitor stream
+type MempoolHandler func(operations []MempoolOperation)
+
+// IMempoolMonitor -
+type IMempoolMonitor interface {
+	MonitorMempool(ctx context.Context, status string, handler MempoolHandler) error
+}
+
+// MonitorMempool - streams mempool operations with `status` to `handler`. Node closes the stream when new head is applied, so it returns nil and has to be called again.
+func (rpc *NodeRPC) MonitorMempool(ctx context.Context, status string, handler MempoolHandler) error {
+	query := make(url.Values)
+	for _, s := range MempoolStatuses {
+		query.Set(s, fmt.Sprintf("%t", s == status))
+	}
+	link := fmt.Sprintf("%s?%s", helpers.URLJoin(rpc.baseURL, "chains/main/mempool/monitor_operations"), query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return errors.Errorf("MonitorMempool.NewRequest: %v", err)
+	}
+
+	// stream is alive while head is not changed, so only context cancels the request
+	client := http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := rpc.checkStatusCode(resp, true); err != nil {
+		return err
+	}
+
+	// standard decoder is used because jsoniter does not return io.EOF after trailing new line of chunk
+	decoder := stdJSON.NewDecoder(resp.Body)
+	for {
+		var operations []MempoolOperation
+		if err := decoder.Decode(&operations); err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		handler(operations)
+	}
+}
+
+// MonitorMempool -
+func (p Pool) MonitorMempool(ctx context.Context, status string, handler MempoolHandler) error {
+	node, err := p.getNode()
+	if err != nil {
+		return err
+	}
+	if err := node.node.MonitorMempool(ctx, status, handler); err != nil {
+		if IsNodeUnavailiableError(err) {
+			node.block()
+		}
+		return err
+	}
+	return nil
+}
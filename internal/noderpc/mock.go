@@ -154,6 +154,21 @@ func (mr *MockINodeMockRecorder) GetContractData(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractData", reflect.TypeOf((*MockINode)(nil).GetContractData), arg0, arg1)
 }
 
+// GetBigMapValue mocks base method
+func (m *MockINode) GetBigMapValue(ptr int64, keyHash string, level int64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBigMapValue", ptr, keyHash, level)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBigMapValue indicates an expected call of GetBigMapValue
+func (mr *MockINodeMockRecorder) GetBigMapValue(ptr, keyHash, level interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBigMapValue", reflect.TypeOf((*MockINode)(nil).GetBigMapValue), ptr, keyHash, level)
+}
+
 // GetOPG mocks base method
 func (m *MockINode) GetOPG(block int64) ([]OperationGroup, error) {
 	m.ctrl.T.Helper()
@@ -214,19 +229,34 @@ func (mr *MockINodeMockRecorder) RunCode(arg0, arg1, arg2, arg3, arg4, arg5, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunCode", reflect.TypeOf((*MockINode)(nil).RunCode), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
 }
 
+// TraceCode mocks base method
+func (m *MockINode) TraceCode(arg0, arg1, arg2 []byte, arg3, arg4, arg5, arg6, arg7 string, arg8, arg9 int64) (TraceCodeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TraceCode", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
+	ret0, _ := ret[0].(TraceCodeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TraceCode indicates an expected call of TraceCode
+func (mr *MockINodeMockRecorder) TraceCode(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceCode", reflect.TypeOf((*MockINode)(nil).TraceCode), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
+}
+
 // RunOperation mocks base method
-func (m *MockINode) RunOperation(arg0, arg1, arg2, arg3 string, arg4, arg5, arg6, arg7, arg8 int64, arg9 []byte) (OperationGroup, error) {
+func (m *MockINode) RunOperation(arg0, arg1 string, arg2 []Operation) (OperationGroup, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RunOperation", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
+	ret := m.ctrl.Call(m, "RunOperation", arg0, arg1, arg2)
 	ret0, _ := ret[0].(OperationGroup)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RunOperation indicates an expected call of RunOperation
-func (mr *MockINodeMockRecorder) RunOperation(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9 interface{}) *gomock.Call {
+func (mr *MockINodeMockRecorder) RunOperation(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunOperation", reflect.TypeOf((*MockINode)(nil).RunOperation), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunOperation", reflect.TypeOf((*MockINode)(nil).RunOperation), arg0, arg1, arg2)
 }
 
 // GetCounter mocks base method
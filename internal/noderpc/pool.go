@@ -182,6 +182,15 @@ func (p Pool) GetContractData(address string, level int64) (ContractData, error)
 	return data.Interface().(ContractData), nil
 }
 
+// GetBigMapValue -
+func (p Pool) GetBigMapValue(ptr int64, keyHash string, level int64) ([]byte, error) {
+	data, err := p.call("GetBigMapValue", ptr, keyHash, level)
+	if err != nil {
+		return nil, err
+	}
+	return data.Interface().([]byte), nil
+}
+
 // GetOPG -
 func (p Pool) GetOPG(block int64) ([]OperationGroup, error) {
 	data, err := p.call("GetOPG", block)
@@ -218,9 +227,18 @@ func (p Pool) RunCode(script, storage, input []byte, chainID, source, payer, ent
 	return data.Interface().(RunCodeResponse), nil
 }
 
+// TraceCode -
+func (p Pool) TraceCode(script, storage, input []byte, chainID, source, payer, entrypoint, proto string, amount, gas int64) (TraceCodeResponse, error) {
+	data, err := p.call("TraceCode", script, storage, input, chainID, source, payer, entrypoint, proto, amount, gas)
+	if err != nil {
+		return TraceCodeResponse{}, err
+	}
+	return data.Interface().(TraceCodeResponse), nil
+}
+
 // RunOperation -
-func (p Pool) RunOperation(chainID, branch, source, destination string, fee, gasLimit, storageLimit, counter, amount int64, parameters []byte) (OperationGroup, error) {
-	data, err := p.call("RunOperation", chainID, branch, source, destination, fee, gasLimit, storageLimit, counter, amount, parameters)
+func (p Pool) RunOperation(chainID, branch string, contents []Operation) (OperationGroup, error) {
+	data, err := p.call("RunOperation", chainID, branch, contents)
 	if err != nil {
 		return OperationGroup{}, err
 	}
@@ -21,19 +21,7 @@ type runOperationRequest struct {
 }
 
 type runOperationItem struct {
-	Branch    string                    `json:"branch"`
-	Signature string                    `json:"signature"`
-	Contents  []runOperationItemContent `json:"contents"`
-}
-
-type runOperationItemContent struct {
-	Kind         string             `json:"kind"`
-	Fee          int64              `json:"fee,string"`
-	Counter      int64              `json:"counter,string"`
-	GasLimit     int64              `json:"gas_limit,string"`
-	StorageLimit int64              `json:"storage_limit"`
-	Source       string             `json:"source"`
-	Destination  string             `json:"destination"`
-	Amount       int64              `json:"amount,string"`
-	Parameters   stdJSON.RawMessage `json:"parameters"`
+	Branch    string      `json:"branch"`
+	Signature string      `json:"signature"`
+	Contents  []Operation `json:"contents"`
 }
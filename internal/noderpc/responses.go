@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/pkg/errors"
 )
 
 // Header is a header in a block returned by the Tezos RPC API.
@@ -38,6 +39,15 @@ func (slice *Int64StringSlice) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON -
+func (slice Int64StringSlice) MarshalJSON() ([]byte, error) {
+	s := make([]string, len(slice))
+	for i := range slice {
+		s[i] = strconv.FormatInt(slice[i], 10)
+	}
+	return json.Marshal(s)
+}
+
 // Constants -
 type Constants struct {
 	CostPerByte                  int64            `json:"cost_per_byte,string"`
@@ -108,6 +118,7 @@ type Script struct {
 
 // OperationMetadata -
 type OperationMetadata struct {
+	BalanceUpdates     []BalanceUpdate  `json:"balance_updates,omitempty"`
 	OperationResult    *OperationResult `json:"operation_result,omitempty"`
 	Internal           []Operation      `json:"internal_operation_results,omitempty"`
 	InternalOperations []Operation      `json:"internal_operations,omitempty"`
@@ -124,9 +135,77 @@ type OperationResult struct {
 	PaidStorageSizeDiff          *int64             `json:"paid_storage_size_diff,omitempty,string"`
 	AllocatedDestinationContract *bool              `json:"allocated_destination_contract,omitempty"`
 	BigMapDiffs                  []BigMapDiff       `json:"big_map_diff,omitempty"`
+	BalanceUpdates               []BalanceUpdate    `json:"balance_updates,omitempty"`
+	LazyStorageDiff              []LazyStorageDiff  `json:"lazy_storage_diff,omitempty"`
 	Errors                       stdJSON.RawMessage `json:"errors,omitempty"`
 }
 
+// BalanceUpdate - change of account balance or of frozen deposits, fees and rewards of delegate
+type BalanceUpdate struct {
+	Kind     string `json:"kind"`
+	Contract string `json:"contract,omitempty"`
+	Category string `json:"category,omitempty"`
+	Delegate string `json:"delegate,omitempty"`
+	Change   int64  `json:"change,string"`
+}
+
+// LazyStorageDiff -
+type LazyStorageDiff struct {
+	Kind string             `json:"kind"`
+	ID   int64              `json:"id,string"`
+	Diff stdJSON.RawMessage `json:"diff"`
+}
+
+// SaplingStateDiff - `diff` field of lazy storage diff with `sapling_state` kind
+type SaplingStateDiff struct {
+	Action   string         `json:"action"`
+	Source   *int64         `json:"source,omitempty,string"`
+	MemoSize *int64         `json:"memo_size,omitempty"`
+	Updates  SaplingUpdates `json:"updates"`
+}
+
+// SaplingUpdates -
+type SaplingUpdates struct {
+	CommitmentsAndCiphertexts []CommitmentAndCiphertext `json:"commitments_and_ciphertexts"`
+	Nullifiers                []string                  `json:"nullifiers"`
+}
+
+// CommitmentAndCiphertext - pair of commitment and ciphertext of sapling output
+type CommitmentAndCiphertext struct {
+	Commitment string
+	Ciphertext Ciphertext
+}
+
+// UnmarshalJSON -
+func (cc *CommitmentAndCiphertext) UnmarshalJSON(data []byte) error {
+	var pair []stdJSON.RawMessage
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return errors.Errorf("invalid commitment and ciphertext pair length: %d", len(pair))
+	}
+	if err := json.Unmarshal(pair[0], &cc.Commitment); err != nil {
+		return err
+	}
+	return json.Unmarshal(pair[1], &cc.Ciphertext)
+}
+
+// MarshalJSON -
+func (cc CommitmentAndCiphertext) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{cc.Commitment, cc.Ciphertext})
+}
+
+// Ciphertext - encrypted sapling output
+type Ciphertext struct {
+	CV         string `json:"cv"`
+	EPK        string `json:"epk"`
+	PayloadEnc string `json:"payload_enc"`
+	NonceEnc   string `json:"nonce_enc"`
+	PayloadOut string `json:"payload_out"`
+	NonceOut   string `json:"nonce_out"`
+}
+
 // BigMapDiff -
 type BigMapDiff struct {
 	Action       string             `json:"action"`
@@ -147,6 +226,19 @@ type RunCodeResponse struct {
 	BigMapDiffs []BigMapDiff       `json:"big_map_diff,omitempty"`
 }
 
+// TraceCodeResponse -
+type TraceCodeResponse struct {
+	RunCodeResponse
+	Trace []TraceStep `json:"trace"`
+}
+
+// TraceStep - state of interpreter after execution of instruction at `Location`. `Gas` is remaining gas.
+type TraceStep struct {
+	Location int64                `json:"location"`
+	Gas      string               `json:"gas"`
+	Stack    []stdJSON.RawMessage `json:"stack"`
+}
+
 // RunCodeError -
 type RunCodeError struct {
 	ID string `json:"id"`
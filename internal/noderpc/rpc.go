@@ -7,11 +7,13 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/ast"
 	"github.com/baking-bad/bcdhub/internal/helpers"
 	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/monitoring"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 )
@@ -102,6 +104,9 @@ func (rpc *NodeRPC) makeRequest(req *http.Request) (*http.Response, error) {
 		Timeout: rpc.timeout,
 	}
 
+	start := time.Now()
+	endpoint := endpointPattern(strings.TrimPrefix(req.URL.String(), rpc.baseURL))
+
 	count := 0
 	for ; count < rpc.retryCount; count++ {
 		resp, err := client.Do(req)
@@ -109,12 +114,37 @@ func (rpc *NodeRPC) makeRequest(req *http.Request) (*http.Response, error) {
 			logger.Warning("Attempt #%d: %s", count+1, err.Error())
 			continue
 		}
+		monitoring.ObserveRPCRequest(rpc.baseURL, endpoint, time.Since(start), resp.StatusCode >= http.StatusInternalServerError)
 		return resp, err
 	}
 
+	monitoring.ObserveRPCRequest(rpc.baseURL, endpoint, time.Since(start), true)
 	return nil, NewMaxRetryExceededError(rpc.baseURL)
 }
 
+// endpointPattern - replaces block, contract address, big map pointer and key hash of RPC path by placeholders, so count of endpoints is finite
+func endpointPattern(path string) string {
+	if idx := strings.IndexByte(path, '?'); idx >= 0 {
+		path = path[:idx]
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 1; i < len(parts); i++ {
+		switch parts[i-1] {
+		case "blocks":
+			parts[i] = "{block}"
+		case "contracts":
+			parts[i] = "{address}"
+		case "big_maps":
+			parts[i] = "{ptr}"
+			if i+1 < len(parts) {
+				i++
+				parts[i] = "{key_hash}"
+			}
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
 func (rpc *NodeRPC) makeGetRequest(uri string) (*http.Response, error) {
 	url := helpers.URLJoin(rpc.baseURL, uri)
 	req, err := http.NewRequest(http.MethodGet, url, nil)
@@ -225,6 +255,25 @@ func (rpc *NodeRPC) GetContractData(address string, level int64) (ContractData,
 	return response, err
 }
 
+// GetBigMapValue - returns value of big map `ptr` by `keyHash` at `level`. If key is absent nil is returned.
+func (rpc *NodeRPC) GetBigMapValue(ptr int64, keyHash string, level int64) ([]byte, error) {
+	resp, err := rpc.makeGetRequest(fmt.Sprintf("chains/main/blocks/%s/context/big_maps/%d/%s", getBlockString(level), ptr, keyHash))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	var response stdJSON.RawMessage
+	if err := rpc.parseResponse(resp, true, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 // GetOPG -
 func (rpc *NodeRPC) GetOPG(block int64) (group []OperationGroup, err error) {
 	err = rpc.get(fmt.Sprintf("chains/main/blocks/%s/operations/3", getBlockString(block)), &group)
@@ -251,6 +300,19 @@ func (rpc *NodeRPC) GetNetworkConstants(level int64) (constants Constants, err e
 
 // RunCode -
 func (rpc *NodeRPC) RunCode(script, storage, input []byte, chainID, source, payer, entrypoint, proto string, amount, gas int64) (response RunCodeResponse, err error) {
+	request := newRunCodeRequest(script, storage, input, chainID, source, payer, entrypoint, amount, gas)
+	err = rpc.post("chains/main/blocks/head/helpers/scripts/run_code", request, true, &response)
+	return
+}
+
+// TraceCode - the same as `RunCode` but response contains execution trace: remaining gas and stack after each instruction.
+func (rpc *NodeRPC) TraceCode(script, storage, input []byte, chainID, source, payer, entrypoint, proto string, amount, gas int64) (response TraceCodeResponse, err error) {
+	request := newRunCodeRequest(script, storage, input, chainID, source, payer, entrypoint, amount, gas)
+	err = rpc.post("chains/main/blocks/head/helpers/scripts/trace_code", request, true, &response)
+	return
+}
+
+func newRunCodeRequest(script, storage, input []byte, chainID, source, payer, entrypoint string, amount, gas int64) runCodeRequest {
 	request := runCodeRequest{
 		Script:  script,
 		Storage: storage,
@@ -274,31 +336,17 @@ func (rpc *NodeRPC) RunCode(script, storage, input []byte, chainID, source, paye
 	if entrypoint != "" {
 		request.Entrypoint = entrypoint
 	}
-
-	err = rpc.post("chains/main/blocks/head/helpers/scripts/run_code", request, true, &response)
-	return
+	return request
 }
 
-// RunOperation -
-func (rpc *NodeRPC) RunOperation(chainID, branch, source, destination string, fee, gasLimit, storageLimit, counter, amount int64, parameters []byte) (group OperationGroup, err error) {
+// RunOperation - simulates operation group with `contents` without signature checking
+func (rpc *NodeRPC) RunOperation(chainID, branch string, contents []Operation) (group OperationGroup, err error) {
 	request := runOperationRequest{
 		ChainID: chainID,
 		Operation: runOperationItem{
 			Branch:    branch,
 			Signature: "sigUHx32f9wesZ1n2BWpixXz4AQaZggEtchaQNHYGRCoWNAXx45WGW2ua3apUUUAGMLPwAU41QoaFCzVSL61VaessLg4YbbP", // base58_encode(b'0' * 64, b'sig').decode()
-			Contents: []runOperationItemContent{
-				{
-					Kind:         "transaction",
-					Fee:          fee,
-					Counter:      counter,
-					GasLimit:     gasLimit,
-					StorageLimit: storageLimit,
-					Source:       source,
-					Destination:  destination,
-					Amount:       amount,
-					Parameters:   parameters,
-				},
-			},
+			Contents:  contents,
 		},
 	}
 
@@ -0,0 +1,51 @@
+package noderpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_endpointPattern(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{
+			path: "chains/main/blocks/head/header",
+			want: "chains/main/blocks/{block}/header",
+		}, {
+			path: "/chains/main/blocks/1234567/operations",
+			want: "chains/main/blocks/{block}/operations",
+		}, {
+			path: "chains/main/blocks/BLockGenesisGenesisGenesisGenesisGenesisf79b5d1CoW2",
+			want: "chains/main/blocks/{block}",
+		}, {
+			path: "chains/main/blocks/100/context/contracts/KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9/script",
+			want: "chains/main/blocks/{block}/context/contracts/{address}/script",
+		}, {
+			path: "chains/main/blocks/head/context/contracts/tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6/counter",
+			want: "chains/main/blocks/{block}/context/contracts/{address}/counter",
+		}, {
+			path: "chains/main/blocks/head/context/big_maps/17/exprtiRSZkLKYRess9GZ3ryb4cVQD36WLo6rE3FyGJKSn7pFmzsYkT",
+			want: "chains/main/blocks/{block}/context/big_maps/{ptr}/{key_hash}",
+		}, {
+			path: "chains/main/blocks/head/context/big_maps/17",
+			want: "chains/main/blocks/{block}/context/big_maps/{ptr}",
+		}, {
+			path: "chains/main/blocks/head/context/constants?level=10",
+			want: "chains/main/blocks/{block}/context/constants",
+		}, {
+			path: "chains/main/blocks/head/helpers/scripts/run_operation",
+			want: "chains/main/blocks/{block}/helpers/scripts/run_operation",
+		}, {
+			path: "monitor/heads/main",
+			want: "monitor/heads/main",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, endpointPattern(tt.path))
+		})
+	}
+}
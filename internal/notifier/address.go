@@ -0,0 +1,91 @@
+package notifier
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// forbiddenNetworks - private, shared, loopback and link-local networks. Cloud metadata services live in 169.254.0.0/16.
+var forbiddenNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for i := range cidrs {
+		_, network, err := net.ParseCIDR(cidrs[i])
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// IsPublicIP - returns false if webhook must not be sent to `ip`: it's private, loopback, link-local, multicast or unspecified address.
+func IsPublicIP(ip net.IP) bool {
+	if ip == nil || ip.IsUnspecified() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return false
+	}
+	for i := range forbiddenNetworks {
+		if forbiddenNetworks[i].Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateURL - checks that webhook URL is HTTP(S) URL which host resolves to public addresses only
+func ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.Errorf("invalid webhook URL scheme: %s", u.Scheme)
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return errors.New("empty webhook host")
+	}
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return errors.Errorf("forbidden webhook host: %s", host)
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return checkIP(host, ip)
+	}
+
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return errors.Errorf("can't resolve webhook host %s: %s", host, err)
+	}
+	for i := range ips {
+		if err := checkIP(host, ips[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkIP(host string, ip net.IP) error {
+	if !IsPublicIP(ip) {
+		return errors.Errorf("forbidden webhook host %s: %s is not a public address", host, ip)
+	}
+	return nil
+}
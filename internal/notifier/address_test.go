@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "https://1.1.1.1/hook"},
+		{url: "http://[2606:4700:4700::1111]:8080/hook"},
+		{url: "ftp://1.1.1.1/hook", wantErr: true},
+		{url: "http://localhost:8080/hook", wantErr: true},
+		{url: "http://api.localhost/hook", wantErr: true},
+		{url: "http://127.0.0.1/hook", wantErr: true},
+		{url: "http://0.0.0.0/hook", wantErr: true},
+		{url: "http://10.1.2.3/hook", wantErr: true},
+		{url: "http://172.20.0.5/hook", wantErr: true},
+		{url: "http://192.168.1.1/hook", wantErr: true},
+		{url: "http://100.64.0.1/hook", wantErr: true},
+		{url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{url: "http://[::1]/hook", wantErr: true},
+		{url: "http://[fd00::1]/hook", wantErr: true},
+		{url: "http://[fe80::1]/hook", wantErr: true},
+		{url: "http://[::ffff:127.0.0.1]/hook", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := ValidateURL(tt.url)
+			assert.Equal(t, tt.wantErr, err != nil, "ValidateURL() error = %v", err)
+		})
+	}
+}
+
+func TestSender_Send(t *testing.T) {
+	var redirected bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/internal" {
+			redirected = true
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.Redirect(w, r, "/internal", http.StatusFound)
+	}))
+	defer server.Close()
+
+	request := Request{
+		URL:     server.URL + "/hook",
+		Secret:  "secret",
+		Event:   "invoke",
+		Payload: []byte(`{}`),
+	}
+
+	_, err := NewSender(time.Second).Send(request)
+	assert.Error(t, err, "loopback address has to be refused on dial")
+
+	sender := NewSender(time.Second)
+	sender.allowPrivate = true
+	code, err := sender.Send(request)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusFound, code)
+	assert.False(t, redirected, "redirect must not be followed")
+}
@@ -0,0 +1,103 @@
+package notifier
+
+import (
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/tezerrors"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/migration"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+)
+
+// OperationEvent - body of operation events. It has the same format as events of `/profile/subscriptions/events`.
+type OperationEvent struct {
+	Network          string    `json:"network"`
+	Hash             string    `json:"hash"`
+	Internal         bool      `json:"internal"`
+	Status           string    `json:"status"`
+	Timestamp        time.Time `json:"timestamp"`
+	Level            int64     `json:"level"`
+	Kind             string    `json:"kind"`
+	Fee              int64     `json:"fee,omitempty"`
+	Amount           int64     `json:"amount,omitempty"`
+	Entrypoint       string    `json:"entrypoint,omitempty"`
+	Source           string    `json:"source"`
+	SourceAlias      string    `json:"source_alias,omitempty"`
+	Destination      string    `json:"destination,omitempty"`
+	DestinationAlias string    `json:"destination_alias,omitempty"`
+	Delegate         string    `json:"delegate,omitempty"`
+	DelegateAlias    string    `json:"delegate_alias,omitempty"`
+
+	Errors []*tezerrors.Error `json:"errors,omitempty"`
+	Burned int64              `json:"burned,omitempty"`
+}
+
+// NewOperationEvent -
+func NewOperationEvent(op operation.Operation) *OperationEvent {
+	return &OperationEvent{
+		Network:          op.Network,
+		Hash:             op.Hash,
+		Internal:         op.Internal,
+		Status:           op.Status,
+		Timestamp:        op.Timestamp.UTC(),
+		Level:            op.Level,
+		Kind:             op.Kind,
+		Fee:              op.Fee,
+		Amount:           op.Amount,
+		Entrypoint:       op.Entrypoint,
+		Source:           op.Source,
+		SourceAlias:      op.SourceAlias,
+		Destination:      op.Destination,
+		DestinationAlias: op.DestinationAlias,
+		Delegate:         op.Delegate,
+		DelegateAlias:    op.DelegateAlias,
+		Errors:           op.Errors,
+		Burned:           op.Burned,
+	}
+}
+
+// MigrationEvent -
+type MigrationEvent struct {
+	Network      string    `json:"network"`
+	Protocol     string    `json:"protocol"`
+	PrevProtocol string    `json:"prev_protocol,omitempty"`
+	Hash         string    `json:"hash,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+	Level        int64     `json:"level"`
+	Address      string    `json:"address"`
+	Kind         string    `json:"kind"`
+}
+
+// NewMigrationEvent -
+func NewMigrationEvent(m migration.Migration) *MigrationEvent {
+	return &MigrationEvent{
+		Network:      m.Network,
+		Protocol:     m.Protocol,
+		PrevProtocol: m.PrevProtocol,
+		Hash:         m.Hash,
+		Timestamp:    m.Timestamp.UTC(),
+		Level:        m.Level,
+		Address:      m.Address,
+		Kind:         m.Kind,
+	}
+}
+
+// ContractEvent -
+type ContractEvent struct {
+	Network   string    `json:"network"`
+	Address   string    `json:"address"`
+	Hash      string    `json:"hash"`
+	ProjectID string    `json:"project_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewContractEvent -
+func NewContractEvent(c contract.Contract) *ContractEvent {
+	return &ContractEvent{
+		Network:   c.Network,
+		Address:   c.Address,
+		Hash:      c.Hash,
+		ProjectID: c.ProjectID,
+		Timestamp: c.Timestamp.UTC(),
+	}
+}
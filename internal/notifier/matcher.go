@@ -0,0 +1,83 @@
+package notifier
+
+import (
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/bcd"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/database"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/migration"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+)
+
+// Subscription - subscription with webhook. `Hash` and `ProjectID` are filled for contract subscriptions and used by `WatchSame` and `WatchSimilar` flags.
+type Subscription struct {
+	database.Subscription
+
+	Hash      string
+	ProjectID string
+}
+
+func (s Subscription) watch(flag uint) bool {
+	return s.WatchMask&flag != 0
+}
+
+// isWatched - implicit accounts are matched by source of operations, contracts by destination. It's the same rule as in events API.
+func (s Subscription) isWatched(op operation.Operation) bool {
+	if strings.HasPrefix(s.Address, "tz") {
+		return op.Source == s.Address
+	}
+	return op.Destination == s.Address
+}
+
+// MatchOperation - returns event type if operation matches the subscription
+func MatchOperation(sub Subscription, op operation.Operation) (string, bool) {
+	if sub.Network != op.Network {
+		return "", false
+	}
+
+	switch {
+	case sub.watch(database.WatchErrors) && op.Status != consts.Applied && sub.isWatched(op):
+		return models.EventTypeError, true
+	case sub.watch(database.WatchDeployments) && op.Kind == consts.Origination && op.Source == sub.Address:
+		return models.EventTypeDeploy, true
+	case sub.watch(database.WatchCalls) && op.Kind == consts.Transaction && op.Status == consts.Applied && sub.isWatched(op):
+		if op.Source == sub.Address {
+			return models.EventTypeCall, true
+		}
+		return models.EventTypeInvoke, true
+	}
+	return "", false
+}
+
+// MatchMigration - returns event type if migration matches the subscription
+func MatchMigration(sub Subscription, m migration.Migration) (string, bool) {
+	if !sub.watch(database.WatchMigrations) || !bcd.IsContract(sub.Address) {
+		return "", false
+	}
+	if sub.Network != m.Network || sub.Address != m.Address {
+		return "", false
+	}
+	switch m.Kind {
+	case consts.MigrationBootstrap, consts.MigrationLambda, consts.MigrationUpdate:
+		return models.EventTypeMigration, true
+	}
+	return "", false
+}
+
+// MatchContract - returns event type if new contract is the same or similar to the subscribed one
+func MatchContract(sub Subscription, c contract.Contract) (string, bool) {
+	if !bcd.IsContract(sub.Address) || c.Address == sub.Address {
+		return "", false
+	}
+
+	switch {
+	case sub.watch(database.WatchSame) && sub.Hash != "" && c.Hash == sub.Hash:
+		return models.EventTypeSame, true
+	case sub.watch(database.WatchSimilar) && sub.ProjectID != "" && c.ProjectID == sub.ProjectID && c.Hash != sub.Hash:
+		return models.EventTypeSimilar, true
+	}
+	return "", false
+}
@@ -0,0 +1,135 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/database"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/migration"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testContract = "KT1Hkg5qeNhfwpKW4fXvq7HGZB9z2EnmCCA9"
+	testAccount  = "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6"
+)
+
+func newTestSubscription(address string, mask uint) Subscription {
+	return Subscription{
+		Subscription: database.Subscription{
+			Address:   address,
+			Network:   consts.Mainnet,
+			WatchMask: mask,
+		},
+		Hash:      "hash",
+		ProjectID: "project",
+	}
+}
+
+func TestMatchOperation(t *testing.T) {
+	tests := []struct {
+		name   string
+		sub    Subscription
+		op     operation.Operation
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "contract is called",
+			sub:    newTestSubscription(testContract, database.WatchCalls),
+			op:     operation.Operation{Network: consts.Mainnet, Kind: consts.Transaction, Status: consts.Applied, Source: testAccount, Destination: testContract},
+			want:   models.EventTypeInvoke,
+			wantOk: true,
+		}, {
+			name:   "account calls",
+			sub:    newTestSubscription(testAccount, database.WatchCalls),
+			op:     operation.Operation{Network: consts.Mainnet, Kind: consts.Transaction, Status: consts.Applied, Source: testAccount, Destination: testContract},
+			want:   models.EventTypeCall,
+			wantOk: true,
+		}, {
+			name: "calls are not watched",
+			sub:  newTestSubscription(testContract, database.WatchDeployments),
+			op:   operation.Operation{Network: consts.Mainnet, Kind: consts.Transaction, Status: consts.Applied, Source: testAccount, Destination: testContract},
+		}, {
+			name: "another network",
+			sub:  newTestSubscription(testContract, database.WatchCalls),
+			op:   operation.Operation{Network: "edo2net", Kind: consts.Transaction, Status: consts.Applied, Source: testAccount, Destination: testContract},
+		}, {
+			name:   "failed call",
+			sub:    newTestSubscription(testContract, database.WatchCalls|database.WatchErrors),
+			op:     operation.Operation{Network: consts.Mainnet, Kind: consts.Transaction, Status: consts.Failed, Source: testAccount, Destination: testContract},
+			want:   models.EventTypeError,
+			wantOk: true,
+		}, {
+			name: "failed call without errors flag",
+			sub:  newTestSubscription(testContract, database.WatchCalls),
+			op:   operation.Operation{Network: consts.Mainnet, Kind: consts.Transaction, Status: consts.Failed, Source: testAccount, Destination: testContract},
+		}, {
+			name:   "deployment",
+			sub:    newTestSubscription(testAccount, database.WatchDeployments),
+			op:     operation.Operation{Network: consts.Mainnet, Kind: consts.Origination, Status: consts.Applied, Source: testAccount, Destination: testContract},
+			want:   models.EventTypeDeploy,
+			wantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MatchOperation(tt.sub, tt.op)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatchMigration(t *testing.T) {
+	m := migration.Migration{Network: consts.Mainnet, Address: testContract, Kind: consts.MigrationUpdate}
+
+	typ, ok := MatchMigration(newTestSubscription(testContract, database.WatchMigrations), m)
+	assert.True(t, ok)
+	assert.Equal(t, models.EventTypeMigration, typ)
+
+	_, ok = MatchMigration(newTestSubscription(testContract, database.WatchCalls), m)
+	assert.False(t, ok)
+}
+
+func TestMatchContract(t *testing.T) {
+	tests := []struct {
+		name     string
+		sub      Subscription
+		contract contract.Contract
+		want     string
+		wantOk   bool
+	}{
+		{
+			name:     "same",
+			sub:      newTestSubscription(testContract, database.WatchSame|database.WatchSimilar),
+			contract: contract.Contract{Network: "edo2net", Address: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", Hash: "hash", ProjectID: "project"},
+			want:     models.EventTypeSame,
+			wantOk:   true,
+		}, {
+			name:     "similar",
+			sub:      newTestSubscription(testContract, database.WatchSimilar),
+			contract: contract.Contract{Network: consts.Mainnet, Address: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", Hash: "another", ProjectID: "project"},
+			want:     models.EventTypeSimilar,
+			wantOk:   true,
+		}, {
+			name:     "same without flag",
+			sub:      newTestSubscription(testContract, database.WatchSimilar),
+			contract: contract.Contract{Network: consts.Mainnet, Address: "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn", Hash: "hash", ProjectID: "project"},
+		}, {
+			name:     "subscribed contract itself",
+			sub:      newTestSubscription(testContract, database.WatchSame),
+			contract: contract.Contract{Network: consts.Mainnet, Address: testContract, Hash: "hash", ProjectID: "project"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MatchContract(tt.sub, tt.contract)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
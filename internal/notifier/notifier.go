@@ -0,0 +1,307 @@
+package notifier
+
+import (
+	"sync"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd"
+	"github.com/baking-bad/bcdhub/internal/database"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/migration"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/jinzhu/gorm"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+const (
+	defaultMaxAttempts      = 8
+	defaultBackoff          = 30 * time.Second
+	defaultMaxBackoff       = 6 * time.Hour
+	defaultTimeout          = 10 * time.Second
+	defaultSubscriptionsTTL = 30 * time.Second
+	defaultWorkers          = 4
+
+	processBatchSize = 100
+)
+
+// Notifier - matches indexed operations, migrations and contracts against subscriptions with webhooks and delivers events to them
+type Notifier struct {
+	db      database.DB
+	storage models.GeneralRepository
+	sender  *Sender
+
+	maxAttempts      uint
+	backoff          time.Duration
+	maxBackoff       time.Duration
+	subscriptionsTTL time.Duration
+	workers          int
+
+	subscriptions []Subscription
+	loadedAt      time.Time
+	mx            sync.Mutex
+}
+
+// NewNotifier -
+func NewNotifier(db database.DB, storage models.GeneralRepository, opts ...NotifierOption) *Notifier {
+	n := &Notifier{
+		db:               db,
+		storage:          storage,
+		sender:           NewSender(defaultTimeout),
+		maxAttempts:      defaultMaxAttempts,
+		backoff:          defaultBackoff,
+		maxBackoff:       defaultMaxBackoff,
+		subscriptionsTTL: defaultSubscriptionsTTL,
+		workers:          defaultWorkers,
+	}
+	for i := range opts {
+		opts[i](n)
+	}
+	return n
+}
+
+// Operations - creates deliveries for operations matched subscriptions
+func (n *Notifier) Operations(operations []operation.Operation) error {
+	subscriptions, err := n.getSubscriptions()
+	if err != nil {
+		return err
+	}
+	for i := range operations {
+		for j := range subscriptions {
+			if typ, ok := MatchOperation(subscriptions[j], operations[i]); ok {
+				if err := n.notify(subscriptions[j], typ, NewOperationEvent(operations[i])); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Migrations - creates deliveries for migrations matched subscriptions
+func (n *Notifier) Migrations(migrations []migration.Migration) error {
+	subscriptions, err := n.getSubscriptions()
+	if err != nil {
+		return err
+	}
+	for i := range migrations {
+		for j := range subscriptions {
+			if typ, ok := MatchMigration(subscriptions[j], migrations[i]); ok {
+				if err := n.notify(subscriptions[j], typ, NewMigrationEvent(migrations[i])); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Contracts - creates deliveries for new contracts which are the same or similar to subscribed ones
+func (n *Notifier) Contracts(contracts []contract.Contract) error {
+	subscriptions, err := n.getSubscriptions()
+	if err != nil {
+		return err
+	}
+	for i := range contracts {
+		for j := range subscriptions {
+			if typ, ok := MatchContract(subscriptions[j], contracts[i]); ok {
+				if err := n.notify(subscriptions[j], typ, NewContractEvent(contracts[i])); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Process - sends pending deliveries which attempt time has come. Deliveries are sent by `workers` concurrent senders. When `stop` is closed the rest of deliveries is left for the next call.
+func (n *Notifier) Process(stop <-chan struct{}) error {
+	for {
+		select {
+		case <-stop:
+			return nil
+		default:
+		}
+
+		deliveries, err := n.db.GetPendingWebhookDeliveries(time.Now().UTC(), processBatchSize)
+		if err != nil {
+			return err
+		}
+		if err := n.deliverBatch(deliveries, stop); err != nil {
+			return err
+		}
+		if len(deliveries) < processBatchSize {
+			return nil
+		}
+	}
+}
+
+// deliverBatch - sends `deliveries` with worker pool and returns the first error
+func (n *Notifier) deliverBatch(deliveries []database.WebhookDelivery, stop <-chan struct{}) error {
+	queue := make(chan *database.WebhookDelivery)
+	errs := make(chan error, n.workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range queue {
+				if err := n.Deliver(delivery); err != nil {
+					select {
+					case errs <- err:
+					default:
+					}
+				}
+			}
+		}()
+	}
+
+send:
+	for i := range deliveries {
+		select {
+		case <-stop:
+			break send
+		case queue <- &deliveries[i]:
+		}
+	}
+	close(queue)
+	wg.Wait()
+	close(errs)
+
+	return <-errs
+}
+
+// Deliver - makes one attempt to send the delivery and saves its result. If attempt is failed the next one is scheduled with exponential backoff.
+func (n *Notifier) Deliver(delivery *database.WebhookDelivery) error {
+	now := time.Now().UTC()
+
+	sub, err := n.db.GetSubscription(delivery.UserID, delivery.Address, delivery.Network)
+	switch {
+	case gorm.IsRecordNotFoundError(err):
+		delivery.Status = database.WebhookStatusFailed
+		delivery.LastError = "subscription was removed"
+		return n.db.UpdateWebhookDelivery(delivery)
+	case err != nil:
+		return err
+	case sub.WebhookURL == "":
+		delivery.Status = database.WebhookStatusFailed
+		delivery.LastError = "webhook was disabled"
+		return n.db.UpdateWebhookDelivery(delivery)
+	}
+
+	delivery.URL = sub.WebhookURL
+	delivery.Attempts++
+
+	code, err := n.sender.Send(Request{
+		URL:        sub.WebhookURL,
+		Secret:     sub.WebhookSecret,
+		Event:      delivery.EventType,
+		DeliveryID: delivery.ID,
+		Payload:    []byte(delivery.Payload),
+	})
+	delivery.ResponseCode = code
+
+	if err == nil {
+		delivery.Status = database.WebhookStatusSuccess
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	} else {
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= n.maxAttempts {
+			delivery.Status = database.WebhookStatusFailed
+		} else {
+			delivery.NextAttemptAt = now.Add(n.delay(delivery.Attempts))
+		}
+	}
+	return n.db.UpdateWebhookDelivery(delivery)
+}
+
+// NewReplay - returns new pending delivery with the same payload. It's sent on the next `Process` call.
+func NewReplay(delivery database.WebhookDelivery) database.WebhookDelivery {
+	return database.WebhookDelivery{
+		UserID:         delivery.UserID,
+		SubscriptionID: delivery.SubscriptionID,
+		URL:            delivery.URL,
+		EventType:      delivery.EventType,
+		Address:        delivery.Address,
+		Network:        delivery.Network,
+		Payload:        delivery.Payload,
+		Status:         database.WebhookStatusPending,
+		NextAttemptAt:  time.Now().UTC(),
+	}
+}
+
+func (n *Notifier) delay(attempts uint) time.Duration {
+	delay := n.backoff
+	for i := uint(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= n.maxBackoff {
+			return n.maxBackoff
+		}
+	}
+	return delay
+}
+
+func (n *Notifier) notify(sub Subscription, typ string, body interface{}) error {
+	payload, err := json.Marshal(models.Event{
+		Type:    typ,
+		Address: sub.Address,
+		Network: sub.Network,
+		Alias:   sub.Alias,
+		Body:    body,
+	})
+	if err != nil {
+		return err
+	}
+
+	return n.db.CreateWebhookDelivery(&database.WebhookDelivery{
+		UserID:         sub.UserID,
+		SubscriptionID: sub.ID,
+		URL:            sub.WebhookURL,
+		EventType:      typ,
+		Address:        sub.Address,
+		Network:        sub.Network,
+		Payload:        string(payload),
+		Status:         database.WebhookStatusPending,
+		NextAttemptAt:  time.Now().UTC(),
+	})
+}
+
+func (n *Notifier) getSubscriptions() ([]Subscription, error) {
+	n.mx.Lock()
+	defer n.mx.Unlock()
+
+	if n.subscriptions != nil && time.Since(n.loadedAt) < n.subscriptionsTTL {
+		return n.subscriptions, nil
+	}
+
+	subs, err := n.db.ListWebhookSubscriptions()
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]Subscription, len(subs))
+	for i := range subs {
+		subscriptions[i].Subscription = subs[i]
+
+		if !bcd.IsContract(subs[i].Address) || subs[i].WatchMask&(database.WatchSame|database.WatchSimilar) == 0 {
+			continue
+		}
+		c := contract.NewEmptyContract(subs[i].Network, subs[i].Address)
+		if err := n.storage.GetByID(&c); err != nil {
+			if n.storage.IsRecordNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		subscriptions[i].Hash = c.Hash
+		subscriptions[i].ProjectID = c.ProjectID
+	}
+
+	n.subscriptions = subscriptions
+	n.loadedAt = time.Now()
+	return subscriptions, nil
+}
@@ -0,0 +1,149 @@
+package notifier
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/database"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifier_Deliver(t *testing.T) {
+	const secret = "secret"
+
+	var fail bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		if err != nil || !Verify(secret, timestamp, body, r.Header.Get(HeaderSignature)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sub := database.Subscription{
+		UserID:        1,
+		Address:       testContract,
+		Network:       consts.Mainnet,
+		WebhookURL:    server.URL,
+		WebhookSecret: secret,
+	}
+
+	tests := []struct {
+		name         string
+		fail         bool
+		attempts     uint
+		wantStatus   string
+		wantAttempts uint
+		wantDelay    time.Duration
+	}{
+		{
+			name:         "success",
+			wantStatus:   database.WebhookStatusSuccess,
+			wantAttempts: 1,
+		}, {
+			name:         "retry with backoff",
+			fail:         true,
+			attempts:     2,
+			wantStatus:   database.WebhookStatusPending,
+			wantAttempts: 3,
+			wantDelay:    4 * time.Minute,
+		}, {
+			name:         "last attempt",
+			fail:         true,
+			attempts:     4,
+			wantStatus:   database.WebhookStatusFailed,
+			wantAttempts: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db := database.NewMockDB(ctrl)
+			db.EXPECT().GetSubscription(sub.UserID, sub.Address, sub.Network).Return(sub, nil).Times(1)
+			db.EXPECT().UpdateWebhookDelivery(gomock.Any()).Return(nil).Times(1)
+
+			n := NewNotifier(db, nil, WithMaxAttempts(5), WithBackoff(time.Minute, time.Hour), WithTimeout(time.Second))
+			n.sender.allowPrivate = true
+			fail = tt.fail
+
+			delivery := database.WebhookDelivery{
+				ID:        1,
+				UserID:    sub.UserID,
+				Address:   sub.Address,
+				Network:   sub.Network,
+				EventType: "invoke",
+				Payload:   `{"type":"invoke"}`,
+				Status:    database.WebhookStatusPending,
+				Attempts:  tt.attempts,
+			}
+			start := time.Now().UTC()
+			if err := n.Deliver(&delivery); err != nil {
+				t.Fatalf("Deliver() error = %v", err)
+			}
+			assert.Equal(t, tt.wantStatus, delivery.Status)
+			assert.Equal(t, tt.wantAttempts, delivery.Attempts)
+			if tt.wantDelay > 0 {
+				assert.WithinDuration(t, start.Add(tt.wantDelay), delivery.NextAttemptAt, 5*time.Second)
+			}
+		})
+	}
+}
+
+func TestNotifier_delay(t *testing.T) {
+	n := NewNotifier(nil, nil, WithBackoff(time.Second, 10*time.Second))
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, delay := range want {
+		assert.Equal(t, delay, n.delay(uint(i+1)), "attempt %d", i+1)
+	}
+}
+
+func TestNotifier_Process(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deliveries := make([]database.WebhookDelivery, 3)
+	for i := range deliveries {
+		deliveries[i] = database.WebhookDelivery{
+			ID:      uint(i + 1),
+			UserID:  1,
+			Address: testContract,
+			Network: consts.Mainnet,
+			Status:  database.WebhookStatusPending,
+		}
+	}
+
+	db := database.NewMockDB(ctrl)
+	db.EXPECT().GetPendingWebhookDeliveries(gomock.Any(), uint(processBatchSize)).Return(deliveries, nil).Times(1)
+	db.EXPECT().GetSubscription(uint(1), testContract, consts.Mainnet).Return(database.Subscription{}, nil).Times(len(deliveries))
+	db.EXPECT().UpdateWebhookDelivery(gomock.Any()).Return(nil).Times(len(deliveries))
+
+	n := NewNotifier(db, nil, WithWorkers(2))
+	if err := n.Process(make(chan struct{})); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	stop := make(chan struct{})
+	close(stop)
+	if err := n.Process(stop); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package notifier
+
+import "time"
+
+// NotifierOption -
+type NotifierOption func(n *Notifier)
+
+// WithMaxAttempts - sets count of attempts after which delivery is marked as failed
+func WithMaxAttempts(attempts uint) NotifierOption {
+	return func(n *Notifier) {
+		if attempts > 0 {
+			n.maxAttempts = attempts
+		}
+	}
+}
+
+// WithBackoff - sets delay before the second attempt. Every next delay is doubled until `max`.
+func WithBackoff(base, max time.Duration) NotifierOption {
+	return func(n *Notifier) {
+		if base > 0 {
+			n.backoff = base
+		}
+		if max >= n.backoff {
+			n.maxBackoff = max
+		}
+	}
+}
+
+// WithTimeout - sets timeout of webhook request
+func WithTimeout(timeout time.Duration) NotifierOption {
+	return func(n *Notifier) {
+		if timeout > 0 {
+			n.sender = NewSender(timeout)
+		}
+	}
+}
+
+// WithSubscriptionsTTL - sets how long subscriptions are cached
+func WithSubscriptionsTTL(ttl time.Duration) NotifierOption {
+	return func(n *Notifier) {
+		n.subscriptionsTTL = ttl
+	}
+}
+
+// WithWorkers - sets count of deliveries which are sent concurrently
+func WithWorkers(workers int) NotifierOption {
+	return func(n *Notifier) {
+		if workers > 0 {
+			n.workers = workers
+		}
+	}
+}
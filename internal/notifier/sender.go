@@ -0,0 +1,145 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Webhook request headers
+const (
+	HeaderEvent     = "X-BCD-Event"
+	HeaderDelivery  = "X-BCD-Delivery"
+	HeaderTimestamp = "X-BCD-Timestamp"
+	HeaderSignature = "X-BCD-Signature"
+)
+
+const (
+	signaturePrefix  = "sha256="
+	secretLength     = 32
+	maxResponseBytes = 1024
+)
+
+// Sign - returns signature of webhook request. It's HMAC-SHA256 of `{timestamp}.{payload}` with subscription secret.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify - checks signature of webhook request
+func Verify(secret string, timestamp int64, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, payload)), []byte(signature))
+}
+
+// GenerateSecret - returns random secret for signing of webhook requests
+func GenerateSecret() (string, error) {
+	buf := make([]byte, secretLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Request -
+type Request struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID uint
+	Payload    []byte
+}
+
+// Sender - sends signed webhook requests. Requests are sent only to public addresses and redirects aren't followed.
+type Sender struct {
+	client *http.Client
+	dialer *net.Dialer
+
+	allowPrivate bool
+}
+
+// NewSender -
+func NewSender(timeout time.Duration) *Sender {
+	s := &Sender{
+		dialer: &net.Dialer{
+			Timeout: timeout,
+		},
+	}
+	s.client = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         s.dialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return s
+}
+
+// dialContext - resolves webhook host and dials checked address, so DNS record can't be changed to private address after subscription is saved
+func (s *Sender) dialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, errors.Errorf("can't resolve webhook host %s", host)
+	}
+	if !s.allowPrivate {
+		for i := range addrs {
+			if err := checkIP(host, addrs[i].IP); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s.dialer.DialContext(ctx, network, net.JoinHostPort(addrs[0].IP.String(), port))
+}
+
+// Send - posts payload to webhook. Returns response status code. Any status except 2xx is an error.
+func (s *Sender) Send(req Request) (int, error) {
+	httpReq, err := http.NewRequest(http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "BCD-Webhook")
+	httpReq.Header.Set(HeaderEvent, req.Event)
+	httpReq.Header.Set(HeaderDelivery, fmt.Sprintf("%d", req.DeliveryID))
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Payload))
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+		return resp.StatusCode, errors.Errorf("invalid status code %d: %s", resp.StatusCode, string(body))
+	}
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxResponseBytes))
+	return resp.StatusCode, nil
+}
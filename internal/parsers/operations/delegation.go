@@ -0,0 +1,97 @@
+package operations
+
+import (
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd"
+	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+)
+
+// Delegation -
+type Delegation struct {
+	*ParseParams
+}
+
+// NewDelegation -
+func NewDelegation(params *ParseParams) Delegation {
+	return Delegation{params}
+}
+
+// Parse -
+func (p Delegation) Parse(data noderpc.Operation) ([]models.Model, error) {
+	delegation := operation.Operation{
+		ID:           helpers.GenerateID(),
+		Network:      p.network,
+		Hash:         p.hash,
+		Protocol:     p.head.Protocol,
+		Level:        p.head.Level,
+		Timestamp:    p.head.Timestamp,
+		Kind:         data.Kind,
+		Initiator:    data.Source,
+		Source:       data.Source,
+		Fee:          data.Fee,
+		Counter:      data.Counter,
+		GasLimit:     data.GasLimit,
+		StorageLimit: data.StorageLimit,
+		Delegate:     data.Delegate,
+		Nonce:        data.Nonce,
+		IndexedTime:  time.Now().UnixNano() / 1000,
+		ContentIndex: p.contentIdx,
+	}
+
+	p.fillInternal(&delegation)
+
+	result := parseOperationResult(&data)
+	delegation.Result = result
+	delegation.Status = delegation.Result.Status
+	delegation.Errors = delegation.Result.Errors
+	delegation.BalanceUpdates = parseBalanceUpdates(&data)
+
+	p.stackTrace.Add(delegation)
+
+	delegationModels := []models.Model{&delegation}
+	if delegation.IsApplied() && bcd.IsContract(delegation.Source) {
+		c, err := p.updateDelegate(delegation)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			delegationModels = append(delegationModels, c)
+		}
+	}
+	return delegationModels, nil
+}
+
+// updateDelegate - sets new delegate of source contract. Empty delegate means that delegation is withdrawn.
+func (p Delegation) updateDelegate(delegation operation.Operation) (*contract.Contract, error) {
+	c := contract.NewEmptyContract(delegation.Network, delegation.Source)
+	if err := p.Storage.GetByID(&c); err != nil {
+		if p.Storage.IsRecordNotFound(err) {
+			logger.With(&delegation).Warningf("Delegation of unknown contract %s", delegation.Source)
+			return nil, nil
+		}
+		return nil, err
+	}
+	c.Delegate = delegation.Delegate
+	c.DelegateAlias = ""
+	return &c, nil
+}
+
+func (p Delegation) fillInternal(op *operation.Operation) {
+	if p.main == nil {
+		p.main = op
+		return
+	}
+
+	op.Counter = p.main.Counter
+	op.Hash = p.main.Hash
+	op.Level = p.main.Level
+	op.Timestamp = p.main.Timestamp
+	op.Internal = true
+	op.Initiator = p.main.Source
+}
@@ -27,6 +27,14 @@ func (opg Group) Parse(data noderpc.OperationGroup) ([]models.Model, error) {
 	opg.hash = data.Hash
 	helpers.SetTagSentry("hash", opg.hash)
 
+	opg.withReveals = false
+	for i := range data.Contents {
+		if isContractOperation(data.Contents[i]) {
+			opg.withReveals = true
+			break
+		}
+	}
+
 	for idx, item := range data.Contents {
 		opg.contentIdx = int64(idx)
 
@@ -73,6 +81,18 @@ func (content Content) Parse(data noderpc.Operation) ([]models.Model, error) {
 			return nil, err
 		}
 		models = append(models, txModels...)
+	case consts.Delegation:
+		delegationModels, err := NewDelegation(content.ParseParams).Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, delegationModels...)
+	case consts.Reveal:
+		revealModels, err := NewReveal(content.ParseParams).Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, revealModels...)
 	default:
 		return nil, errors.Errorf("Invalid operation kind: %s", data.Kind)
 	}
@@ -87,6 +107,17 @@ func (content Content) Parse(data noderpc.Operation) ([]models.Model, error) {
 }
 
 func (content Content) needParse(item noderpc.Operation) bool {
+	if item.Kind == consts.Reveal {
+		return content.withReveals
+	}
+	if item.Kind == consts.Transaction && content.implicitTransactions {
+		return true
+	}
+	return isContractOperation(item)
+}
+
+// isContractOperation - returns true if operation is related to smart contract: contract call, origination or contract delegation
+func isContractOperation(item noderpc.Operation) bool {
 	var destination string
 	if item.Destination != nil {
 		destination = *item.Destination
@@ -94,7 +125,8 @@ func (content Content) needParse(item noderpc.Operation) bool {
 	prefixCondition := bcd.IsContract(item.Source) || bcd.IsContract(destination)
 	transactionCondition := item.Kind == consts.Transaction && prefixCondition
 	originationCondition := (item.Kind == consts.Origination || item.Kind == consts.OriginationNew) && item.Script != nil
-	return originationCondition || transactionCondition
+	delegationCondition := item.Kind == consts.Delegation && bcd.IsContract(item.Source)
+	return originationCondition || transactionCondition || delegationCondition
 }
 
 func (content Content) parseInternal(data noderpc.Operation) ([]models.Model, error) {
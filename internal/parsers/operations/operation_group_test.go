@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/baking-bad/bcdhub/internal/models"
 	"github.com/baking-bad/bcdhub/internal/models/bigmapaction"
 	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
@@ -246,7 +247,7 @@ func TestGroup_Parse(t *testing.T) {
 					Level:        1068669,
 					From:         "tz1aSPEN4RTZbn4aXEsxDiix38dDmacGQ8sq",
 					To:           "tz1invbJv3AEm55ct7QF2dVbWZuaDekssYkV",
-					TokenID:      0,
+					TokenID:      types.NewBigInt(0),
 					AmountBigInt: big.NewInt(8010000),
 					Counter:      5791164,
 				},
@@ -430,7 +431,7 @@ func TestGroup_Parse(t *testing.T) {
 					Level:        1151495,
 					From:         "KT1Ap287P1NzsnToSJdA4aqSNjPomRaHBZSr",
 					To:           "tz1dMH7tW7RhdvVMR4wKVFF1Ke8m8ZDvrTTE",
-					TokenID:      0,
+					TokenID:      types.NewBigInt(0),
 					AmountBigInt: big.NewInt(7.87488e+06),
 					Counter:      6909186,
 					Nonce:        setInt64(0),
@@ -704,6 +705,106 @@ func TestGroup_Parse(t *testing.T) {
 					Timestamp:      timestamp,
 				},
 			},
+		}, {
+			name: "ooW1ighb3QEpRD1uBy8nWfbiRvABiW7Gx9efFRBHRAnSNXifFpv",
+			ParseParams: NewParseParams(
+				rpc, generalRepo, bmdRepo, blockRepo, tzipRepo, tbRepo,
+				WithHead(noderpc.Header{
+					Timestamp: timestamp,
+					Protocol:  "Pt24m4xiPbLDhVgVfABUjirbmda3yohdN82Sp1FeuXXKCDZvkMrQx",
+					Level:     500000,
+					ChainID:   "NetXdQprcVkpaWU",
+				}),
+				WithNetwork("mainnet"),
+			),
+			filename: "./data/rpc/opg/ooW1ighb3QEpRD1uBy8nWfbiRvABiW7Gx9efFRBHRAnSNXifFpv.json",
+			want: []models.Model{
+				&operation.Operation{
+					Kind:         "reveal",
+					Source:       "tz1XNLsyF82czupUrFqCyntVk73Gb22DUuwX",
+					Initiator:    "tz1XNLsyF82czupUrFqCyntVk73Gb22DUuwX",
+					PublicKey:    "edpkuTE7osNJjiucAgvWCLM1wm4bJbu5ErBzQHCY7KoG8Yr9JCJEgE",
+					Fee:          1269,
+					Counter:      1905381,
+					GasLimit:     10000,
+					Status:       "applied",
+					Level:        500000,
+					Network:      "mainnet",
+					Hash:         "ooW1ighb3QEpRD1uBy8nWfbiRvABiW7Gx9efFRBHRAnSNXifFpv",
+					Timestamp:    timestamp,
+					Protocol:     "Pt24m4xiPbLDhVgVfABUjirbmda3yohdN82Sp1FeuXXKCDZvkMrQx",
+					ContentIndex: 0,
+					BalanceUpdates: []operation.BalanceUpdate{
+						{Kind: "contract", Contract: "tz1XNLsyF82czupUrFqCyntVk73Gb22DUuwX", Change: -1269},
+						{Kind: "freezer", Category: "fees", Delegate: "tz1gN21vaBsKKiVkx9rP3X71e3bREcRbuCaZ", Change: 1269},
+					},
+				},
+				&operation.Operation{
+					Kind:         "delegation",
+					Source:       "KT1TcYEiZWyyc37ALcUtWZozVzQMxrnmwSLG",
+					Initiator:    "KT1TcYEiZWyyc37ALcUtWZozVzQMxrnmwSLG",
+					Delegate:     "tz1iYJL945gUbxWZdN5ox5yx81gSgbQPJo9W",
+					Fee:          1300,
+					Counter:      1905382,
+					GasLimit:     10100,
+					Status:       "applied",
+					Level:        500000,
+					Network:      "mainnet",
+					Hash:         "ooW1ighb3QEpRD1uBy8nWfbiRvABiW7Gx9efFRBHRAnSNXifFpv",
+					Timestamp:    timestamp,
+					Protocol:     "Pt24m4xiPbLDhVgVfABUjirbmda3yohdN82Sp1FeuXXKCDZvkMrQx",
+					ContentIndex: 1,
+					BalanceUpdates: []operation.BalanceUpdate{
+						{Kind: "contract", Contract: "KT1TcYEiZWyyc37ALcUtWZozVzQMxrnmwSLG", Change: -1300},
+						{Kind: "freezer", Category: "fees", Delegate: "tz1gN21vaBsKKiVkx9rP3X71e3bREcRbuCaZ", Change: 1300},
+					},
+				},
+				&modelContract.Contract{
+					Network:   "mainnet",
+					Level:     312456,
+					Timestamp: time.Date(2019, 2, 14, 9, 12, 47, 0, time.UTC),
+					Language:  "unknown",
+					Hash:      "4b2a81d3d2d3ba0fba4f9e0b0dbba1d2c2b5e54e22f3d3a2a3d6c3f0bb7e07c1ba0b6e5a5cd3e8a1cfb1a1e48b0a7e0c3c0d2e3b1c2a7b6e4e2d0c1f2b3a4d5e",
+					Address:   "KT1TcYEiZWyyc37ALcUtWZozVzQMxrnmwSLG",
+					Manager:   "tz1XNLsyF82czupUrFqCyntVk73Gb22DUuwX",
+					Delegate:  "tz1iYJL945gUbxWZdN5ox5yx81gSgbQPJo9W",
+					TxCount:   12,
+				},
+			},
+		}, {
+			name: "ooECrP32c6aMGx3AtgzrLfhXoGMUkUNpQeCs5qg4BDm38nfQy31",
+			ParseParams: NewParseParams(
+				rpc, generalRepo, bmdRepo, blockRepo, tzipRepo, tbRepo,
+				WithHead(noderpc.Header{
+					Timestamp: timestamp,
+					Protocol:  "Pt24m4xiPbLDhVgVfABUjirbmda3yohdN82Sp1FeuXXKCDZvkMrQx",
+					Level:     500001,
+					ChainID:   "NetXdQprcVkpaWU",
+				}),
+				WithNetwork("mainnet"),
+			),
+			filename: "./data/rpc/opg/ooECrP32c6aMGx3AtgzrLfhXoGMUkUNpQeCs5qg4BDm38nfQy31.json",
+			want: []models.Model{
+				&operation.Operation{
+					Kind:      "delegation",
+					Source:    "KT1TcYEiZWyyc37ALcUtWZozVzQMxrnmwSLG",
+					Initiator: "KT1TcYEiZWyyc37ALcUtWZozVzQMxrnmwSLG",
+					Delegate:  "tz1ezZZEEXccVdzdWX7ENDGsUxfpyEpA7TMt",
+					Fee:       1300,
+					Counter:   1905383,
+					GasLimit:  10100,
+					Status:    "failed",
+					Level:     500001,
+					Network:   "mainnet",
+					Hash:      "ooECrP32c6aMGx3AtgzrLfhXoGMUkUNpQeCs5qg4BDm38nfQy31",
+					Timestamp: timestamp,
+					Protocol:  "Pt24m4xiPbLDhVgVfABUjirbmda3yohdN82Sp1FeuXXKCDZvkMrQx",
+					BalanceUpdates: []operation.BalanceUpdate{
+						{Kind: "contract", Contract: "KT1TcYEiZWyyc37ALcUtWZozVzQMxrnmwSLG", Change: -1300},
+						{Kind: "freezer", Category: "fees", Delegate: "tz1gN21vaBsKKiVkx9rP3X71e3bREcRbuCaZ", Change: 1300},
+					},
+				},
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -737,3 +838,49 @@ func TestGroup_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestContent_needParse(t *testing.T) {
+	contract := "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"
+	account := "tz1eLWfccL46VAUjtyz9kEKgzuKnwyZH4rTA"
+	tests := []struct {
+		name        string
+		item        noderpc.Operation
+		withReveals bool
+		want        bool
+	}{
+		{
+			name: "transaction to contract",
+			item: noderpc.Operation{Kind: consts.Transaction, Source: account, Destination: &contract},
+			want: true,
+		}, {
+			name: "transaction between accounts",
+			item: noderpc.Operation{Kind: consts.Transaction, Source: account, Destination: &account},
+			want: false,
+		}, {
+			name: "contract delegation",
+			item: noderpc.Operation{Kind: consts.Delegation, Source: contract, Delegate: account},
+			want: true,
+		}, {
+			name: "account delegation",
+			item: noderpc.Operation{Kind: consts.Delegation, Source: account, Delegate: account},
+			want: false,
+		}, {
+			name:        "reveal in contract group",
+			item:        noderpc.Operation{Kind: consts.Reveal, Source: account},
+			withReveals: true,
+			want:        true,
+		}, {
+			name: "reveal without contract operations",
+			item: noderpc.Operation{Kind: consts.Reveal, Source: account},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := NewContent(&ParseParams{withReveals: tt.withReveals})
+			if got := content.needParse(tt.item); got != tt.want {
+				t.Errorf("needParse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
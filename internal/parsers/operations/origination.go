@@ -95,7 +95,21 @@ func (p Origination) appliedHandler(item noderpc.Operation, origination *operati
 	if !rs.Empty {
 		origination.DeffatedStorage = rs.DeffatedStorage
 		models = append(models, rs.Models...)
+
+		if p.ticketParser != nil {
+			tickets, err := p.ticketParser.Parse(origination, rs.Models)
+			if err != nil {
+				return nil, err
+			}
+			models = append(models, tickets...)
+		}
+	}
+
+	saplingDiffs, err := NewSapling().Parse(item, origination)
+	if err != nil {
+		return nil, err
 	}
+	models = append(models, saplingDiffs...)
 
 	return models, nil
 }
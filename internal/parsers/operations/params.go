@@ -14,6 +14,7 @@ import (
 	"github.com/baking-bad/bcdhub/internal/noderpc"
 	"github.com/baking-bad/bcdhub/internal/parsers/contract"
 	"github.com/baking-bad/bcdhub/internal/parsers/stacktrace"
+	ticketParsers "github.com/baking-bad/bcdhub/internal/parsers/ticket"
 	"github.com/baking-bad/bcdhub/internal/parsers/transfer"
 )
 
@@ -23,13 +24,16 @@ type ParseParams struct {
 	BigMapDiffs   bigmapdiff.Repository
 	TokenBalances tokenbalance.Repository
 
-	rpc      noderpc.INode
-	shareDir string
+	rpc                  noderpc.INode
+	shareDir             string
+	skipScriptSaving     bool
+	implicitTransactions bool
 
 	constants protocol.Constants
 
 	contractParser *contract.Parser
 	transferParser *transfer.Parser
+	ticketParser   *ticketParsers.Parser
 
 	storageParser *RichStorage
 
@@ -43,6 +47,8 @@ type ParseParams struct {
 	contentIdx int64
 	main       *operation.Operation
 
+	withReveals bool
+
 	once *sync.Once
 }
 
@@ -70,6 +76,20 @@ func WithShareDirectory(shareDir string) ParseParamsOption {
 	}
 }
 
+// WithoutScriptSaving - scripts of originated contracts are not saved to share directory. It's used for simulated operations.
+func WithoutScriptSaving() ParseParamsOption {
+	return func(dp *ParseParams) {
+		dp.skipScriptSaving = true
+	}
+}
+
+// WithImplicitTransactions - transactions between implicit accounts are parsed too. It's used for simulated operations.
+func WithImplicitTransactions() ParseParamsOption {
+	return func(dp *ParseParams) {
+		dp.implicitTransactions = true
+	}
+}
+
 // WithNetwork -
 func WithNetwork(network string) ParseParamsOption {
 	return func(dp *ParseParams) {
@@ -105,6 +125,13 @@ func WithMainOperation(main *operation.Operation) ParseParamsOption {
 	}
 }
 
+// WithTickets - enables indexing of tickets held by contracts. Ticket balances are saved with the block, so `parser` has to be shared by all operation groups of the block.
+func WithTickets(parser *ticketParsers.Parser) ParseParamsOption {
+	return func(dp *ParseParams) {
+		dp.ticketParser = parser
+	}
+}
+
 // NewParseParams -
 func NewParseParams(rpc noderpc.INode, storage models.GeneralRepository, bmdRepo bigmapdiff.Repository, blockRepo block.Repository, tzipRepo tzip.Repository, tbRepo tokenbalance.Repository, opts ...ParseParamsOption) *ParseParams {
 	params := &ParseParams{
@@ -132,9 +159,11 @@ func NewParseParams(rpc noderpc.INode, storage models.GeneralRepository, bmdRepo
 	}
 	params.transferParser = transferParser
 
-	params.contractParser = contract.NewParser(
-		contract.WithShareDir(params.shareDir),
-	)
+	contractOpts := make([]contract.ParserOption, 0)
+	if !params.skipScriptSaving {
+		contractOpts = append(contractOpts, contract.WithShareDir(params.shareDir))
+	}
+	params.contractParser = contract.NewParser(contractOpts...)
 	storageParser, err := NewRichStorage(bmdRepo, rpc, params.head.Protocol)
 	if err != nil {
 		logger.Error(err)
@@ -37,3 +37,29 @@ func parseOperationResult(data *noderpc.Operation) *operation.Result {
 	}
 	return &operationResult
 }
+
+// parseBalanceUpdates - returns balance updates of operation metadata (fees) followed by ones of operation result
+func parseBalanceUpdates(data *noderpc.Operation) []operation.BalanceUpdate {
+	updates := make([]noderpc.BalanceUpdate, 0)
+	if data.Metadata != nil {
+		updates = append(updates, data.Metadata.BalanceUpdates...)
+	}
+	if result := data.GetResult(); result != nil {
+		updates = append(updates, result.BalanceUpdates...)
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	balanceUpdates := make([]operation.BalanceUpdate, len(updates))
+	for i := range updates {
+		balanceUpdates[i] = operation.BalanceUpdate{
+			Kind:     updates[i].Kind,
+			Contract: updates[i].Contract,
+			Category: updates[i].Category,
+			Delegate: updates[i].Delegate,
+			Change:   updates[i].Change,
+		}
+	}
+	return balanceUpdates
+}
@@ -0,0 +1,52 @@
+package operations
+
+import (
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+)
+
+// Reveal -
+type Reveal struct {
+	*ParseParams
+}
+
+// NewReveal -
+func NewReveal(params *ParseParams) Reveal {
+	return Reveal{params}
+}
+
+// Parse -
+func (p Reveal) Parse(data noderpc.Operation) ([]models.Model, error) {
+	reveal := operation.Operation{
+		ID:           helpers.GenerateID(),
+		Network:      p.network,
+		Hash:         p.hash,
+		Protocol:     p.head.Protocol,
+		Level:        p.head.Level,
+		Timestamp:    p.head.Timestamp,
+		Kind:         data.Kind,
+		Initiator:    data.Source,
+		Source:       data.Source,
+		Fee:          data.Fee,
+		Counter:      data.Counter,
+		GasLimit:     data.GasLimit,
+		StorageLimit: data.StorageLimit,
+		PublicKey:    data.PublicKey,
+		Nonce:        data.Nonce,
+		IndexedTime:  time.Now().UnixNano() / 1000,
+		ContentIndex: p.contentIdx,
+	}
+
+	result := parseOperationResult(&data)
+	reveal.Result = result
+	reveal.Status = reveal.Result.Status
+	reveal.Errors = reveal.Result.Errors
+	reveal.BalanceUpdates = parseBalanceUpdates(&data)
+
+	p.stackTrace.Add(reveal)
+	return []models.Model{&reveal}, nil
+}
@@ -0,0 +1,73 @@
+package operations
+
+import (
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/saplingdiff"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+)
+
+// Sapling - parses sapling state updates from lazy storage diff of operation result
+type Sapling struct {
+}
+
+// NewSapling -
+func NewSapling() Sapling {
+	return Sapling{}
+}
+
+// Parse -
+func (s Sapling) Parse(data noderpc.Operation, operation *operation.Operation) ([]models.Model, error) {
+	result := data.GetResult()
+	if result == nil {
+		return nil, nil
+	}
+
+	diffs := make([]models.Model, 0)
+	for i := range result.LazyStorageDiff {
+		lsd := result.LazyStorageDiff[i]
+		if lsd.Kind != consts.SAPLINGSTATE || lsd.ID < 0 {
+			continue
+		}
+
+		var diff noderpc.SaplingStateDiff
+		if err := json.Unmarshal(lsd.Diff, &diff); err != nil {
+			return nil, err
+		}
+
+		sd := &saplingdiff.SaplingDiff{
+			ID:               helpers.GenerateID(),
+			Ptr:              lsd.ID,
+			Action:           diff.Action,
+			MemoSize:         diff.MemoSize,
+			Commitments:      make([]string, len(diff.Updates.CommitmentsAndCiphertexts)),
+			Ciphertexts:      make([]saplingdiff.Ciphertext, len(diff.Updates.CommitmentsAndCiphertexts)),
+			Nullifiers:       diff.Updates.Nullifiers,
+			CommitmentsCount: int64(len(diff.Updates.CommitmentsAndCiphertexts)),
+			NullifiersCount:  int64(len(diff.Updates.Nullifiers)),
+			OperationID:      operation.ID,
+			Level:            operation.Level,
+			Address:          operation.Destination,
+			Network:          operation.Network,
+			IndexedTime:      time.Now().UnixNano() / 1000,
+			Timestamp:        operation.Timestamp,
+			Protocol:         operation.Protocol,
+		}
+		if sd.Nullifiers == nil {
+			sd.Nullifiers = make([]string, 0)
+		}
+		if diff.Source != nil && *diff.Source >= 0 {
+			sd.SourcePtr = diff.Source
+		}
+		for j, cc := range diff.Updates.CommitmentsAndCiphertexts {
+			sd.Commitments[j] = cc.Commitment
+			sd.Ciphertexts[j] = saplingdiff.Ciphertext(cc.Ciphertext)
+		}
+		diffs = append(diffs, sd)
+	}
+	return diffs, nil
+}
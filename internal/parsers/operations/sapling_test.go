@@ -0,0 +1,107 @@
+package operations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/saplingdiff"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSapling_Parse(t *testing.T) {
+	timestamp := time.Now()
+	source := int64(14)
+
+	tests := []struct {
+		name      string
+		operation *operation.Operation
+		fileName  string
+		want      []models.Model
+	}{
+		{
+			name: "update and copy",
+			operation: &operation.Operation{
+				ID:          "operation_id",
+				Network:     "edo2net",
+				Level:       123,
+				Protocol:    "protocol",
+				Destination: "destination",
+				Timestamp:   timestamp,
+			},
+			fileName: "./data/sapling/test1.json",
+			want: []models.Model{
+				&saplingdiff.SaplingDiff{
+					Ptr:    14,
+					Action: "update",
+					Commitments: []string{
+						"2a2dd2a0bd8ff09d49a6ddd5d1ff1fbf6ebb51f72ea0c1cb7e15b6c1fa5f9c2d",
+					},
+					Ciphertexts: []saplingdiff.Ciphertext{
+						{
+							CV:         "1c3a8eda5f6e0a2d9d5e5f51a9a9c14f4d6e5b8f0e2b6e1a5c6b4f6f3e2c1d0a",
+							EPK:        "4f9b3b7e3f6c2d4d9a7b6a3c2e1f0d9c8b7a6f5e4d3c2b1a0f9e8d7c6b5a4938",
+							PayloadEnc: "c8e4f6a2",
+							NonceEnc:   "0b8f2c6e1d4a7b9c3e5f1a2d4c6b8e0f1a3c5e7d9b2f4a6c",
+							PayloadOut: "3d5f7a9c",
+							NonceOut:   "e1c3a5f7d9b2e4c6a8f0d2b4c6e8a0f2d4b6c8e0a2f4d6b8",
+						},
+					},
+					Nullifiers: []string{
+						"9f7c3e1a5b2d4f6a8c0e2b4d6f8a0c2e4b6d8f0a2c4e6b8d0f2a4c6e8b0d2f4a",
+						"1b3d5f7a9c2e4b6d8f0a2c4e6b8d0f2a4c6e8b0d2f4a6c8e0b2d4f6a8c0e2b4d",
+					},
+					CommitmentsCount: 1,
+					NullifiersCount:  2,
+					OperationID:      "operation_id",
+					Level:            123,
+					Address:          "destination",
+					Network:          "edo2net",
+					Timestamp:        timestamp,
+					Protocol:         "protocol",
+				},
+				&saplingdiff.SaplingDiff{
+					Ptr:         15,
+					Action:      "copy",
+					SourcePtr:   &source,
+					Commitments: []string{},
+					Ciphertexts: []saplingdiff.Ciphertext{},
+					Nullifiers:  []string{},
+					OperationID: "operation_id",
+					Level:       123,
+					Address:     "destination",
+					Network:     "edo2net",
+					Timestamp:   timestamp,
+					Protocol:    "protocol",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var op noderpc.Operation
+			if err := readJSONFile(tt.fileName, &op); err != nil {
+				t.Errorf(`readJSONFile("%s") = error %v`, tt.fileName, err)
+				return
+			}
+			got, err := NewSapling().Parse(op, tt.operation)
+			if err != nil {
+				t.Errorf("Sapling.Parse() = %s", err)
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("Sapling.Parse() len = %d, want %d", len(got), len(tt.want))
+				return
+			}
+			for i := range got {
+				diff := got[i].(*saplingdiff.SaplingDiff)
+				want := tt.want[i].(*saplingdiff.SaplingDiff)
+				want.ID = diff.ID
+				want.IndexedTime = diff.IndexedTime
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -134,8 +134,8 @@ func compareTransfers(one, two *transfer.Transfer) bool {
 		logger.Info("To: %s != %s", one.To, two.To)
 		return false
 	}
-	if one.TokenID != two.TokenID {
-		logger.Info("TokenID: %d != %d", one.TokenID, two.TokenID)
+	if one.TokenID.Cmp(two.TokenID.Int) != 0 {
+		logger.Info("TokenID: %v != %v", one.TokenID, two.TokenID)
 		return false
 	}
 	if one.AmountBigInt.Cmp(two.AmountBigInt) != 0 {
@@ -270,6 +270,10 @@ func compareOperations(t *testing.T, one, two *operation.Operation) bool {
 		logger.Info("DeffatedStorage: %s != %s", one.DeffatedStorage, two.DeffatedStorage)
 		return false
 	}
+	if (len(one.BalanceUpdates) > 0 || len(two.BalanceUpdates) > 0) && !reflect.DeepEqual(one.BalanceUpdates, two.BalanceUpdates) {
+		logger.Info("BalanceUpdates: %v != %v", one.BalanceUpdates, two.BalanceUpdates)
+		return false
+	}
 	if len(one.Tags) == len(two.Tags) && len(one.Tags) > 0 {
 		if !reflect.DeepEqual(one.Tags, two.Tags) {
 			logger.Info("Tags: %s != %s", one.Tags, two.Tags)
@@ -368,6 +372,18 @@ func compareContract(one, two *contract.Contract) bool {
 		logger.Info("Contract.Manager: %s != %s", one.Manager, two.Manager)
 		return false
 	}
+	if one.Delegate != two.Delegate {
+		logger.Info("Contract.Delegate: %s != %s", one.Delegate, two.Delegate)
+		return false
+	}
+	if one.DelegateAlias != two.DelegateAlias {
+		logger.Info("Contract.DelegateAlias: %s != %s", one.DelegateAlias, two.DelegateAlias)
+		return false
+	}
+	if one.TxCount != two.TxCount {
+		logger.Info("Contract.TxCount: %d != %d", one.TxCount, two.TxCount)
+		return false
+	}
 	if one.Level != two.Level {
 		logger.Info("Contract.Level: %d != %d", one.Level, two.Level)
 		return false
@@ -144,6 +144,20 @@ func (p Transaction) appliedHandler(item noderpc.Operation, op *operation.Operat
 
 	resultModels = append(resultModels, rs.Models...)
 
+	if p.ticketParser != nil {
+		tickets, err := p.ticketParser.Parse(op, rs.Models)
+		if err != nil {
+			return nil, err
+		}
+		resultModels = append(resultModels, tickets...)
+	}
+
+	saplingDiffs, err := NewSapling().Parse(item, op)
+	if err != nil {
+		return nil, err
+	}
+	resultModels = append(resultModels, saplingDiffs...)
+
 	migration, err := NewMigration().Parse(item, op)
 	if err != nil {
 		return nil, err
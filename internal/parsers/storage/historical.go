@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/fetch"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/migration"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/pkg/errors"
+)
+
+// Historical - rebuilds contract storage at any level from indexed operations and big map diffs. Storage of contracts which were migrated after their last operation is received from node.
+type Historical struct {
+	operations  operation.Repository
+	bigMapDiffs bigmapdiff.Repository
+	migrations  migration.Repository
+	rpc         noderpc.INode
+	sharePath   string
+}
+
+// NewHistorical -
+func NewHistorical(operations operation.Repository, bigMapDiffs bigmapdiff.Repository, migrations migration.Repository, rpc noderpc.INode, sharePath string) Historical {
+	return Historical{
+		operations:  operations,
+		bigMapDiffs: bigMapDiffs,
+		migrations:  migrations,
+		rpc:         rpc,
+		sharePath:   sharePath,
+	}
+}
+
+// Storage - returns typed storage of `address` as it was at `level`. Big maps contain only pointers. If `level` is 0 the latest indexed storage is returned.
+func (h Historical) Storage(network, address string, level int64) (*ast.TypedAst, operation.Operation, error) {
+	op, err := h.operations.LastAtLevel(network, address, level)
+	if err != nil {
+		return nil, op, err
+	}
+
+	migrated, err := h.isMigratedAfter(network, address, op.Level, level)
+	if err != nil {
+		return nil, op, err
+	}
+	if migrated {
+		storageType, err := h.nodeStorage(address, level)
+		return storageType, op, err
+	}
+
+	data, err := fetch.Contract(address, network, op.Protocol, h.sharePath)
+	if err != nil {
+		return nil, op, err
+	}
+	script, err := ast.NewScript(data)
+	if err != nil {
+		return nil, op, err
+	}
+	storageType, err := script.StorageType()
+	if err != nil {
+		return nil, op, err
+	}
+
+	var tree ast.UntypedAST
+	if err := json.UnmarshalFromString(op.DeffatedStorage, &tree); err != nil {
+		return nil, op, err
+	}
+	if err := storageType.Settle(tree); err != nil {
+		return nil, op, err
+	}
+	return storageType, op, nil
+}
+
+// isMigratedAfter - checks if protocol migration rewrote storage of `address` after operation at `opLevel` and not later than `level`
+func (h Historical) isMigratedAfter(network, address string, opLevel, level int64) (bool, error) {
+	migrations, err := h.migrations.Get(network, address)
+	if err != nil {
+		return false, err
+	}
+	for i := range migrations {
+		if migrations[i].Kind == consts.MigrationBootstrap {
+			continue
+		}
+		if migrations[i].Level > opLevel && (level == 0 || migrations[i].Level <= level) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// nodeStorage - receives script and storage of `address` at `level` from node. Migration storage rewrites aren't indexed, so storage can't be rebuilt from operations.
+func (h Historical) nodeStorage(address string, level int64) (*ast.TypedAst, error) {
+	if h.rpc == nil {
+		return nil, errors.Errorf("storage of %s was rewritten by migration and node is unavailable", address)
+	}
+	data, err := h.rpc.GetScriptJSON(address, level)
+	if err != nil {
+		return nil, err
+	}
+	if data.Code == nil {
+		return nil, errors.Errorf("empty script of %s", address)
+	}
+	storageType, err := data.Code.StorageType()
+	if err != nil {
+		return nil, err
+	}
+
+	var tree ast.UntypedAST
+	if err := json.Unmarshal(data.Storage, &tree); err != nil {
+		return nil, err
+	}
+	if err := storageType.Settle(tree); err != nil {
+		return nil, err
+	}
+	return storageType, nil
+}
+
+// RichStorage - returns typed storage of `address` as it was at `level` with big map contents at the same level. If `level` is 0 the latest indexed storage is returned.
+func (h Historical) RichStorage(network, address string, level int64) (*ast.TypedAst, operation.Operation, error) {
+	storageType, op, err := h.Storage(network, address, level)
+	if err != nil {
+		return nil, op, err
+	}
+
+	bmd := make([]bigmapdiff.BigMapDiff, 0)
+	for ptr := range storageType.FindBigMapByPtr() {
+		keys, err := h.bigMapKeys(network, ptr, level)
+		if err != nil {
+			return nil, op, err
+		}
+		bmd = append(bmd, keys...)
+	}
+
+	if err := Enrich(storageType, bmd, true, true); err != nil {
+		return nil, op, err
+	}
+	return storageType, op, nil
+}
+
+// bigMapKeys - returns last states of big map keys at `level`. Removed keys are returned with empty value.
+func (h Historical) bigMapKeys(network string, ptr, level int64) ([]bigmapdiff.BigMapDiff, error) {
+	return h.bigMapDiffs.GetStates(network, ptr, level)
+}
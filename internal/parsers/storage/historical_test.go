@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/migration"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_bmd "github.com/baking-bad/bcdhub/internal/models/mock/bigmapdiff"
+	mock_migration "github.com/baking-bad/bcdhub/internal/models/mock/migration"
+	mock_operation "github.com/baking-bad/bcdhub/internal/models/mock/operation"
+)
+
+const historicalTestScript = `[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"pair","args":[{"prim":"big_map","args":[{"prim":"address"},{"prim":"nat"}],"annots":["%ledger"]},{"prim":"nat","annots":["%total"]}]}]},{"prim":"code","args":[[{"prim":"CDR"},{"prim":"NIL","args":[{"prim":"operation"}]},{"prim":"PAIR"}]]}]`
+
+func TestHistorical_RichStorage(t *testing.T) {
+	const (
+		network = "edo2net"
+		address = "KT1C2MfcjWb5R1ZDDxVULCsGuxrf5fEn5264"
+		level   = int64(100)
+	)
+
+	sharePath, err := ioutil.TempDir("", "historical")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(sharePath)
+
+	contractDir := filepath.Join(sharePath, "contracts", network)
+	if err := os.MkdirAll(contractDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(contractDir, address+"_babylon.json"), []byte(historicalTestScript), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	operations := mock_operation.NewMockRepository(ctrl)
+	operations.
+		EXPECT().
+		LastAtLevel(network, address, level).
+		Return(operation.Operation{
+			Network:         network,
+			Destination:     address,
+			Level:           95,
+			Protocol:        "PtEdo2ZkT9oKpimTah6x2embF25oss54njMuPzkJTEi5RqfdZFA",
+			DeffatedStorage: `{"prim":"Pair","args":[{"int":"5"},{"int":"100"}]}`,
+		}, nil).
+		Times(1)
+
+	ptr := int64(5)
+	bmd := mock_bmd.NewMockRepository(ctrl)
+	bmd.
+		EXPECT().
+		GetStates(network, ptr, level).
+		Return([]bigmapdiff.BigMapDiff{
+			{
+				Ptr:     ptr,
+				Key:     []byte(`{"bytes":"0000b8c2ec4cd2e6ab78c20c4faf8e8d5b9b2ab9c6d9"}`),
+				KeyHash: "exprtj7SV9eFHgAcxSXKjpQSEw3mYxSVs5AKvQGpgy5BBSrjbq5gmF",
+				Value:   []byte(`{"int":"10"}`),
+				Level:   90,
+			},
+			{
+				Ptr:     ptr,
+				Key:     []byte(`{"bytes":"00006b82198cb179e8306c1bedd08f12dc863f328886"}`),
+				KeyHash: "exprtr3iA2ZhFDtnJZDS1nVxJYeXGWw2AWziVAD7DZf7kxsHmNLZBB",
+				Level:   92,
+			},
+		}, nil).
+		Times(1)
+
+	migrations := mock_migration.NewMockRepository(ctrl)
+	migrations.
+		EXPECT().
+		Get(network, address).
+		Return([]migration.Migration{
+			{Network: network, Address: address, Level: 1, Kind: consts.MigrationBootstrap},
+			{Network: network, Address: address, Level: 120, Kind: consts.MigrationUpdate},
+		}, nil).
+		Times(1)
+
+	historical := NewHistorical(operations, bmd, migrations, noderpc.NewMockINode(ctrl), sharePath)
+	storageType, op, err := historical.RichStorage(network, address, level)
+	if err != nil {
+		t.Fatalf("RichStorage() error = %v", err)
+	}
+	assert.Equal(t, int64(95), op.Level)
+
+	bigMaps := storageType.FindBigMapByPtr()
+	bigMap, ok := bigMaps[ptr]
+	if !assert.True(t, ok, "big map is not found") {
+		return
+	}
+	assert.Equal(t, 1, bigMap.Data.Len(), "removed key has to be skipped")
+}
+
+func TestHistorical_Storage_Migrated(t *testing.T) {
+	const (
+		network = "mainnet"
+		address = "KT1C2MfcjWb5R1ZDDxVULCsGuxrf5fEn5264"
+		level   = int64(655360)
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	operations := mock_operation.NewMockRepository(ctrl)
+	operations.
+		EXPECT().
+		LastAtLevel(network, address, level).
+		Return(operation.Operation{
+			Network:         network,
+			Destination:     address,
+			Level:           500000,
+			Protocol:        "PsddFKi32cMJ2qPjf43Qv5GDWLDPZb3T3bF6fLKiF5HtvHNU7aP",
+			DeffatedStorage: `{"prim":"Pair","args":[{"int":"5"},{"int":"100"}]}`,
+		}, nil).
+		Times(1)
+
+	migrations := mock_migration.NewMockRepository(ctrl)
+	migrations.
+		EXPECT().
+		Get(network, address).
+		Return([]migration.Migration{
+			{Network: network, Address: address, Level: 655360, Kind: consts.MigrationUpdate},
+		}, nil).
+		Times(1)
+
+	code, err := ast.NewScript([]byte(historicalTestScript))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rpc := noderpc.NewMockINode(ctrl)
+	rpc.
+		EXPECT().
+		GetScriptJSON(address, level).
+		Return(noderpc.Script{
+			Code:    code,
+			Storage: []byte(`{"prim":"Pair","args":[{"int":"17"},{"int":"200"}]}`),
+		}, nil).
+		Times(1)
+
+	historical := NewHistorical(operations, mock_bmd.NewMockRepository(ctrl), migrations, rpc, "")
+	storageType, op, err := historical.Storage(network, address, level)
+	if err != nil {
+		t.Fatalf("Storage() error = %v", err)
+	}
+	assert.Equal(t, int64(500000), op.Level)
+
+	bigMaps := storageType.FindBigMapByPtr()
+	_, ok := bigMaps[17]
+	assert.True(t, ok, "storage has to be received from node")
+}
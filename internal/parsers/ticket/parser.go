@@ -0,0 +1,292 @@
+package ticket
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
+	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// Parser - extracts tickets held by contracts from their storage and big map diffs and tickets passed by transaction parameters.
+// Parser remembers balances it has returned, so one parser must be used for all operations of a block: balances are saved with the block after parsing.
+type Parser struct {
+	repo     ticket.Repository
+	holdings map[string]map[string]ticket.Ticket
+}
+
+// NewParser -
+func NewParser(repo ticket.Repository) *Parser {
+	return &Parser{
+		repo:     repo,
+		holdings: make(map[string]map[string]ticket.Ticket),
+	}
+}
+
+// Parse - receives applied operation `op` with script and new storage of its destination and big map diffs made by the operation.
+// Returns ticket updates of destination and its new ticket balances. Tickets passed by parameters are returned as updates in `parameter` location, they don't change balances.
+func (p *Parser) Parse(op *operation.Operation, diffs []models.Model) ([]models.Model, error) {
+	var script ast.Script
+	if err := json.Unmarshal(op.Script, &script); err != nil {
+		return nil, err
+	}
+
+	result, err := p.parseParameters(op, &script)
+	if err != nil {
+		return nil, err
+	}
+	if !hasTickets(script.Storage) {
+		return result, nil
+	}
+
+	storageType, err := script.StorageType()
+	if err != nil {
+		return nil, err
+	}
+
+	var storage ast.UntypedAST
+	if err := json.UnmarshalFromString(op.DeffatedStorage, &storage); err != nil {
+		return nil, err
+	}
+	if err := storageType.Settle(storage); err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]*ticket.Ticket)
+	locations := map[string]struct{}{
+		ticket.LocationStorage: {},
+	}
+	if err := p.collect(op, ticket.LocationStorage, storageType.FindTickets(), current); err != nil {
+		return nil, err
+	}
+
+	bigMaps := storageType.FindBigMapByPtr()
+	for i := range diffs {
+		bmd, ok := diffs[i].(*bigmapdiff.BigMapDiff)
+		if !ok || bmd.Address != op.Destination || bmd.Ptr < 0 {
+			continue
+		}
+		bigMap, ok := bigMaps[bmd.Ptr]
+		if !ok {
+			continue
+		}
+
+		location := ticket.BigMapLocation(bmd.Ptr, bmd.KeyHash)
+		locations[location] = struct{}{}
+		if len(bmd.Value) == 0 {
+			continue
+		}
+
+		var data base.Node
+		if err := json.Unmarshal(bmd.ValueBytes(), &data); err != nil {
+			return nil, err
+		}
+		value := ast.Copy(bigMap.ValueType)
+		if err := value.ParseValue(&data); err != nil {
+			return nil, errors.Wrapf(err, "big map %d key %s", bmd.Ptr, bmd.KeyHash)
+		}
+		if err := p.collect(op, location, value.FindTickets(), current); err != nil {
+			return nil, err
+		}
+	}
+
+	holdings, err := p.getHoldings(op.Network, op.Destination)
+	if err != nil {
+		return nil, err
+	}
+	for id, t := range holdings {
+		if _, ok := locations[t.Location]; !ok {
+			continue
+		}
+		if _, ok := current[id]; !ok {
+			removed := t
+			removed.Value = big.NewInt(0)
+			current[id] = &removed
+		}
+	}
+
+	for id, t := range current {
+		delta := new(big.Int).Set(t.Value)
+		if previous, ok := holdings[id]; ok {
+			delta.Sub(delta, previous.Value)
+		}
+		if delta.Sign() == 0 {
+			continue
+		}
+		holdings[id] = *t
+		result = append(result, t, newUpdate(op, t, delta))
+	}
+	return result, nil
+}
+
+// parseParameters - returns tickets which are transferred from source of transaction to its destination by parameters
+func (p *Parser) parseParameters(op *operation.Operation, script *ast.Script) ([]models.Model, error) {
+	if op.Kind != consts.Transaction || op.Parameters == "" || !hasTickets(script.Parameter) {
+		return nil, nil
+	}
+
+	parameter, err := script.ParameterType()
+	if err != nil {
+		return nil, err
+	}
+	subTree, err := parameter.FromParameters(types.NewParameters([]byte(op.Parameters)))
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]*ticket.Ticket)
+	if err := p.collect(op, ticket.LocationParameter, subTree.FindTickets(), current); err != nil {
+		return nil, err
+	}
+
+	result := make([]models.Model, 0, len(current))
+	for _, t := range current {
+		if t.Value.Sign() == 0 {
+			continue
+		}
+		update := newUpdate(op, t, t.Value)
+		update.Sender = op.Source
+		result = append(result, update)
+	}
+	return result, nil
+}
+
+func newUpdate(op *operation.Operation, t *ticket.Ticket, delta *big.Int) *ticket.Update {
+	return &ticket.Update{
+		ID:          helpers.GenerateID(),
+		Network:     op.Network,
+		OperationID: op.ID,
+		Level:       op.Level,
+		Timestamp:   op.Timestamp,
+		Holder:      t.Holder,
+		Location:    t.Location,
+		Ticketer:    t.Ticketer,
+		ContentType: t.ContentType,
+		Content:     t.Content,
+		ContentHash: t.ContentHash,
+		Value:       delta,
+	}
+}
+
+// getHoldings - returns tickets of `holder` by their IDs including balances which were returned by the parser but aren't saved yet
+func (p *Parser) getHoldings(network, holder string) (map[string]ticket.Ticket, error) {
+	key := fmt.Sprintf("%s_%s", network, holder)
+	if holdings, ok := p.holdings[key]; ok {
+		return holdings, nil
+	}
+
+	tickets, err := p.repo.GetByHolder(network, holder)
+	if err != nil {
+		return nil, err
+	}
+	holdings := make(map[string]ticket.Ticket, len(tickets))
+	for i := range tickets {
+		holdings[tickets[i].GetID()] = tickets[i]
+	}
+	p.holdings[key] = holdings
+	return holdings, nil
+}
+
+func (p *Parser) collect(op *operation.Operation, location string, tickets []*ast.Ticket, result map[string]*ticket.Ticket) error {
+	for i := range tickets {
+		t, err := newTicket(op, location, tickets[i])
+		if err != nil {
+			return err
+		}
+		if existing, ok := result[t.GetID()]; ok {
+			existing.Sum(t)
+		} else {
+			result[t.GetID()] = t
+		}
+	}
+	return nil
+}
+
+func newTicket(op *operation.Operation, location string, t *ast.Ticket) (*ticket.Ticket, error) {
+	ticketer, err := t.Ticketer()
+	if err != nil {
+		return nil, err
+	}
+	amount, err := t.Amount()
+	if err != nil {
+		return nil, err
+	}
+
+	rawType, err := json.Marshal(t.Type)
+	if err != nil {
+		return nil, err
+	}
+	var typ base.Node
+	if err := json.Unmarshal(rawType, &typ); err != nil {
+		return nil, err
+	}
+	clearAnnots(&typ)
+	contentType, err := json.Marshal(&typ)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := t.Content().ToBaseNode(false)
+	if err != nil {
+		return nil, err
+	}
+	contentJSON, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+
+	optimized, err := t.Content().ToBaseNode(true)
+	if err != nil {
+		return nil, err
+	}
+	contentHash, err := getContentHash(&typ, optimized)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ticket.Ticket{
+		Network:     op.Network,
+		Holder:      op.Destination,
+		Location:    location,
+		Ticketer:    ticketer,
+		ContentType: string(contentType),
+		Content:     string(contentJSON),
+		ContentHash: contentHash,
+		Value:       new(big.Int).Set(amount),
+	}, nil
+}
+
+// getContentHash - returns hash of packed pair of content type and content. Content type must be without annotations.
+func getContentHash(typ, content *base.Node) (string, error) {
+	return ast.BigMapKeyHash(&base.Node{
+		Prim: consts.Pair,
+		Args: []*base.Node{typ, content},
+	})
+}
+
+func hasTickets(nodes []*base.Node) bool {
+	for i := range nodes {
+		if nodes[i].Prim == consts.TICKET || hasTickets(nodes[i].Args) {
+			return true
+		}
+	}
+	return false
+}
+
+func clearAnnots(node *base.Node) {
+	node.Annots = nil
+	for i := range node.Args {
+		clearAnnots(node.Args[i])
+	}
+}
@@ -0,0 +1,262 @@
+package ticket
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/base"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	mock_ticket "github.com/baking-bad/bcdhub/internal/models/mock/ticket"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testHolder   = "KT1Dc6A6jTY9sG4UvqKciqbJNAGtXqb4n7vZ"
+	testTicketer = "KT1XtQeSap9wvJGY1Lmek84NU6PK6cjzC9Qd"
+	testScript   = `[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"pair","args":[{"prim":"option","args":[{"prim":"ticket","args":[{"prim":"string"}]}]},{"prim":"big_map","args":[{"prim":"address"},{"prim":"ticket","args":[{"prim":"nat"}]}]}]}]},{"prim":"code","args":[[]]}]`
+)
+
+type testUpdate struct {
+	Location    string
+	Ticketer    string
+	ContentType string
+	Content     string
+	Amount      int64
+}
+
+func newTestTicket(location, contentType, content string, amount int64) ticket.Ticket {
+	return ticket.Ticket{
+		Network:     "delphinet",
+		Holder:      testHolder,
+		Location:    location,
+		Ticketer:    testTicketer,
+		ContentType: contentType,
+		Content:     content,
+		Value:       big.NewInt(amount),
+	}
+}
+
+func TestParser_Parse(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		storage  string
+		diffs    []models.Model
+		previous []ticket.Ticket
+		want     []testUpdate
+	}{
+		{
+			name:    "storage without tickets",
+			script:  `[{"prim":"parameter","args":[{"prim":"unit"}]},{"prim":"storage","args":[{"prim":"nat"}]},{"prim":"code","args":[[]]}]`,
+			storage: `{"int":"1"}`,
+		}, {
+			name:    "new tickets",
+			script:  testScript,
+			storage: `{"prim":"Pair","args":[{"prim":"Some","args":[{"prim":"Pair","args":[{"string":"KT1XtQeSap9wvJGY1Lmek84NU6PK6cjzC9Qd"},{"prim":"Pair","args":[{"string":"abc"},{"int":"4"}]}]}]},{"int":"15"}]}`,
+			diffs: []models.Model{
+				&bigmapdiff.BigMapDiff{
+					Ptr:     15,
+					KeyHash: "expru5X1yxJG6ezR2uHMotwMLNmSzQyh5t1vUnhjx4cS6Pv9qE1Sdo",
+					Value:   []byte(`{"prim":"Pair","args":[{"string":"KT1XtQeSap9wvJGY1Lmek84NU6PK6cjzC9Qd"},{"prim":"Pair","args":[{"int":"1"},{"int":"7"}]}]}`),
+					Address: testHolder,
+				},
+				&bigmapdiff.BigMapDiff{
+					Ptr:     16,
+					KeyHash: "expru5X1yxJG6ezR2uHMotwMLNmSzQyh5t1vUnhjx4cS6Pv9qE1Sdo",
+					Value:   []byte(`{"prim":"Pair","args":[{"string":"KT1XtQeSap9wvJGY1Lmek84NU6PK6cjzC9Qd"},{"prim":"Pair","args":[{"int":"1"},{"int":"7"}]}]}`),
+					Address: testHolder,
+				},
+			},
+			want: []testUpdate{
+				{"15_expru5X1yxJG6ezR2uHMotwMLNmSzQyh5t1vUnhjx4cS6Pv9qE1Sdo", testTicketer, `{"prim":"nat"}`, `{"int":"1"}`, 7},
+				{ticket.LocationStorage, testTicketer, `{"prim":"string"}`, `{"string":"abc"}`, 4},
+			},
+		}, {
+			name:    "changed and removed tickets",
+			script:  testScript,
+			storage: `{"prim":"Pair","args":[{"prim":"Some","args":[{"prim":"Pair","args":[{"string":"KT1XtQeSap9wvJGY1Lmek84NU6PK6cjzC9Qd"},{"prim":"Pair","args":[{"string":"abc"},{"int":"4"}]}]}]},{"int":"15"}]}`,
+			diffs: []models.Model{
+				&bigmapdiff.BigMapDiff{
+					Ptr:     15,
+					KeyHash: "expru5X1yxJG6ezR2uHMotwMLNmSzQyh5t1vUnhjx4cS6Pv9qE1Sdo",
+					Address: testHolder,
+				},
+			},
+			previous: []ticket.Ticket{
+				newTestTicket(ticket.LocationStorage, `{"prim":"string"}`, `{"string":"abc"}`, 10),
+				newTestTicket(ticket.LocationStorage, `{"prim":"string"}`, `{"string":"def"}`, 3),
+				newTestTicket("15_expru5X1yxJG6ezR2uHMotwMLNmSzQyh5t1vUnhjx4cS6Pv9qE1Sdo", `{"prim":"nat"}`, `{"int":"1"}`, 7),
+				newTestTicket("15_exprtZBwZUeYYYfUs9B9Rg2ywHezVHnCCnmF9WsDQVrs582dSK63dC", `{"prim":"nat"}`, `{"int":"2"}`, 5),
+			},
+			want: []testUpdate{
+				{"15_expru5X1yxJG6ezR2uHMotwMLNmSzQyh5t1vUnhjx4cS6Pv9qE1Sdo", testTicketer, `{"prim":"nat"}`, `{"int":"1"}`, -7},
+				{ticket.LocationStorage, testTicketer, `{"prim":"string"}`, `{"string":"abc"}`, -6},
+				{ticket.LocationStorage, testTicketer, `{"prim":"string"}`, `{"string":"def"}`, -3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_ticket.NewMockRepository(ctrl)
+			if tt.want != nil {
+				previous := tt.previous
+				for i := range previous {
+					parsed, err := newTestContentHash(previous[i])
+					if err != nil {
+						t.Errorf("newTestContentHash() error = %v", err)
+						return
+					}
+					previous[i].ContentHash = parsed
+				}
+				repo.EXPECT().GetByHolder("delphinet", testHolder).Return(previous, nil).Times(1)
+			}
+
+			op := operation.Operation{
+				ID:              "test",
+				Network:         "delphinet",
+				Level:           100,
+				Destination:     testHolder,
+				Script:          []byte(tt.script),
+				DeffatedStorage: tt.storage,
+			}
+			got, err := NewParser(repo).Parse(&op, tt.diffs)
+			if err != nil {
+				t.Errorf("Parse() error = %v", err)
+				return
+			}
+
+			updates := make([]testUpdate, 0)
+			balances := make(map[string]int64)
+			for i := range got {
+				switch model := got[i].(type) {
+				case *ticket.Update:
+					assert.Equal(t, "test", model.OperationID)
+					assert.Equal(t, int64(100), model.Level)
+					assert.Equal(t, testHolder, model.Holder)
+					assert.NotEmpty(t, model.ContentHash)
+					updates = append(updates, testUpdate{model.Location, model.Ticketer, model.ContentType, model.Content, model.Value.Int64()})
+				case *ticket.Ticket:
+					balances[model.GetID()] = model.Value.Int64()
+				default:
+					t.Errorf("unexpected model: %T", model)
+				}
+			}
+			assert.Len(t, balances, len(updates), "every update must be returned with new balance")
+			for i := range tt.previous {
+				if amount, ok := balances[tt.previous[i].GetID()]; ok {
+					assert.NotEqual(t, tt.previous[i].Value.Int64(), amount)
+				}
+			}
+			sort.Slice(updates, func(i, j int) bool {
+				if updates[i].Location != updates[j].Location {
+					return updates[i].Location < updates[j].Location
+				}
+				return updates[i].Content < updates[j].Content
+			})
+			if tt.want == nil {
+				assert.Empty(t, updates)
+			} else {
+				assert.Equal(t, tt.want, updates)
+			}
+		})
+	}
+}
+
+func newTestContentHash(t ticket.Ticket) (string, error) {
+	var typ, content base.Node
+	if err := json.UnmarshalFromString(t.ContentType, &typ); err != nil {
+		return "", err
+	}
+	if err := json.UnmarshalFromString(t.Content, &content); err != nil {
+		return "", err
+	}
+	return getContentHash(&typ, &content)
+}
+
+func TestParser_Parse_SameBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// balances of holder are received once: the second operation of the block has to see unsaved balances of the first one
+	repo := mock_ticket.NewMockRepository(ctrl)
+	repo.EXPECT().GetByHolder("delphinet", testHolder).Return([]ticket.Ticket{}, nil).Times(1)
+
+	parser := NewParser(repo)
+	var previous int64
+	for i, amount := range []int64{4, 10, 10, 3} {
+		op := operation.Operation{
+			ID:              fmt.Sprintf("op_%d", i),
+			Network:         "delphinet",
+			Level:           100,
+			Destination:     testHolder,
+			Script:          []byte(testScript),
+			DeffatedStorage: fmt.Sprintf(`{"prim":"Pair","args":[{"prim":"Some","args":[{"prim":"Pair","args":[{"string":"KT1XtQeSap9wvJGY1Lmek84NU6PK6cjzC9Qd"},{"prim":"Pair","args":[{"string":"abc"},{"int":"%d"}]}]}]},{"int":"15"}]}`, amount),
+		}
+		got, err := parser.Parse(&op, nil)
+		if err != nil {
+			t.Errorf("Parse() error = %v", err)
+			return
+		}
+
+		switch i {
+		case 2:
+			assert.Empty(t, got, "balance isn't changed")
+		default:
+			if assert.Len(t, got, 2) {
+				assert.Equal(t, amount, got[0].(*ticket.Ticket).Value.Int64())
+				assert.Equal(t, amount-previous, got[1].(*ticket.Update).Value.Int64())
+			}
+		}
+		previous = amount
+	}
+}
+
+func TestParser_Parse_Parameters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// storage has no tickets, so balances are not requested
+	repo := mock_ticket.NewMockRepository(ctrl)
+	sender := "KT1PWx2mnDueood7fEmfbBDKx1D9BAnnXitn"
+
+	op := operation.Operation{
+		ID:              "op",
+		Kind:            "transaction",
+		Network:         "delphinet",
+		Level:           100,
+		Source:          sender,
+		Destination:     testHolder,
+		Script:          []byte(`[{"prim":"parameter","args":[{"prim":"or","args":[{"prim":"ticket","args":[{"prim":"string"}],"annots":["%receive"]},{"prim":"unit","annots":["%reset"]}]}]},{"prim":"storage","args":[{"prim":"nat"}]},{"prim":"code","args":[[]]}]`),
+		Parameters:      `{"entrypoint":"receive","value":{"prim":"Pair","args":[{"string":"KT1XtQeSap9wvJGY1Lmek84NU6PK6cjzC9Qd"},{"prim":"Pair","args":[{"string":"abc"},{"int":"3"}]}]}}`,
+		DeffatedStorage: `{"int":"1"}`,
+	}
+
+	got, err := NewParser(repo).Parse(&op, nil)
+	if !assert.NoError(t, err) || !assert.Len(t, got, 1) {
+		return
+	}
+	update, ok := got[0].(*ticket.Update)
+	if !assert.True(t, ok, "transfer has to be returned as update without balance") {
+		return
+	}
+	assert.True(t, update.IsTransfer())
+	assert.Equal(t, testHolder, update.Holder)
+	assert.Equal(t, sender, update.Sender)
+	assert.Equal(t, testTicketer, update.Ticketer)
+	assert.Equal(t, `{"string":"abc"}`, update.Content)
+	assert.Equal(t, int64(3), update.Value.Int64())
+
+	op.Parameters = `{"entrypoint":"reset","value":{"prim":"Unit"}}`
+	got, err = NewParser(repo).Parse(&op, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
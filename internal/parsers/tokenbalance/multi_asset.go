@@ -54,7 +54,7 @@ func (p MultiAsset) Parse(data []byte) ([]TokenBalance, error) {
 		balances = append(balances, TokenBalance{
 			Value:   balance.Int,
 			Address: address,
-			TokenID: tokenID.Int64(),
+			TokenID: tokenID,
 		})
 		return false, nil
 	})
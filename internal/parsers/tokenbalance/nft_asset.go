@@ -73,7 +73,7 @@ func (p NftAsset) Parse(data []byte) ([]TokenBalance, error) {
 		balances = append(balances, TokenBalance{
 			Value:   balance,
 			Address: address,
-			TokenID: tokenID.Int64(),
+			TokenID: tokenID,
 		})
 		return false, nil
 	})
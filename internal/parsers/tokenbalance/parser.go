@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 )
@@ -48,7 +49,7 @@ type Parser interface {
 // TokenBalance -
 type TokenBalance struct {
 	Address string
-	TokenID int64
+	TokenID *types.BigInt
 	Value   *big.Int
 }
 
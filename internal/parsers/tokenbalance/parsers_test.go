@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,6 +20,7 @@ func Test_singleAssetBalanceParser_Parse(t *testing.T) {
 			want: []TokenBalance{
 				{
 					Address: "test",
+					TokenID: types.NewBigInt(0),
 					Value:   newBigIntFromString("100000000000000"),
 				},
 			},
@@ -28,6 +30,7 @@ func Test_singleAssetBalanceParser_Parse(t *testing.T) {
 			want: []TokenBalance{
 				{
 					Address: "tz1djRgXXWWJiY1rpMECCxr5d9ZBqWewuiU1",
+					TokenID: types.NewBigInt(0),
 					Value:   newBigIntFromString("1000000000000000"),
 				},
 			},
@@ -63,7 +66,7 @@ func Test_multiAssetBalanceParser_Parse(t *testing.T) {
 			want: []TokenBalance{
 				{
 					Address: "test",
-					TokenID: 1,
+					TokenID: types.NewBigInt(1),
 					Value:   newBigIntFromString("1000000000000000"),
 				},
 			},
@@ -73,17 +76,27 @@ func Test_multiAssetBalanceParser_Parse(t *testing.T) {
 			want: []TokenBalance{
 				{
 					Address: "tz1djRgXXWWJiY1rpMECCxr5d9ZBqWewuiU1",
-					TokenID: 1,
+					TokenID: types.NewBigInt(1),
 					Value:   newBigIntFromString("1000000000000000"),
 				},
 			},
+		}, {
+			name: "token id greater than int64",
+			args: `[{"prim":"Elt","args": [{"args": [{"string": "test"}, {"int": "18446744073709551617"}]}, {"int": "1"}]}]`,
+			want: []TokenBalance{
+				{
+					Address: "test",
+					TokenID: types.NewBigIntFromString("18446744073709551617"),
+					Value:   newBigIntFromString("1"),
+				},
+			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := NewMultiAssetUpdate().Parse([]byte(tt.args))
 			if err != nil {
-				t.Errorf("Parse error=%w", err)
+				t.Errorf("Parse error=%v", err)
 				return
 			}
 			assert.Equal(t, got, tt.want)
@@ -103,7 +116,7 @@ func Test_nftParser_Parse(t *testing.T) {
 			want: []TokenBalance{
 				{
 					Address: "KT1BYYLfMjufYwqFtTSYJND7bzKNyK7mjrjM",
-					TokenID: 1,
+					TokenID: types.NewBigInt(1),
 					Value:   newBigIntFromString("1"),
 				},
 			},
@@ -113,7 +126,7 @@ func Test_nftParser_Parse(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := NewNftAsset().Parse([]byte(tt.args))
 			if err != nil {
-				t.Errorf("Parse error=%w", err)
+				t.Errorf("Parse error=%v", err)
 				return
 			}
 			assert.Equal(t, got, tt.want)
@@ -133,7 +146,7 @@ func Test_nftOptionParser_Parse(t *testing.T) {
 			want: []TokenBalance{
 				{
 					Address: "KT1BYYLfMjufYwqFtTSYJND7bzKNyK7mjrjM",
-					TokenID: 1,
+					TokenID: types.NewBigInt(1),
 					Value:   newBigIntFromString("1"),
 				},
 			},
@@ -143,7 +156,7 @@ func Test_nftOptionParser_Parse(t *testing.T) {
 			want: []TokenBalance{
 				{
 					Address: "",
-					TokenID: 1,
+					TokenID: types.NewBigInt(1),
 					Value:   newBigIntFromString("0"),
 				},
 			},
@@ -153,7 +166,7 @@ func Test_nftOptionParser_Parse(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := NewNftAssetOption().Parse([]byte(tt.args))
 			if err != nil {
-				t.Errorf("Parse error=%w", err)
+				t.Errorf("Parse error=%v", err)
 				return
 			}
 			assert.Equal(t, got, tt.want)
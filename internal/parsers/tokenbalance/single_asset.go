@@ -53,6 +53,7 @@ func (p SingleAsset) Parse(data []byte) ([]TokenBalance, error) {
 		balances = append(balances, TokenBalance{
 			Value:   balance.Int,
 			Address: address,
+			TokenID: types.NewBigInt(0),
 		})
 		return false, nil
 	})
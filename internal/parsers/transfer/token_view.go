@@ -1,6 +1,8 @@
 package transfer
 
 import (
+	"sync"
+
 	"github.com/baking-bad/bcdhub/internal/events"
 	"github.com/baking-bad/bcdhub/internal/models"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
@@ -19,12 +21,18 @@ type ImplementationKey struct {
 // TokenEvents -
 type TokenEvents map[ImplementationKey]tzip.EventImplementation
 
-var tokens []tzip.TZIP
+var (
+	tokens   []tzip.TZIP
+	tokensMx sync.Mutex
+)
 
 // NewTokenEvents -
 func NewTokenEvents(repo tzip.Repository, storage models.GeneralRepository) (TokenEvents, error) {
 	views := make(TokenEvents)
 
+	tokensMx.Lock()
+	defer tokensMx.Unlock()
+
 	count, err := repo.GetWithEventsCounts()
 	if err != nil {
 		return nil, err
@@ -175,7 +175,7 @@ func (p *Parser) executeEvents(impl tzip.EventImplementation, name string, opera
 }
 
 func (p *Parser) makeTransfersFromBalanceEvents(event events.Event, ctx events.Context, operation operation.Operation, isDelta bool) ([]*transfer.Transfer, error) {
-	balances, err := events.Execute(p.rpc, event, ctx)
+	balances, err := events.Execute(noderpc.NewInterpreter(p.rpc), event, ctx)
 	if err != nil {
 		logger.Errorf("Event of %s %s: %s", operation.Network, operation.Destination, err.Error())
 		return nil, nil
@@ -33,6 +33,7 @@ func TestMakeFa1_2Transfers(t *testing.T) {
 					Network:      "edo2net",
 					From:         "tz1grSQDByRpnVs7sPtaprNZRp531ZKz6Jmm",
 					To:           "tz1TGu6TN5GSez2ndXXeDX6LgUDvLzPLqgYV",
+					TokenID:      types.NewBigInt(0),
 					AmountBigInt: big.NewInt(100),
 				},
 			},
@@ -48,6 +49,7 @@ func TestMakeFa1_2Transfers(t *testing.T) {
 					Network:      "mainnet",
 					From:         "KT1Ap287P1NzsnToSJdA4aqSNjPomRaHBZSr",
 					To:           "tz1dMH7tW7RhdvVMR4wKVFF1Ke8m8ZDvrTTE",
+					TokenID:      types.NewBigInt(0),
 					AmountBigInt: big.NewInt(7.87488e+06),
 				},
 			},
@@ -32,7 +32,8 @@ func MakeFa2Transfers(tree ast.Node, operation operation.Operation) ([]*transfer
 				return nil, err
 			}
 			tokenPair := toPair.Args[1].(*ast.Pair)
-			t.TokenID = tokenPair.Args[0].GetValue().(*types.BigInt).Int64()
+			tokenID := tokenPair.Args[0].GetValue().(*types.BigInt)
+			t.TokenID.Set(tokenID.Int)
 			i := tokenPair.Args[1].GetValue().(*types.BigInt)
 			t.AmountBigInt.Set(i.Int)
 			transfers = append(transfers, t)
@@ -33,6 +33,7 @@ func TestMakeFa2Transfers(t *testing.T) {
 					Network:      "mainnet",
 					From:         "tz1gHJt7J1aEtW2wpCR5RJd3CpnbVxUTaEXS",
 					To:           "tz1gsJENNUwg7fQiRwQi5zJYaj7YtwwsE3y2",
+					TokenID:      types.NewBigInt(0),
 					AmountBigInt: big.NewInt(1000000000),
 				},
 			},
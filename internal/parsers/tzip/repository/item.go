@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/baking-bad/bcdhub/internal/models/tzip"
 )
 
@@ -22,7 +23,7 @@ type Metadata struct {
 			Name     string                 `json:"name"`
 			Symbol   string                 `json:"symbol,omitempty"`
 			Decimals *int64                 `json:"decimals,omitempty"`
-			TokenID  int64                  `json:"token_id"`
+			TokenID  *types.BigInt          `json:"token_id"`
 			Extras   map[string]interface{} `json:"extras"`
 		} `json:"static"`
 	} `json:"tokens"`
@@ -3,11 +3,13 @@ package tokens
 import (
 	"encoding/hex"
 	"encoding/json"
+	"math/big"
 	"strconv"
 	"time"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
 	"github.com/baking-bad/bcdhub/internal/bcd/forge"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/baking-bad/bcdhub/internal/logger"
 	"github.com/baking-bad/bcdhub/internal/models/tokenmetadata"
 	"github.com/tidwall/gjson"
@@ -39,7 +41,7 @@ const (
 type TokenMetadata struct {
 	Level              int64                  `json:"-"`
 	Timestamp          time.Time              `json:"-"`
-	TokenID            int64                  `json:"-"`
+	TokenID            *types.BigInt          `json:"-"`
 	Symbol             string                 `json:"symbol"`
 	Name               string                 `json:"name"`
 	Decimals           *int64                 `json:"decimals"`
@@ -93,7 +95,11 @@ func (m *TokenMetadata) Parse(value gjson.Result, address string, ptr int64) err
 		return ErrInvalidStorageStructure
 	}
 
-	m.TokenID = tokenID.Int()
+	id, ok := big.NewInt(0).SetString(tokenID.String(), 10)
+	if !ok {
+		return ErrInvalidStorageStructure
+	}
+	m.TokenID = &types.BigInt{Int: id}
 
 	m.Extras = make(map[string]interface{})
 	for _, item := range arr.Array() {
@@ -3,6 +3,7 @@ package tokens
 import (
 	"testing"
 
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
 )
@@ -20,7 +21,7 @@ func TestTokenMetadata_Parse(t *testing.T) {
 			wantErr: false,
 			want: &TokenMetadata{
 				Link:    "ipfs://QmT63cK5XJiCdPGCjXjRabcAgdjxxuqMMgU6yAmLnaxEZ5",
-				TokenID: 0,
+				TokenID: types.NewBigInt(0),
 				Extras: map[string]interface{}{
 					"@@empty": "ipfs://QmT63cK5XJiCdPGCjXjRabcAgdjxxuqMMgU6yAmLnaxEZ5",
 				},
@@ -30,7 +31,7 @@ func TestTokenMetadata_Parse(t *testing.T) {
 			value:   `{"prim":"Pair","args":[{"int":"1"},[{"prim":"Elt","args":[{"string":"decimals"},{"bytes":"36"}]},{"prim":"Elt","args":[{"string":"name"},{"bytes":"4e616d65"}]},{"prim":"Elt","args":[{"string":"symbol"},{"bytes":"534d42"}]}]]}`,
 			wantErr: false,
 			want: &TokenMetadata{
-				TokenID:  1,
+				TokenID:  types.NewBigInt(1),
 				Decimals: getIntPtr(6),
 				Name:     "Name",
 				Symbol:   "SMB",
@@ -41,7 +42,7 @@ func TestTokenMetadata_Parse(t *testing.T) {
 			value:   `{"prim":"Pair","args":[{"int":"2"},[{"prim":"Elt","args":[{"string":""},{"bytes":"74657a6f732d73746f726167653a636f6e74656e74"}]},{"prim":"Elt","args":[{"string":"content"},{"bytes":"7b226e616d65223a20224e616d65222c202273796d626f6c223a2022534d42222c2022646563696d616c73223a20367d"}]}]]}`,
 			wantErr: false,
 			want: &TokenMetadata{
-				TokenID: 2,
+				TokenID: types.NewBigInt(2),
 				Extras: map[string]interface{}{
 					"@@empty": "tezos-storage:content",
 					"content": "{\"name\": \"Name\", \"symbol\": \"SMB\", \"decimals\": 6}",
@@ -68,7 +69,7 @@ func TestTokenMetadata_Parse(t *testing.T) {
 			value:   `{"prim":"Pair","args":[{"int":"0"},[{"prim":"Elt","args":[{"string":"artifactUri"},{"bytes":"68747470733a2f2f636c6f7564666c6172652d697066732e636f6d2f697066732f516d53395634504b536a516838687a79517a52714b46786b4363535931794c755851594b7837596f54794a595965"}]},{"prim":"Elt","args":[{"string":"booleanAmount"},{"bytes":"74727565"}]},{"prim":"Elt","args":[{"string":"decimals"},{"bytes":"30"}]},{"prim":"Elt","args":[{"string":"displayUri"},{"bytes":"68747470733a2f2f636c6f7564666c6172652d697066732e636f6d2f697066732f516d53395634504b536a516838687a79517a52714b46786b4363535931794c755851594b7837596f54794a595965"}]},{"prim":"Elt","args":[{"string":"name"},{"bytes":"4361742044726177696e67"}]}]]}`,
 			wantErr: false,
 			want: &TokenMetadata{
-				TokenID:     0,
+				TokenID:     types.NewBigInt(0),
 				Decimals:    getIntPtr(0),
 				Name:        "Cat Drawing",
 				ArtifactURI: "https://cloudflare-ipfs.com/ipfs/QmS9V4PKSjQh8hzyQzRqKFxkCcSY1yLuXQYKx7YoTyJYYe",
@@ -114,7 +115,7 @@ func TestTokenMetadata_Merge(t *testing.T) {
 				Symbol:   "symbol",
 				Name:     "name",
 				Decimals: getIntPtr(10),
-				TokenID:  10,
+				TokenID:  types.NewBigInt(10),
 			},
 			want: &TokenMetadata{
 				Symbol:   "symbol",
@@ -128,13 +129,13 @@ func TestTokenMetadata_Merge(t *testing.T) {
 				Symbol:   "symbol",
 				Name:     "name",
 				Decimals: getIntPtr(10),
-				TokenID:  10,
+				TokenID:  types.NewBigInt(10),
 			},
 			want: &TokenMetadata{
 				Symbol:   "symbol",
 				Name:     "name",
 				Decimals: getIntPtr(10),
-				TokenID:  10,
+				TokenID:  types.NewBigInt(10),
 			},
 		}, {
 			name: "test 2",
@@ -142,19 +143,19 @@ func TestTokenMetadata_Merge(t *testing.T) {
 				Symbol:   "symbol old",
 				Name:     "name old",
 				Decimals: getIntPtr(9),
-				TokenID:  11,
+				TokenID:  types.NewBigInt(11),
 			},
 			second: &TokenMetadata{
 				Symbol:   "symbol",
 				Name:     "name",
 				Decimals: getIntPtr(10),
-				TokenID:  10,
+				TokenID:  types.NewBigInt(10),
 			},
 			want: &TokenMetadata{
 				Symbol:   "symbol",
 				Name:     "name",
 				Decimals: getIntPtr(10),
-				TokenID:  11,
+				TokenID:  types.NewBigInt(11),
 			},
 		}, {
 			name: "test 2",
@@ -162,7 +163,7 @@ func TestTokenMetadata_Merge(t *testing.T) {
 				Symbol:   "symbol old",
 				Name:     "name old",
 				Decimals: getIntPtr(9),
-				TokenID:  11,
+				TokenID:  types.NewBigInt(11),
 				Extras: map[string]interface{}{
 					"test": "1234",
 					"a":    "234",
@@ -172,7 +173,7 @@ func TestTokenMetadata_Merge(t *testing.T) {
 				Symbol:   "symbol",
 				Name:     "name",
 				Decimals: getIntPtr(10),
-				TokenID:  10,
+				TokenID:  types.NewBigInt(10),
 				Extras: map[string]interface{}{
 					"test": "12345",
 					"b":    "234",
@@ -182,7 +183,7 @@ func TestTokenMetadata_Merge(t *testing.T) {
 				Symbol:   "symbol",
 				Name:     "name",
 				Decimals: getIntPtr(10),
-				TokenID:  11,
+				TokenID:  types.NewBigInt(11),
 				Extras: map[string]interface{}{
 					"test": "12345",
 					"a":    "234",
@@ -0,0 +1,34 @@
+package bigmapaction
+
+import (
+	"fmt"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapaction"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Postgres
+}
+
+// NewStorage -
+func NewStorage(db *core.Postgres) *Storage {
+	return &Storage{db}
+}
+
+// Get -
+func (storage *Storage) Get(ptr int64, network string) (response []bigmapaction.BigMapAction, err error) {
+	ptrString := fmt.Sprintf("%d", ptr)
+	filters := core.NewFilters().
+		Equal("network", network).
+		Any(
+			core.NewFilters().Equal("source_ptr", ptrString),
+			core.NewFilters().Equal("destination_ptr", ptrString),
+		)
+
+	query := storage.db.Query(models.DocBigMapActions, filters).Order(core.Desc(core.IntField("indexed_time")))
+	err = storage.db.GetAllByQuery(query, &response)
+	return
+}
@@ -0,0 +1,68 @@
+package bigmapdiff
+
+import (
+	"fmt"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func buildGetContext(ctx *bigmapdiff.GetContext) (string, []interface{}) {
+	filters := core.NewFilters()
+
+	if ctx.Ptr != nil {
+		filters.Range("ptr", "=", *ctx.Ptr)
+	}
+	if ctx.Network != "" {
+		filters.Equal("network", ctx.Network)
+	}
+
+	if ctx.Query != "" {
+		pattern := fmt.Sprintf("%%%s%%", ctx.Query)
+		items := make([]*core.Filters, 0)
+		for _, field := range []string{"key", "key_hash", "key_strings", "bin_path", "value", "value_strings"} {
+			items = append(items, core.NewFilters().Raw(fmt.Sprintf("(%s)::text ILIKE ?", core.JSONField(field)), pattern))
+		}
+		filters.Any(items...)
+	}
+
+	if ctx.Size == 0 {
+		ctx.Size = core.DefaultSize
+	}
+
+	if ctx.MaxLevel != nil {
+		filters.Range("level", "<=", *ctx.MaxLevel)
+	}
+
+	if ctx.MinLevel != nil {
+		filters.Range("level", ">", *ctx.MinLevel)
+	}
+
+	if ctx.CurrentLevel != nil {
+		filters.Range("level", "=", *ctx.CurrentLevel)
+	}
+
+	if ctx.Contract != "" {
+		filters.Equal("address", ctx.Contract)
+	}
+
+	ctx.To = ctx.Size + ctx.Offset
+
+	where, args := filters.Build()
+	keyHash := core.Field("key_hash")
+	indexedTime := core.IntField("indexed_time")
+	sql := fmt.Sprintf(`
+		SELECT id, data, count FROM (
+			SELECT id, data, %s AS indexed_time,
+				COUNT(*) OVER (PARTITION BY %s) AS count,
+				ROW_NUMBER() OVER (PARTITION BY %s ORDER BY %s DESC) AS num
+			FROM %s WHERE %s
+		) AS keys WHERE num = 1 ORDER BY indexed_time DESC LIMIT ? OFFSET ?`,
+		indexedTime, keyHash, keyHash, indexedTime, models.DocBigMapDiff, where,
+	)
+	return sql, append(args, ctx.Size, ctx.Offset)
+}
@@ -0,0 +1,214 @@
+package bigmapdiff
+
+import (
+	"fmt"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/bigmapdiff"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/pkg/errors"
+)
+
+var lastIndexed = core.Desc(core.IntField("indexed_time"))
+
+// Storage -
+type Storage struct {
+	db *core.Postgres
+}
+
+// NewStorage -
+func NewStorage(db *core.Postgres) *Storage {
+	return &Storage{db}
+}
+
+// CurrentByKey -
+func (storage *Storage) CurrentByKey(network, keyHash string, ptr int64) (data bigmapdiff.BigMapDiff, err error) {
+	if ptr < 0 {
+		err = errors.Errorf("Invalid pointer value: %d", ptr)
+		return
+	}
+	filters := core.NewFilters().
+		Equal("network", network).
+		Equal("key_hash", keyHash).
+		Range("ptr", "=", ptr)
+
+	query := storage.db.Query(models.DocBigMapDiff, filters).Order(core.Desc(core.IntField("level")))
+	err = storage.db.GetOne(query, &data)
+	return
+}
+
+// GetForAddress -
+func (storage *Storage) GetForAddress(address string) (response []bigmapdiff.BigMapDiff, err error) {
+	query := storage.db.LastByGroup(models.DocBigMapDiff, core.NewFilters().Equal("address", address), lastIndexed, core.Field("key_hash"))
+	err = storage.db.GetAllByQuery(query, &response)
+	return
+}
+
+// GetStates - returns last states of all keys of big map `ptr` at `maxLevel`. If `maxLevel` is 0 current states are returned.
+func (storage *Storage) GetStates(network string, ptr, maxLevel int64) (response []bigmapdiff.BigMapDiff, err error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Range("ptr", "=", ptr)
+	if maxLevel > 0 {
+		filters.Range("level", "<=", maxLevel)
+	}
+
+	query := storage.db.LastByGroup(models.DocBigMapDiff, filters, lastIndexed, core.Field("key_hash"))
+	err = storage.db.GetAllByQuery(query, &response)
+	return
+}
+
+// GetByAddress -
+func (storage *Storage) GetByAddress(network, address string) (response []bigmapdiff.BigMapDiff, err error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Equal("address", address)
+
+	query := storage.db.Query(models.DocBigMapDiff, filters).Order(core.Desc(core.IntField("indexed_time")))
+	err = storage.db.GetAllByQuery(query, &response)
+	return
+}
+
+// GetValuesByKey -
+func (storage *Storage) GetValuesByKey(keyHash string) (response []bigmapdiff.BigMapDiff, err error) {
+	query := storage.db.LastByGroup(
+		models.DocBigMapDiff,
+		core.NewFilters().Equal("key_hash", keyHash),
+		lastIndexed,
+		core.Field("network"), core.Field("address"), core.IntField("ptr"),
+	)
+	err = storage.db.GetAllByQuery(query, &response)
+	return
+}
+
+// Count -
+func (storage *Storage) Count(network string, ptr int64) (count int64, err error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Range("ptr", "=", ptr)
+
+	err = storage.db.Query(models.DocBigMapDiff, filters).
+		Select(fmt.Sprintf("COUNT(DISTINCT %s)", core.Field("key_hash"))).
+		Row().
+		Scan(&count)
+	return
+}
+
+// Previous -
+func (storage *Storage) Previous(filters []bigmapdiff.BigMapDiff, indexedTime int64, address string) ([]bigmapdiff.BigMapDiff, error) {
+	keyHashes := make([]string, len(filters))
+	for i := range filters {
+		keyHashes[i] = filters[i].KeyHash
+	}
+
+	conditions := core.NewFilters().
+		In("key_hash", keyHashes).
+		Equal("address", address).
+		Range("indexed_time", "<", indexedTime)
+
+	var response []bigmapdiff.BigMapDiff
+	query := storage.db.LastByGroup(models.DocBigMapDiff, conditions, lastIndexed, core.Field("key_hash"))
+	if err := storage.db.GetAllByQuery(query, &response); err != nil {
+		return nil, err
+	}
+
+	diffs := make([]bigmapdiff.BigMapDiff, 0)
+	for i := range response {
+		if response[i].Value != nil {
+			diffs = append(diffs, response[i])
+		}
+	}
+	return diffs, nil
+}
+
+// GetUniqueByOperationID -
+func (storage *Storage) GetUniqueByOperationID(operationID string) (response []bigmapdiff.BigMapDiff, err error) {
+	query := storage.db.LastByGroup(
+		models.DocBigMapDiff,
+		core.NewFilters().Equal("operation_id", operationID),
+		lastIndexed,
+		core.IntField("ptr"), core.Field("key_hash"),
+	)
+	err = storage.db.GetAllByQuery(query, &response)
+	return
+}
+
+// GetByPtrAndKeyHash -
+func (storage *Storage) GetByPtrAndKeyHash(ptr int64, network, keyHash string, size, offset int64) ([]bigmapdiff.BigMapDiff, int64, error) {
+	if ptr < 0 {
+		return nil, 0, errors.Errorf("Invalid pointer value: %d", ptr)
+	}
+	if size == 0 {
+		size = core.DefaultSize
+	}
+
+	filters := core.NewFilters().
+		Equal("network", network).
+		Equal("key_hash", keyHash).
+		Range("ptr", "=", ptr)
+
+	query := storage.db.Query(models.DocBigMapDiff, filters).
+		Order(core.Desc(core.IntField("level"))).
+		Limit(size).
+		Offset(offset)
+
+	result := make([]bigmapdiff.BigMapDiff, 0)
+	total, err := storage.db.GetAllByQueryWithTotal(models.DocBigMapDiff, filters, query, &result)
+	return result, total, err
+}
+
+// GetByOperationID -
+func (storage *Storage) GetByOperationID(operationID string) (response []*bigmapdiff.BigMapDiff, err error) {
+	var diffs []bigmapdiff.BigMapDiff
+	query := storage.db.Query(models.DocBigMapDiff, core.NewFilters().Equal("operation_id", operationID))
+	if err = storage.db.GetAllByQuery(query, &diffs); err != nil {
+		return
+	}
+
+	response = make([]*bigmapdiff.BigMapDiff, len(diffs))
+	for i := range diffs {
+		response[i] = &diffs[i]
+	}
+	return
+}
+
+// GetByPtr -
+func (storage *Storage) GetByPtr(address, network string, ptr int64) (response []bigmapdiff.BigMapDiff, err error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Equal("address", address).
+		Range("ptr", "=", ptr)
+
+	query := storage.db.LastByGroup(models.DocBigMapDiff, filters, lastIndexed, core.Field("key_hash")).
+		Order(core.Desc(core.IntField("indexed_time")))
+	err = storage.db.GetAllByQuery(query, &response)
+	return
+}
+
+// Get -
+func (storage *Storage) Get(ctx bigmapdiff.GetContext) ([]bigmapdiff.Bucket, error) {
+	if *ctx.Ptr < 0 {
+		return nil, errors.Errorf("Invalid pointer value: %d", *ctx.Ptr)
+	}
+
+	sql, args := buildGetContext(&ctx)
+	rows, err := storage.db.Raw(sql, args...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]bigmapdiff.Bucket, 0)
+	for rows.Next() {
+		var bucket bigmapdiff.Bucket
+		var data []byte
+		if err := rows.Scan(&bucket.ID, &data, &bucket.Count); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &bucket.BigMapDiff); err != nil {
+			return nil, err
+		}
+		result = append(result, bucket)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,60 @@
+package block
+
+import (
+	"fmt"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/block"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Postgres
+}
+
+// NewStorage -
+func NewStorage(db *core.Postgres) *Storage {
+	return &Storage{db}
+}
+
+// Get -
+func (storage *Storage) Get(network string, level int64) (block block.Block, err error) {
+	block.Network = network
+
+	filters := core.NewFilters().
+		Equal("network", network).
+		Range("level", "=", level)
+	err = storage.db.GetOne(storage.db.Query(models.DocBlocks, filters), &block)
+	return
+}
+
+// Last - returns current indexer state for network
+func (storage *Storage) Last(network string) (block block.Block, err error) {
+	block.Network = network
+
+	query := storage.db.Query(models.DocBlocks, core.NewFilters().Equal("network", network)).
+		Order(core.Desc(core.IntField("level")))
+	if err = storage.db.GetOne(query, &block); err != nil && storage.db.IsRecordNotFound(err) {
+		return block, nil
+	}
+	return
+}
+
+// LastByNetworks - return last block for all networks
+func (storage *Storage) LastByNetworks() (response []block.Block, err error) {
+	last := fmt.Sprintf(
+		`SELECT DISTINCT ON (%s) id FROM %s ORDER BY %s, %s`,
+		core.Field("network"), models.DocBlocks, core.Field("network"), core.Desc(core.IntField("level")),
+	)
+	query := storage.db.Table(models.DocBlocks).Where(fmt.Sprintf("id IN (%s)", last))
+	err = storage.db.GetAllByQuery(query, &response)
+	return
+}
+
+// GetNetworkAlias -
+func (storage *Storage) GetNetworkAlias(chainID string) (string, error) {
+	var block block.Block
+	err := storage.db.GetOne(storage.db.Query(models.DocBlocks, core.NewFilters().Equal("chain_id", chainID)), &block)
+	return block.Network, err
+}
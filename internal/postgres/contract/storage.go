@@ -0,0 +1,358 @@
+package contract
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/pkg/errors"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Postgres
+}
+
+// NewStorage -
+func NewStorage(db *core.Postgres) *Storage {
+	return &Storage{db}
+}
+
+// Get -
+func (storage *Storage) Get(by map[string]interface{}) (c contract.Contract, err error) {
+	err = storage.db.GetOne(storage.db.Query(models.DocContracts, core.FiltersToQuery(by)), &c)
+	return
+}
+
+// GetMany -
+func (storage *Storage) GetMany(by map[string]interface{}) ([]contract.Contract, error) {
+	contracts := make([]contract.Contract, 0)
+	err := storage.db.GetAllByQuery(storage.db.Query(models.DocContracts, core.FiltersToQuery(by)), &contracts)
+	return contracts, err
+}
+
+// GetRandom -
+func (storage *Storage) GetRandom(network string) (c contract.Contract, err error) {
+	filters := core.NewFilters().Range("tx_count", ">=", 2)
+	if network != "" {
+		filters.Equal("network", network)
+	}
+
+	err = storage.db.GetOne(storage.db.Query(models.DocContracts, filters).Order("random()"), &c)
+	return
+}
+
+// IsFA -
+func (storage *Storage) IsFA(network, address string) (bool, error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Equal("address", address).
+		ArrayContains("tags", []string{"fa12", "fa1"})
+
+	count, err := storage.db.CountByQuery(models.DocContracts, filters)
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
+
+// UpdateMigrationsCount -
+func (storage *Storage) UpdateMigrationsCount(address, network string) error {
+	contract := contract.NewEmptyContract(network, address)
+	sql := fmt.Sprintf(
+		`UPDATE %s SET data = jsonb_set(data, '{migrations_count}', to_jsonb(COALESCE(%s, 0) + 1)) WHERE id = ?`,
+		models.DocContracts, core.IntField("migrations_count"),
+	)
+	result := storage.db.Exec(sql, contract.GetID())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return core.NewRecordNotFoundError(models.DocContracts, contract.GetID())
+	}
+	return nil
+}
+
+// GetAddressesByNetworkAndLevel -
+func (storage *Storage) GetAddressesByNetworkAndLevel(network string, maxLevel int64) (addresses []string, err error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Range("level", ">", maxLevel)
+
+	err = storage.db.Query(models.DocContracts, filters).Pluck(core.Field("address"), &addresses).Error
+	return
+}
+
+// GetIDsByAddresses -
+func (storage *Storage) GetIDsByAddresses(addresses []string, network string) (ids []string, err error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		In("address", addresses)
+
+	err = storage.db.Query(models.DocContracts, filters).Pluck("id", &ids).Error
+	return
+}
+
+// GetByAddresses -
+func (storage *Storage) GetByAddresses(addresses []contract.Address) ([]contract.Contract, error) {
+	items := make([]*core.Filters, len(addresses))
+	for i := range addresses {
+		items[i] = core.NewFilters().
+			Equal("address", addresses[i].Address).
+			Equal("network", addresses[i].Network)
+	}
+
+	contracts := make([]contract.Contract, 0)
+	err := storage.db.GetAllByQuery(storage.db.Query(models.DocContracts, core.NewFilters().Any(items...)), &contracts)
+	return contracts, err
+}
+
+// GetProjectsLastContract -
+func (storage *Storage) GetProjectsLastContract(c *contract.Contract) ([]contract.Contract, error) {
+	filters := core.NewFilters()
+
+	if c != nil {
+		items := make([]*core.Filters, 0)
+		if c.Manager != "" {
+			items = append(items, core.NewFilters().Equal("manager", c.Manager))
+		}
+		if c.Language != "" {
+			items = append(items, core.NewFilters().Equal("language", c.Language))
+		}
+		if len(c.Tags) > 0 {
+			items = append(items, getArrayFilter("tags", c.Tags...))
+		}
+		if len(c.Annotations) > 0 {
+			items = append(items, getArrayFilter("annotations", c.Annotations...))
+		}
+		if len(c.FailStrings) > 0 {
+			items = append(items, getArrayFilter("fail_strings", c.FailStrings...))
+		}
+		if len(c.Entrypoints) > 0 {
+			items = append(items, getArrayFilter("entrypoints", c.Entrypoints...))
+		}
+		if c.Fingerprint != nil {
+			items = append(items, core.NewFilters().
+				Equal("fingerprint.parameter", c.Fingerprint.Parameter).
+				Equal("fingerprint.storage", c.Fingerprint.Storage).
+				Equal("fingerprint.code", c.Fingerprint.Code),
+			)
+		}
+		filters.Any(items...)
+	}
+
+	lastContract := core.Desc(core.TimeField("timestamp"))
+	query := storage.db.LastByGroup(models.DocContracts, filters, lastContract, core.Field("project_id")).
+		Order(lastContract)
+
+	contracts := make([]contract.Contract, 0)
+	if err := storage.db.GetAllByQuery(query, &contracts); err != nil {
+		return nil, err
+	}
+	if len(contracts) == 0 {
+		return nil, core.NewRecordNotFoundError(models.DocContracts, "")
+	}
+	return contracts, nil
+}
+
+func getArrayFilter(fieldName string, arr ...string) *core.Filters {
+	minimumShouldMatch := len(arr) / 2
+	if minimumShouldMatch == 0 {
+		minimumShouldMatch = 1
+	}
+	return core.NewFilters().Raw(
+		fmt.Sprintf("(SELECT COUNT(*) FROM jsonb_array_elements_text(%s) AS item WHERE item IN (?)) >= ?", core.JSONField(fieldName)),
+		arr, minimumShouldMatch,
+	)
+}
+
+// GetSameContracts -
+func (storage *Storage) GetSameContracts(c contract.Contract, manager string, size, offset int64) (pcr contract.SameResponse, err error) {
+	if c.Fingerprint == nil {
+		return pcr, errors.Errorf("Invalid contract data")
+	}
+
+	if size == 0 {
+		size = core.DefaultSize
+	} else if size+offset > core.MaxQuerySize {
+		size = core.MaxQuerySize - offset
+	}
+
+	filters := core.NewFilters().
+		Equal("hash", c.Hash).
+		NotEqual("address", c.Address)
+	if manager != "" {
+		filters.Equal("manager", manager)
+	}
+
+	query := storage.db.Query(models.DocContracts, filters).
+		Order(core.Desc(core.TimeField("last_action"))).
+		Limit(size).
+		Offset(offset)
+
+	contracts := make([]contract.Contract, 0)
+	if pcr.Count, err = storage.db.GetAllByQueryWithTotal(models.DocContracts, filters, query, &contracts); err != nil {
+		return
+	}
+	if len(contracts) == 0 {
+		return pcr, core.NewRecordNotFoundError(models.DocContracts, "")
+	}
+	pcr.Contracts = contracts
+	return
+}
+
+// GetSimilarContracts -
+func (storage *Storage) GetSimilarContracts(c contract.Contract, size, offset int64) (pcr []contract.Similar, total int, err error) {
+	if c.Fingerprint == nil {
+		return
+	}
+
+	if size == 0 {
+		size = core.DefaultSize
+	} else if size+offset > core.MaxQuerySize {
+		size = core.MaxQuerySize - offset
+	}
+
+	filters := core.NewFilters().
+		Equal("project_id", c.ProjectID).
+		NotEqual("hash", c.Hash)
+	where, args := filters.Build()
+
+	hash := core.Field("hash")
+	lastAction := core.TimeField("last_action")
+	sql := fmt.Sprintf(`
+		SELECT data, count, COUNT(*) OVER() AS total FROM (
+			SELECT data, %s AS last_action,
+				COUNT(*) OVER (PARTITION BY %s) AS count,
+				ROW_NUMBER() OVER (PARTITION BY %s ORDER BY %s DESC) AS num
+			FROM %s WHERE %s
+		) AS projects WHERE num = 1 ORDER BY last_action DESC LIMIT ? OFFSET ?`,
+		lastAction, hash, hash, lastAction, models.DocContracts, where,
+	)
+
+	rows, err := storage.db.Raw(sql, append(args, size, offset)...).Rows()
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	pcr = make([]contract.Similar, 0)
+	for rows.Next() {
+		var data []byte
+		var count int64
+		if err = rows.Scan(&data, &count, &total); err != nil {
+			return
+		}
+		var cntr contract.Contract
+		if err = json.Unmarshal(data, &cntr); err != nil {
+			return
+		}
+		pcr = append(pcr, contract.Similar{
+			Contract: &cntr,
+			Count:    count,
+		})
+	}
+	err = rows.Err()
+	return
+}
+
+// GetDiffTasks -
+func (storage *Storage) GetDiffTasks() ([]contract.DiffTask, error) {
+	query := storage.db.LastByGroup(
+		models.DocContracts,
+		core.NewFilters(),
+		core.Desc(core.TimeField("last_action")),
+		core.Field("project_id"), core.Field("hash"),
+	)
+
+	var contracts []contract.Contract
+	if err := storage.db.GetAllByQuery(query, &contracts); err != nil {
+		return nil, err
+	}
+
+	projects := make(map[string][]contract.Contract)
+	for i := range contracts {
+		projects[contracts[i].ProjectID] = append(projects[contracts[i].ProjectID], contracts[i])
+	}
+
+	tasks := make([]contract.DiffTask, 0)
+	for _, similar := range projects {
+		if len(similar) < 2 {
+			continue
+		}
+
+		for i := 0; i < len(similar)-1; i++ {
+			for j := i + 1; j < len(similar); j++ {
+				tasks = append(tasks, contract.DiffTask{
+					Network1: similar[i].Network,
+					Address1: similar[i].Address,
+					Network2: similar[j].Network,
+					Address2: similar[j].Address,
+				})
+			}
+		}
+	}
+
+	rand.Seed(time.Now().Unix())
+	rand.Shuffle(len(tasks), func(i, j int) { tasks[i], tasks[j] = tasks[j], tasks[i] })
+	return tasks, nil
+}
+
+// GetTokens -
+func (storage *Storage) GetTokens(network, tokenInterface string, offset, size int64) ([]contract.Contract, int64, error) {
+	tags := []string{consts.FA12Tag, consts.FA1Tag, consts.FA2Tag}
+	if tokenInterface != "" {
+		tags = []string{tokenInterface}
+	}
+
+	filters := core.NewFilters().
+		Equal("network", network).
+		ArrayContains("tags", tags)
+
+	query := storage.db.Query(models.DocContracts, filters).
+		Order(core.Desc(core.TimeField("timestamp"))).
+		Offset(offset)
+	if size > 0 {
+		query = query.Limit(size)
+	}
+
+	contracts := make([]contract.Contract, 0)
+	count, err := storage.db.GetAllByQueryWithTotal(models.DocContracts, filters, query, &contracts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return contracts, count, nil
+}
+
+// UpdateField -
+func (storage *Storage) UpdateField(where []contract.Contract, fields ...string) error {
+	for i := range where {
+		if err := storage.db.UpdateFields(models.DocContracts, where[i].GetID(), where[i], fields...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stats -
+func (storage *Storage) Stats(c contract.Contract) (stats contract.Stats, err error) {
+	sameFilters := core.NewFilters().
+		Equal("hash", c.Hash).
+		NotEqual("address", c.Address)
+	if stats.SameCount, err = storage.db.CountByQuery(models.DocContracts, sameFilters); err != nil {
+		return
+	}
+
+	similarFilters := core.NewFilters().
+		Equal("project_id", c.ProjectID).
+		NotEqual("hash", c.Hash)
+	err = storage.db.Query(models.DocContracts, similarFilters).
+		Select(fmt.Sprintf("COUNT(DISTINCT %s)", core.Field("hash"))).
+		Row().
+		Scan(&stats.SimialarCount)
+	return
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/jinzhu/gorm"
+)
+
+const bulkSize = 1000
+
+// BulkInsert - inserts documents or replaces existing ones with the same id
+func (p *Postgres) BulkInsert(items []models.Model) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	tx := p.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	for index, group := range groupByIndex(items) {
+		for start := 0; start < len(group); start += bulkSize {
+			end := start + bulkSize
+			if end > len(group) {
+				end = len(group)
+			}
+			if err := upsert(tx, index, group[start:end]); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+	return tx.Commit().Error
+}
+
+// BulkUpdate - merges top-level fields of documents into existing ones
+func (p *Postgres) BulkUpdate(updates []models.Model) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	tx := p.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	for i := range updates {
+		data, err := json.MarshalToString(updates[i])
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		sql := fmt.Sprintf(`UPDATE %s SET data = data || ?::jsonb WHERE id = ?`, updates[i].GetIndex())
+		if err := tx.Exec(sql, data, updates[i].GetID()).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// BulkDelete -
+func (p *Postgres) BulkDelete(items []models.Model) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	tx := p.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	for index, group := range groupByIndex(items) {
+		for start := 0; start < len(group); start += bulkSize {
+			end := start + bulkSize
+			if end > len(group) {
+				end = len(group)
+			}
+			sql := fmt.Sprintf(`DELETE FROM %s WHERE id IN (?)`, index)
+			if err := tx.Exec(sql, getIDs(group[start:end])).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+	return tx.Commit().Error
+}
+
+// BulkRemoveField -
+func (p *Postgres) BulkRemoveField(field string, where []models.Model) error {
+	if len(where) == 0 {
+		return nil
+	}
+
+	path := strings.ReplaceAll(field, ".", ",")
+	tx := p.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	for index, group := range groupByIndex(where) {
+		for start := 0; start < len(group); start += bulkSize {
+			end := start + bulkSize
+			if end > len(group) {
+				end = len(group)
+			}
+			sql := fmt.Sprintf(`UPDATE %s SET data = data #- '{%s}' WHERE id IN (?)`, index, path)
+			if err := tx.Exec(sql, getIDs(group[start:end])).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+	return tx.Commit().Error
+}
+
+func upsert(tx *gorm.DB, index string, items []models.Model) error {
+	var builder strings.Builder
+	builder.WriteString("INSERT INTO ")
+	builder.WriteString(index)
+	builder.WriteString(" (id, data) VALUES ")
+
+	// the same document can't be affected twice by one statement, so the last version wins
+	last := make(map[string]int, len(items))
+	for i := range items {
+		last[items[i].GetID()] = i
+	}
+
+	args := make([]interface{}, 0, len(items)*2)
+	for i := range items {
+		if last[items[i].GetID()] != i {
+			continue
+		}
+		data, err := json.MarshalToString(items[i])
+		if err != nil {
+			return err
+		}
+		if len(args) > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString("(?, ?::jsonb)")
+		args = append(args, items[i].GetID(), data)
+	}
+	builder.WriteString(" ON CONFLICT (id) DO UPDATE SET data = EXCLUDED.data")
+
+	return tx.Exec(builder.String(), args...).Error
+}
+
+func groupByIndex(items []models.Model) map[string][]models.Model {
+	groups := make(map[string][]models.Model)
+	for i := range items {
+		index := items[i].GetIndex()
+		groups[index] = append(groups[index], items[i])
+	}
+	return groups
+}
+
+func getIDs(items []models.Model) []string {
+	ids := make([]string, len(items))
+	for i := range items {
+		ids[i] = items[i].GetID()
+	}
+	return ids
+}
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// default errors
+var (
+	ErrQueryPointerIsNil = errors.New("Query pointer is nil")
+)
+
+// IsRecordNotFound -
+func (p *Postgres) IsRecordNotFound(err error) bool {
+	if _, ok := err.(*RecordNotFoundError); ok {
+		return true
+	}
+	return gorm.IsRecordNotFoundError(err)
+}
+
+// RecordNotFoundError -
+type RecordNotFoundError struct {
+	index string
+	id    string
+}
+
+// NewRecordNotFoundError -
+func NewRecordNotFoundError(index, id string) *RecordNotFoundError {
+	return &RecordNotFoundError{index, id}
+}
+
+// Error -
+func (e *RecordNotFoundError) Error() string {
+	var builder strings.Builder
+	builder.WriteString("Record is not found: ")
+	if e.index != "" {
+		builder.WriteString("index=")
+		builder.WriteString(e.index)
+		builder.WriteString(" ")
+	}
+	if e.id != "" {
+		builder.WriteString("id=")
+		builder.WriteString(e.id)
+		builder.WriteString(" ")
+	}
+	return builder.String()
+}
@@ -0,0 +1,262 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/bcd"
+	constants "github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/pkg/errors"
+)
+
+// GetEvents -
+func (p *Postgres) GetEvents(subscriptions []models.SubscriptionRequest, size, offset int64) ([]models.Event, error) {
+	if len(subscriptions) == 0 {
+		return []models.Event{}, nil
+	}
+
+	if size == 0 || size > 50 {
+		size = DefaultSize
+	}
+
+	filters := make(map[string][]*Filters)
+	for i := range subscriptions {
+		getEventsFilters(subscriptions[i], filters)
+	}
+	if len(filters) == 0 {
+		return []models.Event{}, nil
+	}
+
+	subQueries := make([]string, 0, len(filters))
+	args := make([]interface{}, 0)
+	for _, index := range []string{models.DocOperations, models.DocMigrations, models.DocContracts} {
+		items, ok := filters[index]
+		if !ok {
+			continue
+		}
+		where, whereArgs := NewFilters().Any(items...).Build()
+		subQueries = append(subQueries, fmt.Sprintf(`SELECT '%s' AS idx, data FROM %s WHERE %s`, index, index, where))
+		args = append(args, whereArgs...)
+	}
+	args = append(args, size, offset)
+
+	sql := fmt.Sprintf(
+		`SELECT idx, data FROM (%s) AS events ORDER BY %s DESC LIMIT ? OFFSET ?`,
+		strings.Join(subQueries, " UNION ALL "), TimeField("timestamp"),
+	)
+	rows, err := p.Raw(sql, args...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]models.Event, 0)
+	for rows.Next() {
+		var index string
+		var data []byte
+		if err := rows.Scan(&index, &data); err != nil {
+			return nil, err
+		}
+		event, err := parseEvent(subscriptions, index, data)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (m *EventMigration) makeEvent(subscriptions []models.SubscriptionRequest) (models.Event, error) {
+	res := models.Event{
+		Type:    models.EventTypeMigration,
+		Address: m.Address,
+		Network: m.Network,
+		Body:    m,
+	}
+	for i := range subscriptions {
+		if m.Network == subscriptions[i].Network && m.Address == subscriptions[i].Address {
+			res.Alias = subscriptions[i].Alias
+			return res, nil
+		}
+	}
+	return models.Event{}, errors.Errorf("Couldn't find a matching subscription for %v", m)
+}
+
+func (o *EventOperation) makeEvent(subscriptions []models.SubscriptionRequest) (models.Event, error) {
+	res := models.Event{
+		Network: o.Network,
+		Body:    o,
+	}
+	for i := range subscriptions {
+		if o.Network != subscriptions[i].Network {
+			continue
+		}
+		if o.Source != subscriptions[i].Address && o.Destination != subscriptions[i].Address {
+			continue
+		}
+
+		res.Address = subscriptions[i].Address
+		res.Alias = subscriptions[i].Alias
+
+		switch {
+		case o.Status != constants.Applied:
+			res.Type = models.EventTypeError
+		case o.Source == subscriptions[i].Address && o.Kind == constants.Origination:
+			res.Type = models.EventTypeDeploy
+		case o.Source == subscriptions[i].Address && o.Kind == constants.Transaction:
+			res.Type = models.EventTypeCall
+		case o.Destination == subscriptions[i].Address && o.Kind == constants.Transaction:
+			res.Type = models.EventTypeInvoke
+		}
+
+		return res, nil
+	}
+	return models.Event{}, errors.Errorf("Couldn't find a matching subscription for %v", o)
+}
+
+func (c *EventContract) makeEvent(subscriptions []models.SubscriptionRequest) (models.Event, error) {
+	res := models.Event{
+		Body: c,
+	}
+	for i := range subscriptions {
+		if c.Hash == subscriptions[i].Hash || c.ProjectID == subscriptions[i].ProjectID {
+			res.Network = subscriptions[i].Network
+			res.Address = subscriptions[i].Address
+			res.Alias = subscriptions[i].Alias
+
+			if c.Hash == subscriptions[i].Hash {
+				res.Type = models.EventTypeSame
+			} else {
+				res.Type = models.EventTypeSimilar
+			}
+			return res, nil
+		}
+	}
+	return models.Event{}, errors.Errorf("Couldn't find a matching subscription for %v", c)
+}
+
+func parseEvent(subscriptions []models.SubscriptionRequest, index string, data []byte) (models.Event, error) {
+	switch index {
+	case models.DocOperations:
+		var event EventOperation
+		if err := json.Unmarshal(data, &event); err != nil {
+			return models.Event{}, err
+		}
+		return event.makeEvent(subscriptions)
+	case models.DocMigrations:
+		var event EventMigration
+		if err := json.Unmarshal(data, &event); err != nil {
+			return models.Event{}, err
+		}
+		return event.makeEvent(subscriptions)
+	case models.DocContracts:
+		var event EventContract
+		if err := json.Unmarshal(data, &event); err != nil {
+			return models.Event{}, err
+		}
+		return event.makeEvent(subscriptions)
+	default:
+		return models.Event{}, errors.Errorf("[parseEvent] Invalid reponse type: %s", index)
+	}
+}
+
+func getEventsFilters(subscription models.SubscriptionRequest, filters map[string][]*Filters) {
+	if item := getEventsWatchCalls(subscription); item != nil {
+		filters[models.DocOperations] = append(filters[models.DocOperations], item)
+	}
+	if item := getEventsWatchErrors(subscription); item != nil {
+		filters[models.DocOperations] = append(filters[models.DocOperations], item)
+	}
+	if item := getEventsWatchDeployments(subscription); item != nil {
+		filters[models.DocOperations] = append(filters[models.DocOperations], item)
+	}
+
+	if bcd.IsContract(subscription.Address) {
+		if item := getEventsWatchMigrations(subscription); item != nil {
+			filters[models.DocMigrations] = append(filters[models.DocMigrations], item)
+		}
+		if item := getSubscriptionWithSame(subscription); item != nil {
+			filters[models.DocContracts] = append(filters[models.DocContracts], item)
+		}
+		if item := getSubscriptionWithSimilar(subscription); item != nil {
+			filters[models.DocContracts] = append(filters[models.DocContracts], item)
+		}
+	}
+}
+
+func getEventsWatchMigrations(subscription models.SubscriptionRequest) *Filters {
+	if !subscription.WithMigrations {
+		return nil
+	}
+
+	return NewFilters().
+		In("kind", []string{constants.MigrationBootstrap, constants.MigrationLambda, constants.MigrationUpdate}).
+		Equal("network", subscription.Network).
+		Equal("address", subscription.Address)
+}
+
+func getEventsWatchDeployments(subscription models.SubscriptionRequest) *Filters {
+	if !subscription.WithDeployments {
+		return nil
+	}
+
+	return NewFilters().
+		Equal("kind", constants.Origination).
+		Equal("network", subscription.Network).
+		Equal("source", subscription.Address)
+}
+
+func getEventsWatchCalls(subscription models.SubscriptionRequest) *Filters {
+	if !subscription.WithCalls {
+		return nil
+	}
+
+	addressKeyword := "destination"
+	if strings.HasPrefix(subscription.Address, "tz") {
+		addressKeyword = "source"
+	}
+
+	return NewFilters().
+		Equal("kind", constants.Transaction).
+		Equal("status", constants.Applied).
+		Equal("network", subscription.Network).
+		Equal(addressKeyword, subscription.Address)
+}
+
+func getEventsWatchErrors(subscription models.SubscriptionRequest) *Filters {
+	if !subscription.WithErrors {
+		return nil
+	}
+
+	addressKeyword := "destination"
+	if strings.HasPrefix(subscription.Address, "tz") {
+		addressKeyword = "source"
+	}
+
+	return NewFilters().
+		Equal("network", subscription.Network).
+		Equal(addressKeyword, subscription.Address).
+		NotEqual("status", constants.Applied)
+}
+
+func getSubscriptionWithSame(subscription models.SubscriptionRequest) *Filters {
+	if !subscription.WithSame {
+		return nil
+	}
+
+	return NewFilters().
+		Equal("hash", subscription.Hash).
+		NotEqual("address", subscription.Address)
+}
+
+func getSubscriptionWithSimilar(subscription models.SubscriptionRequest) *Filters {
+	if !subscription.WithSimilar {
+		return nil
+	}
+
+	return NewFilters().
+		Equal("project_id", subscription.ProjectID).
+		NotEqual("hash", subscription.Hash).
+		NotEqual("address", subscription.Address)
+}
@@ -0,0 +1,299 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// Sizes -
+const (
+	DefaultSize  = 10
+	MaxQuerySize = 10000
+)
+
+// Query - returns query to `index` table filtered by `filters`
+func (p *Postgres) Query(index string, filters *Filters) *gorm.DB {
+	query := p.Table(index)
+	if filters != nil && !filters.Empty() {
+		where, args := filters.Build()
+		query = query.Where(where, args...)
+	}
+	return query
+}
+
+// GetByID -
+func (p *Postgres) GetByID(ret models.Model) error {
+	query := p.Table(ret.GetIndex()).Where("id = ?", ret.GetID())
+	return p.GetOne(query, ret)
+}
+
+// GetByIDs -
+func (p *Postgres) GetByIDs(output interface{}, ids ...string) error {
+	index, err := getIndex(output)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return p.GetAllByQuery(p.Table(index).Where("id IN (?)", ids), output)
+}
+
+// GetAll -
+func (p *Postgres) GetAll(output interface{}) error {
+	index, err := getIndex(output)
+	if err != nil {
+		return err
+	}
+	return p.GetAllByQuery(p.Table(index), output)
+}
+
+// GetByNetwork -
+func (p *Postgres) GetByNetwork(network string, output interface{}) error {
+	index, err := getIndex(output)
+	if err != nil {
+		return err
+	}
+	query := p.Query(index, NewFilters().Equal("network", network)).Order(Asc(IntField("level")))
+	return p.GetAllByQuery(query, output)
+}
+
+// GetByNetworkWithSort -
+func (p *Postgres) GetByNetworkWithSort(network, sortField, sortOrder string, output interface{}) error {
+	index, err := getIndex(output)
+	if err != nil {
+		return err
+	}
+	query := p.Query(index, NewFilters().Equal("network", network))
+	if sortOrder == "desc" {
+		query = query.Order(Desc(sortExpression(sortField)))
+	} else {
+		query = query.Order(Asc(sortExpression(sortField)))
+	}
+	return p.GetAllByQuery(query, output)
+}
+
+// GetOne - decodes first found document to `output`. Returns `RecordNotFoundError` if nothing found.
+func (p *Postgres) GetOne(query *gorm.DB, output models.Model) error {
+	rows, err := query.Select("id, data").Limit(1).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return NewRecordNotFoundError(output.GetIndex(), output.GetID())
+	}
+
+	var id string
+	var data []byte
+	if err := rows.Scan(&id, &data); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, output); err != nil {
+		return err
+	}
+	setID(reflect.ValueOf(output).Elem(), id)
+	return nil
+}
+
+// ScrollByNetwork - documents are paginated by id. `handler` must not change the chunk.
+func (p *Postgres) ScrollByNetwork(network string, output interface{}, size int64, handler func() error) error {
+	index, err := getIndex(output)
+	if err != nil {
+		return err
+	}
+	el := reflect.ValueOf(output).Elem()
+	if el.Kind() != reflect.Slice {
+		return errors.Errorf("Invalid `output` type: %s", el.Kind())
+	}
+	if size <= 0 {
+		size = MaxQuerySize
+	}
+
+	var lastID string
+	for {
+		el.Set(reflect.MakeSlice(el.Type(), 0, int(size)))
+
+		query := p.Query(index, NewFilters().Equal("network", network))
+		if lastID != "" {
+			query = query.Where("id > ?", lastID)
+		}
+		if err := p.GetAllByQuery(query.Order("id asc").Limit(size), output); err != nil {
+			return err
+		}
+		if el.Len() == 0 {
+			return nil
+		}
+		if err := handler(); err != nil {
+			return err
+		}
+		if int64(el.Len()) < size {
+			return nil
+		}
+
+		last, ok := el.Index(el.Len() - 1).Addr().Interface().(models.Model)
+		if !ok {
+			return errors.Errorf("Implements: 'output' is not implemented `Model` interface")
+		}
+		lastID = last.GetID()
+	}
+}
+
+// GetAllByQuery - decodes all found documents to `output` which is pointer to model or to slice of models
+func (p *Postgres) GetAllByQuery(query *gorm.DB, output interface{}) error {
+	if query == nil {
+		return ErrQueryPointerIsNil
+	}
+
+	typ, err := getElementType(output)
+	if err != nil {
+		return err
+	}
+
+	rows, err := query.Select("id, data").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	el := reflect.ValueOf(output).Elem()
+	for rows.Next() {
+		var id string
+		var data []byte
+		if err := rows.Scan(&id, &data); err != nil {
+			return err
+		}
+
+		val, err := parseDocument(id, data, typ)
+		if err != nil {
+			return err
+		}
+		if el.Kind() == reflect.Slice {
+			el.Set(reflect.Append(el, val))
+		} else {
+			el.Set(val)
+		}
+	}
+	return rows.Err()
+}
+
+// GetAllByQueryWithTotal - the same as `GetAllByQuery` but returns count of documents matched to `filters` without limit and offset
+func (p *Postgres) GetAllByQueryWithTotal(index string, filters *Filters, query *gorm.DB, output interface{}) (int64, error) {
+	var count int64
+	if err := p.Query(index, filters).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	return count, p.GetAllByQuery(query, output)
+}
+
+// CountByQuery -
+func (p *Postgres) CountByQuery(index string, filters *Filters) (count int64, err error) {
+	err = p.Query(index, filters).Count(&count).Error
+	return
+}
+
+// GetUnique - returns distinct text values of `field` for documents matched to `filters`
+func (p *Postgres) GetUnique(index, field string, filters *Filters) ([]string, error) {
+	var values []string
+	err := p.Query(index, filters).
+		Where(Field(field)+" IS NOT NULL").
+		Pluck("DISTINCT "+Field(field), &values).Error
+	return values, err
+}
+
+func getElementType(output interface{}) (reflect.Type, error) {
+	arr := reflect.TypeOf(output)
+	if arr.Kind() != reflect.Ptr {
+		return arr, errors.Errorf("Invalid `output` type: %s", arr.Kind())
+	}
+	arr = arr.Elem()
+	if arr.Kind() == reflect.Slice {
+		return arr.Elem(), nil
+	}
+	return arr, nil
+}
+
+func getIndex(output interface{}) (string, error) {
+	typ, err := getElementType(output)
+	if err != nil {
+		return "", err
+	}
+	newItem := reflect.New(typ)
+	interfaceType := reflect.TypeOf((*models.Model)(nil)).Elem()
+
+	if !newItem.Type().Implements(interfaceType) {
+		return "", errors.Errorf("Implements: 'output' is not implemented `Model` interface")
+	}
+	return newItem.Interface().(models.Model).GetIndex(), nil
+}
+
+func parseDocument(id string, data []byte, typ reflect.Type) (reflect.Value, error) {
+	n := reflect.New(typ).Interface()
+	if err := json.Unmarshal(data, n); err != nil {
+		return reflect.Value{}, err
+	}
+	val := reflect.ValueOf(n).Elem()
+	setID(val, id)
+	return val, nil
+}
+
+func setID(val reflect.Value, id string) {
+	if val.Kind() != reflect.Struct {
+		return
+	}
+	fieldID := val.FieldByName("ID")
+	if fieldID.IsValid() && fieldID.CanSet() && fieldID.Kind() == reflect.String {
+		fieldID.SetString(id)
+	}
+}
+
+// GetCountAgg - returns count of documents matched to `filters` grouped by value of SQL expression `group`
+func (p *Postgres) GetCountAgg(index, group string, filters *Filters) (map[string]int64, error) {
+	where, args := filters.Build()
+	sql := fmt.Sprintf(`SELECT %s AS key, COUNT(*) FROM %s WHERE %s GROUP BY key`, group, index, where)
+	return p.scanCounts(sql, args...)
+}
+
+func (p *Postgres) scanCounts(sql string, args ...interface{}) (map[string]int64, error) {
+	rows, err := p.Raw(sql, args...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var key *string
+		var count int64
+		if err := rows.Scan(&key, &count); err != nil {
+			return nil, err
+		}
+		if key != nil {
+			counts[*key] = count
+		}
+	}
+	return counts, rows.Err()
+}
+
+// LastByGroup - returns query to `index` table which selects only the first document sorted by `order` for every distinct value of `group` expressions
+func (p *Postgres) LastByGroup(index string, filters *Filters, order string, group ...string) *gorm.DB {
+	where, args := filters.Build()
+	groupBy := strings.Join(group, ", ")
+	last := fmt.Sprintf(
+		`SELECT DISTINCT ON (%s) id FROM %s WHERE %s ORDER BY %s, %s`,
+		groupBy, index, where, groupBy, order,
+	)
+	return p.Table(index).Where(fmt.Sprintf("id IN (%s)", last), args...)
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/tzip"
+	"github.com/pkg/errors"
+)
+
+func buildHistogramFilters(ctx models.HistogramContext) *Filters {
+	filters := NewFilters()
+	for _, fltr := range ctx.Filters {
+		switch fltr.Kind {
+		case models.HistogramFilterKindExists:
+			filters.Exists(fltr.Field)
+		case models.HistogramFilterKindMatch:
+			if value, ok := fltr.Value.(string); ok {
+				filters.Equal(fltr.Field, value)
+			} else {
+				filters.Match(fltr.Field, fltr.Value)
+			}
+		case models.HistogramFilterKindIn:
+			if arr, ok := fltr.Value.([]string); ok {
+				filters.In(fltr.Field, arr)
+			}
+		case models.HistogramFilterKindAddresses:
+			if value, ok := fltr.Value.([]string); ok {
+				filters.In(fltr.Field, value)
+			}
+		case models.HistogramFilterDexEnrtypoints:
+			if value, ok := fltr.Value.([]tzip.DAppContract); ok {
+				entrypoints := make([]*Filters, 0)
+				for i := range value {
+					for j := range value[i].DexVolumeEntrypoints {
+						entrypoints = append(entrypoints, NewFilters().
+							Equal("initiator", value[i].Address).
+							Equal("parent", value[i].DexVolumeEntrypoints[j]),
+						)
+					}
+				}
+				filters.Any(entrypoints...)
+			}
+		}
+	}
+	return filters
+}
+
+func buildHistogramFunction(ctx models.HistogramContext) (string, error) {
+	if !ctx.HasFunction() {
+		return "COUNT(*)", nil
+	}
+
+	field := strings.TrimSuffix(ctx.Function.Field, ".keyword")
+	switch ctx.Function.Name {
+	case "sum", "avg", "min", "max":
+		return fmt.Sprintf("COALESCE(%s(%s), 0)", strings.ToUpper(ctx.Function.Name), NumericField(field)), nil
+	case "cardinality":
+		return fmt.Sprintf("COUNT(DISTINCT %s)", Field(field)), nil
+	default:
+		return "", errors.Errorf("Unknown histogram function: %s", ctx.Function.Name)
+	}
+}
+
+// GetDateHistogram -
+func (p *Postgres) GetDateHistogram(period string, opts ...models.HistogramOption) ([][]int64, error) {
+	ctx := models.HistogramContext{
+		Period: period,
+	}
+	for _, opt := range opts {
+		opt(&ctx)
+	}
+
+	switch ctx.Period {
+	case "year", "month", "week", "day":
+	default:
+		return nil, errors.Errorf("Unknown histogram period: %s", ctx.Period)
+	}
+	if len(ctx.Indices) == 0 {
+		return nil, errors.New("Empty histogram indices")
+	}
+
+	function, err := buildHistogramFunction(ctx)
+	if err != nil {
+		return nil, err
+	}
+	where, args := buildHistogramFilters(ctx).Build()
+
+	bucket := fmt.Sprintf("date_trunc('%s', %s AT TIME ZONE 'UTC')", ctx.Period, TimeField("timestamp"))
+	subQueries := make([]string, len(ctx.Indices))
+	allArgs := make([]interface{}, 0, len(args)*len(ctx.Indices))
+	for i := range ctx.Indices {
+		subQueries[i] = fmt.Sprintf("SELECT data FROM %s WHERE %s", ctx.Indices[i], where)
+		allArgs = append(allArgs, args...)
+	}
+
+	sql := fmt.Sprintf(
+		`SELECT (extract(epoch FROM %s) * 1000)::bigint AS key, (%s)::bigint AS value FROM (%s) AS docs GROUP BY key ORDER BY key`,
+		bucket, function, strings.Join(subQueries, " UNION ALL "),
+	)
+
+	rows, err := p.Raw(sql, allArgs...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	histogram := make([][]int64, 0)
+	for rows.Next() {
+		var key, value int64
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		histogram = append(histogram, []int64{key, value})
+	}
+	return histogram, rows.Err()
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+)
+
+// indexedFields - document fields which are used in filters and sortings and have to be indexed
+var indexedFields = map[string][]string{
+	models.DocBigMapActions: {"level", "source_ptr", "destination_ptr"},
+	models.DocBigMapDiff:    {"level", "ptr", "key_hash", "address", "operation_id", "indexed_time"},
+	models.DocBlocks:        {"level"},
+	models.DocContracts:     {"level", "address", "hash", "project_id"},
+	models.DocMigrations:    {"level", "address"},
+	models.DocOperations:    {"level", "hash", "source", "destination", "indexed_time"},
+	models.DocProtocol:      {"hash", "start_level"},
+	models.DocSaplingDiff:   {"level", "ptr", "address", "operation_id"},
+	models.DocTezosDomains:  {"level", "name", "address"},
+	models.DocTickets:       {"holder", "ticketer"},
+	models.DocTicketUpdates: {"level", "holder", "ticketer"},
+	models.DocTokenBalances: {"address", "contract"},
+	models.DocTokenMetadata: {"level", "contract"},
+	models.DocTransfers:     {"level", "contract", "from", "to", "hash"},
+	models.DocTZIP:          {"level", "address"},
+}
+
+var numericFields = map[string]struct{}{
+	"level":           {},
+	"start_level":     {},
+	"ptr":             {},
+	"source_ptr":      {},
+	"destination_ptr": {},
+	"indexed_time":    {},
+}
+
+// fieldExpression - returns SQL expression which is used in index creation for document `field`
+func fieldExpression(field string) string {
+	if _, ok := numericFields[field]; ok {
+		return "(" + IntField(field) + ")"
+	}
+	return "(" + Field(field) + ")"
+}
+
+// sortExpression - returns SQL expression which is used in sorting by document `field`. Numeric fields are compared as numbers, not as text.
+func sortExpression(field string) string {
+	if _, ok := numericFields[field]; ok {
+		return NumericField(field)
+	}
+	return Field(field)
+}
+
+func indexName(index, field string) string {
+	return strings.ReplaceAll(index+"_"+field+"_idx", ".", "_")
+}
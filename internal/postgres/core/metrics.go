@@ -0,0 +1,50 @@
+package core
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/monitoring"
+)
+
+const allTables = "all"
+
+var tableRegexp = regexp.MustCompile(`(?i)\b(?:from|into|update)\s+"?(\w+)`)
+
+// queryLogger - gorm logger which observes duration of queries. Queries are labeled by table which is the repository of model. Nothing is printed.
+type queryLogger struct{}
+
+// Print -
+func (queryLogger) Print(values ...interface{}) {
+	if len(values) < 4 || values[0] != "sql" {
+		return
+	}
+	duration, ok := values[2].(time.Duration)
+	if !ok {
+		return
+	}
+	sql, ok := values[3].(string)
+	if !ok {
+		return
+	}
+	table, method := parseQuery(sql)
+	monitoring.ObserveStorageRequest(table, method, duration)
+}
+
+// parseQuery - returns table and statement of SQL query. Queries without table (e.g. DDL) are labeled by `all` table.
+func parseQuery(sql string) (string, string) {
+	sql = strings.TrimSpace(sql)
+
+	method := sql
+	if idx := strings.IndexAny(sql, " \t\n"); idx >= 0 {
+		method = sql[:idx]
+	}
+	method = strings.ToUpper(method)
+
+	table := allTables
+	if match := tableRegexp.FindStringSubmatch(sql); len(match) == 2 {
+		table = match[1]
+	}
+	return table, method
+}
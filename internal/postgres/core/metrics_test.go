@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		sql        string
+		wantTable  string
+		wantMethod string
+	}{
+		{
+			name:       "select",
+			sql:        `SELECT data FROM operation WHERE (data @> ?::jsonb) LIMIT 10`,
+			wantTable:  "operation",
+			wantMethod: "SELECT",
+		}, {
+			name:       "insert",
+			sql:        `INSERT INTO "contract" (id, data) VALUES ($1, $2)`,
+			wantTable:  "contract",
+			wantMethod: "INSERT",
+		}, {
+			name:       "update",
+			sql:        `update block SET data = jsonb_set(data, '{level}', $1) WHERE id = $2`,
+			wantTable:  "block",
+			wantMethod: "UPDATE",
+		}, {
+			name:       "delete",
+			sql:        "\nDELETE FROM transfer WHERE network = ?",
+			wantTable:  "transfer",
+			wantMethod: "DELETE",
+		}, {
+			name:       "create table",
+			sql:        `CREATE TABLE IF NOT EXISTS tzip (id TEXT PRIMARY KEY, data JSONB NOT NULL)`,
+			wantTable:  allTables,
+			wantMethod: "CREATE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, method := parseQuery(tt.sql)
+			assert.Equal(t, tt.wantTable, table)
+			assert.Equal(t, tt.wantMethod, method)
+		})
+	}
+}
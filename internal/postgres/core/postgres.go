@@ -0,0 +1,155 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/monitoring"
+	"github.com/jinzhu/gorm"
+	jsoniter "github.com/json-iterator/go"
+
+	// postgres driver
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// Postgres -
+type Postgres struct {
+	*gorm.DB
+}
+
+// New -
+func New(connection string) (*Postgres, error) {
+	db, err := gorm.Open("postgres", connection)
+	if err != nil {
+		return nil, err
+	}
+	// queries aren't printed, detailed mode is needed to observe their duration
+	if monitoring.IsEnabled() {
+		db.SetLogger(queryLogger{})
+		db.LogMode(true)
+	}
+
+	return &Postgres{db}, nil
+}
+
+// WaitNew -
+func WaitNew(connection string, timeout int) *Postgres {
+	var pg *Postgres
+	var err error
+
+	for pg == nil {
+		pg, err = New(connection)
+		if err != nil {
+			logger.Warning("Waiting postgres up %d seconds...", timeout)
+			time.Sleep(time.Second * time.Duration(timeout))
+		}
+	}
+	return pg
+}
+
+// CreateIndexes - creates document table and its indexes for every model
+func (p *Postgres) CreateIndexes() error {
+	for _, index := range models.AllDocuments() {
+		if err := p.createTableIfNotExists(index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Postgres) createTableIfNotExists(index string) error {
+	queries := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (id TEXT PRIMARY KEY, data JSONB NOT NULL)`, index),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_data_idx ON %s USING GIN (data jsonb_path_ops)`, index, index),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_network_idx ON %s ((data->>'network'))`, index, index),
+	}
+	for _, field := range indexedFields[index] {
+		queries = append(queries, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s ON %s (%s)`, indexName(index, field), index, fieldExpression(field)))
+	}
+
+	for i := range queries {
+		if err := p.Exec(queries[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteIndices -
+func (p *Postgres) DeleteIndices(indices []string) error {
+	for i := range indices {
+		if err := p.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s`, indices[i])).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteByLevelAndNetwork -
+func (p *Postgres) DeleteByLevelAndNetwork(indices []string, network string, maxLevel int64) error {
+	for i := range indices {
+		sql := fmt.Sprintf(`DELETE FROM %s WHERE %s = ? AND %s > ?`, indices[i], Field("network"), IntField("level"))
+		result := p.Exec(sql, network, maxLevel)
+		if result.Error != nil {
+			return result.Error
+		}
+		logger.Info("Removed %d records from %s", result.RowsAffected, indices[i])
+	}
+	return nil
+}
+
+// DeleteByContract -
+func (p *Postgres) DeleteByContract(indices []string, network, address string) error {
+	filters := NewFilters()
+	if network != "" {
+		filters.Equal("network", network)
+	}
+	if address != "" {
+		filters.Equal("contract", address)
+	}
+	where, args := filters.Build()
+
+	for i := range indices {
+		result := p.Exec(fmt.Sprintf(`DELETE FROM %s WHERE %s`, indices[i], where), args...)
+		if result.Error != nil {
+			return result.Error
+		}
+		logger.Info("Removed %d records from %s", result.RowsAffected, indices[i])
+	}
+	return nil
+}
+
+// SetAlias -
+func (p *Postgres) SetAlias(network, address, alias string) error {
+	updates := []struct {
+		index string
+		field string
+		alias string
+	}{
+		{models.DocContracts, "address", "alias"},
+		{models.DocContracts, "delegate", "delegate_alias"},
+		{models.DocOperations, "source", "source_alias"},
+		{models.DocOperations, "destination", "destination_alias"},
+		{models.DocOperations, "delegate", "delegate_alias"},
+	}
+
+	tx := p.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	for _, update := range updates {
+		sql := fmt.Sprintf(
+			`UPDATE %s SET data = jsonb_set(data, '{%s}', to_jsonb(?::text)) WHERE %s = ? AND %s = ?`,
+			update.index, update.alias, Field("network"), Field(update.field),
+		)
+		if err := tx.Exec(sql, alias, network, address).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%s %s %s %w", network, address, alias, err)
+		}
+	}
+	return tx.Commit().Error
+}
@@ -0,0 +1,202 @@
+package core
+
+import (
+	stdJSON "encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Field - returns SQL expression extracting document field as text. Nested fields are separated by dots.
+func Field(name string) string {
+	if strings.Contains(name, ".") {
+		return fmt.Sprintf("data#>>'{%s}'", strings.ReplaceAll(name, ".", ","))
+	}
+	return fmt.Sprintf("data->>'%s'", name)
+}
+
+// JSONField - returns SQL expression extracting document field as jsonb
+func JSONField(name string) string {
+	if strings.Contains(name, ".") {
+		return fmt.Sprintf("data#>'{%s}'", strings.ReplaceAll(name, ".", ","))
+	}
+	return fmt.Sprintf("data->'%s'", name)
+}
+
+// IntField - returns SQL expression extracting document field as bigint
+func IntField(name string) string {
+	return fmt.Sprintf("(%s)::bigint", Field(name))
+}
+
+// NumericField - returns SQL expression extracting document field as numeric
+func NumericField(name string) string {
+	return fmt.Sprintf("(%s)::numeric", Field(name))
+}
+
+// BoolField - returns SQL expression extracting document field as boolean
+func BoolField(name string) string {
+	return fmt.Sprintf("(%s)::boolean", Field(name))
+}
+
+// TimeField - returns SQL expression extracting document field as timestamp
+func TimeField(name string) string {
+	return fmt.Sprintf("(%s)::timestamptz", Field(name))
+}
+
+// Asc -
+func Asc(expression string) string {
+	return fmt.Sprintf("%s ASC", expression)
+}
+
+// Desc -
+func Desc(expression string) string {
+	return fmt.Sprintf("%s DESC", expression)
+}
+
+// Filters - conjunction of conditions on document fields
+type Filters struct {
+	conditions []string
+	args       []interface{}
+}
+
+// NewFilters -
+func NewFilters() *Filters {
+	return &Filters{
+		conditions: make([]string, 0),
+		args:       make([]interface{}, 0),
+	}
+}
+
+// Empty -
+func (f *Filters) Empty() bool {
+	return len(f.conditions) == 0
+}
+
+// Raw - adds raw SQL condition
+func (f *Filters) Raw(condition string, args ...interface{}) *Filters {
+	f.conditions = append(f.conditions, fmt.Sprintf("(%s)", condition))
+	f.args = append(f.args, args...)
+	return f
+}
+
+// Equal - adds text equality condition
+func (f *Filters) Equal(field string, value string) *Filters {
+	return f.Raw(fmt.Sprintf("%s = ?", Field(field)), value)
+}
+
+// NotEqual - adds text inequality condition. Documents without the field are matched too.
+func (f *Filters) NotEqual(field string, value string) *Filters {
+	return f.Raw(fmt.Sprintf("%s IS DISTINCT FROM ?", Field(field)), value)
+}
+
+// Match - adds containment condition. Value is compared with its JSON type, so it can be number, boolean or string.
+func (f *Filters) Match(field string, value interface{}) *Filters {
+	doc, err := stdJSON.Marshal(nested(field, value))
+	if err != nil {
+		return f.Raw("FALSE")
+	}
+	return f.Raw("data @> ?::jsonb", string(doc))
+}
+
+// Range - adds numeric comparison. `op` is one of SQL comparison operators
+func (f *Filters) Range(field, op string, value interface{}) *Filters {
+	return f.Raw(fmt.Sprintf("%s %s ?", IntField(field), op), value)
+}
+
+// TimeRange - adds timestamp comparison. `op` is one of SQL comparison operators
+func (f *Filters) TimeRange(field, op string, value interface{}) *Filters {
+	return f.Raw(fmt.Sprintf("%s %s ?", TimeField(field), op), value)
+}
+
+// In - adds condition that text field is one of `values`
+func (f *Filters) In(field string, values []string) *Filters {
+	if len(values) == 0 {
+		return f.Raw("FALSE")
+	}
+	return f.Raw(fmt.Sprintf("%s IN (?)", Field(field)), values)
+}
+
+// ArrayContains - adds condition that array field contains one of `values`
+func (f *Filters) ArrayContains(field string, values []string) *Filters {
+	if len(values) == 0 {
+		return f.Raw("FALSE")
+	}
+	return f.Raw(fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements_text(%s) AS item WHERE item IN (?))", JSONField(field)), values)
+}
+
+// Exists - adds condition that field is set and not null
+func (f *Filters) Exists(field string) *Filters {
+	return f.Raw(fmt.Sprintf("%s IS NOT NULL AND %s <> 'null'::jsonb", JSONField(field), JSONField(field)))
+}
+
+// Any - adds disjunction of `items`
+func (f *Filters) Any(items ...*Filters) *Filters {
+	conditions := make([]string, 0, len(items))
+	args := make([]interface{}, 0)
+	for i := range items {
+		if items[i].Empty() {
+			continue
+		}
+		where, itemArgs := items[i].Build()
+		conditions = append(conditions, where)
+		args = append(args, itemArgs...)
+	}
+	if len(conditions) == 0 {
+		return f.Raw("FALSE")
+	}
+	return f.Raw(strings.Join(conditions, " OR "), args...)
+}
+
+// Not - adds negation of `item`
+func (f *Filters) Not(item *Filters) *Filters {
+	if item.Empty() {
+		return f
+	}
+	where, args := item.Build()
+	return f.Raw(fmt.Sprintf("NOT %s", where), args...)
+}
+
+// Build - returns SQL condition and its arguments
+func (f *Filters) Build() (string, []interface{}) {
+	if f.Empty() {
+		return "(TRUE)", nil
+	}
+	return fmt.Sprintf("(%s)", strings.Join(f.conditions, " AND ")), f.args
+}
+
+func nested(field string, value interface{}) map[string]interface{} {
+	parts := strings.Split(field, ".")
+	result := map[string]interface{}{
+		parts[len(parts)-1]: value,
+	}
+	for i := len(parts) - 2; i >= 0; i-- {
+		result = map[string]interface{}{
+			parts[i]: result,
+		}
+	}
+	return result
+}
+
+// FiltersToQuery - converts map of field values to filters. Keys with `.or` suffix are matched with any of values.
+func FiltersToQuery(by map[string]interface{}) *Filters {
+	filters := NewFilters()
+	for k, v := range by {
+		if strings.HasSuffix(k, ".or") {
+			field := strings.TrimSuffix(k, ".or")
+			if field == "" {
+				continue
+			}
+			values, ok := v.([]interface{})
+			if !ok {
+				continue
+			}
+			items := make([]*Filters, len(values))
+			for i := range values {
+				items[i] = NewFilters().Match(field, values[i])
+			}
+			filters.Any(items...)
+		} else {
+			filters.Match(k, v)
+		}
+	}
+	return filters
+}
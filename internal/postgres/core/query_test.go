@@ -0,0 +1,82 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiltersToQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    map[string]interface{}
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{
+			name: "or",
+			filter: map[string]interface{}{
+				"source.or": []interface{}{
+					"tz1W2zByMLGXqemN9jM9s3aagx7cX5S4QojY",
+					"tz1Y63jVYqAMTbomAuGadHqohBpDJ95DP1GP",
+				},
+			},
+			wantWhere: "((((data @> ?::jsonb)) OR ((data @> ?::jsonb))))",
+			wantArgs: []interface{}{
+				`{"source":"tz1W2zByMLGXqemN9jM9s3aagx7cX5S4QojY"}`,
+				`{"source":"tz1Y63jVYqAMTbomAuGadHqohBpDJ95DP1GP"}`,
+			},
+		},
+		{
+			name: "empty or field",
+			filter: map[string]interface{}{
+				".or": []interface{}{
+					"tz1W2zByMLGXqemN9jM9s3aagx7cX5S4QojY",
+				},
+			},
+			wantWhere: "(TRUE)",
+		},
+		{
+			name: "nested match",
+			filter: map[string]interface{}{
+				"fingerprint.code": "abc",
+			},
+			wantWhere: "((data @> ?::jsonb))",
+			wantArgs: []interface{}{
+				`{"fingerprint":{"code":"abc"}}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := FiltersToQuery(tt.filter).Build()
+			assert.Equal(t, tt.wantWhere, where)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestFilters_Build(t *testing.T) {
+	where, args := NewFilters().
+		Equal("network", "mainnet").
+		Range("level", ">", 10).
+		Any(
+			NewFilters().Equal("source", "a"),
+			NewFilters().Equal("destination", "a"),
+		).
+		Build()
+
+	assert.Equal(t, "((data->>'network' = ?) AND ((data->>'level')::bigint > ?) AND (((data->>'source' = ?)) OR ((data->>'destination' = ?))))", where)
+	assert.Equal(t, []interface{}{"mainnet", 10, "a", "a"}, args)
+}
+
+func TestField(t *testing.T) {
+	assert.Equal(t, "data->>'level'", Field("level"))
+	assert.Equal(t, "data#>>'{result,consumed_gas}'", Field("result.consumed_gas"))
+	assert.Equal(t, "(data#>>'{result,consumed_gas}')::bigint", IntField("result.consumed_gas"))
+}
+
+func Test_sortExpression(t *testing.T) {
+	assert.Equal(t, "(data->>'start_level')::numeric", sortExpression("start_level"))
+	assert.Equal(t, "data->>'hash'", sortExpression("hash"))
+}
@@ -0,0 +1,269 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/search"
+	"github.com/pkg/errors"
+)
+
+type searchField struct {
+	name  string
+	score int
+}
+
+// SearchByText -
+func (p *Postgres) SearchByText(text string, offset int64, fields []string, filters map[string]interface{}, group bool) (models.Result, error) {
+	result := models.Result{}
+	if text == "" {
+		return result, errors.Errorf("Empty search string. Please query something")
+	}
+
+	ctx, searchFields, err := prepareSearch(text, filters, fields)
+	if err != nil {
+		return result, err
+	}
+	ctx.Offset = offset
+
+	conditions, err := prepareSearchFilters(filters)
+	if err != nil {
+		return result, err
+	}
+
+	sql, args := buildSearchQuery(ctx, searchFields, conditions, group)
+
+	start := time.Now()
+	rows, err := p.Raw(sql, args...).Rows()
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	items := make([]models.Item, 0)
+	for rows.Next() {
+		var index string
+		var data []byte
+		var total, groupCount int64
+		if err := rows.Scan(&index, &data, &total, &groupCount); err != nil {
+			return result, err
+		}
+		result.Count = total
+
+		val, err := search.Parse(index, getHighlights(ctx, searchFields, data), data)
+		if err != nil {
+			logger.Error(err)
+			return models.Result{}, nil
+		}
+		switch t := val.(type) {
+		case models.Item:
+			if groupCount > 1 {
+				t.Group = models.NewGroup(groupCount)
+			}
+			items = append(items, t)
+		case []models.Item:
+			items = append(items, t...)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+
+	result.Items = items
+	result.Time = time.Since(start).Milliseconds()
+	return result, nil
+}
+
+func prepareSearch(text string, filters map[string]interface{}, fields []string) (search.Context, []searchField, error) {
+	ctx := search.NewContext()
+
+	if search.IsPtrSearch(text) {
+		ctx.Text = strings.TrimPrefix(text, "ptr:")
+		ctx.Indices = []string{models.DocBigMapDiff}
+		ctx.Fields = []string{"ptr"}
+		return ctx, nil, nil
+	}
+
+	var indices []string
+	if val, ok := filters["indices"]; ok {
+		indices = val.([]string)
+		delete(filters, "indices")
+	}
+
+	info, err := search.GetScores(text, fields, indices...)
+	if err != nil {
+		return ctx, nil, err
+	}
+	ctx.Text = text
+	ctx.Indices = info.Indices
+	ctx.Fields = info.Scores
+
+	searchFields := make([]searchField, 0, len(info.Scores))
+	for _, score := range info.Scores {
+		s := strings.Split(score, "^")
+		field := searchField{s[0], 1}
+		if len(s) == 2 {
+			if value, err := strconv.Atoi(s[1]); err == nil {
+				field.score = value
+			}
+		}
+		searchFields = append(searchFields, field)
+	}
+	return ctx, searchFields, nil
+}
+
+func prepareSearchFilters(filters map[string]interface{}) (*Filters, error) {
+	conditions := NewFilters()
+	for k, v := range filters {
+		switch k {
+		case "from":
+			val, ok := v.(string)
+			if !ok {
+				return nil, errors.Errorf("Invalid type for 'from' filter (wait string): %T", v)
+			}
+			if val != "" {
+				conditions.TimeRange("timestamp", ">", val)
+			}
+		case "to":
+			val, ok := v.(string)
+			if !ok {
+				return nil, errors.Errorf("Invalid type for 'to' filter (wait string): %T", v)
+			}
+			if val != "" {
+				conditions.TimeRange("timestamp", "<", val)
+			}
+		case "networks":
+			val, ok := v.([]string)
+			if !ok {
+				return nil, errors.Errorf("Invalid type for 'network' filter (wait []string): %T", v)
+			}
+			if len(val) > 0 {
+				conditions.In("network", val)
+			}
+		case "languages":
+			val, ok := v.([]string)
+			if !ok {
+				return nil, errors.Errorf("Invalid type for 'language' filter (wait []string): %T", v)
+			}
+			if len(val) > 0 {
+				conditions.In("language", val)
+			}
+		case "tags":
+			val, ok := v.([]string)
+			if !ok {
+				return nil, errors.Errorf("Invalid type for 'tags' filter (wait []string): %T", v)
+			}
+			if len(val) > 0 {
+				conditions.ArrayContains("tags", val)
+			}
+		default:
+			return nil, errors.Errorf("Unknown search filter: %s", k)
+		}
+	}
+	return conditions, nil
+}
+
+// searchGroups - SQL expressions of keys which found documents are grouped by. Documents of other tables are not grouped.
+var searchGroups = map[string]string{
+	models.DocContracts:     fmt.Sprintf("%s || '|' || %s || '|' || %s", Field("fingerprint.parameter"), Field("fingerprint.storage"), Field("fingerprint.code")),
+	models.DocOperations:    Field("hash"),
+	models.DocTZIP:          fmt.Sprintf("%s || '|' || %s", Field("network"), Field("address")),
+	models.DocBigMapDiff:    Field("key_hash"),
+	models.DocTezosDomains:  fmt.Sprintf("%s || '|' || %s", Field("name"), Field("network")),
+	models.DocTokenMetadata: fmt.Sprintf("%s || %s || %s", Field("network"), Field("contract"), Field("token_id")),
+}
+
+func searchGroupKey(index string) string {
+	key, ok := searchGroups[index]
+	if !ok {
+		return fmt.Sprintf("'%s|' || id", index)
+	}
+	return fmt.Sprintf("'%s|' || COALESCE(%s, id)", index, key)
+}
+
+// buildSearchQuery - returns query of found documents with total count of them and count of documents in group of every document.
+// If `group` is true, only the best document of group is returned and groups are sorted by the best score and the last action of their documents.
+func buildSearchQuery(ctx search.Context, fields []searchField, filters *Filters, group bool) (string, []interface{}) {
+	subQueries := make([]string, 0, len(ctx.Indices))
+	args := make([]interface{}, 0)
+
+	for _, index := range ctx.Indices {
+		conditions := NewFilters()
+		score := "1"
+		if len(fields) == 0 {
+			conditions.Equal("ptr", ctx.Text)
+		} else {
+			pattern := fmt.Sprintf("%%%s%%", escapeLike(ctx.Text))
+			matches := make([]*Filters, len(fields))
+			scores := make([]string, len(fields))
+			for i := range fields {
+				matches[i] = NewFilters().Raw(fmt.Sprintf("%s ILIKE ?", Field(fields[i].name)), pattern)
+				scores[i] = fmt.Sprintf("CASE WHEN %s ILIKE ? THEN %d ELSE 0 END", Field(fields[i].name), fields[i].score)
+				args = append(args, pattern)
+			}
+			score = strings.Join(scores, " + ")
+			conditions.Any(matches...)
+		}
+		if !filters.Empty() {
+			where, filterArgs := filters.Build()
+			conditions.Raw(where, filterArgs...)
+		}
+
+		where, whereArgs := conditions.Build()
+		args = append(args, whereArgs...)
+		subQueries = append(subQueries, fmt.Sprintf(
+			`SELECT '%s' AS idx, data, (%s) AS score, %s AS ts, %s AS grp FROM %s WHERE %s`,
+			index, score, TimeField("timestamp"), searchGroupKey(index), index, where,
+		))
+	}
+	args = append(args, DefaultSize, ctx.Offset)
+
+	found := strings.Join(subQueries, " UNION ALL ")
+	if !group {
+		sql := fmt.Sprintf(
+			`SELECT idx, data, COUNT(*) OVER() AS total, 1 AS group_count FROM (%s) AS found ORDER BY score DESC, ts DESC NULLS LAST LIMIT ? OFFSET ?`,
+			found,
+		)
+		return sql, args
+	}
+
+	sql := fmt.Sprintf(
+		`SELECT idx, data, total, group_count FROM (`+
+			`SELECT idx, data, COUNT(*) OVER() AS total, COUNT(*) OVER(PARTITION BY grp) AS group_count, `+
+			`ROW_NUMBER() OVER(PARTITION BY grp ORDER BY score DESC, ts DESC NULLS LAST) AS position, `+
+			`MAX(score) OVER(PARTITION BY grp) AS group_score, MAX(COALESCE(%s, ts)) OVER(PARTITION BY grp) AS group_time `+
+			`FROM (%s) AS found) AS grouped `+
+			`WHERE position = 1 ORDER BY group_score DESC, group_time DESC NULLS LAST LIMIT ? OFFSET ?`,
+		TimeField("last_action"), found,
+	)
+	return sql, args
+}
+
+func getHighlights(ctx search.Context, fields []searchField, data []byte) map[string][]string {
+	highlights := make(map[string][]string)
+	if len(fields) == 0 {
+		return highlights
+	}
+
+	text := strings.ToLower(ctx.Text)
+	for i := range fields {
+		path := make([]interface{}, 0)
+		for _, part := range strings.Split(fields[i].name, ".") {
+			path = append(path, part)
+		}
+		value := json.Get(data, path...).ToString()
+		if value == "" || !strings.Contains(strings.ToLower(value), text) {
+			continue
+		}
+		highlights[fields[i].name] = []string{fmt.Sprintf("<em>%s</em>", value)}
+	}
+	return highlights
+}
+
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+}
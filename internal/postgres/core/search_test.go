@@ -0,0 +1,28 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/search"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_buildSearchQuery(t *testing.T) {
+	ctx := search.NewContext()
+	ctx.Text = "KT1"
+	ctx.Indices = []string{models.DocContracts, models.DocTransfers}
+	fields := []searchField{{name: "address", score: 10}}
+
+	sql, args := buildSearchQuery(ctx, fields, NewFilters(), false)
+	assert.NotContains(t, sql, "PARTITION BY")
+	assert.Len(t, args, 6)
+
+	sql, args = buildSearchQuery(ctx, fields, NewFilters(), true)
+	assert.Len(t, args, 6)
+	assert.Contains(t, sql, "'contract|' || COALESCE(data#>>'{fingerprint,parameter}' || '|' || data#>>'{fingerprint,storage}' || '|' || data#>>'{fingerprint,code}', id) AS grp")
+	assert.Contains(t, sql, "'transfer|' || id AS grp")
+	assert.Contains(t, sql, "COUNT(*) OVER(PARTITION BY grp) AS group_count")
+	assert.True(t, strings.HasSuffix(sql, "WHERE position = 1 ORDER BY group_score DESC, group_time DESC NULLS LAST LIMIT ? OFFSET ?"))
+}
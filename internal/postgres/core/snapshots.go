@@ -0,0 +1,57 @@
+package core
+
+import (
+	"io"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+)
+
+// CreateAWSRepository -
+func (p *Postgres) CreateAWSRepository(name, awsBucketName, awsRegion string) error {
+	return nil
+}
+
+// ListRepositories -
+func (p *Postgres) ListRepositories() ([]models.Repository, error) {
+	return nil, nil
+}
+
+// CreateSnapshots -
+func (p *Postgres) CreateSnapshots(repository, snapshot string, indices []string) error {
+	return nil
+}
+
+// RestoreSnapshots -
+func (p *Postgres) RestoreSnapshots(repository, snapshot string, indices []string) error {
+	return nil
+}
+
+// ListSnapshots -
+func (p *Postgres) ListSnapshots(repository string) (string, error) {
+	return "", nil
+}
+
+// SetSnapshotPolicy -
+func (p *Postgres) SetSnapshotPolicy(policyID, cronSchedule, name, repository string, expireAfterInDays int64) error {
+	return nil
+}
+
+// GetAllPolicies -
+func (p *Postgres) GetAllPolicies() ([]string, error) {
+	return nil, nil
+}
+
+// GetMappings -
+func (p *Postgres) GetMappings(indices []string) (map[string]string, error) {
+	return nil, nil
+}
+
+// CreateMapping -
+func (p *Postgres) CreateMapping(index string, reader io.Reader) error {
+	return nil
+}
+
+// ReloadSecureSettings -
+func (p *Postgres) ReloadSecureSettings() error {
+	return nil
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+)
+
+// GetNetworkCountStats -
+func (p *Postgres) GetNetworkCountStats(network string) (map[string]int64, error) {
+	where, args := NewFilters().
+		Equal("network", network).
+		Any(
+			NewFilters().Exists("entrypoint"),
+			NewFilters().Exists("fingerprint"),
+		).Build()
+
+	counts := make(map[string]int64)
+	for _, index := range []string{models.DocContracts, models.DocOperations} {
+		var count int64
+		if err := p.Table(index).Where(where, args...).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		counts[index] = count
+	}
+	return counts, nil
+}
+
+// GetCallsCountByNetwork -
+func (p *Postgres) GetCallsCountByNetwork(network string) (map[string]int64, error) {
+	filters := NewFilters().Exists("entrypoint")
+	if network != "" {
+		filters.Equal("network", network)
+	}
+	return p.GetCountAgg(models.DocOperations, Field("network"), filters)
+}
+
+// GetContractStatsByNetwork -
+func (p *Postgres) GetContractStatsByNetwork(network string) (map[string]models.ContractCountStats, error) {
+	filters := NewFilters()
+	if network != "" {
+		filters.Equal("network", network)
+	}
+	where, args := filters.Build()
+
+	sql := fmt.Sprintf(
+		`SELECT %s AS network, COUNT(*), COUNT(DISTINCT concat_ws('|', %s, %s, %s)), COALESCE(SUM(%s), 0)::bigint FROM %s WHERE %s GROUP BY network`,
+		Field("network"), Field("fingerprint.parameter"), Field("fingerprint.storage"), Field("fingerprint.code"),
+		NumericField("balance"), models.DocContracts, where,
+	)
+	rows, err := p.Raw(sql, args...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]models.ContractCountStats)
+	for rows.Next() {
+		var key string
+		var stats models.ContractCountStats
+		if err := rows.Scan(&key, &stats.Total, &stats.SameCount, &stats.Balance); err != nil {
+			return nil, err
+		}
+		counts[key] = stats
+	}
+	return counts, rows.Err()
+}
+
+// GetFACountByNetwork -
+func (p *Postgres) GetFACountByNetwork(network string) (map[string]int64, error) {
+	filters := NewFilters().ArrayContains("tags", []string{"fa1", "fa12"})
+	if network != "" {
+		filters.Equal("network", network)
+	}
+	return p.GetCountAgg(models.DocContracts, Field("network"), filters)
+}
+
+// GetLanguagesForNetwork -
+func (p *Postgres) GetLanguagesForNetwork(network string) (map[string]int64, error) {
+	return p.GetCountAgg(models.DocContracts, Field("language"), NewFilters().Equal("network", network))
+}
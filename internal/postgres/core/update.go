@@ -0,0 +1,58 @@
+package core
+
+import (
+	stdJSON "encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+)
+
+// UpdateDoc - updates document
+func (p *Postgres) UpdateDoc(model models.Model) error {
+	data, err := json.MarshalToString(model)
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf(`INSERT INTO %s (id, data) VALUES (?, ?::jsonb) ON CONFLICT (id) DO UPDATE SET data = EXCLUDED.data`, model.GetIndex())
+	return p.Exec(sql, model.GetID(), data).Error
+}
+
+// BuildFieldsForModel - returns JSON document containing only `fields` of `data`
+func (p *Postgres) BuildFieldsForModel(data interface{}, fields ...string) (string, error) {
+	val := reflect.ValueOf(data)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	t := val.Type()
+
+	mapFields := make(map[string]struct{})
+	for i := range fields {
+		mapFields[fields[i]] = struct{}{}
+	}
+
+	updateFields := make(map[string]interface{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if _, ok := mapFields[field.Name]; !ok {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		tagName := strings.Split(tag, ",")[0]
+		updateFields[tagName] = val.Field(i).Interface()
+	}
+
+	result, err := stdJSON.Marshal(updateFields)
+	return string(result), err
+}
+
+// UpdateFields - updates `fields` of document with `id`. If document does not exist it will be created.
+func (p *Postgres) UpdateFields(index, id string, data interface{}, fields ...string) error {
+	updated, err := p.BuildFieldsForModel(data, fields...)
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf(`INSERT INTO %s (id, data) VALUES (?, ?::jsonb) ON CONFLICT (id) DO UPDATE SET data = %s.data || EXCLUDED.data`, index, index)
+	return p.Exec(sql, id, updated).Error
+}
@@ -0,0 +1,39 @@
+package migration
+
+import (
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/migration"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Postgres
+}
+
+// NewStorage -
+func NewStorage(db *core.Postgres) *Storage {
+	return &Storage{db}
+}
+
+// Get -
+func (storage *Storage) Get(network, address string) (migrations []migration.Migration, err error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Equal("address", address)
+
+	query := storage.db.Query(models.DocMigrations, filters).Order(core.Desc(core.IntField("level")))
+	err = storage.db.GetAllByQuery(query, &migrations)
+	return
+}
+
+// Count -
+func (storage *Storage) Count(network, address string) (int64, error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Any(
+			core.NewFilters().Equal("source", address),
+			core.NewFilters().Equal("destination", address),
+		)
+	return storage.db.CountByQuery(models.DocMigrations, filters)
+}
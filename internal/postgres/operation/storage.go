@@ -0,0 +1,335 @@
+package operation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd"
+	constants "github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/operation"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/pkg/errors"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Postgres
+}
+
+// NewStorage -
+func NewStorage(db *core.Postgres) *Storage {
+	return &Storage{db}
+}
+
+func addressFilter(address string) *core.Filters {
+	return core.NewFilters().Any(
+		core.NewFilters().Equal("source", address),
+		core.NewFilters().Equal("destination", address),
+	)
+}
+
+func prepareOperationFilters(filters map[string]interface{}, conditions *core.Filters) error {
+	for k, v := range filters {
+		if v == "" {
+			continue
+		}
+		switch k {
+		case "from":
+			conditions.Raw(fmt.Sprintf("extract(epoch FROM %s) * 1000 >= ?", core.TimeField("timestamp")), v)
+		case "to":
+			conditions.Raw(fmt.Sprintf("extract(epoch FROM %s) * 1000 <= ?", core.TimeField("timestamp")), v)
+		case "entrypoints":
+			conditions.In("entrypoint", parseList(v))
+		case "last_id":
+			conditions.Raw(fmt.Sprintf("%s < ?::bigint", core.IntField("indexed_time")), v)
+		case "status":
+			conditions.In("status", parseList(v))
+		default:
+			return errors.Errorf("Unknown operation filter: %s %v", k, v)
+		}
+	}
+	return nil
+}
+
+// parseList - parses list of quoted values: 'a','b','c'
+func parseList(value interface{}) []string {
+	str := fmt.Sprintf("%v", value)
+	items := strings.Split(str, ",")
+	result := make([]string, 0, len(items))
+	for i := range items {
+		item := strings.Trim(strings.TrimSpace(items[i]), "'")
+		if item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// GetByContract -
+func (storage *Storage) GetByContract(network, address string, size uint64, filters map[string]interface{}) (po operation.Pageable, err error) {
+	if size == 0 || size > core.MaxQuerySize {
+		size = core.DefaultSize
+	}
+
+	opgFilters := core.NewFilters().
+		Equal("network", network).
+		Any(addressFilter(address))
+	if err = prepareOperationFilters(filters, opgFilters); err != nil {
+		return
+	}
+	opgWhere, opgArgs := opgFilters.Build()
+
+	opg := fmt.Sprintf(
+		`SELECT %s, COALESCE(%s, 0) FROM %s WHERE %s GROUP BY 1, 2, %s ORDER BY %s LIMIT ?`,
+		core.Field("hash"), core.IntField("counter"), models.DocOperations, opgWhere,
+		core.IntField("level"), core.Desc(core.IntField("level")),
+	)
+	args := append([]interface{}{network}, opgArgs...)
+	args = append(args, size)
+
+	query := storage.db.Table(models.DocOperations).
+		Where(
+			fmt.Sprintf("%s = ? AND (%s, COALESCE(%s, 0)) IN (%s)", core.Field("network"), core.Field("hash"), core.IntField("counter"), opg),
+			args...,
+		).
+		Order(sortOperations())
+
+	ops := make([]operation.Operation, 0)
+	if err = storage.db.GetAllByQuery(query, &ops); err != nil {
+		return
+	}
+
+	var lastID int64
+	for i := range ops {
+		if lastID == 0 || ops[i].IndexedTime < lastID {
+			lastID = ops[i].IndexedTime
+		}
+	}
+
+	po.Operations = ops
+	po.LastID = fmt.Sprintf("%d", lastID)
+	return
+}
+
+func sortOperations() string {
+	return fmt.Sprintf(
+		"%s DESC, COALESCE(%s, 0) DESC, (CASE WHEN %s THEN 998 - COALESCE(%s, 0) ELSE 999 END) DESC",
+		core.IntField("level"), core.IntField("counter"), core.BoolField("internal"), core.IntField("nonce"),
+	)
+}
+
+// Last -
+func (storage *Storage) Last(network, address string, indexedTime int64) (op operation.Operation, err error) {
+	filters := core.NewFilters().
+		Equal("destination", address).
+		Range("indexed_time", "<", indexedTime).
+		Equal("network", network).
+		Equal("status", constants.Applied).
+		Raw(fmt.Sprintf("COALESCE(%s, '') <> ''", core.Field("deffated_storage")))
+
+	query := storage.db.Query(models.DocOperations, filters).Order(core.Desc(core.IntField("indexed_time")))
+	err = storage.db.GetOne(query, &op)
+	return
+}
+
+// LastAtLevel -
+func (storage *Storage) LastAtLevel(network, address string, level int64) (op operation.Operation, err error) {
+	filters := core.NewFilters().
+		Equal("destination", address).
+		Equal("network", network).
+		Equal("status", constants.Applied).
+		Raw(fmt.Sprintf("COALESCE(%s, '') <> ''", core.Field("deffated_storage")))
+	if level > 0 {
+		filters.Range("level", "<=", level)
+	}
+
+	query := storage.db.Query(models.DocOperations, filters).Order(core.Desc(core.IntField("indexed_time")))
+	err = storage.db.GetOne(query, &op)
+	return
+}
+
+// Get -
+func (storage *Storage) Get(filters map[string]interface{}, size int64, sort bool) ([]operation.Operation, error) {
+	operations := make([]operation.Operation, 0)
+
+	query := storage.db.Query(models.DocOperations, core.FiltersToQuery(filters))
+	if sort {
+		query = query.Order(sortOperations())
+	}
+	if size > 0 {
+		query = query.Limit(size)
+	}
+
+	err := storage.db.GetAllByQuery(query, &operations)
+	return operations, err
+}
+
+// GetStats -
+func (storage *Storage) GetStats(network, address string) (stats operation.Stats, err error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Any(addressFilter(address))
+
+	var lastAction *time.Time
+	err = storage.db.Query(models.DocOperations, filters).
+		Select(fmt.Sprintf("COUNT(%s), MAX(%s)", core.Field("hash"), core.TimeField("timestamp"))).
+		Row().
+		Scan(&stats.Count, &lastAction)
+	if lastAction != nil {
+		stats.LastAction = lastAction.UTC()
+	}
+	return
+}
+
+// GetContract24HoursVolume -
+func (storage *Storage) GetContract24HoursVolume(network, address string, entrypoints []string) (volume float64, err error) {
+	filters := core.NewFilters().
+		Equal("destination", address).
+		Equal("network", network).
+		Equal("status", constants.Applied).
+		Raw(fmt.Sprintf("%s <= now() AND %s > now() - interval '24 hours'", core.TimeField("timestamp"), core.TimeField("timestamp")))
+
+	if len(entrypoints) > 0 {
+		filters.In("entrypoint", entrypoints)
+	}
+
+	err = storage.db.Query(models.DocOperations, filters).
+		Select(fmt.Sprintf("COALESCE(SUM(%s), 0)::float8", core.NumericField("amount"))).
+		Row().
+		Scan(&volume)
+	return
+}
+
+// GetTokensStats -
+func (storage *Storage) GetTokensStats(network string, addresses, entrypoints []string) (map[string]operation.TokenUsageStats, error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		In("destination", addresses).
+		In("entrypoint", entrypoints)
+	where, args := filters.Build()
+
+	sql := fmt.Sprintf(
+		`SELECT %s AS destination, %s AS entrypoint, COUNT(*), COALESCE(AVG(%s), 0)::bigint FROM %s WHERE %s GROUP BY destination, entrypoint`,
+		core.Field("destination"), core.Field("entrypoint"), core.NumericField("result.consumed_gas"), models.DocOperations, where,
+	)
+	rows, err := storage.db.Raw(sql, args...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usageStats := make(map[string]operation.TokenUsageStats)
+	for rows.Next() {
+		var destination, entrypoint string
+		var usage operation.TokenMethodUsageStats
+		if err := rows.Scan(&destination, &entrypoint, &usage.Count, &usage.ConsumedGas); err != nil {
+			return nil, err
+		}
+
+		if _, ok := usageStats[destination]; !ok {
+			usageStats[destination] = make(operation.TokenUsageStats)
+		}
+		usageStats[destination][entrypoint] = usage
+	}
+
+	return usageStats, rows.Err()
+}
+
+// GetParticipatingContracts -
+func (storage *Storage) GetParticipatingContracts(network string, fromLevel, toLevel int64) ([]string, error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Range("level", "<=", fromLevel).
+		Range("level", ">", toLevel)
+	where, args := filters.Build()
+
+	sql := fmt.Sprintf(
+		`SELECT DISTINCT address FROM (SELECT %s AS address FROM %s WHERE %s UNION ALL SELECT %s FROM %s WHERE %s) AS addresses`,
+		core.Field("source"), models.DocOperations, where,
+		core.Field("destination"), models.DocOperations, where,
+	)
+
+	var response []string
+	if err := storage.db.Raw(sql, append(args, args...)...).Pluck("address", &response).Error; err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0)
+	for i := range response {
+		if bcd.IsContract(response[i]) {
+			addresses = append(addresses, response[i])
+		}
+	}
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+	return addresses, nil
+}
+
+// RecalcStats -
+func (storage *Storage) RecalcStats(network, address string) (stats operation.ContractStats, err error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Any(addressFilter(address))
+
+	var lastAction *time.Time
+	err = storage.db.Query(models.DocOperations, filters).
+		Select(
+			fmt.Sprintf(
+				`COUNT(*), MAX(%s), COALESCE(SUM(CASE WHEN %s = ? AND %s IS NOT NULL THEN (CASE WHEN %s = ? THEN %s ELSE -%s END) ELSE 0 END), 0)::bigint`,
+				core.TimeField("timestamp"), core.Field("status"), core.Field("amount"), core.Field("destination"),
+				core.IntField("amount"), core.IntField("amount"),
+			),
+			constants.Applied, address,
+		).
+		Row().
+		Scan(&stats.TxCount, &lastAction, &stats.Balance)
+	if lastAction != nil {
+		stats.LastAction = lastAction.UTC()
+	}
+	return
+}
+
+// GetDAppStats -
+func (storage *Storage) GetDAppStats(network string, addresses []string, period string) (stats operation.DAppStats, err error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Exists("entrypoint").
+		In("destination", addresses).
+		Equal("status", constants.Applied)
+
+	if err = periodToRange(period, filters); err != nil {
+		return
+	}
+
+	err = storage.db.Query(models.DocOperations, filters).
+		Select(fmt.Sprintf(
+			"COUNT(DISTINCT %s), COUNT(*), COALESCE(SUM(%s), 0)::bigint",
+			core.Field("source"), core.NumericField("amount"),
+		)).
+		Row().
+		Scan(&stats.Users, &stats.Calls, &stats.Volume)
+	return
+}
+
+func periodToRange(period string, filters *core.Filters) error {
+	var interval string
+	switch period {
+	case "year":
+		interval = "1 year"
+	case "month":
+		interval = "1 month"
+	case "week":
+		interval = "1 week"
+	case "day":
+		interval = "1 day"
+	case "all":
+		return nil
+	default:
+		return errors.Errorf("Unknown period value: %s", period)
+	}
+	filters.Raw(fmt.Sprintf("%s >= date_trunc('day', now() - interval '%s')", core.TimeField("timestamp"), interval))
+	return nil
+}
@@ -0,0 +1,50 @@
+package protocol
+
+import (
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Postgres
+}
+
+// NewStorage -
+func NewStorage(db *core.Postgres) *Storage {
+	return &Storage{db}
+}
+
+// GetProtocol - returns current protocol for `network` and `level` (`hash` is optional, leave empty string for default)
+func (storage *Storage) GetProtocol(network, hash string, level int64) (p protocol.Protocol, err error) {
+	filters := core.NewFilters().Equal("network", network)
+	if level > -1 {
+		filters.Range("start_level", "<=", level)
+	}
+	if hash != "" {
+		filters.Equal("hash", hash)
+	}
+
+	query := storage.db.Query(models.DocProtocol, filters).Order(core.Desc(core.IntField("start_level")))
+	err = storage.db.GetOne(query, &p)
+	return
+}
+
+// GetSymLinks - returns list of symlinks in `network` after `level`
+func (storage *Storage) GetSymLinks(network string, level int64) (map[string]struct{}, error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Range("start_level", ">", level)
+
+	symLinks, err := storage.db.GetUnique(models.DocProtocol, "sym_link", filters)
+	if err != nil {
+		return nil, err
+	}
+
+	symMap := make(map[string]struct{})
+	for i := range symLinks {
+		symMap[symLinks[i]] = struct{}{}
+	}
+	return symMap, nil
+}
@@ -0,0 +1,67 @@
+package saplingdiff
+
+import (
+	"fmt"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/saplingdiff"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Postgres
+}
+
+// NewStorage -
+func NewStorage(db *core.Postgres) *Storage {
+	return &Storage{db}
+}
+
+func poolFilters(network string, ptr int64) *core.Filters {
+	return core.NewFilters().
+		Equal("network", network).
+		Range("ptr", "=", ptr)
+}
+
+// Get - returns sapling pool updates sorted by level descending
+func (storage *Storage) Get(network string, ptr int64, size, offset int64) ([]saplingdiff.SaplingDiff, error) {
+	if size == 0 {
+		size = core.DefaultSize
+	}
+
+	query := storage.db.Query(models.DocSaplingDiff, poolFilters(network, ptr)).
+		Order(core.Desc(core.IntField("level"))).
+		Limit(size).
+		Offset(offset)
+
+	diffs := make([]saplingdiff.SaplingDiff, 0)
+	err := storage.db.GetAllByQuery(query, &diffs)
+	return diffs, err
+}
+
+// First - returns the earliest update of sapling pool. It contains pool allocation (or copy) with memo size.
+func (storage *Storage) First(network string, ptr int64) (diff saplingdiff.SaplingDiff, err error) {
+	query := storage.db.Query(models.DocSaplingDiff, poolFilters(network, ptr)).
+		Order(core.Asc(core.IntField("level")))
+
+	err = storage.db.GetOne(query, &diff)
+	return
+}
+
+// GetStats - returns aggregated updates of sapling pool. If `maxLevel` is positive updates after it are skipped.
+func (storage *Storage) GetStats(network string, ptr int64, maxLevel int64) (stats saplingdiff.Stats, err error) {
+	filters := poolFilters(network, ptr)
+	if maxLevel > 0 {
+		filters.Range("level", "<=", maxLevel)
+	}
+
+	err = storage.db.Query(models.DocSaplingDiff, filters).
+		Select(fmt.Sprintf(
+			"COUNT(*), COALESCE(SUM(%s), 0)::bigint, COALESCE(SUM(%s), 0)::bigint, COALESCE(MIN(%s), 0), COALESCE(MAX(%s), 0)",
+			core.IntField("commitments_count"), core.IntField("nullifiers_count"), core.IntField("level"), core.IntField("level"),
+		)).
+		Row().
+		Scan(&stats.Count, &stats.Commitments, &stats.Nullifiers, &stats.FirstLevel, &stats.LastLevel)
+	return
+}
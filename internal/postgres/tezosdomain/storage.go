@@ -0,0 +1,62 @@
+package tezosdomain
+
+import (
+	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/tezosdomain"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/pkg/errors"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Postgres
+}
+
+// NewStorage -
+func NewStorage(db *core.Postgres) *Storage {
+	return &Storage{db}
+}
+
+// ListDomains -
+func (storage *Storage) ListDomains(network string, size, offset int64) (tezosdomain.DomainsResponse, error) {
+	if size > core.MaxQuerySize || size == 0 {
+		size = core.DefaultSize
+	}
+
+	filters := core.NewFilters().Equal("network", network)
+	query := storage.db.Query(models.DocTezosDomains, filters).
+		Order(core.Desc(core.TimeField("timestamp"))).
+		Limit(size).
+		Offset(offset)
+
+	domains := make([]tezosdomain.TezosDomain, 0)
+	total, err := storage.db.GetAllByQueryWithTotal(models.DocTezosDomains, filters, query, &domains)
+	if err != nil {
+		return tezosdomain.DomainsResponse{}, err
+	}
+	if total == 0 {
+		return tezosdomain.DomainsResponse{}, nil
+	}
+	return tezosdomain.DomainsResponse{
+		Domains: domains,
+		Total:   total,
+	}, nil
+}
+
+// ResolveDomainByAddress -
+func (storage *Storage) ResolveDomainByAddress(network string, address string) (*tezosdomain.TezosDomain, error) {
+	if !helpers.IsAddress(address) {
+		return nil, errors.Errorf("Invalid address: %s", address)
+	}
+
+	filters := core.NewFilters().
+		Equal("network", network).
+		Equal("address", address)
+
+	var td tezosdomain.TezosDomain
+	if err := storage.db.GetOne(storage.db.Query(models.DocTezosDomains, filters), &td); err != nil {
+		return nil, err
+	}
+	return &td, nil
+}
@@ -0,0 +1,94 @@
+package ticket
+
+import (
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Postgres
+}
+
+// NewStorage -
+func NewStorage(db *core.Postgres) *Storage {
+	return &Storage{db}
+}
+
+// Update -
+func (storage *Storage) Update(updates []*ticket.Ticket) error {
+	if len(updates) == 0 {
+		return nil
+	}
+	buf := make([]ticket.Ticket, 0)
+	ids := make([]string, len(updates))
+	for i := range updates {
+		ids[i] = updates[i].GetID()
+	}
+	if err := storage.db.GetByIDs(&buf, ids...); err != nil {
+		return err
+	}
+
+	updatedModels := make([]models.Model, 0)
+	insertedModels := make([]models.Model, 0)
+
+	for i := range updates {
+		var found bool
+		for j := range buf {
+			if buf[j].GetID() == updates[i].GetID() {
+				found = true
+				updates[i].Sum(&buf[j])
+				updatedModels = append(updatedModels, updates[i])
+				break
+			}
+		}
+
+		if !found {
+			insertedModels = append(insertedModels, updates[i])
+		}
+	}
+
+	if err := storage.db.BulkInsert(insertedModels); err != nil {
+		return err
+	}
+
+	return storage.db.BulkUpdate(updatedModels)
+}
+
+func nonZeroAmount() *core.Filters {
+	return core.NewFilters().NotEqual("amount", "0")
+}
+
+// GetByHolder -
+func (storage *Storage) GetByHolder(network, holder string) ([]ticket.Ticket, error) {
+	filters := nonZeroAmount().
+		Equal("network", network).
+		Equal("holder", holder)
+
+	tickets := make([]ticket.Ticket, 0)
+	err := storage.db.GetAllByQuery(storage.db.Query(models.DocTickets, filters), &tickets)
+	return tickets, err
+}
+
+// GetByTicketer -
+func (storage *Storage) GetByTicketer(network, ticketer string) ([]ticket.Ticket, error) {
+	filters := nonZeroAmount().
+		Equal("network", network).
+		Equal("ticketer", ticketer)
+
+	tickets := make([]ticket.Ticket, 0)
+	err := storage.db.GetAllByQuery(storage.db.Query(models.DocTickets, filters), &tickets)
+	return tickets, err
+}
+
+// GetUpdates -
+func (storage *Storage) GetUpdates(network string, level int64) ([]ticket.Update, error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Range("level", ">", level)
+
+	updates := make([]ticket.Update, 0)
+	err := storage.db.GetAllByQuery(storage.db.Query(models.DocTicketUpdates, filters), &updates)
+	return updates, err
+}
@@ -0,0 +1,110 @@
+package tokenbalance
+
+import (
+	"fmt"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/tokenbalance"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Postgres
+}
+
+// NewStorage -
+func NewStorage(db *core.Postgres) *Storage {
+	return &Storage{db}
+}
+
+// Update -
+func (storage *Storage) Update(updates []*tokenbalance.TokenBalance) error {
+	if len(updates) == 0 {
+		return nil
+	}
+	buf := make([]tokenbalance.TokenBalance, 0)
+	ids := make([]string, len(updates))
+	for i := range updates {
+		ids[i] = updates[i].GetID()
+	}
+	if err := storage.db.GetByIDs(&buf, ids...); err != nil {
+		return err
+	}
+
+	updatedModels := make([]models.Model, 0)
+	insertedModels := make([]models.Model, 0)
+
+	for i := range updates {
+		var found bool
+		for j := range buf {
+			if buf[j].GetID() == updates[i].GetID() {
+				found = true
+				updates[i].Sum(&buf[j])
+				updatedModels = append(updatedModels, updates[i])
+				break
+			}
+		}
+
+		if !found {
+			insertedModels = append(insertedModels, updates[i])
+		}
+	}
+
+	if err := storage.db.BulkInsert(insertedModels); err != nil {
+		return err
+	}
+
+	return storage.db.BulkUpdate(updatedModels)
+}
+
+func nonZeroBalance() *core.Filters {
+	return core.NewFilters().NotEqual("balance", "0")
+}
+
+// GetHolders -
+func (storage *Storage) GetHolders(network, contract string, tokenID *types.BigInt) ([]tokenbalance.TokenBalance, error) {
+	filters := nonZeroBalance().
+		Equal("network", network).
+		Equal("contract", contract).
+		Equal("token_id", tokenID.String())
+
+	balances := make([]tokenbalance.TokenBalance, 0)
+	err := storage.db.GetAllByQuery(storage.db.Query(models.DocTokenBalances, filters), &balances)
+	return balances, err
+}
+
+// GetAccountBalances -
+func (storage *Storage) GetAccountBalances(network, address string, size, offset int64) ([]tokenbalance.TokenBalance, int64, error) {
+	if size == 0 {
+		size = core.DefaultSize
+	}
+
+	filters := nonZeroBalance().
+		Equal("address", address).
+		Equal("network", network)
+
+	query := storage.db.Query(models.DocTokenBalances, filters).
+		Order(core.Desc(core.NumericField("token_id"))).
+		Limit(size).
+		Offset(offset)
+
+	tokenBalances := make([]tokenbalance.TokenBalance, 0)
+	count, err := storage.db.GetAllByQueryWithTotal(models.DocTokenBalances, filters, query, &tokenBalances)
+	if err != nil {
+		return nil, 0, err
+	}
+	return tokenBalances, count, nil
+}
+
+// BurnNft -
+func (storage *Storage) BurnNft(network, contract string, tokenID *types.BigInt) error {
+	where, args := core.NewFilters().
+		Equal("network", network).
+		Equal("contract", contract).
+		Equal("token_id", tokenID.String()).
+		Build()
+
+	return storage.db.Exec(fmt.Sprintf(`DELETE FROM %s WHERE %s`, models.DocTokenBalances, where), args...).Error
+}
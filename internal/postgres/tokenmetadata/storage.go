@@ -0,0 +1,82 @@
+package tokenmetadata
+
+import (
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/tokenmetadata"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/jinzhu/gorm"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Postgres
+}
+
+// NewStorage -
+func NewStorage(db *core.Postgres) *Storage {
+	return &Storage{db}
+}
+
+func (storage *Storage) buildGetTokenMetadataContext(ctx ...tokenmetadata.GetContext) *gorm.DB {
+	items := make([]*core.Filters, 0)
+
+	for _, c := range ctx {
+		filter := core.NewFilters().Raw("TRUE")
+		if c.Contract != "" {
+			filter.Equal("contract", c.Contract)
+		}
+		if c.Network != "" {
+			filter.Equal("network", c.Network)
+		}
+		if c.MaxLevel > 0 {
+			filter.Range("level", "<=", c.MaxLevel)
+		}
+		if c.MinLevel > 0 {
+			filter.Range("level", ">", c.MinLevel)
+		}
+		if c.TokenID != nil {
+			filter.Equal("token_id", c.TokenID.String())
+		}
+		items = append(items, filter)
+	}
+
+	return storage.db.Query(models.DocTokenMetadata, core.NewFilters().Any(items...)).
+		Order(core.Desc(core.IntField("level")))
+}
+
+// Get -
+func (storage *Storage) Get(ctx []tokenmetadata.GetContext, size, offset int64) (tokens []tokenmetadata.TokenMetadata, err error) {
+	query := storage.buildGetTokenMetadataContext(ctx...).Offset(offset)
+	if size > 0 {
+		query = query.Limit(size)
+	}
+	err = storage.db.GetAllByQuery(query, &tokens)
+	return
+}
+
+// GetAll -
+func (storage *Storage) GetAll(ctx ...tokenmetadata.GetContext) (tokens []tokenmetadata.TokenMetadata, err error) {
+	err = storage.db.GetAllByQuery(storage.buildGetTokenMetadataContext(ctx...), &tokens)
+	return
+}
+
+// GetWithExtras -
+func (storage *Storage) GetWithExtras() ([]tokenmetadata.TokenMetadata, error) {
+	fields := []string{
+		"extras.description", "extras.artifactUri", "extras.displayUri", "extras.thumbnailUri",
+		"extras.externalUri", "extras.isTransferable", "extras.isBooleanAmount", "extras.shouldPreferSymbol",
+	}
+	items := make([]*core.Filters, len(fields))
+	for i := range fields {
+		items[i] = core.NewFilters().Exists(fields[i])
+	}
+
+	tokens := make([]tokenmetadata.TokenMetadata, 0)
+	if err := storage.db.GetAllByQuery(storage.db.Query(models.DocTokenMetadata, core.NewFilters().Any(items...)), &tokens); err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, core.NewRecordNotFoundError(models.DocTokenMetadata, "")
+	}
+	return tokens, nil
+}
@@ -0,0 +1,70 @@
+package transfer
+
+import (
+	"github.com/baking-bad/bcdhub/internal/models/transfer"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+)
+
+func buildGetContext(ctx transfer.GetContext) *core.Filters {
+	filters := core.NewFilters()
+
+	if ctx.Network != "" {
+		filters.Equal("network", ctx.Network)
+	}
+	if ctx.Address != "" {
+		filters.Any(
+			core.NewFilters().Equal("from", ctx.Address),
+			core.NewFilters().Equal("to", ctx.Address),
+		)
+	}
+	if ctx.Start > 0 {
+		filters.Raw("extract(epoch FROM "+core.TimeField("timestamp")+") * 1000 >= ?", ctx.Start)
+	}
+	if ctx.End > 0 {
+		filters.Raw("extract(epoch FROM "+core.TimeField("timestamp")+") * 1000 < ?", ctx.End)
+	}
+	if ctx.LastID != "" {
+		op := "<"
+		if ctx.SortOrder == "asc" {
+			op = ">"
+		}
+		filters.Raw(core.IntField("indexed_time")+" "+op+" ?::bigint", ctx.LastID)
+	}
+	if len(ctx.Contracts) > 0 {
+		filters.In("contract", ctx.Contracts)
+	}
+	if ctx.TokenID != nil {
+		filters.Equal("token_id", ctx.TokenID.String())
+	}
+	if ctx.Hash != "" {
+		filters.Equal("hash", ctx.Hash)
+	}
+	if ctx.Counter != nil {
+		filters.Range("counter", "=", *ctx.Counter)
+	}
+	if ctx.Nonce != nil {
+		filters.Range("nonce", "=", *ctx.Nonce)
+	}
+	return filters
+}
+
+func getSize(ctx transfer.GetContext) int64 {
+	if ctx.Size > 0 && ctx.Size <= maxTransfersSize {
+		return ctx.Size
+	}
+	return maxTransfersSize
+}
+
+func getOffset(ctx transfer.GetContext) int64 {
+	if ctx.Offset > 0 && ctx.Offset <= maxTransfersSize {
+		return ctx.Offset
+	}
+	return 0
+}
+
+func getSort(ctx transfer.GetContext) string {
+	if ctx.SortOrder == "asc" {
+		return core.Asc(core.TimeField("timestamp"))
+	}
+	return core.Desc(core.TimeField("timestamp"))
+}
@@ -0,0 +1,151 @@
+package transfer
+
+import (
+	"fmt"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/transfer"
+	"github.com/baking-bad/bcdhub/internal/models/tzip"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/pkg/errors"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Postgres
+}
+
+// NewStorage -
+func NewStorage(db *core.Postgres) *Storage {
+	return &Storage{db}
+}
+
+const (
+	maxTransfersSize = 10000
+)
+
+// Get -
+func (storage *Storage) Get(ctx transfer.GetContext) (po transfer.Pageable, err error) {
+	filters := buildGetContext(ctx)
+	query := storage.db.Query(models.DocTransfers, filters).
+		Order(getSort(ctx)).
+		Limit(getSize(ctx)).
+		Offset(getOffset(ctx))
+
+	transfers := make([]transfer.Transfer, 0)
+	if po.Total, err = storage.db.GetAllByQueryWithTotal(models.DocTransfers, filters, query, &transfers); err != nil {
+		return
+	}
+	po.Transfers = transfers
+	if len(transfers) > 0 {
+		po.LastID = fmt.Sprintf("%d", transfers[len(transfers)-1].IndexedTime)
+	}
+	return po, nil
+}
+
+// GetAll -
+func (storage *Storage) GetAll(network string, level int64) ([]transfer.Transfer, error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Range("level", ">", level)
+
+	transfers := make([]transfer.Transfer, 0)
+	err := storage.db.GetAllByQuery(storage.db.Query(models.DocTransfers, filters), &transfers)
+	return transfers, err
+}
+
+// GetTokenSupply -
+func (storage *Storage) GetTokenSupply(network, address string, tokenID *types.BigInt) (result transfer.TokenSupply, err error) {
+	filters := core.NewFilters().
+		Equal("network", network).
+		Equal("contract", address).
+		Equal("token_id", tokenID.String()).
+		Equal("status", consts.Applied)
+
+	from := fmt.Sprintf("COALESCE(%s, '')", core.Field("from"))
+	to := fmt.Sprintf("COALESCE(%s, '')", core.Field("to"))
+	amount := core.NumericField("amount")
+	err = storage.db.Query(models.DocTransfers, filters).
+		Select(fmt.Sprintf(
+			`COALESCE(SUM(CASE WHEN %s = '' THEN %s WHEN %s = '' THEN -%s ELSE 0 END), 0)::float8,
+			COALESCE(SUM(CASE WHEN %s <> '' AND %s <> '' THEN %s ELSE 0 END), 0)::float8`,
+			from, amount, to, amount, from, to, amount,
+		)).
+		Row().
+		Scan(&result.Supply, &result.Transfered)
+	return
+}
+
+// GetToken24HoursVolume - returns token volume for last 24 hours
+func (storage *Storage) GetToken24HoursVolume(network, contract string, initiators, entrypoints []string, tokenID *types.BigInt) (volume float64, err error) {
+	timestamp := core.TimeField("timestamp")
+	filters := core.NewFilters().
+		Equal("contract", contract).
+		Equal("network", network).
+		Equal("status", consts.Applied).
+		Equal("token_id", tokenID.String()).
+		Raw(fmt.Sprintf("%s <= now() AND %s > now() - interval '24 hours'", timestamp, timestamp)).
+		In("parent", entrypoints).
+		In("initiator", initiators)
+
+	err = storage.db.Query(models.DocTransfers, filters).
+		Select(fmt.Sprintf("COALESCE(SUM(%s), 0)::float8", core.NumericField("amount"))).
+		Row().
+		Scan(&volume)
+	return
+}
+
+// GetTokenVolumeSeries -
+func (storage *Storage) GetTokenVolumeSeries(network, period string, contracts []string, entrypoints []tzip.DAppContract, tokenID *types.BigInt) ([][]float64, error) {
+	switch period {
+	case "year", "month", "week", "day":
+	default:
+		return nil, errors.Errorf("Unknown histogram period: %s", period)
+	}
+
+	filters := core.NewFilters().
+		Raw(fmt.Sprintf("%s IS DISTINCT FROM %s", core.Field("from"), core.Field("to"))).
+		Equal("network", network).
+		Equal("status", consts.Applied).
+		Equal("token_id", tokenID.String())
+
+	if len(contracts) > 0 {
+		filters.In("contract", contracts)
+	}
+
+	if len(entrypoints) > 0 {
+		items := make([]*core.Filters, 0)
+		for i := range entrypoints {
+			for j := range entrypoints[i].DexVolumeEntrypoints {
+				items = append(items, core.NewFilters().
+					Equal("initiator", entrypoints[i].Address).
+					Equal("parent", entrypoints[i].DexVolumeEntrypoints[j]),
+				)
+			}
+		}
+		filters.Any(items...)
+	}
+	where, args := filters.Build()
+
+	sql := fmt.Sprintf(
+		`SELECT (extract(epoch FROM date_trunc('%s', %s AT TIME ZONE 'UTC')) * 1000)::float8 AS key, COALESCE(SUM(%s), 0)::float8 FROM %s WHERE %s GROUP BY key ORDER BY key`,
+		period, core.TimeField("timestamp"), core.NumericField("amount"), models.DocTransfers, where,
+	)
+	rows, err := storage.db.Raw(sql, args...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	histogram := make([][]float64, 0)
+	for rows.Next() {
+		var key, value float64
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		histogram = append(histogram, []float64{key, value})
+	}
+	return histogram, rows.Err()
+}
@@ -0,0 +1,119 @@
+package tzip
+
+import (
+	"fmt"
+
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/tzip"
+	"github.com/baking-bad/bcdhub/internal/postgres/core"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Postgres
+}
+
+// NewStorage -
+func NewStorage(db *core.Postgres) *Storage {
+	return &Storage{db}
+}
+
+// Get -
+func (storage *Storage) Get(network, address string) (t tzip.TZIP, err error) {
+	t.Address = address
+	t.Network = network
+	err = storage.db.GetByID(&t)
+	return
+}
+
+func (storage *Storage) getAll(filters *core.Filters, order ...string) ([]tzip.TZIP, error) {
+	query := storage.db.Query(models.DocTZIP, filters)
+	for i := range order {
+		query = query.Order(order[i])
+	}
+
+	data := make([]tzip.TZIP, 0)
+	if err := storage.db.GetAllByQuery(query, &data); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, core.NewRecordNotFoundError(models.DocTZIP, "")
+	}
+	return data, nil
+}
+
+func (storage *Storage) getOne(filters *core.Filters) (*tzip.TZIP, error) {
+	var data tzip.TZIP
+	if err := storage.db.GetOne(storage.db.Query(models.DocTZIP, filters), &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetDApps -
+func (storage *Storage) GetDApps() ([]tzip.DApp, error) {
+	order := fmt.Sprintf("(SELECT MIN((dapp->>'order')::bigint) FROM jsonb_array_elements(%s) AS dapp) ASC", core.JSONField("dapps"))
+	data, err := storage.getAll(core.NewFilters().Exists("dapps"), order)
+	if err != nil {
+		return nil, err
+	}
+
+	dapps := make([]tzip.DApp, 0)
+	for i := range data {
+		dapps = append(dapps, data[i].DApps...)
+	}
+	return dapps, nil
+}
+
+// GetDAppBySlug -
+func (storage *Storage) GetDAppBySlug(slug string) (*tzip.DApp, error) {
+	model, err := storage.getOne(core.NewFilters().Match("dapps", []map[string]string{{"slug": slug}}))
+	if err != nil {
+		return nil, err
+	}
+	for i := range model.DApps {
+		if model.DApps[i].Slug == slug {
+			return &model.DApps[i], nil
+		}
+	}
+	return nil, core.NewRecordNotFoundError(models.DocTZIP, "")
+}
+
+// GetBySlug -
+func (storage *Storage) GetBySlug(slug string) (*tzip.TZIP, error) {
+	return storage.getOne(core.NewFilters().Equal("slug", slug))
+}
+
+// GetAliasesMap -
+func (storage *Storage) GetAliasesMap(network string) (map[string]string, error) {
+	data, err := storage.getAll(core.NewFilters().Equal("network", network))
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := make(map[string]string)
+	for i := range data {
+		aliases[data[i].Address] = data[i].Name
+	}
+	return aliases, nil
+}
+
+// GetAliases -
+func (storage *Storage) GetAliases(network string) ([]tzip.TZIP, error) {
+	return storage.getAll(core.NewFilters().Equal("network", network).Exists("name"))
+}
+
+// GetAlias -
+func (storage *Storage) GetAlias(network, address string) (*tzip.TZIP, error) {
+	return storage.getOne(core.NewFilters().Equal("network", network).Equal("address", address))
+}
+
+// GetWithEvents -
+func (storage *Storage) GetWithEvents() ([]tzip.TZIP, error) {
+	return storage.getAll(core.NewFilters().Exists("events"))
+}
+
+// GetWithEventsCounts -
+func (storage *Storage) GetWithEventsCounts() (int64, error) {
+	return storage.db.CountByQuery(models.DocTZIP, core.NewFilters().Exists("events"))
+}
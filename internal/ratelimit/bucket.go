@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limits - token bucket settings: bucket holds up to `Burst` tokens and is refilled by `Rate` tokens per second. Every request takes a token.
+type Limits struct {
+	Rate  float64
+	Burst int
+}
+
+// IsUnlimited - returns true if limits are not set
+func (l Limits) IsUnlimited() bool {
+	return l.Rate <= 0 || l.Burst <= 0
+}
+
+// Result - state of bucket after request
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset - time until the bucket is full
+	Reset time.Duration
+	// RetryAfter - time until the next token if request is not allowed
+	RetryAfter time.Duration
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limits Limits
+}
+
+func (b *bucket) take(now time.Time, limits Limits) Result {
+	b.limits = limits
+	b.tokens = math.Min(float64(limits.Burst), b.tokens+now.Sub(b.last).Seconds()*limits.Rate)
+	b.last = now
+
+	result := Result{
+		Limit: limits.Burst,
+	}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - b.tokens) / limits.Rate)
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = secondsToDuration((float64(limits.Burst) - b.tokens) / limits.Rate)
+	return result
+}
+
+func (b *bucket) isFull(now time.Time) bool {
+	return b.tokens+now.Sub(b.last).Seconds()*b.limits.Rate >= float64(b.limits.Burst)
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
+
+// Buckets - token buckets of clients. Full buckets are the same as absent ones, so they are removed by `Cleanup`.
+type Buckets struct {
+	mx      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// NewBuckets -
+func NewBuckets() *Buckets {
+	return &Buckets{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Take - takes token from bucket of client `id`. Request is always allowed if limits are not set.
+func (b *Buckets) Take(id string, limits Limits) Result {
+	if limits.IsUnlimited() {
+		return Result{Allowed: true}
+	}
+
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	now := b.now()
+	item, ok := b.buckets[id]
+	if !ok {
+		item = &bucket{
+			tokens: float64(limits.Burst),
+			last:   now,
+		}
+		b.buckets[id] = item
+	}
+	return item.take(now, limits)
+}
+
+// Cleanup - removes buckets which are full
+func (b *Buckets) Cleanup() {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	now := b.now()
+	for id, item := range b.buckets {
+		if item.isFull(now) {
+			delete(b.buckets, id)
+		}
+	}
+}
+
+// Len - returns count of buckets
+func (b *Buckets) Len() int {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	return len(b.buckets)
+}
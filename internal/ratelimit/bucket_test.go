@@ -0,0 +1,55 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuckets_Take(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	buckets := NewBuckets()
+	buckets.now = func() time.Time { return now }
+
+	limits := Limits{Rate: 2, Burst: 3}
+	for i := 0; i < 3; i++ {
+		result := buckets.Take("ip:127.0.0.1", limits)
+		assert.True(t, result.Allowed, "request %d", i)
+		assert.Equal(t, 3, result.Limit)
+		assert.Equal(t, 2-i, result.Remaining)
+	}
+
+	result := buckets.Take("ip:127.0.0.1", limits)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+	assert.Equal(t, 1500*time.Millisecond, result.Reset)
+
+	assert.True(t, buckets.Take("ip:127.0.0.2", limits).Allowed, "buckets of clients are independent")
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, buckets.Take("ip:127.0.0.1", limits).Allowed)
+	assert.False(t, buckets.Take("ip:127.0.0.1", limits).Allowed)
+
+	assert.True(t, buckets.Take("ip:127.0.0.1", Limits{}).Allowed, "unlimited")
+}
+
+func TestBuckets_Cleanup(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	buckets := NewBuckets()
+	buckets.now = func() time.Time { return now }
+
+	limits := Limits{Rate: 1, Burst: 10}
+	buckets.Take("a", limits)
+	now = now.Add(5 * time.Second)
+	buckets.Take("b", limits)
+	assert.Equal(t, 2, buckets.Len())
+
+	buckets.Cleanup()
+	assert.Equal(t, 1, buckets.Len())
+
+	now = now.Add(time.Second)
+	buckets.Cleanup()
+	assert.Equal(t, 0, buckets.Len())
+}
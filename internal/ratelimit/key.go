@@ -0,0 +1,29 @@
+package ratelimit
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	keyPrefix    = "bcd_"
+	keyLength    = 32
+	prefixLength = 8
+)
+
+// GenerateKey - returns random API key, its hash which is stored instead of the key and prefix which is shown to user
+func GenerateKey() (key, hash, prefix string, err error) {
+	buf := make([]byte, keyLength)
+	if _, err = rand.Read(buf); err != nil {
+		return
+	}
+	key = keyPrefix + hex.EncodeToString(buf)
+	return key, HashKey(key), key[:len(keyPrefix)+prefixLength], nil
+}
+
+// HashKey - returns hex of SHA-256 hash of API key
+func HashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,170 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/logger"
+)
+
+const (
+	defaultPeriod = time.Minute
+)
+
+// Quota - daily quota of API key. `Limit` is zero if quota is not set.
+type Quota struct {
+	Limit     uint
+	Remaining uint
+	// Reset - time until the quota is restored
+	Reset time.Duration
+}
+
+// Limiter - applies token bucket limits to clients identified by IP or API key and daily quota to API keys.
+// Expensive requests are limited by their own buckets and do not spend tokens of the general ones.
+type Limiter struct {
+	buckets *Buckets
+	usage   *Usage
+
+	ip           Limits
+	expensiveIP  Limits
+	key          Limits
+	expensiveKey Limits
+	quota        uint
+	period       time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// LimiterOption -
+type LimiterOption func(l *Limiter)
+
+// WithIPLimits - sets limits of anonymous clients
+func WithIPLimits(general, expensive Limits) LimiterOption {
+	return func(l *Limiter) {
+		l.ip = general
+		l.expensiveIP = expensive
+	}
+}
+
+// WithKeyLimits - sets limits of API keys
+func WithKeyLimits(general, expensive Limits) LimiterOption {
+	return func(l *Limiter) {
+		l.key = general
+		l.expensiveKey = expensive
+	}
+}
+
+// WithDailyQuota - sets count of requests which are allowed to API key per UTC day
+func WithDailyQuota(quota uint) LimiterOption {
+	return func(l *Limiter) {
+		l.quota = quota
+	}
+}
+
+// WithPeriod - sets period of usage flushing and buckets cleanup
+func WithPeriod(period time.Duration) LimiterOption {
+	return func(l *Limiter) {
+		if period > 0 {
+			l.period = period
+		}
+	}
+}
+
+// NewLimiter -
+func NewLimiter(storage UsageStorage, opts ...LimiterOption) *Limiter {
+	l := &Limiter{
+		buckets: NewBuckets(),
+		usage:   NewUsage(storage),
+		period:  defaultPeriod,
+	}
+	for i := range opts {
+		opts[i](l)
+	}
+	return l
+}
+
+// Start - starts periodic usage flushing and buckets cleanup
+func (l *Limiter) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	l.cancel = cancel
+
+	l.wg.Add(1)
+	go l.sync(ctx)
+}
+
+// Close - stops background work and flushes usage
+func (l *Limiter) Close() error {
+	if l.cancel != nil {
+		l.cancel()
+	}
+	l.wg.Wait()
+	return l.usage.Flush()
+}
+
+// AllowIP - takes token of anonymous client
+func (l *Limiter) AllowIP(ip string, expensive bool) Result {
+	if expensive {
+		return l.buckets.Take(fmt.Sprintf("expensive:ip:%s", ip), l.expensiveIP)
+	}
+	return l.buckets.Take(fmt.Sprintf("ip:%s", ip), l.ip)
+}
+
+// AllowKey - checks daily quota of API key and takes token from its bucket. Request and its result are counted in key usage.
+func (l *Limiter) AllowKey(keyID uint, expensive bool) (Result, Quota, error) {
+	quota := Quota{
+		Limit: l.quota,
+	}
+	if l.quota > 0 {
+		requests, err := l.usage.Requests(keyID)
+		if err != nil {
+			return Result{}, quota, err
+		}
+		now := l.usage.now()
+		quota.Reset = today(now).AddDate(0, 0, 1).Sub(now)
+
+		if requests >= l.quota {
+			if err := l.usage.Reject(keyID); err != nil {
+				return Result{}, quota, err
+			}
+			return Result{RetryAfter: quota.Reset}, quota, nil
+		}
+		quota.Remaining = l.quota - requests
+	}
+
+	var result Result
+	if expensive {
+		result = l.buckets.Take(fmt.Sprintf("expensive:key:%d", keyID), l.expensiveKey)
+	} else {
+		result = l.buckets.Take(fmt.Sprintf("key:%d", keyID), l.key)
+	}
+
+	if !result.Allowed {
+		return result, quota, l.usage.Reject(keyID)
+	}
+	if quota.Remaining > 0 {
+		quota.Remaining--
+	}
+	return result, quota, l.usage.Accept(keyID, expensive)
+}
+
+func (l *Limiter) sync(ctx context.Context) {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(l.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.buckets.Cleanup()
+			if err := l.usage.Flush(); err != nil {
+				logger.Errorf("[rate limit] usage flush: %s", err)
+			}
+		}
+	}
+}
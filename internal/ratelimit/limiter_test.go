@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/database"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_AllowKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2021, 3, 1, 18, 0, 0, 0, time.UTC)
+	day := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	db := database.NewMockDB(ctrl)
+	db.EXPECT().ListAPIKeyUsage(uint(1), day).Return([]database.APIKeyUsage{
+		{APIKeyID: 1, Day: day, Requests: 3},
+	}, nil).Times(1)
+
+	l := NewLimiter(db, WithKeyLimits(Limits{Rate: 1, Burst: 100}, Limits{Rate: 1, Burst: 1}), WithDailyQuota(5))
+	l.usage.now = func() time.Time { return now }
+	l.buckets.now = l.usage.now
+
+	result, quota, err := l.AllowKey(1, true)
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, Quota{Limit: 5, Remaining: 1, Reset: 6 * time.Hour}, quota)
+
+	result, _, err = l.AllowKey(1, true)
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed, "expensive bucket is empty")
+
+	result, quota, err = l.AllowKey(1, false)
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed, "general bucket is not spent by expensive requests")
+	assert.Equal(t, uint(0), quota.Remaining)
+
+	result, quota, err = l.AllowKey(1, false)
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed, "quota is exceeded")
+	assert.Equal(t, 6*time.Hour, result.RetryAfter)
+
+	db.EXPECT().IncrementAPIKeyUsage(database.APIKeyUsage{
+		APIKeyID:  1,
+		Day:       day,
+		Requests:  2,
+		Expensive: 1,
+		Rejected:  2,
+	}, now).Return(nil).Times(1)
+	assert.NoError(t, l.usage.Flush())
+	assert.NoError(t, l.usage.Flush(), "nothing to flush")
+}
+
+func TestUsage_Flush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2021, 3, 1, 23, 59, 0, 0, time.UTC)
+	db := database.NewMockDB(ctrl)
+	db.EXPECT().ListAPIKeyUsage(uint(1), gomock.Any()).Return(nil, nil).Times(2)
+
+	usage := NewUsage(db)
+	usage.now = func() time.Time { return now }
+	assert.NoError(t, usage.Accept(1, false))
+
+	// counters of the previous day are flushed after rollover
+	now = now.Add(2 * time.Minute)
+	assert.NoError(t, usage.Accept(1, false))
+	requests, err := usage.Requests(1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), requests)
+
+	db.EXPECT().IncrementAPIKeyUsage(gomock.Any(), gomock.Any()).Return(errors.New("connection refused")).Times(2)
+	assert.Error(t, usage.Flush())
+
+	// failed counters are kept till the next flush
+	db.EXPECT().IncrementAPIKeyUsage(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	assert.NoError(t, usage.Flush())
+}
@@ -0,0 +1,169 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/database"
+)
+
+// UsageStorage -
+type UsageStorage interface {
+	ListAPIKeyUsage(keyID uint, since time.Time) ([]database.APIKeyUsage, error)
+	IncrementAPIKeyUsage(delta database.APIKeyUsage, usedAt time.Time) error
+}
+
+type counter struct {
+	// requests - accepted requests of the day: persisted before the first request of the day to this instance and counted by it
+	requests uint
+	pending  database.APIKeyUsage
+	usedAt   time.Time
+}
+
+// Usage - daily usage counters of API keys. Counters are accumulated in memory and added to storage by `Flush`.
+// Usage of key by other API instances is read from storage once a day, so quota may be exceeded by requests which are not flushed yet.
+type Usage struct {
+	storage UsageStorage
+
+	mx       sync.Mutex
+	day      time.Time
+	counters map[uint]*counter
+	stale    []database.APIKeyUsage
+	now      func() time.Time
+}
+
+// NewUsage -
+func NewUsage(storage UsageStorage) *Usage {
+	return &Usage{
+		storage:  storage,
+		counters: make(map[uint]*counter),
+		stale:    make([]database.APIKeyUsage, 0),
+		now:      time.Now,
+	}
+}
+
+func today(now time.Time) time.Time {
+	year, month, day := now.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// get - returns counter of key for today. It must be called under lock.
+func (u *Usage) get(keyID uint) (*counter, error) {
+	now := u.now()
+	if day := today(now); !day.Equal(u.day) {
+		for _, c := range u.counters {
+			if !isEmpty(c.pending) {
+				u.stale = append(u.stale, c.pending)
+			}
+		}
+		u.counters = make(map[uint]*counter)
+		u.day = day
+	}
+
+	if c, ok := u.counters[keyID]; ok {
+		return c, nil
+	}
+
+	usage, err := u.storage.ListAPIKeyUsage(keyID, u.day)
+	if err != nil {
+		return nil, err
+	}
+	c := &counter{
+		pending: database.APIKeyUsage{
+			APIKeyID: keyID,
+			Day:      u.day,
+		},
+	}
+	for i := range usage {
+		if usage[i].Day.Equal(u.day) {
+			c.requests += usage[i].Requests
+		}
+	}
+	u.counters[keyID] = c
+	return c, nil
+}
+
+// Requests - returns count of accepted requests of key today
+func (u *Usage) Requests(keyID uint) (uint, error) {
+	u.mx.Lock()
+	defer u.mx.Unlock()
+
+	c, err := u.get(keyID)
+	if err != nil {
+		return 0, err
+	}
+	return c.requests, nil
+}
+
+// Accept - counts accepted request of key
+func (u *Usage) Accept(keyID uint, expensive bool) error {
+	u.mx.Lock()
+	defer u.mx.Unlock()
+
+	c, err := u.get(keyID)
+	if err != nil {
+		return err
+	}
+	c.requests++
+	c.pending.Requests++
+	if expensive {
+		c.pending.Expensive++
+	}
+	c.usedAt = u.now()
+	return nil
+}
+
+// Reject - counts rejected request of key
+func (u *Usage) Reject(keyID uint) error {
+	u.mx.Lock()
+	defer u.mx.Unlock()
+
+	c, err := u.get(keyID)
+	if err != nil {
+		return err
+	}
+	c.pending.Rejected++
+	c.usedAt = u.now()
+	return nil
+}
+
+// Flush - adds counted requests to storage. Counters which are failed to add are kept till the next flush.
+func (u *Usage) Flush() error {
+	type item struct {
+		delta  database.APIKeyUsage
+		usedAt time.Time
+	}
+
+	u.mx.Lock()
+	items := make([]item, 0, len(u.stale)+len(u.counters))
+	for i := range u.stale {
+		items = append(items, item{u.stale[i], u.now()})
+	}
+	u.stale = u.stale[:0]
+	for _, c := range u.counters {
+		if isEmpty(c.pending) {
+			continue
+		}
+		items = append(items, item{c.pending, c.usedAt})
+		c.pending.Requests = 0
+		c.pending.Expensive = 0
+		c.pending.Rejected = 0
+	}
+	u.mx.Unlock()
+
+	var flushErr error
+	for i := range items {
+		if err := u.storage.IncrementAPIKeyUsage(items[i].delta, items[i].usedAt); err != nil {
+			flushErr = err
+
+			u.mx.Lock()
+			u.stale = append(u.stale, items[i].delta)
+			u.mx.Unlock()
+		}
+	}
+	return flushErr
+}
+
+func isEmpty(usage database.APIKeyUsage) bool {
+	return usage.Requests == 0 && usage.Expensive == 0 && usage.Rejected == 0
+}
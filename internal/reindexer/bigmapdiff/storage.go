@@ -48,6 +48,21 @@ func (storage *Storage) GetForAddress(address string) ([]bigmapdiff.BigMapDiff,
 	})
 }
 
+// GetStates - returns last states of all keys of big map `ptr` at `maxLevel`. If `maxLevel` is 0 current states are returned.
+func (storage *Storage) GetStates(network string, ptr, maxLevel int64) ([]bigmapdiff.BigMapDiff, error) {
+	query := storage.db.Query(models.DocBigMapDiff).
+		Match("network", network).
+		WhereInt64("ptr", reindexer.EQ, ptr)
+	if maxLevel > 0 {
+		query = query.WhereInt64("level", reindexer.LE, maxLevel)
+	}
+	query = query.Sort("indexed_time", true)
+
+	return storage.getTop(query, func(bmd bigmapdiff.BigMapDiff) string {
+		return bmd.KeyHash
+	})
+}
+
 // GetByAddress -
 func (storage *Storage) GetByAddress(network, address string) (response []bigmapdiff.BigMapDiff, err error) {
 	query := storage.db.Query(models.DocBigMapDiff).
@@ -3,6 +3,7 @@ package contract
 import (
 	"math/rand"
 
+	"github.com/baking-bad/bcdhub/internal/bcd/consts"
 	"github.com/baking-bad/bcdhub/internal/models"
 	"github.com/baking-bad/bcdhub/internal/models/contract"
 	"github.com/baking-bad/bcdhub/internal/reindexer/core"
@@ -246,8 +247,8 @@ func (storage *Storage) GetDiffTasks() ([]contract.DiffTask, error) {
 
 // GetTokens -
 func (storage *Storage) GetTokens(network, tokenInterface string, offset, size int64) ([]contract.Contract, int64, error) {
-	tags := []string{"fa12", "fa1", "fa2"}
-	if tokenInterface == "fa12" || tokenInterface == "fa1" || tokenInterface == "fa2" {
+	tags := []string{consts.FA12Tag, consts.FA1Tag, consts.FA2Tag}
+	if tokenInterface != "" {
 		tags = []string{tokenInterface}
 	}
 
@@ -11,6 +11,8 @@ import (
 // Sizes -
 const (
 	DefaultSize = 10
+
+	defaultScrollSize = 1000
 )
 
 // Count -
@@ -90,6 +92,55 @@ func (r *Reindexer) GetByNetworkWithSort(network, sortField, sortOrder string, o
 	return r.GetAllByQuery(query, output)
 }
 
+// ScrollByNetwork -
+func (r *Reindexer) ScrollByNetwork(network string, output interface{}, size int64, handler func() error) error {
+	index, err := getIndex(output)
+	if err != nil {
+		return err
+	}
+	typ, err := getElementType(output)
+	if err != nil {
+		return err
+	}
+	el := reflect.ValueOf(output).Elem()
+	if el.Kind() != reflect.Slice {
+		return errors.Errorf("Invalid `output` type: %s", el.Kind())
+	}
+	if size <= 0 {
+		size = defaultScrollSize
+	}
+
+	it := r.Query(index).
+		WhereString("network", reindexer.EQ, network).
+		Exec()
+	defer it.Close()
+
+	if it.Error() != nil {
+		return it.Error()
+	}
+
+	el.Set(reflect.MakeSlice(el.Type(), 0, int(size)))
+	for it.Next() {
+		obj := reflect.New(typ).Interface()
+		it.NextObj(obj)
+		el.Set(reflect.Append(el, reflect.ValueOf(obj).Elem()))
+
+		if int64(el.Len()) == size {
+			if err := handler(); err != nil {
+				return err
+			}
+			el.Set(reflect.MakeSlice(el.Type(), 0, int(size)))
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if el.Len() > 0 {
+		return handler()
+	}
+	return nil
+}
+
 // GetAllByQuery -
 func (r *Reindexer) GetAllByQuery(query *reindexer.Query, output interface{}) error {
 	if query == nil {
@@ -121,6 +121,12 @@ func prepareFilters(filters map[string]interface{}, query *reindexer.Query) erro
 				return errors.Errorf("Invalid type for 'network' filter (wait []string): %T", value)
 			}
 			query = query.Match("language", languages...)
+		case "tags":
+			tags, ok := value.([]string)
+			if !ok {
+				return errors.Errorf("Invalid type for 'tags' filter (wait []string): %T", value)
+			}
+			query = query.Match("tags", tags...)
 		default:
 			return errors.Errorf("Unknown search filter: %s", field)
 		}
@@ -138,6 +138,22 @@ func (storage *Storage) Last(network, address string, indexedTime int64) (op ope
 	return
 }
 
+// LastAtLevel -
+func (storage *Storage) LastAtLevel(network, address string, level int64) (op operation.Operation, err error) {
+	query := storage.db.Query(models.DocOperations).
+		Match("destination", address).
+		Match("network", network).
+		Match("status", consts.Applied).
+		Not().WhereString("deffated_storage", reindexer.EMPTY, "")
+	if level > 0 {
+		query = query.WhereInt64("level", reindexer.LE, level)
+	}
+	query = query.Sort("indexed_time", true)
+
+	err = storage.db.GetOne(query, &op)
+	return
+}
+
 // Get -
 func (storage *Storage) Get(filters map[string]interface{}, size int64, sort bool) (operations []operation.Operation, err error) {
 	query := storage.db.Query(models.DocOperations)
@@ -0,0 +1,79 @@
+package saplingdiff
+
+import (
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/saplingdiff"
+	"github.com/baking-bad/bcdhub/internal/reindexer/core"
+	"github.com/restream/reindexer"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Reindexer
+}
+
+// NewStorage -
+func NewStorage(db *core.Reindexer) *Storage {
+	return &Storage{db}
+}
+
+func (storage *Storage) poolQuery(network string, ptr int64) *reindexer.Query {
+	return storage.db.Query(models.DocSaplingDiff).
+		Match("network", network).
+		WhereInt64("ptr", reindexer.EQ, ptr)
+}
+
+// Get - returns sapling pool updates sorted by level descending
+func (storage *Storage) Get(network string, ptr int64, size, offset int64) (diffs []saplingdiff.SaplingDiff, err error) {
+	if size == 0 {
+		size = core.DefaultSize
+	}
+
+	query := storage.poolQuery(network, ptr).
+		Limit(int(size)).
+		Offset(int(offset)).
+		Sort("level", true)
+
+	err = storage.db.GetAllByQuery(query, &diffs)
+	return
+}
+
+// First - returns the earliest update of sapling pool. It contains pool allocation (or copy) with memo size.
+func (storage *Storage) First(network string, ptr int64) (diff saplingdiff.SaplingDiff, err error) {
+	query := storage.poolQuery(network, ptr).
+		Sort("level", false)
+
+	err = storage.db.GetOne(query, &diff)
+	return
+}
+
+// GetStats - returns aggregated updates of sapling pool. If `maxLevel` is positive updates after it are skipped.
+func (storage *Storage) GetStats(network string, ptr int64, maxLevel int64) (stats saplingdiff.Stats, err error) {
+	query := storage.poolQuery(network, ptr)
+	if maxLevel > 0 {
+		query = query.WhereInt64("level", reindexer.LE, maxLevel)
+	}
+	query = query.ReqTotal()
+	query.AggregateSum("commitments_count")
+	query.AggregateSum("nullifiers_count")
+	query.AggregateMin("level")
+	query.AggregateMax("level")
+
+	it := query.Exec()
+	defer it.Close()
+
+	if err = it.Error(); err != nil {
+		return
+	}
+
+	stats.Count = int64(it.TotalCount())
+	if stats.Count == 0 {
+		return
+	}
+	aggs := it.AggResults()
+	stats.Commitments = int64(aggs[0].Value)
+	stats.Nullifiers = int64(aggs[1].Value)
+	stats.FirstLevel = int64(aggs[2].Value)
+	stats.LastLevel = int64(aggs[3].Value)
+	return
+}
@@ -0,0 +1,90 @@
+package ticket
+
+import (
+	"github.com/baking-bad/bcdhub/internal/models"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
+	"github.com/baking-bad/bcdhub/internal/reindexer/core"
+	"github.com/restream/reindexer"
+)
+
+// Storage -
+type Storage struct {
+	db *core.Reindexer
+}
+
+// NewStorage -
+func NewStorage(db *core.Reindexer) *Storage {
+	return &Storage{db}
+}
+
+// Update -
+func (storage *Storage) Update(updates []*ticket.Ticket) error {
+	if len(updates) == 0 {
+		return nil
+	}
+	buf := make([]ticket.Ticket, 0)
+	ids := make([]string, len(updates))
+	for i := range updates {
+		ids[i] = updates[i].GetID()
+	}
+	if err := storage.db.GetByIDs(&buf, ids...); err != nil {
+		return err
+	}
+
+	updatedModels := make([]models.Model, 0)
+	insertedModels := make([]models.Model, 0)
+
+	for i := range updates {
+		var found bool
+		for j := range buf {
+			if buf[j].GetID() == updates[i].GetID() {
+				found = true
+				updates[i].Sum(&buf[j])
+				updatedModels = append(updatedModels, updates[i])
+				break
+			}
+		}
+
+		if !found {
+			insertedModels = append(insertedModels, updates[i])
+		}
+	}
+
+	if err := storage.db.BulkInsert(insertedModels); err != nil {
+		return err
+	}
+
+	return storage.db.BulkUpdate(updatedModels)
+}
+
+// GetByHolder -
+func (storage *Storage) GetByHolder(network, holder string) (tickets []ticket.Ticket, err error) {
+	query := storage.db.Query(models.DocTickets).
+		Match("network", network).
+		Match("holder", holder).
+		Not().WhereString("amount", reindexer.EQ, "0")
+
+	err = storage.db.GetAllByQuery(query, &tickets)
+	return
+}
+
+// GetByTicketer -
+func (storage *Storage) GetByTicketer(network, ticketer string) (tickets []ticket.Ticket, err error) {
+	query := storage.db.Query(models.DocTickets).
+		Match("network", network).
+		Match("ticketer", ticketer).
+		Not().WhereString("amount", reindexer.EQ, "0")
+
+	err = storage.db.GetAllByQuery(query, &tickets)
+	return
+}
+
+// GetUpdates -
+func (storage *Storage) GetUpdates(network string, level int64) (updates []ticket.Update, err error) {
+	query := storage.db.Query(models.DocTicketUpdates).
+		Match("network", network).
+		WhereInt64("level", reindexer.GT, level)
+
+	err = storage.db.GetAllByQuery(query, &updates)
+	return
+}
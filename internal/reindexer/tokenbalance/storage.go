@@ -1,6 +1,7 @@
 package tokenbalance
 
 import (
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 	"github.com/baking-bad/bcdhub/internal/models"
 	"github.com/baking-bad/bcdhub/internal/models/tokenbalance"
 	"github.com/baking-bad/bcdhub/internal/reindexer/core"
@@ -72,11 +73,11 @@ func (storage *Storage) Update(updates []*tokenbalance.TokenBalance) error {
 // }
 
 // GetHolders -
-func (storage *Storage) GetHolders(network, contract string, tokenID int64) (balances []tokenbalance.TokenBalance, err error) {
+func (storage *Storage) GetHolders(network, contract string, tokenID *types.BigInt) (balances []tokenbalance.TokenBalance, err error) {
 	query := storage.db.Query(models.DocTokenBalances).
 		Match("network", network).
 		Match("contract", contract).
-		WhereInt64("token_id", reindexer.EQ, tokenID).
+		WhereString("token_id", reindexer.EQ, tokenID.String()).
 		WhereInt64("balance", reindexer.GT, 0)
 
 	err = storage.db.GetAllByQuery(query, &balances)
@@ -94,6 +95,6 @@ func (storage *Storage) GetAccountBalances(network, address string, size, offset
 }
 
 // BurnNft -
-func (storage *Storage) BurnNft(network, contract string, tokenID int64) error {
+func (storage *Storage) BurnNft(network, contract string, tokenID *types.BigInt) error {
 	return nil
 }
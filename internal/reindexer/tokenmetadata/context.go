@@ -12,7 +12,7 @@ func buildGetTokenMetadataContext(query *reindexer.Query, ctx ...tokenmetadata.G
 	if ctx[0].Network != "" {
 		query.Match("network", ctx[0].Network)
 	}
-	if ctx[0].TokenID != -1 {
-		query.WhereInt64("tokens.static.token_id", reindexer.EQ, ctx[0].TokenID)
+	if ctx[0].TokenID != nil {
+		query.WhereString("tokens.static.token_id", reindexer.EQ, ctx[0].TokenID.String())
 	}
 }
@@ -47,8 +47,8 @@ func filterAddress(ctx transfer.GetContext, query *reindexer.Query) {
 }
 
 func filterTokenID(ctx transfer.GetContext, query *reindexer.Query) {
-	if ctx.TokenID >= 0 {
-		query.WhereInt64("token_id", reindexer.EQ, ctx.TokenID)
+	if ctx.TokenID != nil {
+		query.WhereString("token_id", reindexer.EQ, ctx.TokenID.String())
 	}
 }
 
@@ -2,6 +2,7 @@ package transfer
 
 import (
 	"fmt"
+	"github.com/baking-bad/bcdhub/internal/bcd/types"
 
 	"github.com/baking-bad/bcdhub/internal/bcd/consts"
 	"github.com/baking-bad/bcdhub/internal/models"
@@ -56,12 +57,12 @@ func (storage *Storage) GetAll(network string, level int64) (transfers []transfe
 }
 
 // GetTokenSupply -
-func (storage *Storage) GetTokenSupply(network, address string, tokenID int64) (result transfer.TokenSupply, err error) {
+func (storage *Storage) GetTokenSupply(network, address string, tokenID *types.BigInt) (result transfer.TokenSupply, err error) {
 	it := storage.db.Query(models.DocTransfers).
 		Match("network", network).
 		Match("contract", address).
 		Match("status", consts.Applied).
-		WhereInt64("token_id", reindexer.EQ, tokenID).
+		WhereString("token_id", reindexer.EQ, tokenID.String()).
 		Exec()
 	defer it.Close()
 
@@ -86,11 +87,11 @@ func (storage *Storage) GetTokenSupply(network, address string, tokenID int64) (
 }
 
 // GetTokenVolumeSeries -
-func (storage *Storage) GetTokenVolumeSeries(network, period string, contracts []string, entrypoints []tzip.DAppContract, tokenID uint) ([][]float64, error) {
+func (storage *Storage) GetTokenVolumeSeries(network, period string, contracts []string, entrypoints []tzip.DAppContract, tokenID *types.BigInt) ([][]float64, error) {
 	return nil, nil
 }
 
 // GetToken24HoursVolume -
-func (storage *Storage) GetToken24HoursVolume(network, contract string, initiators, entrypoints []string, tokenID int64) (float64, error) {
+func (storage *Storage) GetToken24HoursVolume(network, contract string, initiators, entrypoints []string, tokenID *types.BigInt) (float64, error) {
 	return 0, nil
 }
@@ -16,7 +16,7 @@ func Remove(storage models.GeneralRepository, contractsRepo contract.Repository,
 
 func removeOthers(storage models.GeneralRepository, network string) error {
 	logger.Info("Deleting general data...")
-	return storage.DeleteByLevelAndNetwork([]string{models.DocBigMapDiff, models.DocBigMapActions, models.DocMigrations, models.DocOperations, models.DocTransfers, models.DocBlocks, models.DocProtocol}, network, -1)
+	return storage.DeleteByLevelAndNetwork([]string{models.DocBigMapDiff, models.DocBigMapActions, models.DocMigrations, models.DocOperations, models.DocTransfers, models.DocTicketUpdates, models.DocSaplingDiff, models.DocBlocks, models.DocProtocol}, network, -1)
 }
 
 func removeContracts(storage models.GeneralRepository, contractsRepo contract.Repository, network string) error {
@@ -9,6 +9,7 @@ import (
 	"github.com/baking-bad/bcdhub/internal/models/contract"
 	"github.com/baking-bad/bcdhub/internal/models/operation"
 	"github.com/baking-bad/bcdhub/internal/models/protocol"
+	"github.com/baking-bad/bcdhub/internal/models/ticket"
 	"github.com/baking-bad/bcdhub/internal/models/tokenbalance"
 	"github.com/baking-bad/bcdhub/internal/models/transfer"
 	"github.com/baking-bad/bcdhub/internal/mq"
@@ -23,6 +24,7 @@ type Manager struct {
 	operationRepo operation.Repository
 	transfersRepo transfer.Repository
 	tbRepo        tokenbalance.Repository
+	ticketsRepo   ticket.Repository
 	protocolsRepo protocol.Repository
 	messageQueue  mq.IMessagePublisher
 	rpc           noderpc.INode
@@ -30,9 +32,9 @@ type Manager struct {
 }
 
 // NewManager -
-func NewManager(storage models.GeneralRepository, contractsRepo contract.Repository, operationRepo operation.Repository, transfersRepo transfer.Repository, tbRepo tokenbalance.Repository, protocolsRepo protocol.Repository, messageQueue mq.IMessagePublisher, rpc noderpc.INode, sharePath string) Manager {
+func NewManager(storage models.GeneralRepository, contractsRepo contract.Repository, operationRepo operation.Repository, transfersRepo transfer.Repository, tbRepo tokenbalance.Repository, ticketsRepo ticket.Repository, protocolsRepo protocol.Repository, messageQueue mq.IMessagePublisher, rpc noderpc.INode, sharePath string) Manager {
 	return Manager{
-		storage, contractsRepo, operationRepo, transfersRepo, tbRepo, protocolsRepo, messageQueue, rpc, sharePath,
+		storage, contractsRepo, operationRepo, transfersRepo, tbRepo, ticketsRepo, protocolsRepo, messageQueue, rpc, sharePath,
 	}
 }
 
@@ -50,6 +52,9 @@ func (rm Manager) Rollback(fromState block.Block, toLevel int64) error {
 	if err := rm.rollbackTokenBalances(fromState.Network, toLevel); err != nil {
 		return err
 	}
+	if err := rm.rollbackTickets(fromState.Network, toLevel); err != nil {
+		return err
+	}
 	if err := rm.rollbackOperations(fromState.Network, toLevel); err != nil {
 		return err
 	}
@@ -108,14 +113,41 @@ func (rm Manager) rollbackTokenBalances(network string, toLevel int64) error {
 	return rm.tbRepo.Update(updates)
 }
 
+func (rm Manager) rollbackTickets(network string, toLevel int64) error {
+	ticketUpdates, err := rm.ticketsRepo.GetUpdates(network, toLevel)
+	if err != nil {
+		return err
+	}
+	if len(ticketUpdates) == 0 {
+		return nil
+	}
+
+	exists := make(map[string]*ticket.Ticket)
+	updates := make([]*ticket.Ticket, 0)
+	for i := range ticketUpdates {
+		if ticketUpdates[i].IsTransfer() {
+			continue
+		}
+		upd := ticketUpdates[i].ToTicket(true)
+		if t, ok := exists[upd.GetID()]; ok {
+			t.Sum(upd)
+		} else {
+			updates = append(updates, upd)
+			exists[upd.GetID()] = upd
+		}
+	}
+
+	return rm.ticketsRepo.Update(updates)
+}
+
 func (rm Manager) rollbackBlocks(network string, toLevel int64) error {
 	logger.Info("Deleting blocks...")
 	return rm.storage.DeleteByLevelAndNetwork([]string{models.DocBlocks}, network, toLevel)
 }
 
 func (rm Manager) rollbackOperations(network string, toLevel int64) error {
-	logger.Info("Deleting operations, migrations, transfers and big map diffs...")
-	return rm.storage.DeleteByLevelAndNetwork([]string{models.DocBigMapDiff, models.DocBigMapActions, models.DocTZIP, models.DocMigrations, models.DocOperations, models.DocTransfers, models.DocTokenMetadata}, network, toLevel)
+	logger.Info("Deleting operations, migrations, transfers, ticket updates, sapling and big map diffs...")
+	return rm.storage.DeleteByLevelAndNetwork([]string{models.DocBigMapDiff, models.DocBigMapActions, models.DocTZIP, models.DocMigrations, models.DocOperations, models.DocTransfers, models.DocTokenMetadata, models.DocTicketUpdates, models.DocSaplingDiff}, network, toLevel)
 }
 
 func (rm Manager) rollbackContracts(fromState block.Block, toLevel int64) error {
@@ -16,6 +16,12 @@ var (
 	ErrNodeReturn = errors.New(`Node return error`)
 )
 
+// View kinds
+const (
+	KindMichelsonStorageView = "michelson_storage_view"
+	KindRestAPIQuery         = "rest_api_query"
+)
+
 // Context -
 type Context struct {
 	Network                  string
@@ -28,12 +34,14 @@ type Context struct {
 	ChainID                  string
 	HardGasLimitPerOperation int64
 	Amount                   int64
+
+	// Arguments - named arguments of views which are not implemented in Michelson
+	Arguments map[string]interface{}
 }
 
 // View -
 type View interface {
-	GetCode(storageType []byte) ([]byte, error)
-	GetParameter(parameter string, storageType []byte) ([]byte, error)
+	Execute(rpc noderpc.INode, ctx Context) ([]byte, error)
 	Parse(response []byte, output interface{}) error
 }
 
@@ -56,31 +64,5 @@ func Execute(rpc noderpc.INode, view View, ctx Context, output interface{}) erro
 
 // ExecuteWithoutParsing -
 func ExecuteWithoutParsing(rpc noderpc.INode, view View, ctx Context) ([]byte, error) {
-	script, err := rpc.GetScriptJSON(ctx.Contract, 0)
-	if err != nil {
-		return nil, err
-	}
-
-	parameter, err := view.GetParameter(ctx.Parameters, script.Storage)
-	if err != nil {
-		return nil, err
-	}
-
-	storageType, err := json.Marshal(script.Code.Storage[0])
-	if err != nil {
-		return nil, err
-	}
-	code, err := view.GetCode(storageType)
-	if err != nil {
-		return nil, err
-	}
-
-	storage := []byte(`{"prim": "None"}`)
-
-	response, err := rpc.RunCode(code, storage, parameter, ctx.ChainID, ctx.Source, ctx.Initiator, ctx.Entrypoint, ctx.Protocol, ctx.Amount, ctx.HardGasLimitPerOperation)
-	if err != nil {
-		return nil, err
-	}
-
-	return response.Storage, nil
+	return view.Execute(rpc, ctx)
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 
 	"github.com/baking-bad/bcdhub/internal/models/tzip"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
 )
 
 // MichelsonStorageView -
@@ -51,6 +52,37 @@ func (msv *MichelsonStorageView) GetCode(storageType []byte) ([]byte, error) {
 	return script.Bytes(), nil
 }
 
+// Execute - runs view code with current contract storage by `run_code`
+func (msv *MichelsonStorageView) Execute(rpc noderpc.INode, ctx Context) ([]byte, error) {
+	script, err := rpc.GetScriptJSON(ctx.Contract, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	parameter, err := msv.GetParameter(ctx.Parameters, script.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	storageType, err := json.Marshal(script.Code.Storage[0])
+	if err != nil {
+		return nil, err
+	}
+	code, err := msv.GetCode(storageType)
+	if err != nil {
+		return nil, err
+	}
+
+	storage := []byte(`{"prim": "None"}`)
+
+	response, err := rpc.RunCode(code, storage, parameter, ctx.ChainID, ctx.Source, ctx.Initiator, ctx.Entrypoint, ctx.Protocol, ctx.Amount, ctx.HardGasLimitPerOperation)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Storage, nil
+}
+
 // Parse -
 func (msv *MichelsonStorageView) Parse(response []byte, output interface{}) error {
 	return nil
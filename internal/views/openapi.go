@@ -0,0 +1,42 @@
+package views
+
+// OpenAPI - part of OpenAPI specification which is needed to call REST API views
+type OpenAPI struct {
+	Servers []OpenAPIServer                        `json:"servers"`
+	Paths   map[string]map[string]OpenAPIOperation `json:"paths"`
+}
+
+// OpenAPIServer -
+type OpenAPIServer struct {
+	URL string `json:"url"`
+}
+
+// OpenAPIOperation -
+type OpenAPIOperation struct {
+	OperationID string             `json:"operationId,omitempty"`
+	Summary     string             `json:"summary,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Parameters  []OpenAPIParameter `json:"parameters,omitempty"`
+}
+
+// OpenAPIParameter -
+type OpenAPIParameter struct {
+	Name        string        `json:"name"`
+	In          string        `json:"in"`
+	Description string        `json:"description,omitempty"`
+	Required    bool          `json:"required,omitempty"`
+	Schema      OpenAPISchema `json:"schema"`
+}
+
+// OpenAPISchema -
+type OpenAPISchema struct {
+	Type   string `json:"type,omitempty"`
+	Format string `json:"format,omitempty"`
+}
+
+// OpenAPI parameter locations
+const (
+	OpenAPIInPath   = "path"
+	OpenAPIInQuery  = "query"
+	OpenAPIInHeader = "header"
+)
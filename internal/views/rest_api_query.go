@@ -0,0 +1,242 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/models/tzip"
+	"github.com/baking-bad/bcdhub/internal/noderpc"
+	"github.com/baking-bad/bcdhub/internal/notifier"
+	"github.com/pkg/errors"
+)
+
+const (
+	restAPITimeout     = 10 * time.Second
+	restAPIMaxResponse = 1 << 20
+)
+
+// errors
+var (
+	ErrUnknownRestAPIOperation = errors.New("Operation is not found in OpenAPI specification")
+	ErrEmptyRestAPIBaseURI     = errors.New("Neither baseUri nor servers are set")
+	ErrRequiredArgument        = errors.New("Required argument is not set")
+	ErrRestAPIMethod           = errors.New("Only GET method is allowed for REST API query")
+	ErrRestAPIScheme           = errors.New("Only HTTP(S) REST API is allowed")
+)
+
+// SpecificationStorage - receives OpenAPI specification by URI from metadata. It's implemented by `tzipStorage.Full`.
+type SpecificationStorage interface {
+	Get(network, address, url string, ptr int64, output interface{}) error
+}
+
+// RestAPIQuery - view which calls REST API described by OpenAPI specification. URL is taken from contract metadata, so only GET requests to public addresses are sent and redirects aren't followed.
+type RestAPIQuery struct {
+	Name             string
+	SpecificationURI string
+	BaseURI          string
+	Path             string
+	Method           string
+	Operation        *OpenAPIOperation
+
+	timeout      time.Duration
+	allowPrivate bool
+}
+
+// NewRestAPIQuery -
+func NewRestAPIQuery(impl tzip.ViewImplementation, name string) *RestAPIQuery {
+	view := &RestAPIQuery{
+		Name:    name,
+		Method:  http.MethodGet,
+		timeout: restAPITimeout,
+	}
+	if impl.RestAPIQuery == nil {
+		return view
+	}
+	view.SpecificationURI = impl.RestAPIQuery.SpecificationURI
+	view.BaseURI = impl.RestAPIQuery.BaseURI
+	view.Path = impl.RestAPIQuery.Path
+	if impl.RestAPIQuery.Method != "" {
+		view.Method = strings.ToUpper(impl.RestAPIQuery.Method)
+	}
+	return view
+}
+
+// LoadSpecification - receives OpenAPI specification of view and finds the called operation in it. `ptr` is a pointer to metadata big map of `address`.
+func (v *RestAPIQuery) LoadSpecification(storage SpecificationStorage, network, address string, ptr int64) error {
+	var spec OpenAPI
+	if err := storage.Get(network, address, v.SpecificationURI, ptr, &spec); err != nil {
+		return err
+	}
+	return v.SetSpecification(spec)
+}
+
+// SetSpecification -
+func (v *RestAPIQuery) SetSpecification(spec OpenAPI) error {
+	if v.Method != http.MethodGet {
+		return errors.Wrap(ErrRestAPIMethod, v.Method)
+	}
+	operations, ok := spec.Paths[v.Path]
+	if !ok {
+		return errors.Wrap(ErrUnknownRestAPIOperation, v.Path)
+	}
+	operation, ok := operations[strings.ToLower(v.Method)]
+	if !ok {
+		return errors.Wrapf(ErrUnknownRestAPIOperation, "%s %s", v.Method, v.Path)
+	}
+	v.Operation = &operation
+
+	if v.BaseURI == "" && len(spec.Servers) > 0 {
+		v.BaseURI = spec.Servers[0].URL
+	}
+	return nil
+}
+
+// Parameters - returns parameters of called operation which can be passed to view
+func (v *RestAPIQuery) Parameters() []OpenAPIParameter {
+	if v.Operation == nil {
+		return nil
+	}
+	params := make([]OpenAPIParameter, 0)
+	for _, param := range v.Operation.Parameters {
+		switch param.In {
+		case OpenAPIInPath, OpenAPIInQuery, OpenAPIInHeader:
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// Execute - calls REST API with `ctx.Arguments`. Node is not used.
+func (v *RestAPIQuery) Execute(rpc noderpc.INode, ctx Context) ([]byte, error) {
+	req, err := v.buildRequest(ctx.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{
+		Timeout: v.timeout,
+	}
+	client := http.Client{
+		Timeout: v.timeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				return v.dialContext(ctx, dialer, network, address)
+			},
+			TLSHandshakeTimeout: v.timeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, restAPIMaxResponse))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, errors.Errorf("%s %s: invalid status code %d: %s", v.Method, req.URL.String(), resp.StatusCode, string(data))
+	}
+	return data, nil
+}
+
+// dialContext - resolves REST API host and dials checked address, so host can't point to private network
+func (v *RestAPIQuery) dialContext(ctx context.Context, dialer *net.Dialer, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, errors.Errorf("can't resolve REST API host %s", host)
+	}
+	if !v.allowPrivate {
+		for i := range addrs {
+			if !notifier.IsPublicIP(addrs[i].IP) {
+				return nil, errors.Errorf("forbidden REST API host %s: %s is not a public address", host, addrs[i].IP)
+			}
+		}
+	}
+	return dialer.DialContext(ctx, network, net.JoinHostPort(addrs[0].IP.String(), port))
+}
+
+// Parse -
+func (v *RestAPIQuery) Parse(response []byte, output interface{}) error {
+	return json.Unmarshal(response, output)
+}
+
+func (v *RestAPIQuery) buildRequest(args map[string]interface{}) (*http.Request, error) {
+	if v.BaseURI == "" {
+		return nil, ErrEmptyRestAPIBaseURI
+	}
+	if v.Method != http.MethodGet {
+		return nil, errors.Wrap(ErrRestAPIMethod, v.Method)
+	}
+
+	path := v.Path
+	query := make(url.Values)
+	header := make(http.Header)
+	for _, param := range v.Parameters() {
+		value, ok := args[param.Name]
+		if !ok || value == nil {
+			if param.Required || param.In == OpenAPIInPath {
+				return nil, errors.Wrap(ErrRequiredArgument, param.Name)
+			}
+			continue
+		}
+		str := argumentToString(value)
+		switch param.In {
+		case OpenAPIInPath:
+			path = strings.ReplaceAll(path, fmt.Sprintf("{%s}", param.Name), url.PathEscape(str))
+		case OpenAPIInQuery:
+			query.Set(param.Name, str)
+		case OpenAPIInHeader:
+			header.Set(param.Name, str)
+		}
+	}
+
+	link := strings.TrimSuffix(v.BaseURI, "/") + path
+	if len(query) > 0 {
+		link = fmt.Sprintf("%s?%s", link, query.Encode())
+	}
+
+	req, err := http.NewRequest(v.Method, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return nil, errors.Wrap(ErrRestAPIScheme, req.URL.Scheme)
+	}
+	req.Header = header
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+func argumentToString(value interface{}) string {
+	switch typ := value.(type) {
+	case string:
+		return typ
+	case float64:
+		return strconv.FormatFloat(typ, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(typ)
+	default:
+		return fmt.Sprintf("%v", typ)
+	}
+}
@@ -0,0 +1,166 @@
+package views
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baking-bad/bcdhub/internal/models/tzip"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const restAPITestSpecification = `{
+	"openapi": "3.0.0",
+	"servers": [{"url": "https://api.example.com"}],
+	"paths": {
+		"/balance/{owner}": {
+			"get": {
+				"summary": "Balance of owner",
+				"parameters": [
+					{"name": "owner", "in": "path", "required": true, "schema": {"type": "string"}},
+					{"name": "token_id", "in": "query", "schema": {"type": "integer"}},
+					{"name": "X-Api-Version", "in": "header", "schema": {"type": "string"}},
+					{"name": "session", "in": "cookie", "schema": {"type": "string"}}
+				]
+			}
+		}
+	}
+}`
+
+func newTestRestAPIQuery(t *testing.T, baseURI string) *RestAPIQuery {
+	view := NewRestAPIQuery(tzip.ViewImplementation{
+		RestAPIQuery: &tzip.RestAPIQuery{
+			SpecificationURI: "https://api.example.com/openapi.json",
+			BaseURI:          baseURI,
+			Path:             "/balance/{owner}",
+		},
+	}, "getBalance")
+
+	var spec OpenAPI
+	if err := json.UnmarshalFromString(restAPITestSpecification, &spec); err != nil {
+		t.Fatal(err)
+	}
+	if err := view.SetSpecification(spec); err != nil {
+		t.Fatal(err)
+	}
+	return view
+}
+
+func TestRestAPIQuery_Execute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/balance/tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("token_id") != "12" || r.Header.Get("X-Api-Version") != "v1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"balance":"100"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "all arguments",
+			args: map[string]interface{}{
+				"owner":         "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6",
+				"token_id":      float64(12),
+				"X-Api-Version": "v1",
+			},
+			want: `{"balance":"100"}`,
+		}, {
+			name: "optional arguments are skipped",
+			args: map[string]interface{}{
+				"owner": "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6",
+			},
+			wantErr: true,
+		}, {
+			name: "required argument is not set",
+			args: map[string]interface{}{
+				"token_id": float64(12),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := newTestRestAPIQuery(t, server.URL)
+			view.allowPrivate = true
+			got, err := view.Execute(nil, Context{Arguments: tt.args})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				assert.Equal(t, tt.want, string(got))
+			}
+		})
+	}
+}
+
+func TestRestAPIQuery_Execute_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+	}))
+	defer server.Close()
+
+	args := map[string]interface{}{
+		"owner": "tz1aSkwEot3L2kmUvcoxzjMomb9mvBNuzFK6",
+	}
+
+	t.Run("private address", func(t *testing.T) {
+		local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer local.Close()
+
+		view := newTestRestAPIQuery(t, local.URL)
+		_, err := view.Execute(nil, Context{Arguments: args})
+		assert.Error(t, err)
+	})
+	t.Run("redirect isn't followed", func(t *testing.T) {
+		view := newTestRestAPIQuery(t, server.URL)
+		view.allowPrivate = true
+		_, err := view.Execute(nil, Context{Arguments: args})
+		assert.Error(t, err)
+	})
+	t.Run("not HTTP scheme", func(t *testing.T) {
+		view := newTestRestAPIQuery(t, "file:///etc")
+		_, err := view.Execute(nil, Context{Arguments: args})
+		assert.True(t, errors.Is(err, ErrRestAPIScheme))
+	})
+	t.Run("not GET method", func(t *testing.T) {
+		view := newTestRestAPIQuery(t, server.URL)
+		view.Method = http.MethodPost
+		_, err := view.Execute(nil, Context{Arguments: args})
+		assert.True(t, errors.Is(err, ErrRestAPIMethod))
+	})
+}
+
+func TestRestAPIQuery_SetSpecification(t *testing.T) {
+	view := newTestRestAPIQuery(t, "")
+	assert.Equal(t, "https://api.example.com", view.BaseURI)
+	assert.Equal(t, http.MethodGet, view.Method)
+
+	params := view.Parameters()
+	names := make([]string, len(params))
+	for i := range params {
+		names[i] = params[i].Name
+	}
+	assert.Equal(t, []string{"owner", "token_id", "X-Api-Version"}, names, "cookie parameters are not supported")
+
+	unknown := NewRestAPIQuery(tzip.ViewImplementation{
+		RestAPIQuery: &tzip.RestAPIQuery{
+			Path:   "/balance/{owner}",
+			Method: "post",
+		},
+	}, "getBalance")
+	assert.True(t, errors.Is(unknown.SetSpecification(OpenAPI{}), ErrRestAPIMethod))
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+
+	"github.com/baking-bad/bcdhub/internal/audit"
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/mq"
+	"github.com/pkg/errors"
+)
+
+type auditCommand struct {
+	Network string `short:"n" long:"network" description:"Network" required:"true"`
+	Sample  int    `short:"s" long:"sample" description:"Count of random contracts to audit. By default all contracts are audited"`
+	MaxKeys int64  `short:"k" long:"max_keys" description:"Max count of keys checked in every big map. By default all keys are checked"`
+	Checks  string `short:"c" long:"checks" description:"Comma-separated list of checks: contract, storage, big_map, ledger. By default all checks are run"`
+	Repair  bool   `short:"r" long:"repair" description:"Fix big map values and ledger balances by node data"`
+	Output  string `short:"o" long:"output" description:"Path to JSON report. By default report is printed to stdout"`
+	Queue   bool   `short:"q" long:"queue" description:"Send contracts to audit queue of metrics service instead of auditing them here"`
+}
+
+var auditCmd auditCommand
+
+// Execute
+func (x *auditCommand) Execute(_ []string) error {
+	if x.Queue {
+		return x.sendToQueue()
+	}
+
+	checks, err := x.parseChecks()
+	if err != nil {
+		return err
+	}
+
+	if x.Repair {
+		logger.Warning("Do you want to repair data of '%s' by node? (yes - continue. no - cancel)", x.Network)
+		if !yes() {
+			logger.Info("Cancelled")
+			return nil
+		}
+	}
+
+	rpc, err := ctx.GetRPC(x.Network)
+	if err != nil {
+		return err
+	}
+
+	auditor := audit.NewAuditor(
+		rpc, ctx.Storage, ctx.Blocks, ctx.Contracts, ctx.Operations, ctx.BigMapDiffs, ctx.Migrations, ctx.TokenBalances, ctx.SharePath,
+		audit.WithChecks(checks...),
+		audit.WithSampleSize(x.Sample),
+		audit.WithMaxKeys(x.MaxKeys),
+		audit.WithRepair(x.Repair),
+	)
+	report, err := auditor.Audit(x.Network)
+	if err != nil {
+		return err
+	}
+
+	if err := x.writeReport(report); err != nil {
+		return err
+	}
+
+	logger.Info("%d of %d contracts of '%s' are audited: %d mismatches, %d failures", report.Audited, report.Indexed, x.Network, len(report.Mismatches), len(report.Failures))
+	return nil
+}
+
+func (x *auditCommand) parseChecks() ([]string, error) {
+	if x.Checks == "" {
+		return nil, nil
+	}
+	checks := strings.Split(x.Checks, ",")
+	for i := range checks {
+		checks[i] = strings.TrimSpace(checks[i])
+		valid := false
+		for _, check := range audit.AllChecks() {
+			if check == checks[i] {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, errors.Errorf("unknown check: %s", checks[i])
+		}
+	}
+	return checks, nil
+}
+
+func (x *auditCommand) writeReport(report audit.Report) error {
+	var w io.Writer = os.Stdout
+	if x.Output != "" {
+		f, err := os.Create(x.Output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+func (x *auditCommand) sendToQueue() error {
+	state, err := ctx.Blocks.Last(x.Network)
+	if err != nil {
+		return err
+	}
+
+	addresses, err := ctx.Contracts.GetAddressesByNetworkAndLevel(x.Network, state.Level)
+	if err != nil {
+		return err
+	}
+	if x.Sample > 0 && x.Sample < len(addresses) {
+		rand.Shuffle(len(addresses), func(i, j int) {
+			addresses[i], addresses[j] = addresses[j], addresses[i]
+		})
+		addresses = addresses[:x.Sample]
+	}
+
+	ids, err := ctx.Contracts.GetIDsByAddresses(addresses, x.Network)
+	if err != nil {
+		return err
+	}
+	for i := range ids {
+		if err := ctx.MQ.SendRaw(mq.QueueAudit, []byte(ids[i])); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("%d contracts of '%s' are sent to %s queue", len(ids), x.Network, mq.QueueAudit)
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/dump"
+	"github.com/baking-bad/bcdhub/internal/logger"
+)
+
+type dumpCommand struct {
+	Network string `short:"n" long:"network" description:"Network" required:"true"`
+	Output  string `short:"o" long:"output" description:"Path to archive. By default archive is created in 'dumps' directory of share path"`
+	Size    int64  `short:"s" long:"size" description:"Count of documents received from storage per request" default:"1000"`
+}
+
+var dumpCmd dumpCommand
+
+// Execute
+func (x *dumpCommand) Execute(_ []string) error {
+	output := x.Output
+	if output == "" {
+		output = filepath.Join(
+			ctx.SharePath,
+			"dumps",
+			fmt.Sprintf("%s_%s.bcd.gz", x.Network, strings.ToLower(time.Now().UTC().Format("20060102T150405"))),
+		)
+	}
+	if err := os.MkdirAll(filepath.Dir(output), os.ModePerm); err != nil {
+		return err
+	}
+
+	// archive is written to temporary file, so interrupted dump does not look like completed one
+	tmp := output + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	summary, err := dump.Dump(ctx.Storage, x.Network, f, x.Size)
+	if err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, output); err != nil {
+		return err
+	}
+
+	logger.Info("%d documents of '%s' are dumped to %s", summary.Total(), x.Network, output)
+	return nil
+}
+
+type restoreDumpCommand struct {
+	Input string `short:"i" long:"input" description:"Path to archive created by dump command" required:"true"`
+	Size  int64  `short:"s" long:"size" description:"Count of documents saved to storage per request" default:"1000"`
+}
+
+var restoreDumpCmd restoreDumpCommand
+
+// Execute
+func (x *restoreDumpCommand) Execute(_ []string) error {
+	f, err := os.Open(x.Input)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	logger.Warning("Do you want to restore %s? Storage should not contain data of the archived network. (yes - continue. no - cancel)", x.Input)
+	if !yes() {
+		logger.Info("Cancelled")
+		return nil
+	}
+
+	if err := ctx.Storage.CreateIndexes(); err != nil {
+		return err
+	}
+
+	header, summary, err := dump.Restore(ctx.Storage, f, x.Size)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("%d documents of '%s' dumped at %s are restored", summary.Total(), header.Network, header.CreatedAt.Format(time.RFC3339))
+	return nil
+}
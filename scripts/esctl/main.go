@@ -67,10 +67,31 @@ func main() {
 		logger.Fatal(err)
 	}
 
-	if _, err := parser.AddCommand("restore",
+	if _, err := parser.AddCommand("restore_snapshot",
 		"Restore snapshot",
 		"Restore snapshot",
-		&restoreCmd); err != nil {
+		&restoreSnapshotCmd); err != nil {
+		logger.Fatal(err)
+	}
+
+	if _, err := parser.AddCommand("dump",
+		"Dump network data",
+		"Dump all indexed data of network to compressed archive on local disk",
+		&dumpCmd); err != nil {
+		logger.Fatal(err)
+	}
+
+	if _, err := parser.AddCommand("restore",
+		"Restore network data",
+		"Restore network data from archive created by dump command",
+		&restoreDumpCmd); err != nil {
+		logger.Fatal(err)
+	}
+
+	if _, err := parser.AddCommand("audit",
+		"Audit network data",
+		"Compare indexed contracts, storage, big maps and ledger balances with node and report mismatches",
+		&auditCmd); err != nil {
 		logger.Fatal(err)
 	}
 
@@ -36,12 +36,12 @@ func (x *snapshotCommand) Execute(_ []string) error {
 	return ctx.Storage.CreateSnapshots(name, snapshotName, models.AllDocuments())
 }
 
-type restoreCommand struct{}
+type restoreSnapshotCommand struct{}
 
-var restoreCmd restoreCommand
+var restoreSnapshotCmd restoreSnapshotCommand
 
 // Execute
-func (x *restoreCommand) Execute(_ []string) error {
+func (x *restoreSnapshotCommand) Execute(_ []string) error {
 	if err := listRepositories(ctx.Storage); err != nil {
 		return err
 	}
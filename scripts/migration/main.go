@@ -29,6 +29,8 @@ var migrationsList = []migrations.Migration{
 	&migrations.TokenBalanceRecalc{},
 	&migrations.TokenMetadataSetDecimals{},
 	&migrations.NFTMetadata{},
+	&migrations.SetContractInterfaces{},
+	&migrations.TokenIDKeyword{},
 }
 
 func main() {
@@ -51,6 +53,7 @@ func main() {
 		config.WithRPC(cfg.RPC),
 		config.WithConfigCopy(cfg),
 		config.WithLoadErrorDescriptions(),
+		config.WithLoadContractInterfaces(cfg.SharePath),
 	)
 	defer ctx.Close()
 
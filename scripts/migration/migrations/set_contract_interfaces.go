@@ -0,0 +1,120 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/baking-bad/bcdhub/internal/bcd/ast"
+	"github.com/baking-bad/bcdhub/internal/bcd/ast/interfaces"
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/fetch"
+	"github.com/baking-bad/bcdhub/internal/helpers"
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/models/contract"
+	"github.com/schollz/progressbar/v3"
+)
+
+// SetContractInterfaces - migration that sets contract tags by built-in and registered contract interfaces
+type SetContractInterfaces struct{}
+
+// Key -
+func (m *SetContractInterfaces) Key() string {
+	return "set_contract_interfaces"
+}
+
+// Description -
+func (m *SetContractInterfaces) Description() string {
+	return "set contract tags by contract interfaces from share path"
+}
+
+// Do - migrate function
+func (m *SetContractInterfaces) Do(ctx *config.Context) error {
+	start := time.Now()
+	names := interfaces.Names()
+
+	for _, network := range ctx.Config.Scripts.Networks {
+		contracts, err := ctx.Contracts.GetMany(map[string]interface{}{
+			"network": network,
+		})
+		if err != nil {
+			return err
+		}
+
+		logger.Info("Found %d contracts in %s", len(contracts), network)
+
+		bar := progressbar.NewOptions(len(contracts), progressbar.OptionSetPredictTime(false), progressbar.OptionClearOnFinish(), progressbar.OptionShowCount())
+
+		updates := make([]contract.Contract, 0)
+		var count int
+		for i := range contracts {
+			bar.Add(1) //nolint
+
+			changed, err := m.setTags(ctx, &contracts[i], names)
+			if err != nil {
+				return err
+			}
+			if changed {
+				updates = append(updates, contracts[i])
+			}
+
+			if len(updates) == 1000 || (i == len(contracts)-1 && len(updates) > 0) {
+				if err := ctx.Contracts.UpdateField(updates, "Tags"); err != nil {
+					return err
+				}
+				count += len(updates)
+				updates = make([]contract.Contract, 0)
+			}
+		}
+
+		logger.Info("Tags of %d contracts in %s are updated", count, network)
+	}
+
+	logger.Info("Time spent: %v", time.Since(start))
+	return nil
+}
+
+func (m *SetContractInterfaces) setTags(ctx *config.Context, c *contract.Contract, names []string) (bool, error) {
+	data, err := fetch.Contract(c.Address, c.Network, "", ctx.SharePath)
+	if err != nil {
+		return false, err
+	}
+	script, err := ast.NewScript(data)
+	if err != nil {
+		return false, err
+	}
+	parameter, err := script.ParameterType()
+	if err != nil {
+		return false, err
+	}
+	storage, err := script.StorageType()
+	if err != nil {
+		return false, err
+	}
+
+	tags := make([]string, 0, len(c.Tags))
+	for i := range c.Tags {
+		if !helpers.StringInArray(c.Tags[i], names) {
+			tags = append(tags, c.Tags[i])
+		}
+	}
+	for _, match := range ast.MatchContractInterfaces(parameter, storage) {
+		tags = append(tags, match.Name)
+	}
+
+	if equalTags(tags, c.Tags) {
+		return false, nil
+	}
+	c.Tags = tags
+	return true, nil
+}
+
+func equalTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !helpers.StringInArray(a[i], b) {
+			return false
+		}
+	}
+	return true
+}
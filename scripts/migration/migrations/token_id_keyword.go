@@ -0,0 +1,123 @@
+package migrations
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/baking-bad/bcdhub/internal/config"
+	"github.com/baking-bad/bcdhub/internal/elastic/core"
+	"github.com/baking-bad/bcdhub/internal/logger"
+	"github.com/baking-bad/bcdhub/internal/models"
+	pgCore "github.com/baking-bad/bcdhub/internal/postgres/core"
+	"github.com/pkg/errors"
+)
+
+const tokenIDToStringScript = "if (ctx._source.token_id != null) { ctx._source.token_id = ctx._source.token_id.toString() }"
+
+// TokenIDKeyword - migration that changes type of `token_id` field from number to string in transfers, token balances and token metadata
+type TokenIDKeyword struct{}
+
+// Key -
+func (m *TokenIDKeyword) Key() string {
+	return "token_id_keyword"
+}
+
+// Description -
+func (m *TokenIDKeyword) Description() string {
+	return "reindex transfers, token balances and token metadata with string `token_id`"
+}
+
+// Do - migrate function
+func (m *TokenIDKeyword) Do(ctx *config.Context) error {
+	indices := []string{models.DocTransfers, models.DocTokenBalances, models.DocTokenMetadata}
+
+	switch storage := ctx.Storage.(type) {
+	case *core.Elastic:
+		for i := range indices {
+			if err := m.reindexElastic(storage, indices[i]); err != nil {
+				return err
+			}
+		}
+	case *pgCore.Postgres:
+		for i := range indices {
+			if err := m.updatePostgres(storage, indices[i]); err != nil {
+				return err
+			}
+		}
+	default:
+		logger.Info("Storage doesn't keep mappings. Nothing to migrate.")
+	}
+	return nil
+}
+
+// reindexElastic - mapping of existing field can't be changed, so documents are moved to temporary index, source index is recreated with `keyword` mapping and documents are moved back
+func (m *TokenIDKeyword) reindexElastic(e *core.Elastic, index string) error {
+	mappings, err := e.GetMappings([]string{index})
+	if err != nil {
+		return err
+	}
+	raw, ok := mappings[index]
+	if !ok {
+		return errors.Errorf("unknown index: %s", index)
+	}
+
+	var mapping struct {
+		Mappings map[string]interface{} `json:"mappings"`
+	}
+	if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+		return err
+	}
+	properties, ok := mapping.Mappings["properties"].(map[string]interface{})
+	if !ok {
+		return errors.Errorf("%s: mapping without properties", index)
+	}
+	if field, ok := properties["token_id"].(map[string]interface{}); ok && field["type"] == "keyword" {
+		logger.Info("%s: token_id is already keyword. Skip.", index)
+		return nil
+	}
+	properties["token_id"] = map[string]interface{}{
+		"type": "keyword",
+	}
+	body, err := json.Marshal(mapping)
+	if err != nil {
+		return err
+	}
+
+	tmp := fmt.Sprintf("%s_token_id_keyword", index)
+	if err := e.DeleteIndices([]string{tmp}); err != nil {
+		return err
+	}
+
+	logger.Info("%s: copying documents to %s...", index, tmp)
+	if err := e.CreateMapping(tmp, bytes.NewReader(body)); err != nil {
+		return err
+	}
+	if err := e.ReindexWithScript(index, tmp, tokenIDToStringScript); err != nil {
+		return err
+	}
+
+	logger.Info("%s: recreating index...", index)
+	if err := e.DeleteIndices([]string{index}); err != nil {
+		return err
+	}
+	if err := e.CreateMapping(index, bytes.NewReader(body)); err != nil {
+		return err
+	}
+	if err := e.ReindexWithScript(tmp, index, ""); err != nil {
+		return err
+	}
+	return e.DeleteIndices([]string{tmp})
+}
+
+func (m *TokenIDKeyword) updatePostgres(pg *pgCore.Postgres, table string) error {
+	result := pg.Exec(fmt.Sprintf(
+		`UPDATE %s SET data = jsonb_set(data, '{token_id}', to_jsonb(data->>'token_id')) WHERE jsonb_typeof(data->'token_id') = 'number'`,
+		table,
+	))
+	if result.Error != nil {
+		return result.Error
+	}
+	logger.Info("%s: %d rows updated", table, result.RowsAffected)
+	return nil
+}